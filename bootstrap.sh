@@ -88,6 +88,7 @@ repos="github.com/golang/glog \
        github.com/tools/godep \
        golang.org/x/net/context \
        golang.org/x/oauth2/google \
+       golang.org/x/text/collate \
        golang.org/x/tools/cmd/goimports \
        google.golang.org/grpc \
        google.golang.org/cloud \
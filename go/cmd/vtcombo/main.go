@@ -100,6 +100,9 @@ func main() {
 		topodatapb.TabletType_REPLICA,
 		topodatapb.TabletType_RDONLY,
 	}
+	// tabletserver already serves the default query log URLs.
+	flag.Lookup("vtgate_query_log_stream_handler").Value.Set("/debug/vtgate/querylog")
+	flag.Lookup("vtgate_querylogz_handler").Value.Set("/debug/vtgate/querylogz")
 	vtgate.Init(healthCheck, ts, resilientSrvTopoServer, schema, cell, 1*time.Millisecond /*retryDelay*/, 2 /*retryCount*/, 30*time.Second /*connTimeoutTotal*/, 10*time.Second /*connTimeoutPerConn*/, 365*24*time.Hour /*connLife*/, tabletTypesToWait, 0 /*maxInFlight*/, "" /*testGateway*/)
 
 	servenv.OnTerm(func() {
@@ -304,6 +304,89 @@ func (itc *internalTabletConn) Rollback(ctx context.Context, transactionID int64
 	return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
 }
 
+// Prepare is part of tabletconn.TabletConn
+func (itc *internalTabletConn) Prepare(ctx context.Context, transactionID int64, dtid string) error {
+	err := itc.tablet.qsc.QueryService().Prepare(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, transactionID, dtid)
+	return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+}
+
+// CommitPrepared is part of tabletconn.TabletConn
+func (itc *internalTabletConn) CommitPrepared(ctx context.Context, dtid string) error {
+	err := itc.tablet.qsc.QueryService().CommitPrepared(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, dtid)
+	return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+}
+
+// RollbackPrepared is part of tabletconn.TabletConn
+func (itc *internalTabletConn) RollbackPrepared(ctx context.Context, dtid string, originalID int64) error {
+	err := itc.tablet.qsc.QueryService().RollbackPrepared(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, dtid, originalID)
+	return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+}
+
+// CreateTransaction is part of tabletconn.TabletConn
+func (itc *internalTabletConn) CreateTransaction(ctx context.Context, dtid string, participants []*querypb.Target) error {
+	err := itc.tablet.qsc.QueryService().CreateTransaction(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, dtid, participants)
+	return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+}
+
+// StartCommit is part of tabletconn.TabletConn
+func (itc *internalTabletConn) StartCommit(ctx context.Context, transactionID int64, dtid string) error {
+	err := itc.tablet.qsc.QueryService().StartCommit(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, transactionID, dtid)
+	return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+}
+
+// SetRollback is part of tabletconn.TabletConn
+func (itc *internalTabletConn) SetRollback(ctx context.Context, dtid string, transactionID int64) error {
+	err := itc.tablet.qsc.QueryService().SetRollback(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, dtid, transactionID)
+	return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+}
+
+// ConcludeTransaction is part of tabletconn.TabletConn
+func (itc *internalTabletConn) ConcludeTransaction(ctx context.Context, dtid string) error {
+	err := itc.tablet.qsc.QueryService().ConcludeTransaction(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, dtid)
+	return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+}
+
+// ReadTransaction is part of tabletconn.TabletConn
+func (itc *internalTabletConn) ReadTransaction(ctx context.Context, dtid string) (*querypb.TransactionMetadata, error) {
+	metadata, err := itc.tablet.qsc.QueryService().ReadTransaction(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, dtid)
+	if err != nil {
+		return nil, tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+	}
+	return metadata, nil
+}
+
 // Execute2 is part of tabletconn.TabletConn
 func (itc *internalTabletConn) Execute2(ctx context.Context, query string, bindVars map[string]interface{}, transactionID int64) (*sqltypes.Result, error) {
 	return itc.Execute(ctx, query, bindVars, transactionID)
@@ -7,12 +7,16 @@ import (
 	"io/ioutil"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 
+	"github.com/youtube/vitess/go/vt/logutil"
 	"github.com/youtube/vitess/go/vt/schemamanager"
 	"github.com/youtube/vitess/go/vt/tabletmanager/tmclient"
 	"github.com/youtube/vitess/go/vt/topo"
 	"github.com/youtube/vitess/go/vt/topo/topoproto"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+	"github.com/youtube/vitess/go/vt/wrangler"
 	"golang.org/x/net/context"
 )
 
@@ -248,6 +252,8 @@ func initAPI(ctx context.Context, ts topo.Server, actions *ActionRepository) {
 
 	// VSchema
 	http.HandleFunc(apiPrefix+"vschema/", func(w http.ResponseWriter, r *http.Request) {
+		keyspace := getItemPath(r.URL.Path)
+
 		// Save VSchema
 		if r.Method == "POST" {
 			vschema, err := ioutil.ReadAll(r.Body)
@@ -255,19 +261,85 @@ func initAPI(ctx context.Context, ts topo.Server, actions *ActionRepository) {
 				httpErrorf(w, r, "can't read request body: %v", err)
 				return
 			}
-			if err := ts.SaveVSchema(ctx, string(vschema)); err != nil {
+			wr := wrangler.New(logutil.NewConsoleLogger(), ts, tmclient.NewTabletManagerClient())
+			if keyspace != "" {
+				err = wr.ApplyVSchema(ctx, keyspace, string(vschema))
+			} else {
+				err = applyVSchemas(ctx, wr, vschema)
+			}
+			if err != nil {
 				httpErrorf(w, r, "can't save vschema: %v", err)
 			}
 			return
 		}
 
 		// Get VSchema
-		vschema, err := ts.GetVSchema(ctx)
+		var vschema []byte
+		var err error
+		if keyspace != "" {
+			var data string
+			data, err = ts.GetVSchema(ctx, keyspace)
+			vschema = []byte(data)
+		} else {
+			vschema, err = getVSchemas(ctx, ts)
+		}
 		if err != nil {
 			httpErrorf(w, r, "can't get vschema: %v", err)
 			return
 		}
 		w.Header().Set("Content-Type", jsonContentType)
-		w.Write([]byte(vschema))
+		w.Write(vschema)
 	})
 }
+
+// getVSchemas returns the vschemas of all the keyspaces,
+// combined in the format of a vtgate vschema file.
+func getVSchemas(ctx context.Context, ts topo.Server) ([]byte, error) {
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	source := &planbuilder.SchemaFormal{Keyspaces: make(map[string]planbuilder.KeyspaceFormal)}
+	for _, keyspace := range keyspaces {
+		vschema, err := ts.GetVSchema(ctx, keyspace)
+		if err != nil {
+			return nil, err
+		}
+		ks, err := planbuilder.NewKeyspaceFormal([]byte(vschema))
+		if err != nil {
+			return nil, fmt.Errorf("keyspace %v: %v", keyspace, err)
+		}
+		source.Keyspaces[keyspace] = *ks
+	}
+	return json.Marshal(source)
+}
+
+// applyVSchemas applies the vschema of each keyspace of a vtgate
+// vschema file. The unsharded keyspaces go first, because they
+// hold the sequences the sharded ones may refer to.
+func applyVSchemas(ctx context.Context, wr *wrangler.Wrangler, data []byte) error {
+	var source planbuilder.SchemaFormal
+	if err := json.Unmarshal(data, &source); err != nil {
+		return err
+	}
+	var unsharded, sharded []string
+	for keyspace, ks := range source.Keyspaces {
+		if ks.Sharded {
+			sharded = append(sharded, keyspace)
+		} else {
+			unsharded = append(unsharded, keyspace)
+		}
+	}
+	sort.Strings(unsharded)
+	sort.Strings(sharded)
+	for _, keyspace := range append(unsharded, sharded...) {
+		vschema, err := json.Marshal(source.Keyspaces[keyspace])
+		if err != nil {
+			return err
+		}
+		if err := wr.ApplyVSchema(ctx, keyspace, string(vschema)); err != nil {
+			return fmt.Errorf("keyspace %v: %v", keyspace, err)
+		}
+	}
+	return nil
+}
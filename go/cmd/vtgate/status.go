@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/youtube/vitess/go/vt/servenv"
 	_ "github.com/youtube/vitess/go/vt/status"
+	"github.com/youtube/vitess/go/vt/vtgate"
 )
 
 var (
@@ -72,6 +73,21 @@ var (
   {{end}}
 </table>
 <small>This is just a cache, so some data may not be visible here yet.</small>
+`
+
+	vschemaTemplate = `
+<table>
+  <tr>
+    <th>Version</th>
+    <th>Loaded</th>
+    <th>Last Error</th>
+  </tr>
+  <tr>
+    <td>{{.Version}}</td>
+    <td>{{if .Version}}{{.LoadTime}}{{else}}not loaded{{end}}</td>
+    <td>{{if .LastError}}<b>{{.LastError}}</b>{{end}}</td>
+  </tr>
+</table>
 `
 
 	statsTemplate = `
@@ -225,6 +241,47 @@ google.setOnLoadCallback(function() {
   </tr>
   {{end}}
 </table>
+`
+
+	tabletPickerTemplate = `
+<style>
+  table {
+    border-collapse: collapse;
+  }
+  td, th {
+    border: 1px solid #999;
+    padding: 0.5rem;
+  }
+</style>
+<table>
+  <tr>
+    <th colspan="9">Tablet Weights</th>
+  </tr>
+  <tr>
+    <th>Cell</th>
+    <th>Keyspace</th>
+    <th>Shard</th>
+    <th>TabletType</th>
+    <th>EndPoint</th>
+    <th>Latency</th>
+    <th>CPU Usage</th>
+    <th>RepLag</th>
+    <th>Weight</th>
+  </tr>
+  {{range $i, $tw := .}}
+  <tr>
+    <td>{{$tw.Cell}}</td>
+    <td>{{$tw.Target.Keyspace}}</td>
+    <td>{{$tw.Target.Shard}}</td>
+    <td>{{$tw.Target.TabletType}}</td>
+    <td>{{if $tw.Name}}{{$tw.Name}}{{else}}{{$tw.EndPoint.Host}}:{{index $tw.EndPoint.PortMap "vt"}}{{end}}</td>
+    <td>{{$tw.Latency}}</td>
+    <td>{{$tw.CPUUsage}}</td>
+    <td>{{$tw.SecondsBehindMaster}}</td>
+    <td>{{printf "%.4f" $tw.Weight}}</td>
+  </tr>
+  {{end}}
+</table>
 `
 )
 
@@ -239,6 +296,12 @@ func init() {
 		servenv.AddStatusPart("Health Check Cache (NOT FOR QUERY ROUTING)", healthCheckTemplate, func() interface{} {
 			return healthCheck.CacheStatus()
 		})
+		servenv.AddStatusPart("Tablet Picker", tabletPickerTemplate, func() interface{} {
+			return vtgate.GetTabletWeights()
+		})
+		servenv.AddStatusPart("VSchema", vschemaTemplate, func() interface{} {
+			return vtgate.GetVSchemaStatus()
+		})
 		servenv.AddStatusPart("Stats", statsTemplate, func() interface{} {
 			return nil
 		})
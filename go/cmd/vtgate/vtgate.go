@@ -36,6 +36,7 @@ var (
 	healthCheckRetryDelay = flag.Duration("healthcheck_retry_delay", 2*time.Millisecond, "health check retry delay")
 	tabletTypesToWait     = flag.String("tablet_types_to_wait", "", "wait till connected for specified tablet types during Gateway initialization")
 	testGateway           = flag.String("test_gateway", "", "additional gateway to test health check module")
+	vschemaRetryDelay     = flag.Duration("vschema_retry_delay", 10*time.Second, "delay between the attempts to watch the VSchema in topo, until one succeeds")
 )
 
 var resilientSrvTopoServer *vtgate.ResilientSrvTopoServer
@@ -68,22 +69,8 @@ func main() {
 			exit.Return(1)
 		}
 		log.Infof("v3 is enabled: loaded schema from file: %v", *schemaFile)
-	} else {
-		ctx := context.Background()
-		schemaJSON, err := ts.GetVSchema(ctx)
-		if err != nil {
-			log.Warningf("Skipping v3 initialization: GetVSchema failed: %v", err)
-			goto startServer
-		}
-		schema, err = planbuilder.NewSchema([]byte(schemaJSON))
-		if err != nil {
-			log.Warningf("Skipping v3 initialization: GetVSchema failed: %v", err)
-			goto startServer
-		}
-		log.Infof("v3 is enabled: loaded schema from topo")
 	}
 
-startServer:
 	resilientSrvTopoServer = vtgate.NewResilientSrvTopoServer(ts, "ResilientSrvTopoServer")
 
 	healthCheck = discovery.NewHealthCheck(*connTimeoutTotal, *healthCheckRetryDelay)
@@ -100,5 +87,29 @@ startServer:
 		}
 	}
 	vtgate.Init(healthCheck, ts, resilientSrvTopoServer, schema, *cell, *retryDelay, *retryCount, *connTimeoutTotal, *connTimeoutPerConn, *connLife, tabletTypes, *maxInFlight, *testGateway)
+	if *schemaFile == "" {
+		// The schema is reloaded every time it changes in topo.
+		// If topo can't be read yet, v3 is enabled once it can.
+		if err := vtgate.WatchVSchema(context.Background(), ts); err != nil {
+			log.Warningf("v3 is not enabled yet, retrying in %v: WatchVSchema failed: %v", *vschemaRetryDelay, err)
+			go watchVSchemaWithRetry(ts)
+		} else {
+			log.Infof("v3 is enabled: loaded schema from topo")
+		}
+	}
 	servenv.RunDefault()
 }
+
+// watchVSchemaWithRetry calls vtgate.WatchVSchema every
+// -vschema_retry_delay, until it succeeds.
+func watchVSchemaWithRetry(ts topo.Server) {
+	for {
+		time.Sleep(*vschemaRetryDelay)
+		err := vtgate.WatchVSchema(context.Background(), ts)
+		if err == nil {
+			log.Infof("v3 is enabled: loaded schema from topo")
+			return
+		}
+		log.Warningf("v3 is not enabled yet, retrying in %v: WatchVSchema failed: %v", *vschemaRetryDelay, err)
+	}
+}
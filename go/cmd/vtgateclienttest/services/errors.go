@@ -249,6 +249,13 @@ func (c *errorClient) Rollback(ctx context.Context, session *vtgatepb.Session) e
 	return c.fallbackClient.Rollback(ctx, session)
 }
 
+func (c *errorClient) ResolveTransaction(ctx context.Context, dtid string) error {
+	if err := requestToError(dtid); err != nil {
+		return err
+	}
+	return c.fallbackClient.ResolveTransaction(ctx, dtid)
+}
+
 func (c *errorClient) SplitQuery(ctx context.Context, keyspace string, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64) ([]*vtgatepb.SplitQueryResponse_Part, error) {
 	if err := requestToError(sql); err != nil {
 		return nil, err
@@ -256,6 +263,13 @@ func (c *errorClient) SplitQuery(ctx context.Context, keyspace string, sql strin
 	return c.fallbackClient.SplitQuery(ctx, sql, keyspace, bindVariables, splitColumn, splitCount)
 }
 
+func (c *errorClient) Explain(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	if err := requestToError(sql); err != nil {
+		return nil, err
+	}
+	return c.fallbackClient.Explain(ctx, sql, bindVariables, tabletType)
+}
+
 func (c *errorClient) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	if err := requestToError(keyspace); err != nil {
 		return nil, err
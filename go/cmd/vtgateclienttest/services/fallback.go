@@ -82,10 +82,18 @@ func (c fallbackClient) Rollback(ctx context.Context, session *vtgatepb.Session)
 	return c.fallback.Rollback(ctx, session)
 }
 
+func (c fallbackClient) ResolveTransaction(ctx context.Context, dtid string) error {
+	return c.fallback.ResolveTransaction(ctx, dtid)
+}
+
 func (c fallbackClient) SplitQuery(ctx context.Context, keyspace string, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64) ([]*vtgatepb.SplitQueryResponse_Part, error) {
 	return c.fallback.SplitQuery(ctx, sql, keyspace, bindVariables, splitColumn, splitCount)
 }
 
+func (c fallbackClient) Explain(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	return c.fallback.Explain(ctx, sql, bindVariables, tabletType)
+}
+
 func (c fallbackClient) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	return c.fallback.GetSrvKeyspace(ctx, keyspace)
 }
@@ -87,10 +87,18 @@ func (c *terminalClient) Rollback(ctx context.Context, session *vtgatepb.Session
 	return errTerminal
 }
 
+func (c *terminalClient) ResolveTransaction(ctx context.Context, dtid string) error {
+	return errTerminal
+}
+
 func (c *terminalClient) SplitQuery(ctx context.Context, keyspace string, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64) ([]*vtgatepb.SplitQueryResponse_Part, error) {
 	return nil, errTerminal
 }
 
+func (c *terminalClient) Explain(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	return nil, errTerminal
+}
+
 func (c *terminalClient) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	return nil, errTerminal
 }
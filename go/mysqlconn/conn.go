@@ -0,0 +1,324 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mysqlconn
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+
+	"github.com/youtube/vitess/go/sqldb"
+	"github.com/youtube/vitess/go/sqltypes"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+const connBufferSize = 16 * 1024
+
+// Conn is a connection between a client and a server, using the
+// MySQL client/server protocol. The server side is created by
+// the Listener. Conn is not thread-safe: the Listener uses one
+// go routine per connection.
+type Conn struct {
+	conn net.Conn
+
+	// ConnectionID is set by the server in the handshake.
+	ConnectionID uint32
+
+	// User is the user name the client sent in the handshake.
+	User string
+
+	// SchemaName is the default database name of the
+	// connection. It's set by the handshake and COM_INIT_DB.
+	SchemaName string
+
+	// StatusFlags are sent to the client in the OK and EOF
+	// packets. The Handler can change them, for instance to
+	// report that a transaction is open.
+	StatusFlags uint16
+
+	// ClientData is a place where the Handler can store
+	// per-connection data.
+	ClientData interface{}
+
+	reader *bufio.Reader
+	writer *bufio.Writer
+
+	// sequence is the sequence number of the next packet. It's
+	// reset to 0 for each new command.
+	sequence uint8
+
+	// maxMessageSize is the maximum length of the messages
+	// readPacket returns. 0 means no limit.
+	maxMessageSize int
+}
+
+// errMessageTooLarge is returned by readPacket for a message
+// longer than maxMessageSize.
+var errMessageTooLarge = errors.New("message is larger than the maximum allowed size")
+
+func newConn(conn net.Conn) *Conn {
+	return &Conn{
+		conn:        conn,
+		StatusFlags: ServerStatusAutocommit,
+		reader:      bufio.NewReaderSize(conn, connBufferSize),
+		writer:      bufio.NewWriterSize(conn, connBufferSize),
+	}
+}
+
+// RemoteAddr returns the address of the other side of the connection.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close closes the connection.
+func (c *Conn) Close() {
+	c.conn.Close()
+}
+
+// readPacket reads the payload of the next packet, joining the
+// packets of payloads that are longer than maxPacketSize. It
+// returns errMessageTooLarge, before reading it, for a payload
+// longer than maxMessageSize.
+func (c *Conn) readPacket() ([]byte, error) {
+	var data []byte
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+			return nil, err
+		}
+		length := int(uint32(header[0]) | uint32(header[1])<<8 | uint32(header[2])<<16)
+		if header[3] != c.sequence {
+			return nil, fmt.Errorf("invalid sequence number, expected %v got %v", c.sequence, header[3])
+		}
+		c.sequence++
+		if c.maxMessageSize > 0 && len(data)+length > c.maxMessageSize {
+			return nil, errMessageTooLarge
+		}
+
+		start := len(data)
+		data = append(data, make([]byte, length)...)
+		if _, err := io.ReadFull(c.reader, data[start:]); err != nil {
+			return nil, err
+		}
+		if length < maxPacketSize {
+			return data, nil
+		}
+	}
+}
+
+// writePacket writes data as one or more packets. It doesn't flush.
+func (c *Conn) writePacket(data []byte) error {
+	for {
+		length := len(data)
+		if length > maxPacketSize {
+			length = maxPacketSize
+		}
+		header := [4]byte{byte(length), byte(length >> 8), byte(length >> 16), c.sequence}
+		if _, err := c.writer.Write(header[:]); err != nil {
+			return err
+		}
+		if _, err := c.writer.Write(data[:length]); err != nil {
+			return err
+		}
+		c.sequence++
+		data = data[length:]
+		// A payload of exactly maxPacketSize bytes is
+		// followed by an empty packet.
+		if length < maxPacketSize {
+			return nil
+		}
+	}
+}
+
+func (c *Conn) flush() error {
+	return c.writer.Flush()
+}
+
+// writeOKPacket writes an OK packet and flushes.
+func (c *Conn) writeOKPacket(affectedRows, lastInsertID uint64) error {
+	data := []byte{OKPacket}
+	data = appendLenEncInt(data, affectedRows)
+	data = appendLenEncInt(data, lastInsertID)
+	data = appendUint16(data, c.StatusFlags)
+	data = appendUint16(data, 0) // warnings
+	if err := c.writePacket(data); err != nil {
+		return err
+	}
+	return c.flush()
+}
+
+// writeEOFPacket writes an EOF packet. It doesn't flush.
+func (c *Conn) writeEOFPacket() error {
+	data := []byte{EOFPacket}
+	data = appendUint16(data, 0) // warnings
+	data = appendUint16(data, c.StatusFlags)
+	return c.writePacket(data)
+}
+
+// writeErrorPacket writes an error packet and flushes.
+func (c *Conn) writeErrorPacket(errorCode uint16, sqlState string, format string, args ...interface{}) error {
+	data := []byte{ErrPacket}
+	data = appendUint16(data, errorCode)
+	data = append(data, '#')
+	data = append(data, sqlState...)
+	data = append(data, fmt.Sprintf(format, args...)...)
+	if err := c.writePacket(data); err != nil {
+		return err
+	}
+	return c.flush()
+}
+
+// errnoRegexp extracts the MySQL error code from the errors
+// that carry one in their message, like the ones of vttablet.
+var errnoRegexp = regexp.MustCompile(`\(errno (\d+)\)`)
+
+// writeErrorPacketFromError writes an error packet for err, with
+// the MySQL error code of err if there is one, and flushes.
+func (c *Conn) writeErrorPacketFromError(err error) error {
+	errorCode := ERUnknownError
+	if se, ok := err.(*sqldb.SQLError); ok {
+		errorCode = se.Number()
+	} else if match := errnoRegexp.FindStringSubmatch(err.Error()); match != nil {
+		if num, perr := strconv.Atoi(match[1]); perr == nil {
+			errorCode = num
+		}
+	}
+	return c.writeErrorPacket(uint16(errorCode), SSUnknownSQLState, "%v", err)
+}
+
+// writeResult writes the result of a query, and flushes. A result
+// without fields is sent as an OK packet, otherwise as a text
+// result set.
+func (c *Conn) writeResult(result *sqltypes.Result) error {
+	if len(result.Fields) == 0 {
+		return c.writeOKPacket(result.RowsAffected, result.InsertID)
+	}
+
+	if err := c.writePacket(appendLenEncInt(nil, uint64(len(result.Fields)))); err != nil {
+		return err
+	}
+	for _, field := range result.Fields {
+		if err := c.writePacket(columnDefinition(field)); err != nil {
+			return err
+		}
+	}
+	if err := c.writeEOFPacket(); err != nil {
+		return err
+	}
+	for _, row := range result.Rows {
+		var data []byte
+		for _, value := range row {
+			if value.IsNull() {
+				data = append(data, NullValue)
+				continue
+			}
+			data = appendLenEncString(data, value.Raw())
+		}
+		if err := c.writePacket(data); err != nil {
+			return err
+		}
+	}
+	if err := c.writeEOFPacket(); err != nil {
+		return err
+	}
+	return c.flush()
+}
+
+// columnDefinition returns the ColumnDefinition41 packet of field.
+func columnDefinition(field *querypb.Field) []byte {
+	mysqlType, flags := sqltypes.TypeToMySQL(field.Type)
+	charset := uint16(CharacterSetUtf8)
+	if sqltypes.IsIntegral(field.Type) || sqltypes.IsFloat(field.Type) || field.Type == sqltypes.Decimal {
+		charset = CharacterSetBinary
+		flags |= NumFlag
+	} else if sqltypes.IsBinary(field.Type) {
+		charset = CharacterSetBinary
+	}
+
+	data := appendLenEncString(nil, []byte("def")) // catalog
+	data = appendLenEncString(data, nil)           // schema
+	data = appendLenEncString(data, nil)           // table
+	data = appendLenEncString(data, nil)           // org_table
+	data = appendLenEncString(data, []byte(field.Name))
+	data = appendLenEncString(data, nil) // org_name
+	data = append(data, 0x0c)            // length of the fixed-length fields
+	data = appendUint16(data, charset)
+	data = appendUint32(data, 0) // column length
+	data = append(data, byte(mysqlType))
+	data = appendUint16(data, uint16(flags))
+	data = append(data, 0)    // decimals
+	data = append(data, 0, 0) // filler
+	return data
+}
+
+func appendUint16(data []byte, i uint16) []byte {
+	return append(data, byte(i), byte(i>>8))
+}
+
+func appendUint32(data []byte, i uint32) []byte {
+	return append(data, byte(i), byte(i>>8), byte(i>>16), byte(i>>24))
+}
+
+func appendLenEncInt(data []byte, i uint64) []byte {
+	switch {
+	case i < 251:
+		return append(data, byte(i))
+	case i < 1<<16:
+		return append(data, 0xfc, byte(i), byte(i>>8))
+	case i < 1<<24:
+		return append(data, 0xfd, byte(i), byte(i>>8), byte(i>>16))
+	default:
+		return append(data, 0xfe, byte(i), byte(i>>8), byte(i>>16), byte(i>>24),
+			byte(i>>32), byte(i>>40), byte(i>>48), byte(i>>56))
+	}
+}
+
+func appendLenEncString(data []byte, s []byte) []byte {
+	data = appendLenEncInt(data, uint64(len(s)))
+	return append(data, s...)
+}
+
+// readLenEncInt reads a length-encoded integer at pos, and
+// returns it with the position after it.
+func readLenEncInt(data []byte, pos int) (uint64, int, bool) {
+	if pos >= len(data) {
+		return 0, pos, false
+	}
+	var size int
+	switch data[pos] {
+	case 0xfc:
+		size = 2
+	case 0xfd:
+		size = 3
+	case 0xfe:
+		size = 8
+	default:
+		return uint64(data[pos]), pos + 1, true
+	}
+	if pos+1+size > len(data) {
+		return 0, pos, false
+	}
+	var i uint64
+	for j := 0; j < size; j++ {
+		i |= uint64(data[pos+1+j]) << uint(8*j)
+	}
+	return i, pos + 1 + size, true
+}
+
+// readNullString reads a NUL-terminated string at pos, and
+// returns it with the position after the NUL.
+func readNullString(data []byte, pos int) (string, int, bool) {
+	for end := pos; end < len(data); end++ {
+		if data[end] == 0 {
+			return string(data[pos:end]), end + 1, true
+		}
+	}
+	return "", pos, false
+}
@@ -0,0 +1,98 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mysqlconn
+
+const (
+	// maxPacketSize is the maximum payload length of a packet.
+	// Longer payloads are split in multiple packets.
+	maxPacketSize = (1 << 24) - 1
+
+	// DefaultMaxMessageSize is the maximum length of the
+	// messages the server reads, unless Listener.MaxMessageSize
+	// is set. Like max_allowed_packet in MySQL, it caps the
+	// memory a client can make the server allocate.
+	DefaultMaxMessageSize = 1 << 24
+
+	// protocolVersion is the handshake protocol version.
+	protocolVersion = 10
+
+	// DefaultServerVersion is the server version sent in the
+	// handshake, unless Listener.ServerVersion is set.
+	DefaultServerVersion = "5.5.10-Vitess"
+
+	// mysqlNativePassword is the only authentication
+	// method we advertise.
+	mysqlNativePassword = "mysql_native_password"
+)
+
+// Capability flags.
+// Originally found in include/mysql/mysql_com.h
+const (
+	CapabilityClientLongPassword               = 1
+	CapabilityClientLongFlag                   = 1 << 2
+	CapabilityClientConnectWithDB              = 1 << 3
+	CapabilityClientProtocol41                 = 1 << 9
+	CapabilityClientTransactions               = 1 << 13
+	CapabilityClientSecureConnection           = 1 << 15
+	CapabilityClientPluginAuth                 = 1 << 19
+	CapabilityClientPluginAuthLenencClientData = 1 << 21
+)
+
+// Status flags. They are returned by the server in OK and EOF packets.
+// Originally found in include/mysql/mysql_com.h
+const (
+	ServerStatusInTrans    = 0x0001
+	ServerStatusAutocommit = 0x0002
+)
+
+// Packet types.
+const (
+	OKPacket  = 0x00
+	EOFPacket = 0xfe
+	ErrPacket = 0xff
+
+	// NullValue is the encoded value of NULL in a text result set.
+	NullValue = 0xfb
+)
+
+// Commands the server handles.
+// Originally found in include/mysql/mysql_com.h
+const (
+	ComQuit   = 0x01
+	ComInitDB = 0x02
+	ComQuery  = 0x03
+	ComPing   = 0x0e
+)
+
+// Error codes the server returns.
+// Originally found in include/mysql/mysqld_error.h
+const (
+	ERUnknownComError   = 1047
+	ERUnknownError      = 1105
+	ERNetPacketTooLarge = 1153
+
+	// SSUnknownSQLState is the generic SQLSTATE.
+	SSUnknownSQLState = "HY000"
+
+	// SSNetError is the SQLSTATE of the network errors.
+	SSNetError = "08S01"
+)
+
+// Character sets.
+// Originally found in include/mysql/mysql_com.h
+const (
+	// CharacterSetUtf8 is utf8_general_ci.
+	CharacterSetUtf8 = 33
+
+	// CharacterSetBinary is used for binary and numeric columns.
+	CharacterSetBinary = 63
+)
+
+// Column flags, in addition to the ones sqltypes.TypeToMySQL returns.
+// Originally found in include/mysql/mysql_com.h
+const (
+	// NumFlag is set on numeric columns.
+	NumFlag = 32768
+)
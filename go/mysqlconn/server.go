@@ -0,0 +1,334 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mysqlconn
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	log "github.com/golang/glog"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/tb"
+)
+
+// serverCapabilities are the capabilities the server advertises in
+// the handshake.
+const serverCapabilities = CapabilityClientLongPassword |
+	CapabilityClientLongFlag |
+	CapabilityClientConnectWithDB |
+	CapabilityClientProtocol41 |
+	CapabilityClientTransactions |
+	CapabilityClientSecureConnection |
+	CapabilityClientPluginAuth |
+	CapabilityClientPluginAuthLenencClientData
+
+// Handler is the interface used by the server to notify of events
+// and run queries. Its methods are called from the go routine of
+// each connection, so they need to be thread-safe across
+// connections.
+type Handler interface {
+	// NewConnection is called when a connection is created,
+	// after the handshake.
+	NewConnection(c *Conn)
+
+	// ConnectionClosed is called when a connection is closed.
+	ConnectionClosed(c *Conn)
+
+	// ComQuery is called when a connection receives a query.
+	// A result without fields is sent as an OK packet.
+	// The database sent in the handshake, and the COM_INIT_DB
+	// commands, are sent to ComQuery as USE statements.
+	ComQuery(c *Conn, query string) (*sqltypes.Result, error)
+}
+
+// Listener is the MySQL server protocol listener. It doesn't
+// authenticate the clients: the user name they send is only used
+// to identify them.
+type Listener struct {
+	// ServerVersion is the version the server advertises in the
+	// handshake. It defaults to DefaultServerVersion.
+	ServerVersion string
+
+	// MaxMessageSize is the maximum length of the messages the
+	// clients can send. Longer messages are rejected, and the
+	// connection is closed. It defaults to DefaultMaxMessageSize.
+	MaxMessageSize int
+
+	handler  Handler
+	listener net.Listener
+
+	// connectionID is the id of the last connection.
+	connectionID uint32
+
+	// closed is set to 1 by Close.
+	closed int32
+}
+
+// NewListener creates a new Listener listening on the given address.
+// Call Accept to start serving connections.
+func NewListener(protocol, address string, handler Handler) (*Listener, error) {
+	listener, err := net.Listen(protocol, address)
+	if err != nil {
+		return nil, err
+	}
+	return &Listener{
+		ServerVersion:  DefaultServerVersion,
+		MaxMessageSize: DefaultMaxMessageSize,
+		handler:        handler,
+		listener:       listener,
+	}, nil
+}
+
+// Addr returns the listener address.
+func (l *Listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Accept runs an accept loop until the listener is closed.
+// Like net/http, it retries the temporary errors, for instance
+// when the process runs out of file descriptors, with a backoff.
+func (l *Listener) Accept() {
+	var tempDelay time.Duration
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			if atomic.LoadInt32(&l.closed) == 1 {
+				return
+			}
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				if tempDelay == 0 {
+					tempDelay = 5 * time.Millisecond
+				} else {
+					tempDelay *= 2
+				}
+				if max := 1 * time.Second; tempDelay > max {
+					tempDelay = max
+				}
+				log.Warningf("mysql_server: Accept error: %v; retrying in %v", err, tempDelay)
+				time.Sleep(tempDelay)
+				continue
+			}
+			log.Errorf("mysql_server: Accept error, stopping the listener: %v", err)
+			return
+		}
+		tempDelay = 0
+		connectionID := atomic.AddUint32(&l.connectionID, 1)
+		go l.handle(conn, connectionID)
+	}
+}
+
+// Close stops the listener. Existing connections are not closed.
+func (l *Listener) Close() {
+	atomic.StoreInt32(&l.closed, 1)
+	l.listener.Close()
+}
+
+// handle runs the handshake and the command loop of a connection.
+func (l *Listener) handle(conn net.Conn, connectionID uint32) {
+	c := newConn(conn)
+	c.ConnectionID = connectionID
+	c.maxMessageSize = l.MaxMessageSize
+	defer c.Close()
+
+	// Catch panics, and close the connection in any case.
+	defer func() {
+		if x := recover(); x != nil {
+			log.Errorf("mysql_server caught panic:\n%v\n%s", x, tb.Stack(4))
+		}
+	}()
+
+	if err := l.writeHandshakeV10(c); err != nil {
+		log.Infof("Cannot send HandshakeV10 packet to %v: %v", c.RemoteAddr(), err)
+		return
+	}
+	response, err := c.readPacket()
+	if err != nil {
+		log.Infof("Cannot read client handshake response from %v: %v", c.RemoteAddr(), err)
+		l.writeMessageTooLarge(c, err)
+		return
+	}
+	if err := l.parseClientHandshakePacket(c, response); err != nil {
+		log.Infof("Cannot parse client handshake response from %v: %v", c.RemoteAddr(), err)
+		c.writeErrorPacket(ERUnknownError, SSUnknownSQLState, "%v", err)
+		return
+	}
+
+	l.handler.NewConnection(c)
+	defer l.handler.ConnectionClosed(c)
+
+	if c.SchemaName != "" {
+		if err := l.useSchema(c, c.SchemaName); err != nil {
+			log.Infof("Cannot use database %v for %v: %v", c.SchemaName, c.RemoteAddr(), err)
+			c.writeErrorPacketFromError(err)
+			return
+		}
+	}
+	if err := c.writeOKPacket(0, 0); err != nil {
+		log.Infof("Cannot write OK packet to %v: %v", c.RemoteAddr(), err)
+		return
+	}
+
+	for {
+		c.sequence = 0
+		data, err := c.readPacket()
+		if err != nil {
+			if err != io.EOF {
+				log.Infof("Error reading packet from %v: %v", c.RemoteAddr(), err)
+			}
+			l.writeMessageTooLarge(c, err)
+			return
+		}
+		if len(data) == 0 {
+			log.Infof("Empty packet from %v", c.RemoteAddr())
+			return
+		}
+
+		switch data[0] {
+		case ComQuit:
+			return
+		case ComInitDB:
+			if err = l.useSchema(c, string(data[1:])); err != nil {
+				err = c.writeErrorPacketFromError(err)
+			} else {
+				err = c.writeOKPacket(0, 0)
+			}
+		case ComQuery:
+			var result *sqltypes.Result
+			result, err = l.handler.ComQuery(c, string(data[1:]))
+			if err != nil {
+				err = c.writeErrorPacketFromError(err)
+			} else {
+				err = c.writeResult(result)
+			}
+		case ComPing:
+			err = c.writeOKPacket(0, 0)
+		default:
+			log.Infof("Unsupported command %v from %v", data[0], c.RemoteAddr())
+			err = c.writeErrorPacket(ERUnknownComError, SSUnknownSQLState, "command handling not implemented yet: %v", data[0])
+		}
+		if err != nil {
+			log.Infof("Error writing response to %v: %v", c.RemoteAddr(), err)
+			return
+		}
+	}
+}
+
+// writeMessageTooLarge tells the client that its message was
+// rejected, if err is errMessageTooLarge. The rest of the message
+// isn't read, so the connection must be closed after it.
+func (l *Listener) writeMessageTooLarge(c *Conn, err error) {
+	if err != errMessageTooLarge {
+		return
+	}
+	if err := c.writeErrorPacket(ERNetPacketTooLarge, SSNetError, "Got a packet bigger than %v bytes", l.MaxMessageSize); err != nil {
+		log.Infof("Error writing response to %v: %v", c.RemoteAddr(), err)
+	}
+}
+
+// useSchema sends a USE statement for schemaName to the handler,
+// and makes it the schema name of the connection if it succeeds.
+// The backquotes in schemaName are escaped, so it can't end the
+// quoted identifier.
+func (l *Listener) useSchema(c *Conn, schemaName string) error {
+	if _, err := l.handler.ComQuery(c, "use `"+strings.Replace(schemaName, "`", "``", -1)+"`"); err != nil {
+		return err
+	}
+	c.SchemaName = schemaName
+	return nil
+}
+
+// writeHandshakeV10 writes the initial handshake packet. The salt
+// is random, but since the clients are not authenticated, it is
+// never used.
+func (l *Listener) writeHandshakeV10(c *Conn) error {
+	salt := make([]byte, 20)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	// The salt can't contain NUL bytes, as the second part is
+	// NUL-terminated.
+	for i := range salt {
+		salt[i] = salt[i]&0x7f | 1
+	}
+
+	data := []byte{protocolVersion}
+	data = append(data, l.ServerVersion...)
+	data = append(data, 0)
+	data = appendUint32(data, c.ConnectionID)
+	data = append(data, salt[:8]...)
+	data = append(data, 0) // filler
+	data = appendUint16(data, uint16(serverCapabilities&0xffff))
+	data = append(data, CharacterSetUtf8)
+	data = appendUint16(data, c.StatusFlags)
+	data = appendUint16(data, uint16(serverCapabilities>>16))
+	data = append(data, byte(len(salt)+1))
+	data = append(data, make([]byte, 10)...) // reserved
+	data = append(data, salt[8:]...)
+	data = append(data, 0)
+	data = append(data, mysqlNativePassword...)
+	data = append(data, 0)
+
+	if err := c.writePacket(data); err != nil {
+		return err
+	}
+	return c.flush()
+}
+
+// parseClientHandshakePacket parses a HandshakeResponse41 packet,
+// and sets the user and schema name of the connection.
+func (l *Listener) parseClientHandshakePacket(c *Conn, data []byte) error {
+	// capability flags, max packet size, character set, and
+	// 23 reserved bytes.
+	if len(data) < 32 {
+		return fmt.Errorf("client handshake response is too short: %v bytes", len(data))
+	}
+	clientFlags := uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16 | uint32(data[3])<<24
+	if clientFlags&CapabilityClientProtocol41 == 0 {
+		return fmt.Errorf("client does not support protocol 4.1")
+	}
+	pos := 32
+
+	user, pos, ok := readNullString(data, pos)
+	if !ok {
+		return fmt.Errorf("cannot read user name")
+	}
+	c.User = user
+
+	// The auth response is skipped, we don't check it.
+	switch {
+	case clientFlags&CapabilityClientPluginAuthLenencClientData != 0:
+		var length uint64
+		length, pos, ok = readLenEncInt(data, pos)
+		if !ok || pos+int(length) > len(data) {
+			return fmt.Errorf("cannot read auth response")
+		}
+		pos += int(length)
+	case clientFlags&CapabilityClientSecureConnection != 0:
+		if pos >= len(data) || pos+1+int(data[pos]) > len(data) {
+			return fmt.Errorf("cannot read auth response")
+		}
+		pos += 1 + int(data[pos])
+	default:
+		if _, pos, ok = readNullString(data, pos); !ok {
+			return fmt.Errorf("cannot read auth response")
+		}
+	}
+
+	if clientFlags&CapabilityClientConnectWithDB != 0 && pos < len(data) {
+		schemaName, _, ok := readNullString(data, pos)
+		if !ok {
+			// Some clients don't NUL-terminate the last field.
+			schemaName = string(data[pos:])
+		}
+		c.SchemaName = schemaName
+	}
+	return nil
+}
@@ -0,0 +1,416 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mysqlconn
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/youtube/vitess/go/sqldb"
+	"github.com/youtube/vitess/go/sqltypes"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+type testHandler struct {
+	mu      sync.Mutex
+	queries []string
+	closed  chan *Conn
+}
+
+func newTestHandler() *testHandler {
+	return &testHandler{
+		closed: make(chan *Conn, 10),
+	}
+}
+
+func (th *testHandler) NewConnection(c *Conn) {
+}
+
+func (th *testHandler) ConnectionClosed(c *Conn) {
+	th.closed <- c
+}
+
+func (th *testHandler) ComQuery(c *Conn, query string) (*sqltypes.Result, error) {
+	th.mu.Lock()
+	th.queries = append(th.queries, fmt.Sprintf("%v:%v:%v", c.User, c.SchemaName, query))
+	th.mu.Unlock()
+
+	switch query {
+	case "select rows":
+		return &sqltypes.Result{
+			Fields: []*querypb.Field{
+				{Name: "id", Type: sqltypes.Int64},
+				{Name: "name", Type: sqltypes.VarChar},
+			},
+			Rows: [][]sqltypes.Value{
+				{sqltypes.MakeTrusted(sqltypes.Int64, []byte("10")), sqltypes.MakeTrusted(sqltypes.VarChar, []byte("abcd"))},
+				{sqltypes.MakeTrusted(sqltypes.Int64, []byte("20")), sqltypes.NULL},
+			},
+		}, nil
+	case "insert":
+		return &sqltypes.Result{
+			RowsAffected: 2,
+			InsertID:     300,
+		}, nil
+	case "sql error":
+		return nil, &sqldb.SQLError{Num: 1062, Message: "duplicate entry"}
+	case "vttablet error":
+		return nil, fmt.Errorf("vtgate: target: ks.0.master, error: unknown column (errno 1054) (sqlstate 42S22)")
+	case "error":
+		return nil, fmt.Errorf("generic error")
+	case "use `baddb`", "use `bad``db`":
+		return nil, &sqldb.SQLError{Num: 1049, Message: "unknown database baddb"}
+	}
+	return &sqltypes.Result{}, nil
+}
+
+// testClient is a minimal client side of the protocol.
+type testClient struct {
+	t *testing.T
+	*Conn
+}
+
+// connect dials the listener and runs the client side of the
+// handshake.
+func connect(t *testing.T, l *Listener, user, schemaName string) *testClient {
+	c := handshake(t, l, user, schemaName)
+	c.readOK()
+	return c
+}
+
+// handshake dials the listener and sends the handshake response,
+// without reading the server reply to it.
+func handshake(t *testing.T, l *Listener, user, schemaName string) *testClient {
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	c := &testClient{t: t, Conn: newConn(conn)}
+
+	data, err := c.readPacket()
+	if err != nil {
+		t.Fatalf("cannot read handshake: %v", err)
+	}
+	if data[0] != protocolVersion {
+		t.Fatalf("bad protocol version: %v", data[0])
+	}
+	serverVersion, pos, ok := readNullString(data, 1)
+	if !ok || serverVersion != DefaultServerVersion {
+		t.Fatalf("bad server version: %v", serverVersion)
+	}
+	c.ConnectionID = uint32(data[pos]) | uint32(data[pos+1])<<8 | uint32(data[pos+2])<<16 | uint32(data[pos+3])<<24
+
+	flags := uint32(CapabilityClientProtocol41 | CapabilityClientSecureConnection)
+	if schemaName != "" {
+		flags |= CapabilityClientConnectWithDB
+	}
+	response := appendUint32(nil, flags)
+	response = appendUint32(response, maxPacketSize)
+	response = append(response, CharacterSetUtf8)
+	response = append(response, make([]byte, 23)...)
+	response = append(response, user...)
+	response = append(response, 0)
+	// A fake 20-byte auth response.
+	response = append(response, 20)
+	response = append(response, make([]byte, 20)...)
+	if schemaName != "" {
+		response = append(response, schemaName...)
+		response = append(response, 0)
+	}
+	if err := c.writePacket(response); err != nil {
+		t.Fatalf("cannot write handshake response: %v", err)
+	}
+	if err := c.flush(); err != nil {
+		t.Fatalf("cannot flush handshake response: %v", err)
+	}
+	return c
+}
+
+// command sends a command packet.
+func (c *testClient) command(cmd byte, arg string) {
+	c.sequence = 0
+	if err := c.writePacket(append([]byte{cmd}, arg...)); err != nil {
+		c.t.Fatalf("cannot write command: %v", err)
+	}
+	if err := c.flush(); err != nil {
+		c.t.Fatalf("cannot flush command: %v", err)
+	}
+}
+
+func (c *testClient) readPacketOrFail() []byte {
+	data, err := c.readPacket()
+	if err != nil {
+		c.t.Fatalf("readPacket failed: %v", err)
+	}
+	return data
+}
+
+// readOK reads an OK packet, and returns the affected rows, the
+// insert id and the status flags.
+func (c *testClient) readOK() (uint64, uint64, uint16) {
+	data := c.readPacketOrFail()
+	if data[0] != OKPacket {
+		c.t.Fatalf("expected OK packet, got %v", data)
+	}
+	affectedRows, pos, _ := readLenEncInt(data, 1)
+	insertID, pos, _ := readLenEncInt(data, pos)
+	return affectedRows, insertID, uint16(data[pos]) | uint16(data[pos+1])<<8
+}
+
+// readError reads an error packet, and returns the error code, the
+// SQLSTATE and the message.
+func (c *testClient) readError() (int, string, string) {
+	data := c.readPacketOrFail()
+	if data[0] != ErrPacket || data[3] != '#' {
+		c.t.Fatalf("expected error packet, got %v", data)
+	}
+	return int(data[1]) | int(data[2])<<8, string(data[4:9]), string(data[9:])
+}
+
+// readResult reads a text result set, and returns the column names
+// and types, and the rows with NULL values as nil.
+func (c *testClient) readResult() ([]string, []byte, [][][]byte) {
+	data := c.readPacketOrFail()
+	count, _, _ := readLenEncInt(data, 0)
+	var names []string
+	var types []byte
+	for i := uint64(0); i < count; i++ {
+		data = c.readPacketOrFail()
+		pos := 0
+		var fields [][]byte
+		for j := 0; j < 6; j++ {
+			length, next, _ := readLenEncInt(data, pos)
+			fields = append(fields, data[next:next+int(length)])
+			pos = next + int(length)
+		}
+		if string(fields[0]) != "def" {
+			c.t.Errorf("bad catalog: %s", fields[0])
+		}
+		names = append(names, string(fields[4]))
+		// skip the 0x0c, the character set and the length
+		types = append(types, data[pos+7])
+	}
+	if data = c.readPacketOrFail(); data[0] != EOFPacket {
+		c.t.Fatalf("expected EOF packet after the fields, got %v", data)
+	}
+	var rows [][][]byte
+	for {
+		data = c.readPacketOrFail()
+		if data[0] == EOFPacket && len(data) < 9 {
+			return names, types, rows
+		}
+		var row [][]byte
+		for pos := 0; pos < len(data); {
+			if data[pos] == NullValue {
+				row = append(row, nil)
+				pos++
+				continue
+			}
+			length, next, _ := readLenEncInt(data, pos)
+			row = append(row, data[next:next+int(length)])
+			pos = next + int(length)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestServer(t *testing.T) {
+	th := newTestHandler()
+	l, err := NewListener("tcp", ":0", th)
+	if err != nil {
+		t.Fatalf("NewListener failed: %v", err)
+	}
+	defer l.Close()
+	go l.Accept()
+
+	c := connect(t, l, "user1", "ks")
+
+	// A result set.
+	c.command(ComQuery, "select rows")
+	names, types, rows := c.readResult()
+	if want := []string{"id", "name"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names: %v, want %v", names, want)
+	}
+	if want := []byte{8, 253}; !reflect.DeepEqual(types, want) {
+		t.Errorf("types: %v, want %v", types, want)
+	}
+	wantRows := [][][]byte{
+		{[]byte("10"), []byte("abcd")},
+		{[]byte("20"), nil},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("rows: %q, want %q", rows, wantRows)
+	}
+
+	// A DML result.
+	c.command(ComQuery, "insert")
+	affectedRows, insertID, status := c.readOK()
+	if affectedRows != 2 || insertID != 300 || status != ServerStatusAutocommit {
+		t.Errorf("insert: %v %v %v, want 2 300 %v", affectedRows, insertID, status, ServerStatusAutocommit)
+	}
+
+	// Errors.
+	testcases := []struct {
+		query   string
+		code    int
+		message string
+	}{{
+		query:   "sql error",
+		code:    1062,
+		message: "duplicate entry (errno 1062)",
+	}, {
+		query:   "vttablet error",
+		code:    1054,
+		message: "unknown column (errno 1054)",
+	}, {
+		query:   "error",
+		code:    ERUnknownError,
+		message: "generic error",
+	}}
+	for _, tc := range testcases {
+		c.command(ComQuery, tc.query)
+		code, sqlState, message := c.readError()
+		if code != tc.code || sqlState != SSUnknownSQLState || !strings.Contains(message, tc.message) {
+			t.Errorf("%v: %v %v %v, want %v %v %v", tc.query, code, sqlState, message, tc.code, SSUnknownSQLState, tc.message)
+		}
+	}
+
+	// COM_INIT_DB changes the schema name if the handler accepts
+	// it, COM_PING works.
+	c.command(ComInitDB, "baddb")
+	if code, _, _ := c.readError(); code != 1049 {
+		t.Errorf("COM_INIT_DB baddb: %v, want 1049", code)
+	}
+	c.command(ComInitDB, "ks2")
+	c.readOK()
+	c.command(ComInitDB, "bad`db")
+	if code, _, _ := c.readError(); code != 1049 {
+		t.Errorf("COM_INIT_DB bad`db: %v, want 1049", code)
+	}
+	c.command(ComPing, "")
+	c.readOK()
+	c.command(ComQuery, "other")
+	c.readOK()
+
+	// Unknown commands are rejected, and the connection stays up.
+	c.command(0x10, "")
+	if code, _, _ := c.readError(); code != ERUnknownComError {
+		t.Errorf("unknown command: %v, want %v", code, ERUnknownComError)
+	}
+
+	want := []string{
+		"user1:ks:use `ks`",
+		"user1:ks:select rows",
+		"user1:ks:insert",
+		"user1:ks:sql error",
+		"user1:ks:vttablet error",
+		"user1:ks:error",
+		"user1:ks:use `baddb`",
+		"user1:ks:use `ks2`",
+		"user1:ks2:use `bad``db`",
+		"user1:ks2:other",
+	}
+	th.mu.Lock()
+	if !reflect.DeepEqual(th.queries, want) {
+		t.Errorf("queries: %v, want %v", th.queries, want)
+	}
+	th.mu.Unlock()
+
+	// A second connection gets another id.
+	c2 := connect(t, l, "user2", "")
+	if c2.ConnectionID == c.ConnectionID {
+		t.Errorf("both connections have id %v", c.ConnectionID)
+	}
+	c2.Close()
+	<-th.closed
+
+	// A database the handler rejects fails the handshake.
+	c3 := handshake(t, l, "user3", "baddb")
+	if code, _, _ := c3.readError(); code != 1049 {
+		t.Errorf("handshake with baddb: %v, want 1049", code)
+	}
+	<-th.closed
+	c3.Close()
+
+	// COM_QUIT closes the connection.
+	c.command(ComQuit, "")
+	closed := <-th.closed
+	if closed.User != "user1" {
+		t.Errorf("closed connection: %v, want user1", closed.User)
+	}
+}
+
+func TestServerMessageTooLarge(t *testing.T) {
+	th := newTestHandler()
+	l, err := NewListener("tcp", ":0", th)
+	if err != nil {
+		t.Fatalf("NewListener failed: %v", err)
+	}
+	l.MaxMessageSize = 100
+	defer l.Close()
+	go l.Accept()
+
+	c := connect(t, l, "user1", "")
+	c.command(ComQuery, strings.Repeat("a", 100))
+	if code, sqlState, _ := c.readError(); code != ERNetPacketTooLarge || sqlState != SSNetError {
+		t.Errorf("large query: %v %v, want %v %v", code, sqlState, ERNetPacketTooLarge, SSNetError)
+	}
+	<-th.closed
+	c.Close()
+
+	th.mu.Lock()
+	if len(th.queries) != 0 {
+		t.Errorf("queries: %v, want none", th.queries)
+	}
+	th.mu.Unlock()
+}
+
+func TestPacketSplitting(t *testing.T) {
+	client, server := net.Pipe()
+	cConn := newConn(client)
+	sConn := newConn(server)
+
+	for _, length := range []int{0, 10, maxPacketSize - 1, maxPacketSize, maxPacketSize + 10} {
+		data := make([]byte, length)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		cConn.sequence = 0
+		sConn.sequence = 0
+		done := make(chan error)
+		go func() {
+			err := cConn.writePacket(data)
+			if err == nil {
+				err = cConn.flush()
+			}
+			done <- err
+		}()
+		got, err := sConn.readPacket()
+		if err != nil {
+			t.Fatalf("readPacket(%v) failed: %v", length, err)
+		}
+		if err := <-done; err != nil {
+			t.Fatalf("writePacket(%v) failed: %v", length, err)
+		}
+		if !reflect.DeepEqual(got, data) && !(len(got) == 0 && length == 0) {
+			t.Errorf("readPacket(%v) returned %v bytes", length, len(got))
+		}
+	}
+}
+
+func TestLenEncInt(t *testing.T) {
+	for _, i := range []uint64{0, 250, 251, 1<<16 - 1, 1 << 16, 1<<24 - 1, 1 << 24, 1<<64 - 1} {
+		data := appendLenEncInt(nil, i)
+		got, pos, ok := readLenEncInt(data, 0)
+		if !ok || got != i || pos != len(data) {
+			t.Errorf("readLenEncInt(appendLenEncInt(%v)): %v %v %v", i, got, pos, ok)
+		}
+	}
+}
@@ -148,11 +148,21 @@ func (f *fakeVTGateService) Rollback(ctx context.Context, session *vtgatepb.Sess
 	return nil
 }
 
+// ResolveTransaction is part of the VTGateService interface
+func (f *fakeVTGateService) ResolveTransaction(ctx context.Context, dtid string) error {
+	return nil
+}
+
 // SplitQuery is part of the VTGateService interface
 func (f *fakeVTGateService) SplitQuery(ctx context.Context, keyspace string, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64) ([]*vtgatepb.SplitQueryResponse_Part, error) {
 	return nil, nil
 }
 
+// Explain is part of the VTGateService interface
+func (f *fakeVTGateService) Explain(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	return &vtgatepb.QueryPlan{}, nil
+}
+
 // GetSrvKeyspace is part of the VTGateService interface
 func (f *fakeVTGateService) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	return &topodatapb.SrvKeyspace{}, nil
@@ -481,6 +481,30 @@ func (hc *HealthCheckImpl) deleteEndPointFromTargetProtected(target *querypb.Tar
 	}
 }
 
+// CellHealth is the aggregated health of the endpoints of a cell.
+type CellHealth struct {
+	Cell    string
+	Healthy int // number of endpoints that are serving without error
+	Total   int
+}
+
+// AggregateByCell aggregates the health of the endpoints of epsList per cell.
+func AggregateByCell(epsList []*EndPointStats) map[string]*CellHealth {
+	res := make(map[string]*CellHealth)
+	for _, eps := range epsList {
+		ch, ok := res[eps.Cell]
+		if !ok {
+			ch = &CellHealth{Cell: eps.Cell}
+			res[eps.Cell] = ch
+		}
+		ch.Total++
+		if eps.Serving && eps.LastError == nil {
+			ch.Healthy++
+		}
+	}
+	return res
+}
+
 // EndPointsCacheStatus is the current endpoints for a cell/target.
 // TODO: change this to reflect the e2e information about the endpoints.
 type EndPointsCacheStatus struct {
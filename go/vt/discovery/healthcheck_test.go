@@ -113,6 +113,11 @@ func TestHealthCheck(t *testing.T) {
 	if len(epsList) != 1 || !reflect.DeepEqual(epsList[0], want) {
 		t.Errorf(`hc.GetEndPointStatsFromTarget("k", "s", REPLICA) = %+v; want %+v`, epsList, want)
 	}
+	chm := AggregateByCell(epsList)
+	chmWant := map[string]*CellHealth{"cell": {Cell: "cell", Healthy: 1, Total: 1}}
+	if !reflect.DeepEqual(chm, chmWant) {
+		t.Errorf(`AggregateByCell(%+v) = %+v; want %+v`, epsList, chm, chmWant)
+	}
 
 	// Serving & RealtimeStats changed
 	shr = &querypb.StreamHealthResponse{
@@ -161,6 +166,13 @@ func TestHealthCheck(t *testing.T) {
 		t.Errorf(`<-l.output: %+v; want %+v`, res, want)
 	}
 
+	epsList = hc.GetEndPointStatsFromTarget("k", "s", topodatapb.TabletType_REPLICA)
+	chm = AggregateByCell(epsList)
+	chmWant = map[string]*CellHealth{"cell": {Cell: "cell", Healthy: 0, Total: 1}}
+	if !reflect.DeepEqual(chm, chmWant) {
+		t.Errorf(`AggregateByCell(%+v) = %+v; want %+v`, epsList, chm, chmWant)
+	}
+
 	// remove endpoint
 	hc.deleteConn(ep)
 	t.Logf(`hc.RemoveEndPoint({Host: "a", PortMap: {"vt": 1}})`)
@@ -184,6 +196,24 @@ func TestHealthCheck(t *testing.T) {
 	}
 }
 
+func TestAggregateByCell(t *testing.T) {
+	epsList := []*EndPointStats{
+		{Cell: "cell1", Serving: true},
+		{Cell: "cell1", Serving: false},
+		{Cell: "cell2", Serving: true, LastError: fmt.Errorf("error")},
+		{Cell: "cell3", Serving: true},
+	}
+	got := AggregateByCell(epsList)
+	want := map[string]*CellHealth{
+		"cell1": {Cell: "cell1", Healthy: 1, Total: 2},
+		"cell2": {Cell: "cell2", Healthy: 0, Total: 1},
+		"cell3": {Cell: "cell3", Healthy: 1, Total: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AggregateByCell(%+v) = %+v; want %+v", epsList, got, want)
+	}
+}
+
 type listener struct {
 	output chan *EndPointStats
 }
@@ -265,6 +295,38 @@ func (fc *fakeConn) Rollback2(ctx context.Context, transactionID int64) error {
 	return fc.Rollback(ctx, transactionID)
 }
 
+func (fc *fakeConn) Prepare(ctx context.Context, transactionID int64, dtid string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fc *fakeConn) CommitPrepared(ctx context.Context, dtid string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fc *fakeConn) RollbackPrepared(ctx context.Context, dtid string, originalID int64) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fc *fakeConn) CreateTransaction(ctx context.Context, dtid string, participants []*querypb.Target) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fc *fakeConn) StartCommit(ctx context.Context, transactionID int64, dtid string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fc *fakeConn) SetRollback(ctx context.Context, dtid string, transactionID int64) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fc *fakeConn) ConcludeTransaction(ctx context.Context, dtid string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (fc *fakeConn) ReadTransaction(ctx context.Context, dtid string) (*querypb.TransactionMetadata, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (fc *fakeConn) SplitQuery(ctx context.Context, query querytypes.BoundQuery, splitColumn string, splitCount int64) ([]querytypes.QuerySplit, error) {
 	return nil, fmt.Errorf("not implemented")
 }
@@ -163,3 +163,10 @@ func TestVSchema(t *testing.T) {
 	defer ts.Close()
 	test.CheckVSchema(ctx, t, ts)
 }
+
+func TestWatchVSchema(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t, []string{"test"})
+	defer ts.Close()
+	test.CheckWatchVSchema(ctx, t, ts)
+}
@@ -1,36 +1,40 @@
 package etcdtopo
 
 import (
+	"time"
+
+	"github.com/coreos/go-etcd/etcd"
+	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/vt/topo"
 	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
 	"golang.org/x/net/context"
-	// vindexes needs to be imported so that they register
-	// themselves against vtgate/planbuilder. This will allow
-	// us to sanity check the schema being uploaded.
-	_ "github.com/youtube/vitess/go/vt/vtgate/vindexes"
 )
 
 /*
 This file contains the vschema management code for etcdtopo.Server
 */
 
-// SaveVSchema saves the JSON vschema into the topo.
-func (s *Server) SaveVSchema(ctx context.Context, vschema string) error {
-	_, err := planbuilder.NewSchema([]byte(vschema))
-	if err != nil {
+// SaveVSchema saves the JSON vschema of a keyspace into the topo.
+func (s *Server) SaveVSchema(ctx context.Context, keyspace, vschema string) error {
+	if _, err := planbuilder.NewKeyspaceFormal([]byte(vschema)); err != nil {
 		return err
 	}
 
-	_, err = s.getGlobal().Set(vschemaPath, vschema, 0 /* ttl */)
-	if err != nil {
+	global := s.getGlobal()
+	// etcd creates the missing directories, so we need to
+	// make sure the keyspace exists first.
+	if _, err := global.Get(keyspaceFilePath(keyspace), false /* sort */, false /* recursive */); err != nil {
+		return convertError(err)
+	}
+	if _, err := global.Set(vschemaFilePath(keyspace), vschema, 0 /* ttl */); err != nil {
 		return convertError(err)
 	}
 	return nil
 }
 
-// GetVSchema fetches the JSON vschema from the topo.
-func (s *Server) GetVSchema(ctx context.Context) (string, error) {
-	resp, err := s.getGlobal().Get(vschemaPath, false /* sort */, false /* recursive */)
+// GetVSchema fetches the JSON vschema of a keyspace from the topo.
+func (s *Server) GetVSchema(ctx context.Context, keyspace string) (string, error) {
+	resp, err := s.getGlobal().Get(vschemaFilePath(keyspace), false /* sort */, false /* recursive */)
 	if err != nil {
 		err = convertError(err)
 		if err == topo.ErrNoNode {
@@ -43,3 +47,78 @@ func (s *Server) GetVSchema(ctx context.Context) (string, error) {
 	}
 	return resp.Node.Value, nil
 }
+
+// WatchVSchema is part of the topo.Server interface.
+func (s *Server) WatchVSchema(ctx context.Context, keyspace string) (<-chan string, chan<- struct{}, error) {
+	filePath := vschemaFilePath(keyspace)
+	global := s.getGlobal()
+	notifications := make(chan string, 10)
+	stopWatching := make(chan struct{})
+
+	// The watch go routine will stop if the 'stop' channel is closed.
+	// Otherwise it will try to watch everything in a loop, and send events
+	// to the 'watch' channel.
+	watch := make(chan *etcd.Response)
+	stop := make(chan bool)
+	go func() {
+		vschema := "{}"
+		var modifiedVersion int64
+
+		resp, err := global.Get(filePath, false /* sort */, false /* recursive */)
+		if err != nil || resp.Node == nil {
+			// node doesn't exist
+		} else {
+			vschema = resp.Node.Value
+			modifiedVersion = int64(resp.Node.ModifiedIndex)
+		}
+
+		// re-check for stop here to be safe, in case the
+		// Get took a long time
+		select {
+		case <-stop:
+			return
+		case notifications <- vschema:
+		}
+
+		for {
+			if _, err := global.Watch(filePath, uint64(modifiedVersion+1), false /* recursive */, watch, stop); err != nil {
+				log.Errorf("Watch on %v failed, waiting for %v to retry: %v", filePath, WatchSleepDuration, err)
+				timer := time.After(WatchSleepDuration)
+				select {
+				case <-stop:
+					return
+				case <-timer:
+				}
+			}
+		}
+	}()
+
+	// This go routine is the main event handling routine:
+	// - it will stop if stopWatching is closed.
+	// - if it receives a notification from the watch, it will forward it
+	// to the notifications channel.
+	go func() {
+		for {
+			select {
+			case resp := <-watch:
+				vschema := "{}"
+				if resp.Node != nil && resp.Node.Value != "" {
+					vschema = resp.Node.Value
+				}
+				select {
+				case notifications <- vschema:
+				case <-stopWatching:
+					close(stop)
+					close(notifications)
+					return
+				}
+			case <-stopWatching:
+				close(stop)
+				close(notifications)
+				return
+			}
+		}
+	}()
+
+	return notifications, stopWatching, nil
+}
@@ -2,7 +2,9 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package tabletserver
+// Package logz provides the helpers of the HTML pages that show
+// a snapshot of a log, like querylogz.
+package logz
 
 import (
 	"bytes"
@@ -11,7 +13,8 @@ import (
 	"time"
 )
 
-func startHTMLTable(w http.ResponseWriter) {
+// StartHTMLTable writes the start of a logz page, and opens its table.
+func StartHTMLTable(w http.ResponseWriter) {
 	w.Write([]byte(`
 		<!DOCTYPE html>
 		<html>
@@ -69,7 +72,9 @@ func startHTMLTable(w http.ResponseWriter) {
 	`))
 }
 
-func endHTMLTable(w http.ResponseWriter) {
+// EndHTMLTable writes the end of a logz page, which makes the
+// columns of its table sortable.
+func EndHTMLTable(w http.ResponseWriter) {
 	defer w.Write([]byte(`
 </table>
 <script src="http://ajax.googleapis.com/ajax/libs/jquery/2.1.0/jquery.min.js"></script>
@@ -117,9 +122,9 @@ $(function() {
 </html>`))
 }
 
-// wrappable inserts zero-width whitespaces to make
+// Wrappable inserts zero-width whitespaces to make
 // the string wrappable.
-func wrappable(in string) string {
+func Wrappable(in string) string {
 	buf := bytes.NewBuffer(nil)
 	for _, ch := range in {
 		buf.WriteRune(ch)
@@ -140,7 +145,9 @@ func adjustValue(val int, lower int, upper int) int {
 	return val
 }
 
-func parseTimeoutLimitParams(req *http.Request) (time.Duration, int) {
+// ParseTimeoutLimitParams returns the timeout and limit URL
+// parameters of a logz page, or their default values.
+func ParseTimeoutLimitParams(req *http.Request) (time.Duration, int) {
 	timeout := 10
 	limit := 300
 	if ts, ok := req.URL.Query()["timeout"]; ok {
@@ -6,9 +6,11 @@
 Package query is a generated protocol buffer package.
 
 It is generated from these files:
+
 	query.proto
 
 It has these top-level messages:
+
 	Target
 	VTGateCallerID
 	Value
@@ -37,6 +39,23 @@ It has these top-level messages:
 	StreamHealthRequest
 	RealtimeStats
 	StreamHealthResponse
+	PrepareRequest
+	PrepareResponse
+	CommitPreparedRequest
+	CommitPreparedResponse
+	RollbackPreparedRequest
+	RollbackPreparedResponse
+	CreateTransactionRequest
+	CreateTransactionResponse
+	StartCommitRequest
+	StartCommitResponse
+	SetRollbackRequest
+	SetRollbackResponse
+	ConcludeTransactionRequest
+	ConcludeTransactionResponse
+	TransactionMetadata
+	ReadTransactionRequest
+	ReadTransactionResponse
 */
 package query
 
@@ -251,6 +270,34 @@ func (x Type) String() string {
 }
 func (Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+// TransactionState represents the state of a distributed transaction
+type TransactionState int32
+
+const (
+	TransactionState_UNKNOWN  TransactionState = 0
+	TransactionState_PREPARE  TransactionState = 1
+	TransactionState_COMMIT   TransactionState = 2
+	TransactionState_ROLLBACK TransactionState = 3
+)
+
+var TransactionState_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "PREPARE",
+	2: "COMMIT",
+	3: "ROLLBACK",
+}
+var TransactionState_value = map[string]int32{
+	"UNKNOWN":  0,
+	"PREPARE":  1,
+	"COMMIT":   2,
+	"ROLLBACK": 3,
+}
+
+func (x TransactionState) String() string {
+	return proto.EnumName(TransactionState_name, int32(x))
+}
+func (TransactionState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
 // Target describes what the client expects the tablet is.
 // If the tablet does not match, an error is returned.
 type Target struct {
@@ -907,6 +954,390 @@ func (m *StreamHealthResponse) GetRealtimeStats() *RealtimeStats {
 	return nil
 }
 
+// PrepareRequest is the payload to Prepare
+type PrepareRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	TransactionId     int64           `protobuf:"varint,4,opt,name=transaction_id" json:"transaction_id,omitempty"`
+	Dtid              string          `protobuf:"bytes,5,opt,name=dtid" json:"dtid,omitempty"`
+}
+
+func (m *PrepareRequest) Reset()                    { *m = PrepareRequest{} }
+func (m *PrepareRequest) String() string            { return proto.CompactTextString(m) }
+func (*PrepareRequest) ProtoMessage()               {}
+func (*PrepareRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *PrepareRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *PrepareRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *PrepareRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// PrepareResponse is the returned value from Prepare
+type PrepareResponse struct {
+}
+
+func (m *PrepareResponse) Reset()                    { *m = PrepareResponse{} }
+func (m *PrepareResponse) String() string            { return proto.CompactTextString(m) }
+func (*PrepareResponse) ProtoMessage()               {}
+func (*PrepareResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+// CommitPreparedRequest is the payload to CommitPrepared
+type CommitPreparedRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	Dtid              string          `protobuf:"bytes,4,opt,name=dtid" json:"dtid,omitempty"`
+}
+
+func (m *CommitPreparedRequest) Reset()                    { *m = CommitPreparedRequest{} }
+func (m *CommitPreparedRequest) String() string            { return proto.CompactTextString(m) }
+func (*CommitPreparedRequest) ProtoMessage()               {}
+func (*CommitPreparedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *CommitPreparedRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *CommitPreparedRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *CommitPreparedRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// CommitPreparedResponse is the returned value from CommitPrepared
+type CommitPreparedResponse struct {
+}
+
+func (m *CommitPreparedResponse) Reset()                    { *m = CommitPreparedResponse{} }
+func (m *CommitPreparedResponse) String() string            { return proto.CompactTextString(m) }
+func (*CommitPreparedResponse) ProtoMessage()               {}
+func (*CommitPreparedResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+// RollbackPreparedRequest is the payload to RollbackPrepared
+type RollbackPreparedRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	TransactionId     int64           `protobuf:"varint,4,opt,name=transaction_id" json:"transaction_id,omitempty"`
+	Dtid              string          `protobuf:"bytes,5,opt,name=dtid" json:"dtid,omitempty"`
+}
+
+func (m *RollbackPreparedRequest) Reset()                    { *m = RollbackPreparedRequest{} }
+func (m *RollbackPreparedRequest) String() string            { return proto.CompactTextString(m) }
+func (*RollbackPreparedRequest) ProtoMessage()               {}
+func (*RollbackPreparedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *RollbackPreparedRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *RollbackPreparedRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *RollbackPreparedRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// RollbackPreparedResponse is the returned value from RollbackPrepared
+type RollbackPreparedResponse struct {
+}
+
+func (m *RollbackPreparedResponse) Reset()                    { *m = RollbackPreparedResponse{} }
+func (m *RollbackPreparedResponse) String() string            { return proto.CompactTextString(m) }
+func (*RollbackPreparedResponse) ProtoMessage()               {}
+func (*RollbackPreparedResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+// CreateTransactionRequest is the payload to CreateTransaction
+type CreateTransactionRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	Dtid              string          `protobuf:"bytes,4,opt,name=dtid" json:"dtid,omitempty"`
+	Participants      []*Target       `protobuf:"bytes,5,rep,name=participants" json:"participants,omitempty"`
+}
+
+func (m *CreateTransactionRequest) Reset()                    { *m = CreateTransactionRequest{} }
+func (m *CreateTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateTransactionRequest) ProtoMessage()               {}
+func (*CreateTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *CreateTransactionRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *CreateTransactionRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *CreateTransactionRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+func (m *CreateTransactionRequest) GetParticipants() []*Target {
+	if m != nil {
+		return m.Participants
+	}
+	return nil
+}
+
+// CreateTransactionResponse is the returned value from CreateTransaction
+type CreateTransactionResponse struct {
+}
+
+func (m *CreateTransactionResponse) Reset()                    { *m = CreateTransactionResponse{} }
+func (m *CreateTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateTransactionResponse) ProtoMessage()               {}
+func (*CreateTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+// StartCommitRequest is the payload to StartCommit
+type StartCommitRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	TransactionId     int64           `protobuf:"varint,4,opt,name=transaction_id" json:"transaction_id,omitempty"`
+	Dtid              string          `protobuf:"bytes,5,opt,name=dtid" json:"dtid,omitempty"`
+}
+
+func (m *StartCommitRequest) Reset()                    { *m = StartCommitRequest{} }
+func (m *StartCommitRequest) String() string            { return proto.CompactTextString(m) }
+func (*StartCommitRequest) ProtoMessage()               {}
+func (*StartCommitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *StartCommitRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *StartCommitRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *StartCommitRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// StartCommitResponse is the returned value from StartCommit
+type StartCommitResponse struct {
+}
+
+func (m *StartCommitResponse) Reset()                    { *m = StartCommitResponse{} }
+func (m *StartCommitResponse) String() string            { return proto.CompactTextString(m) }
+func (*StartCommitResponse) ProtoMessage()               {}
+func (*StartCommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+// SetRollbackRequest is the payload to SetRollback
+type SetRollbackRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	TransactionId     int64           `protobuf:"varint,4,opt,name=transaction_id" json:"transaction_id,omitempty"`
+	Dtid              string          `protobuf:"bytes,5,opt,name=dtid" json:"dtid,omitempty"`
+}
+
+func (m *SetRollbackRequest) Reset()                    { *m = SetRollbackRequest{} }
+func (m *SetRollbackRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetRollbackRequest) ProtoMessage()               {}
+func (*SetRollbackRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *SetRollbackRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *SetRollbackRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *SetRollbackRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// SetRollbackResponse is the returned value from SetRollback
+type SetRollbackResponse struct {
+}
+
+func (m *SetRollbackResponse) Reset()                    { *m = SetRollbackResponse{} }
+func (m *SetRollbackResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetRollbackResponse) ProtoMessage()               {}
+func (*SetRollbackResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+// ConcludeTransactionRequest is the payload to ConcludeTransaction
+type ConcludeTransactionRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	Dtid              string          `protobuf:"bytes,4,opt,name=dtid" json:"dtid,omitempty"`
+}
+
+func (m *ConcludeTransactionRequest) Reset()                    { *m = ConcludeTransactionRequest{} }
+func (m *ConcludeTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*ConcludeTransactionRequest) ProtoMessage()               {}
+func (*ConcludeTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *ConcludeTransactionRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *ConcludeTransactionRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *ConcludeTransactionRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// ConcludeTransactionResponse is the returned value from ConcludeTransaction
+type ConcludeTransactionResponse struct {
+}
+
+func (m *ConcludeTransactionResponse) Reset()                    { *m = ConcludeTransactionResponse{} }
+func (m *ConcludeTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*ConcludeTransactionResponse) ProtoMessage()               {}
+func (*ConcludeTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+// TransactionMetadata contains the metadata for a distributed transaction
+type TransactionMetadata struct {
+	Dtid         string           `protobuf:"bytes,1,opt,name=dtid" json:"dtid,omitempty"`
+	State        TransactionState `protobuf:"varint,2,opt,name=state,enum=query.TransactionState" json:"state,omitempty"`
+	TimeCreated  int64            `protobuf:"varint,3,opt,name=time_created" json:"time_created,omitempty"`
+	Participants []*Target        `protobuf:"bytes,4,rep,name=participants" json:"participants,omitempty"`
+}
+
+func (m *TransactionMetadata) Reset()                    { *m = TransactionMetadata{} }
+func (m *TransactionMetadata) String() string            { return proto.CompactTextString(m) }
+func (*TransactionMetadata) ProtoMessage()               {}
+func (*TransactionMetadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *TransactionMetadata) GetParticipants() []*Target {
+	if m != nil {
+		return m.Participants
+	}
+	return nil
+}
+
+// ReadTransactionRequest is the payload to ReadTransaction
+type ReadTransactionRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	Dtid              string          `protobuf:"bytes,4,opt,name=dtid" json:"dtid,omitempty"`
+}
+
+func (m *ReadTransactionRequest) Reset()                    { *m = ReadTransactionRequest{} }
+func (m *ReadTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReadTransactionRequest) ProtoMessage()               {}
+func (*ReadTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *ReadTransactionRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *ReadTransactionRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *ReadTransactionRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// ReadTransactionResponse is the returned value from ReadTransaction
+type ReadTransactionResponse struct {
+	Metadata *TransactionMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
+}
+
+func (m *ReadTransactionResponse) Reset()                    { *m = ReadTransactionResponse{} }
+func (m *ReadTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReadTransactionResponse) ProtoMessage()               {}
+func (*ReadTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *ReadTransactionResponse) GetMetadata() *TransactionMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Target)(nil), "query.Target")
 	proto.RegisterType((*VTGateCallerID)(nil), "query.VTGateCallerID")
@@ -936,93 +1367,130 @@ func init() {
 	proto.RegisterType((*StreamHealthRequest)(nil), "query.StreamHealthRequest")
 	proto.RegisterType((*RealtimeStats)(nil), "query.RealtimeStats")
 	proto.RegisterType((*StreamHealthResponse)(nil), "query.StreamHealthResponse")
+	proto.RegisterType((*PrepareRequest)(nil), "query.PrepareRequest")
+	proto.RegisterType((*PrepareResponse)(nil), "query.PrepareResponse")
+	proto.RegisterType((*CommitPreparedRequest)(nil), "query.CommitPreparedRequest")
+	proto.RegisterType((*CommitPreparedResponse)(nil), "query.CommitPreparedResponse")
+	proto.RegisterType((*RollbackPreparedRequest)(nil), "query.RollbackPreparedRequest")
+	proto.RegisterType((*RollbackPreparedResponse)(nil), "query.RollbackPreparedResponse")
+	proto.RegisterType((*CreateTransactionRequest)(nil), "query.CreateTransactionRequest")
+	proto.RegisterType((*CreateTransactionResponse)(nil), "query.CreateTransactionResponse")
+	proto.RegisterType((*StartCommitRequest)(nil), "query.StartCommitRequest")
+	proto.RegisterType((*StartCommitResponse)(nil), "query.StartCommitResponse")
+	proto.RegisterType((*SetRollbackRequest)(nil), "query.SetRollbackRequest")
+	proto.RegisterType((*SetRollbackResponse)(nil), "query.SetRollbackResponse")
+	proto.RegisterType((*ConcludeTransactionRequest)(nil), "query.ConcludeTransactionRequest")
+	proto.RegisterType((*ConcludeTransactionResponse)(nil), "query.ConcludeTransactionResponse")
+	proto.RegisterType((*TransactionMetadata)(nil), "query.TransactionMetadata")
+	proto.RegisterType((*ReadTransactionRequest)(nil), "query.ReadTransactionRequest")
+	proto.RegisterType((*ReadTransactionResponse)(nil), "query.ReadTransactionResponse")
 	proto.RegisterEnum("query.Flag", Flag_name, Flag_value)
 	proto.RegisterEnum("query.Type", Type_name, Type_value)
+	proto.RegisterEnum("query.TransactionState", TransactionState_name, TransactionState_value)
 }
 
 var fileDescriptor0 = []byte{
-	// 1327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x57, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0x66, 0xed, 0xb5, 0xe3, 0x1c, 0x27, 0xce, 0x74, 0xe3, 0xa0, 0x50, 0xb5, 0x52, 0xb5, 0xa0,
-	0x52, 0x22, 0x64, 0xa5, 0x6e, 0xa9, 0x2a, 0xe0, 0x02, 0x3b, 0xd9, 0xa6, 0x2b, 0x25, 0x4e, 0x6a,
-	0xaf, 0x23, 0x7a, 0xb5, 0x9a, 0xd8, 0x13, 0x67, 0xd5, 0xf5, 0xae, 0xbb, 0x33, 0x9b, 0x36, 0x77,
-	0xe1, 0xff, 0xff, 0x4f, 0xfc, 0x15, 0xb8, 0x03, 0x6e, 0x78, 0x07, 0xc4, 0x1b, 0xf0, 0x0c, 0x3c,
-	0x02, 0x57, 0xdc, 0x22, 0x66, 0x66, 0x67, 0xd7, 0x4e, 0x63, 0x10, 0xdc, 0xa0, 0xf6, 0x2a, 0x7b,
-	0xce, 0x99, 0x39, 0xe7, 0xfb, 0xbe, 0x39, 0x33, 0x3e, 0x81, 0xf2, 0xdd, 0x98, 0x44, 0x47, 0xb5,
-	0x51, 0x14, 0xb2, 0xd0, 0x28, 0x48, 0xe3, 0x6c, 0x85, 0x85, 0xa3, 0xb0, 0x8f, 0x19, 0x4e, 0xdc,
-	0x67, 0xcb, 0x87, 0x2c, 0x1a, 0xf5, 0x12, 0xc3, 0x74, 0xa0, 0xe8, 0xe0, 0x68, 0x40, 0x98, 0x81,
-	0xa0, 0x74, 0x87, 0x1c, 0xd1, 0x11, 0xee, 0x91, 0x65, 0xed, 0x82, 0x76, 0x69, 0xd6, 0x98, 0x87,
-	0x02, 0x3d, 0xc0, 0x51, 0x7f, 0x39, 0x27, 0xcd, 0xe7, 0xa0, 0xcc, 0xf0, 0x9e, 0x4f, 0x98, 0xcb,
-	0x8e, 0x46, 0x64, 0x39, 0xcf, 0x9d, 0x95, 0x7a, 0xb5, 0x96, 0x65, 0x77, 0x64, 0xd0, 0xe1, 0x31,
-	0xd3, 0x84, 0xca, 0xae, 0xb3, 0x81, 0x19, 0x59, 0xc3, 0xbe, 0x4f, 0x22, 0x7b, 0x5d, 0x64, 0x8f,
-	0x29, 0x89, 0x02, 0x3c, 0x54, 0xd9, 0xcd, 0xcb, 0x50, 0xd8, 0xc5, 0x7e, 0x4c, 0x8c, 0xa7, 0x40,
-	0x97, 0x09, 0x35, 0x99, 0xb0, 0x5c, 0x4b, 0x28, 0x88, 0x3c, 0x02, 0xc1, 0xa1, 0x58, 0x23, 0x11,
-	0xcc, 0x99, 0xbb, 0x30, 0xd7, 0xf4, 0x82, 0xfe, 0x2e, 0x8e, 0x3c, 0x51, 0xeb, 0xdf, 0xef, 0x34,
-	0xce, 0x41, 0x51, 0x9a, 0x94, 0xc3, 0xce, 0x5f, 0x2a, 0xd7, 0xe7, 0xd4, 0x5a, 0x89, 0xc0, 0xfc,
-	0x5e, 0x03, 0x68, 0x86, 0x71, 0xd0, 0xbf, 0x25, 0x9c, 0x46, 0x19, 0xf2, 0xf4, 0xae, 0xaf, 0x44,
-	0x78, 0x19, 0x2a, 0x7b, 0xbc, 0xa6, 0x7b, 0xa8, 0x8a, 0x52, 0x9e, 0x51, 0x64, 0x78, 0x46, 0x65,
-	0x18, 0xef, 0xab, 0x4d, 0x62, 0xa3, 0x56, 0xc0, 0xb8, 0xf6, 0x36, 0x18, 0xa7, 0xbd, 0xa2, 0x00,
-	0x97, 0x5a, 0x15, 0x30, 0x27, 0x91, 0x96, 0xeb, 0x8b, 0x69, 0xde, 0x89, 0x6d, 0x2f, 0xe6, 0xae,
-	0x6b, 0xe6, 0x2a, 0x14, 0x6e, 0x78, 0xc4, 0xef, 0x1b, 0x73, 0xa0, 0x8f, 0x65, 0xcc, 0x34, 0xc8,
-	0x9d, 0xd2, 0xc0, 0xbc, 0x08, 0xf9, 0x76, 0x78, 0xcf, 0x58, 0x80, 0x19, 0x9f, 0x04, 0x03, 0x76,
-	0x40, 0xf9, 0x96, 0xfc, 0x25, 0xc3, 0xa8, 0x64, 0x62, 0x24, 0xb2, 0x86, 0x50, 0x96, 0x04, 0xda,
-	0x84, 0xc6, 0x3e, 0x13, 0x5a, 0xed, 0x8b, 0x42, 0xc9, 0xf2, 0xb1, 0x56, 0x49, 0xf5, 0x25, 0x98,
-	0x8f, 0xc2, 0x7b, 0xd4, 0xc5, 0xfb, 0xfb, 0xa4, 0xc7, 0x48, 0xd2, 0x1c, 0xba, 0x71, 0x06, 0x66,
-	0xbd, 0x80, 0x1f, 0x30, 0x73, 0xbd, 0xbe, 0x6c, 0x0d, 0xdd, 0x58, 0x06, 0x5d, 0xac, 0x5c, 0xd6,
-	0x65, 0x16, 0x50, 0x59, 0x38, 0x22, 0xf3, 0x81, 0x06, 0x8b, 0x1b, 0x84, 0x75, 0x08, 0xa5, 0x5e,
-	0x18, 0xd8, 0xfd, 0x36, 0xe1, 0x31, 0xca, 0x8c, 0xe7, 0x61, 0x91, 0xc8, 0xb4, 0xde, 0x21, 0x71,
-	0x7b, 0xb2, 0x75, 0x44, 0x3a, 0x4d, 0x0a, 0xb3, 0x50, 0x4b, 0xfa, 0x36, 0x6b, 0xa9, 0x3a, 0x2c,
-	0x7a, 0xc3, 0x21, 0xe9, 0x7b, 0xbc, 0xcf, 0x26, 0x56, 0x27, 0x32, 0x2e, 0xa5, 0x07, 0x7c, 0xaa,
-	0x0d, 0xb3, 0x26, 0xcf, 0x9f, 0x6c, 0x72, 0x5d, 0x76, 0xe5, 0x0a, 0x54, 0x4f, 0x22, 0xa3, 0xa3,
-	0x90, 0xf3, 0x32, 0x0c, 0x00, 0x9a, 0x38, 0x53, 0x44, 0x79, 0xf3, 0x37, 0x0d, 0x2a, 0xd6, 0x7d,
-	0xd2, 0x8b, 0x19, 0xf9, 0xff, 0x18, 0x9c, 0x87, 0x22, 0x93, 0x17, 0x56, 0xe2, 0x2f, 0xd7, 0xe7,
-	0xd3, 0x13, 0x4f, 0x6e, 0xf1, 0x05, 0x48, 0x6e, 0xbd, 0xa4, 0x53, 0xae, 0x9f, 0x39, 0xd5, 0xa5,
-	0xc6, 0x93, 0x50, 0x61, 0x11, 0x0e, 0x28, 0xe6, 0x20, 0x13, 0x36, 0x05, 0xc1, 0xe6, 0x21, 0x86,
-	0x45, 0xc9, 0xf0, 0x05, 0x58, 0xc8, 0x08, 0x2a, 0x21, 0x4c, 0x28, 0x46, 0xb2, 0x4f, 0x14, 0x29,
-	0x43, 0x55, 0x98, 0xe8, 0x20, 0xf3, 0x4f, 0x7e, 0xbe, 0x6a, 0x5f, 0x13, 0xb3, 0xde, 0xc1, 0x23,
-	0xa3, 0x8e, 0x09, 0x33, 0xc2, 0xf6, 0x48, 0xda, 0x95, 0xd3, 0xf5, 0xc1, 0xd4, 0x9d, 0x90, 0x48,
-	0xea, 0x53, 0x9a, 0xa2, 0x5b, 0x71, 0x8a, 0x6e, 0x33, 0x52, 0xb7, 0x97, 0xa0, 0x7a, 0x92, 0xbf,
-	0x12, 0xef, 0x69, 0x98, 0x49, 0xc4, 0x4b, 0xef, 0xd6, 0x34, 0xf5, 0x7e, 0xd5, 0xa0, 0xda, 0x61,
-	0x11, 0xc1, 0xc3, 0xc7, 0xaf, 0xb9, 0x4e, 0x8a, 0x51, 0x50, 0x62, 0x2c, 0x3d, 0x44, 0xe7, 0x3f,
-	0xb4, 0xd2, 0x0f, 0x1a, 0x7f, 0xf3, 0xc9, 0xc0, 0x0b, 0x1e, 0x19, 0x11, 0x4e, 0x52, 0xd4, 0x25,
-	0xc5, 0x67, 0x61, 0x5e, 0x81, 0x54, 0xd4, 0x4e, 0x37, 0x4b, 0xf2, 0x64, 0xfc, 0xac, 0xc1, 0xfc,
-	0x5a, 0x38, 0x1c, 0x7a, 0xec, 0x91, 0xe1, 0x73, 0x1a, 0xaa, 0x3e, 0xa5, 0xaf, 0x93, 0xa3, 0x44,
-	0x50, 0x49, 0xd1, 0x27, 0x44, 0xcd, 0x5f, 0x34, 0x58, 0x68, 0x87, 0xbe, 0xbf, 0x87, 0x7b, 0x77,
-	0x1e, 0x4b, 0x4a, 0x06, 0xa0, 0x31, 0x7e, 0x45, 0xea, 0x0f, 0x0d, 0xce, 0x74, 0x46, 0xbe, 0xc7,
-	0x54, 0x27, 0x3e, 0x36, 0xd7, 0xaf, 0x0a, 0x73, 0x54, 0xe0, 0x76, 0x7b, 0xa1, 0x1f, 0x0f, 0x93,
-	0x97, 0x6b, 0xd6, 0x58, 0x84, 0x72, 0xea, 0x8d, 0x03, 0xf6, 0x0f, 0xcf, 0x56, 0x03, 0x40, 0xe6,
-	0x91, 0xdc, 0xc7, 0xe5, 0xb4, 0xbf, 0x2b, 0xc7, 0x7f, 0xf4, 0xf9, 0x2f, 0xbc, 0x4a, 0x9b, 0x93,
-	0x29, 0xae, 0x83, 0x31, 0xa9, 0x5c, 0x76, 0xd3, 0xb3, 0x77, 0x57, 0x3b, 0xf1, 0xee, 0x8e, 0xcb,
-	0x99, 0x4b, 0xb0, 0x98, 0x3c, 0x13, 0x37, 0x09, 0xf6, 0x59, 0xfa, 0x9b, 0x61, 0xfe, 0xc4, 0x6f,
-	0x4c, 0x5b, 0x78, 0xbc, 0x21, 0xe9, 0x30, 0xcc, 0xa8, 0x20, 0x79, 0x20, 0x97, 0xb8, 0x24, 0x8a,
-	0xc2, 0x48, 0xcd, 0x41, 0xe7, 0x61, 0x89, 0x92, 0x5e, 0x18, 0xf4, 0xa9, 0xbb, 0x47, 0x0e, 0xc4,
-	0xc4, 0x36, 0xc4, 0x94, 0x91, 0x48, 0xe2, 0x9a, 0xe7, 0x43, 0x4d, 0x95, 0x8f, 0x71, 0x7e, 0x38,
-	0x70, 0x47, 0x3e, 0x3e, 0x22, 0x11, 0x55, 0xa8, 0x85, 0xd0, 0x05, 0x7e, 0x58, 0x2b, 0x53, 0x37,
-	0xbb, 0xfb, 0x9e, 0xcf, 0xff, 0x90, 0xbe, 0x1b, 0x11, 0x0e, 0xb2, 0x87, 0xe5, 0xef, 0x41, 0xd2,
-	0x4c, 0x9c, 0x7c, 0x6f, 0x14, 0xbb, 0x31, 0xc5, 0x03, 0x22, 0x85, 0xd6, 0xcc, 0x1f, 0xb3, 0x97,
-	0x3b, 0xe5, 0xa0, 0xf8, 0x8f, 0x0f, 0x56, 0x9b, 0x76, 0xb0, 0x7c, 0x42, 0xe3, 0xa3, 0xd3, 0xa1,
-	0x17, 0x0c, 0x24, 0xda, 0x92, 0x51, 0x83, 0x8b, 0x6a, 0xd4, 0x26, 0xf7, 0x99, 0x98, 0x9a, 0x7d,
-	0xff, 0x48, 0x40, 0xc0, 0x11, 0x09, 0xf8, 0xcc, 0xe5, 0x0a, 0x31, 0x28, 0xc3, 0xc3, 0x91, 0xc4,
-	0x9f, 0xe7, 0xad, 0x59, 0x89, 0x94, 0x46, 0x2e, 0x15, 0x22, 0xa9, 0x16, 0xa9, 0xa6, 0x43, 0xd7,
-	0xa4, 0x80, 0x2b, 0x77, 0x40, 0xbf, 0xe1, 0xe3, 0x81, 0x51, 0x02, 0xbd, 0xb5, 0xdd, 0xb2, 0xd0,
-	0x13, 0x1c, 0x00, 0xd8, 0x1d, 0xbb, 0xe5, 0x58, 0x1b, 0xed, 0xc6, 0x26, 0x3a, 0xce, 0x25, 0x8e,
-	0x6e, 0xab, 0x63, 0x6f, 0xb4, 0xac, 0x75, 0x74, 0xac, 0xf3, 0xa1, 0x73, 0xc6, 0xee, 0xdc, 0xd8,
-	0xdc, 0x6e, 0x38, 0xe8, 0xb8, 0xc4, 0x87, 0xa6, 0x92, 0xdd, 0xb9, 0xd5, 0xdd, 0x76, 0x44, 0x10,
-	0xf1, 0x79, 0xb6, 0x68, 0x77, 0x1c, 0xeb, 0x55, 0x1e, 0xbb, 0x90, 0xc4, 0x9a, 0x76, 0xab, 0xd1,
-	0xbe, 0x8d, 0x8e, 0x5f, 0x59, 0xf9, 0x3d, 0x07, 0xba, 0x9a, 0xc8, 0x67, 0x5b, 0xdd, 0xcd, 0x4d,
-	0xd7, 0xb9, 0xbd, 0x23, 0x4a, 0xce, 0x82, 0xce, 0x0b, 0x5e, 0x47, 0xaf, 0xe5, 0x78, 0x27, 0x16,
-	0xba, 0xf2, 0xfb, 0xf5, 0xa2, 0xf8, 0xe6, 0x9f, 0x97, 0xaf, 0xa1, 0x37, 0x72, 0x22, 0x6d, 0x37,
-	0x31, 0xde, 0x4c, 0x03, 0xf5, 0xab, 0xe8, 0xad, 0x2c, 0xc0, 0x8d, 0xb7, 0xd3, 0xc0, 0x95, 0x3a,
-	0x7a, 0x27, 0x0b, 0x70, 0xe3, 0xdd, 0x34, 0x70, 0xed, 0x2a, 0x7a, 0x2f, 0x0b, 0x70, 0xe3, 0xfd,
-	0xa2, 0xe0, 0x22, 0x99, 0xf0, 0x65, 0x1f, 0x94, 0x32, 0x8b, 0xc7, 0x3e, 0x2c, 0xf1, 0xd9, 0x78,
-	0xd6, 0xb1, 0xb7, 0xac, 0x8e, 0xd3, 0xd8, 0xda, 0x41, 0x1f, 0x21, 0x01, 0x73, 0xbd, 0xe1, 0x58,
-	0xe8, 0x63, 0xf9, 0x29, 0x42, 0xe8, 0x13, 0x24, 0x38, 0x0a, 0xaf, 0x34, 0x3f, 0x95, 0x91, 0xdb,
-	0x56, 0xa3, 0x8d, 0x3e, 0x2b, 0xf2, 0x42, 0x33, 0xeb, 0xd6, 0x9a, 0xbd, 0xc5, 0x65, 0x34, 0xe4,
-	0x0e, 0xa1, 0xca, 0xe7, 0xab, 0xe2, 0xb3, 0xb9, 0xb9, 0xdd, 0x44, 0x5f, 0xec, 0x88, 0x82, 0xbb,
-	0x8d, 0xf6, 0xda, 0x4d, 0xbe, 0xe1, 0xcb, 0x55, 0x51, 0x90, 0x5b, 0x4a, 0xaf, 0xaf, 0x76, 0xc4,
-	0x42, 0x19, 0xfa, 0x7a, 0x55, 0x80, 0x56, 0xfe, 0x07, 0x3b, 0xfc, 0xb0, 0xf2, 0x4d, 0xdb, 0x41,
-	0xdf, 0xc8, 0x6a, 0x56, 0xab, 0xbb, 0x85, 0xbe, 0x45, 0xc2, 0xd9, 0xb1, 0x1c, 0xf4, 0x9d, 0x70,
-	0x16, 0x9c, 0xee, 0xce, 0xa6, 0x85, 0xce, 0x35, 0xcf, 0xc2, 0x72, 0x2f, 0x1c, 0xd6, 0x8e, 0xc2,
-	0x98, 0xc5, 0x7b, 0xa4, 0x76, 0xe8, 0x31, 0x7e, 0xcf, 0x93, 0x7f, 0xf7, 0xf6, 0x8a, 0xf2, 0xcf,
-	0x95, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x46, 0x2c, 0xcb, 0xee, 0x28, 0x0e, 0x00, 0x00,
+	// 1626 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x58, 0xdd, 0x6e, 0x23, 0x49,
+	0x15, 0xde, 0xb6, 0xdb, 0x8e, 0x7d, 0xec, 0x38, 0x95, 0x4a, 0x32, 0xe3, 0xcd, 0xce, 0x48, 0x51,
+	0x2f, 0x1a, 0x86, 0x68, 0x64, 0xcd, 0x7a, 0xc2, 0x68, 0x04, 0x5c, 0x60, 0x7b, 0x3c, 0xd9, 0xd6,
+	0x3a, 0x8e, 0xb7, 0xdd, 0x0e, 0xcc, 0x55, 0xab, 0xd2, 0x5d, 0x93, 0xb4, 0xa6, 0xdd, 0xed, 0xe9,
+	0x2a, 0x67, 0xd6, 0x77, 0xe1, 0x6f, 0xf9, 0xff, 0x13, 0x7f, 0x0b, 0xdc, 0x01, 0x02, 0x21, 0x1e,
+	0x80, 0x0b, 0x10, 0xe2, 0x05, 0xe0, 0x15, 0x78, 0x04, 0xae, 0xb8, 0x45, 0xa8, 0xaa, 0xab, 0x6d,
+	0x67, 0x6c, 0xd0, 0x70, 0xb3, 0x72, 0xae, 0xdc, 0x75, 0x4e, 0xd5, 0x39, 0xdf, 0xf7, 0xd5, 0xa9,
+	0x3a, 0x25, 0x43, 0xe9, 0xc5, 0x98, 0xc6, 0x93, 0xda, 0x28, 0x8e, 0x78, 0x84, 0x73, 0x72, 0xb0,
+	0x5b, 0xe1, 0xd1, 0x28, 0xf2, 0x08, 0x27, 0x89, 0x79, 0xb7, 0x74, 0xc1, 0xe3, 0x91, 0x9b, 0x0c,
+	0x0c, 0x1b, 0xf2, 0x36, 0x89, 0xcf, 0x28, 0xc7, 0x08, 0x0a, 0xcf, 0xe9, 0x84, 0x8d, 0x88, 0x4b,
+	0xab, 0xda, 0x9e, 0x76, 0xb7, 0x88, 0xd7, 0x21, 0xc7, 0xce, 0x49, 0xec, 0x55, 0x33, 0x72, 0xf8,
+	0x29, 0x28, 0x71, 0x72, 0x1a, 0x50, 0xee, 0xf0, 0xc9, 0x88, 0x56, 0xb3, 0x7b, 0xda, 0xdd, 0x4a,
+	0x7d, 0xbb, 0x36, 0x8d, 0x6e, 0x4b, 0xa7, 0x3d, 0x19, 0x51, 0xc3, 0x80, 0xca, 0x89, 0x7d, 0x48,
+	0x38, 0x6d, 0x91, 0x20, 0xa0, 0xb1, 0xf9, 0x58, 0x44, 0x1f, 0x33, 0x1a, 0x87, 0x64, 0xa8, 0xa2,
+	0x1b, 0xef, 0x40, 0xee, 0x84, 0x04, 0x63, 0x8a, 0xdf, 0x04, 0x5d, 0x06, 0xd4, 0x64, 0xc0, 0x52,
+	0x2d, 0xa1, 0x20, 0xe2, 0x08, 0x04, 0x17, 0x62, 0x8e, 0x44, 0x50, 0x36, 0x4e, 0xa0, 0xdc, 0xf4,
+	0x43, 0xef, 0x84, 0xc4, 0xbe, 0xc8, 0xf5, 0xfa, 0x2b, 0xf1, 0x2d, 0xc8, 0xcb, 0x21, 0xab, 0x66,
+	0xf7, 0xb2, 0x77, 0x4b, 0xf5, 0xb2, 0x9a, 0x2b, 0x11, 0x18, 0xbf, 0xd2, 0x00, 0x9a, 0xd1, 0x38,
+	0xf4, 0xde, 0x17, 0x46, 0x5c, 0x82, 0x2c, 0x7b, 0x11, 0x28, 0x11, 0x3e, 0x07, 0x95, 0x53, 0x3f,
+	0xf4, 0x9c, 0x0b, 0x95, 0x94, 0x55, 0x33, 0x32, 0xc2, 0x27, 0x54, 0x84, 0xd9, 0xba, 0xda, 0x3c,
+	0x36, 0xd6, 0x0e, 0x79, 0x3c, 0xd9, 0x35, 0x01, 0x2f, 0x5a, 0x45, 0x82, 0xe7, 0x74, 0xa2, 0x12,
+	0x18, 0xf3, 0x48, 0x4b, 0xf5, 0xad, 0x34, 0xee, 0xdc, 0xb2, 0xcf, 0x64, 0x1e, 0x69, 0xc6, 0x7d,
+	0xc8, 0x3d, 0xf1, 0x69, 0xe0, 0xe1, 0x32, 0xe8, 0x33, 0x19, 0xa7, 0x1a, 0x64, 0x16, 0x34, 0x30,
+	0xee, 0x40, 0xd6, 0x8a, 0x5e, 0xe2, 0x0d, 0x58, 0x0b, 0x68, 0x78, 0xc6, 0xcf, 0x59, 0x55, 0xdb,
+	0xcb, 0xde, 0xc5, 0xb8, 0x32, 0x15, 0x23, 0x91, 0x35, 0x82, 0x92, 0x24, 0x60, 0x51, 0x36, 0x0e,
+	0xb8, 0xd0, 0xea, 0x99, 0x48, 0x94, 0x4c, 0x9f, 0x69, 0x95, 0x64, 0xdf, 0x81, 0xf5, 0x38, 0x7a,
+	0xc9, 0x1c, 0xf2, 0xec, 0x19, 0x75, 0x39, 0x4d, 0x8a, 0x43, 0xc7, 0x9b, 0x50, 0xf4, 0x43, 0x46,
+	0x63, 0xee, 0xf8, 0x9e, 0x2c, 0x0d, 0x1d, 0x57, 0x41, 0x17, 0x33, 0xab, 0xba, 0x8c, 0x02, 0x2a,
+	0x8a, 0x15, 0xbd, 0x34, 0x3e, 0xd2, 0x60, 0xeb, 0x90, 0xf2, 0x3e, 0x65, 0xcc, 0x8f, 0x42, 0xd3,
+	0xb3, 0xe8, 0x8b, 0x31, 0x65, 0x1c, 0xdf, 0x83, 0x2d, 0x2a, 0xc3, 0xfa, 0x17, 0xd4, 0x71, 0x65,
+	0xe9, 0x88, 0x70, 0x9a, 0x14, 0x66, 0xa3, 0x96, 0xd4, 0xed, 0xb4, 0xa4, 0xea, 0xb0, 0xe5, 0x0f,
+	0x87, 0xd4, 0xf3, 0x09, 0x9f, 0x9f, 0x9d, 0xc8, 0xb8, 0x93, 0x6e, 0xf0, 0x42, 0x19, 0x4e, 0x8b,
+	0x3c, 0x7b, 0xb5, 0xc8, 0x75, 0x59, 0x95, 0xfb, 0xb0, 0x7d, 0x15, 0x19, 0x1b, 0x45, 0x21, 0xa3,
+	0x18, 0x03, 0xb0, 0xc4, 0x98, 0x22, 0xca, 0x1a, 0xff, 0xd0, 0xa0, 0xd2, 0xfe, 0x80, 0xba, 0x63,
+	0x4e, 0x3f, 0x3e, 0x06, 0xb7, 0x21, 0xcf, 0xe5, 0x81, 0x95, 0xf8, 0x4b, 0xf5, 0xf5, 0x74, 0xc7,
+	0xa5, 0x11, 0xef, 0x41, 0x72, 0xea, 0x25, 0x9d, 0x52, 0x7d, 0x73, 0xa1, 0x4a, 0xf1, 0x0d, 0xa8,
+	0xf0, 0x98, 0x84, 0x8c, 0xb8, 0x5c, 0xb1, 0xc9, 0x09, 0x36, 0xaf, 0x30, 0xcc, 0x4b, 0x86, 0x9f,
+	0x86, 0x8d, 0x29, 0x41, 0x25, 0x84, 0x01, 0xf9, 0x58, 0xd6, 0x89, 0x22, 0x85, 0x55, 0x86, 0xb9,
+	0x0a, 0x32, 0xfe, 0xad, 0xc1, 0x96, 0x5a, 0xd7, 0x24, 0xdc, 0x3d, 0x5f, 0x19, 0x75, 0x0c, 0x58,
+	0x13, 0x63, 0x9f, 0xa6, 0x55, 0xb9, 0x5c, 0x1f, 0xc2, 0x9c, 0x39, 0x89, 0xa4, 0x3e, 0x85, 0x25,
+	0xba, 0xe5, 0x97, 0xe8, 0xb6, 0x26, 0x75, 0xfb, 0x2c, 0x6c, 0x5f, 0xe5, 0xaf, 0xc4, 0x7b, 0x1b,
+	0xd6, 0x12, 0xf1, 0xd2, 0xb3, 0xb5, 0x4c, 0xbd, 0xbf, 0x69, 0xb0, 0xdd, 0xe7, 0x31, 0x25, 0xc3,
+	0xeb, 0x57, 0x5c, 0x57, 0xc5, 0xc8, 0x29, 0x31, 0x76, 0x5e, 0xa1, 0xf3, 0x7f, 0x94, 0xd2, 0xaf,
+	0x35, 0x28, 0x37, 0xe9, 0x99, 0x1f, 0xae, 0x8c, 0x08, 0x57, 0x29, 0xea, 0x92, 0xe2, 0x27, 0x61,
+	0x5d, 0x81, 0x54, 0xd4, 0x16, 0x8b, 0x25, 0xb9, 0x32, 0xfe, 0xac, 0xc1, 0x7a, 0x2b, 0x1a, 0x0e,
+	0x7d, 0xbe, 0x32, 0x7c, 0x16, 0xa1, 0xea, 0x4b, 0xea, 0x3a, 0xd9, 0x4a, 0x04, 0x95, 0x14, 0x7d,
+	0x42, 0xd4, 0xf8, 0x8b, 0x06, 0x1b, 0x56, 0x14, 0x04, 0xa7, 0xc4, 0x7d, 0x7e, 0x2d, 0x29, 0x61,
+	0x40, 0x33, 0xfc, 0x8a, 0xd4, 0xbf, 0x34, 0xd8, 0xec, 0x8f, 0x02, 0x9f, 0xab, 0x4a, 0xbc, 0x36,
+	0xc7, 0x6f, 0x1b, 0xca, 0x4c, 0xe0, 0x76, 0xdc, 0x28, 0x18, 0x0f, 0x93, 0x9b, 0xab, 0x88, 0xb7,
+	0xa0, 0x94, 0x5a, 0xc7, 0x21, 0xff, 0x1f, 0xd7, 0x56, 0x03, 0x40, 0xc6, 0x91, 0xdc, 0x67, 0xe9,
+	0xb4, 0xff, 0x96, 0x6e, 0x13, 0x8a, 0x71, 0xf4, 0x52, 0x85, 0xcd, 0xc8, 0x10, 0x8f, 0x00, 0xcf,
+	0x2b, 0x37, 0x3d, 0xe9, 0xd3, 0x7b, 0x57, 0xbb, 0x72, 0xef, 0xce, 0xd2, 0x19, 0x3b, 0xb0, 0x95,
+	0x5c, 0x13, 0xef, 0x52, 0x12, 0xf0, 0xb4, 0x67, 0x18, 0xbf, 0xd7, 0x60, 0xdd, 0x12, 0x16, 0x7f,
+	0x48, 0xfb, 0x9c, 0x70, 0x26, 0x48, 0x9e, 0xcb, 0x29, 0x0e, 0x8d, 0xe3, 0x28, 0x56, 0xef, 0xa0,
+	0xdb, 0xb0, 0xc3, 0xa8, 0x1b, 0x85, 0x1e, 0x73, 0x4e, 0xe9, 0xb9, 0x78, 0xb1, 0x0d, 0x09, 0xe3,
+	0x34, 0x96, 0xb8, 0xd6, 0xf1, 0x2d, 0xd8, 0x3e, 0xf5, 0xc3, 0x20, 0x3a, 0x73, 0x46, 0x01, 0x99,
+	0xd0, 0x98, 0x29, 0xd4, 0x42, 0xe8, 0x1c, 0xae, 0xc3, 0xfe, 0xd2, 0xc5, 0xce, 0x33, 0x3f, 0xe0,
+	0x34, 0xa6, 0x9e, 0x13, 0xd3, 0x51, 0xe0, 0xbb, 0x44, 0xf6, 0x83, 0xa4, 0x98, 0x36, 0xa1, 0xe8,
+	0x8e, 0xc6, 0xce, 0x98, 0x91, 0x33, 0x2a, 0x85, 0xd6, 0x8c, 0xdf, 0x4c, 0x6f, 0xee, 0x94, 0x83,
+	0xe2, 0x3f, 0xdb, 0x58, 0x6d, 0xd9, 0xc6, 0x6e, 0xc0, 0x1a, 0xa3, 0xf1, 0x85, 0x1f, 0x9e, 0x49,
+	0xb4, 0x05, 0x5c, 0x83, 0x3b, 0xea, 0xa9, 0x4d, 0x3f, 0xe0, 0xe2, 0xd5, 0x1c, 0x04, 0x13, 0x01,
+	0x81, 0xc4, 0x34, 0xe4, 0xd4, 0x73, 0x84, 0x18, 0x8c, 0x93, 0xe1, 0x48, 0xe2, 0xcf, 0xe2, 0x7b,
+	0x50, 0x89, 0x95, 0x46, 0x0e, 0x13, 0x22, 0xa9, 0x12, 0xd9, 0x4e, 0x1f, 0x5d, 0xf3, 0x02, 0x1a,
+	0x7f, 0xd4, 0xa0, 0xd2, 0x4b, 0xa2, 0xad, 0xfc, 0x91, 0x2d, 0x83, 0xee, 0x71, 0x75, 0x58, 0x8b,
+	0xc6, 0x26, 0x6c, 0x4c, 0x81, 0xab, 0xb3, 0xfa, 0x5b, 0x0d, 0x76, 0x92, 0x3b, 0x49, 0x79, 0xbc,
+	0x95, 0xe1, 0x94, 0x62, 0x4f, 0x5e, 0x96, 0x55, 0xb8, 0xf1, 0x2a, 0x4e, 0x45, 0xe1, 0xaf, 0x1a,
+	0xdc, 0x4c, 0xef, 0xa0, 0x95, 0x23, 0xf1, 0x7a, 0x1b, 0xb3, 0x0b, 0xd5, 0x45, 0x06, 0x8a, 0xde,
+	0xdf, 0x35, 0xa8, 0xb6, 0x62, 0x4a, 0x38, 0xb5, 0x67, 0x81, 0x56, 0x73, 0x93, 0xf0, 0xdb, 0x50,
+	0x1e, 0x91, 0x98, 0xfb, 0xae, 0x3f, 0x22, 0x21, 0x67, 0xd5, 0xdc, 0x5e, 0x76, 0x61, 0x89, 0xf1,
+	0x16, 0xbc, 0xb9, 0x84, 0x8f, 0x62, 0xfb, 0x27, 0x0d, 0x70, 0x9f, 0x93, 0x98, 0x5f, 0x93, 0x36,
+	0x7f, 0x75, 0x1f, 0xe5, 0x25, 0x3c, 0x07, 0x7e, 0x9e, 0x14, 0xe5, 0xd7, 0xa6, 0xd1, 0x2f, 0x92,
+	0x9a, 0x07, 0xaf, 0x48, 0xfd, 0x41, 0x83, 0xdd, 0x56, 0x14, 0xba, 0xc1, 0xd8, 0x5b, 0xfd, 0xca,
+	0x34, 0x6e, 0xc3, 0x5b, 0x4b, 0xc1, 0x2a, 0x32, 0x1f, 0x6a, 0xb0, 0x35, 0x67, 0x3f, 0xa2, 0x9c,
+	0x78, 0x84, 0x93, 0x69, 0x90, 0xa4, 0x49, 0xde, 0x81, 0x9c, 0x68, 0x0f, 0xe9, 0xbf, 0x05, 0x37,
+	0xd3, 0x84, 0xb3, 0x85, 0xa2, 0x43, 0x50, 0xd1, 0x62, 0x65, 0x2f, 0x71, 0x65, 0x99, 0x7b, 0xaa,
+	0xcb, 0xbc, 0x7a, 0x38, 0xf4, 0x65, 0x87, 0xe3, 0x77, 0x1a, 0xdc, 0xb0, 0x28, 0xf1, 0x56, 0x5f,
+	0xd1, 0x43, 0xb8, 0xb9, 0x00, 0x54, 0xf5, 0xeb, 0x7b, 0x50, 0x18, 0x2a, 0x05, 0x15, 0xbc, 0xdd,
+	0x45, 0xa9, 0x52, 0x8d, 0xf7, 0x9f, 0x83, 0xfe, 0x24, 0x20, 0x67, 0xb8, 0x00, 0x7a, 0xf7, 0xb8,
+	0xdb, 0x46, 0x6f, 0xe0, 0x0d, 0x00, 0xb3, 0x6f, 0x76, 0xed, 0xf6, 0xa1, 0xd5, 0xe8, 0xa0, 0xcb,
+	0x4c, 0x62, 0x18, 0x74, 0xfb, 0xe6, 0x61, 0xb7, 0xfd, 0x18, 0x5d, 0xea, 0xb8, 0x0c, 0x6b, 0x66,
+	0xff, 0x49, 0xe7, 0xb8, 0x61, 0xa3, 0xcb, 0x02, 0x5e, 0x87, 0x82, 0xd9, 0x7f, 0x7f, 0x70, 0x6c,
+	0x0b, 0x27, 0xc2, 0x25, 0xc8, 0x9b, 0x7d, 0xbb, 0xfd, 0x45, 0x1b, 0x5d, 0xee, 0x25, 0xbe, 0xa6,
+	0xd9, 0x6d, 0x58, 0x4f, 0xd1, 0xe5, 0xe7, 0xf7, 0xff, 0x99, 0x01, 0x5d, 0xfd, 0xc3, 0x55, 0xec,
+	0x0e, 0x3a, 0x1d, 0xc7, 0x7e, 0xda, 0x13, 0x29, 0x8b, 0xa0, 0x9b, 0x5d, 0xfb, 0x11, 0xfa, 0x52,
+	0x06, 0x03, 0xe4, 0x06, 0xf2, 0xfb, 0xcb, 0x79, 0xf1, 0x6d, 0x76, 0xed, 0x77, 0x1e, 0xa2, 0xaf,
+	0x64, 0x44, 0xd8, 0x41, 0x32, 0xf8, 0x6a, 0xea, 0xa8, 0x1f, 0xa0, 0xaf, 0x4d, 0x1d, 0xf5, 0x03,
+	0xf4, 0x61, 0xea, 0x78, 0x50, 0x47, 0x5f, 0x9f, 0x3a, 0x1e, 0xd4, 0xd1, 0x37, 0x52, 0xc7, 0xc3,
+	0x03, 0xf4, 0xcd, 0xa9, 0xe3, 0xe1, 0x01, 0xfa, 0x56, 0x5e, 0x70, 0x91, 0x4c, 0x1e, 0xd4, 0xd1,
+	0xb7, 0x0b, 0xd3, 0xd1, 0xc3, 0x03, 0xf4, 0x9d, 0x02, 0xae, 0x40, 0xd1, 0x36, 0x8f, 0xda, 0x7d,
+	0xbb, 0x71, 0xd4, 0x43, 0xdf, 0x45, 0x02, 0xe6, 0xe3, 0x86, 0xdd, 0x46, 0xdf, 0x93, 0x9f, 0xc2,
+	0x85, 0xbe, 0x8f, 0x04, 0x47, 0x61, 0x95, 0xc3, 0x1f, 0x48, 0xcf, 0xd3, 0x76, 0xc3, 0x42, 0x3f,
+	0xcc, 0xe3, 0x12, 0xac, 0x3d, 0x6e, 0xb7, 0xcc, 0xa3, 0x46, 0x07, 0x61, 0xb9, 0x42, 0xa8, 0xf2,
+	0xa3, 0xfb, 0xe2, 0xb3, 0xd9, 0x39, 0x6e, 0xa2, 0x1f, 0xf7, 0x44, 0xc2, 0x93, 0x86, 0xd5, 0x7a,
+	0xb7, 0x61, 0xa1, 0x9f, 0xdc, 0x17, 0x09, 0x4f, 0x1a, 0x96, 0xd2, 0xeb, 0xa7, 0x3d, 0x31, 0x51,
+	0xba, 0x7e, 0x76, 0x5f, 0x80, 0x56, 0xf6, 0x8f, 0x7a, 0xb8, 0x00, 0xd9, 0xa6, 0x69, 0xa3, 0x9f,
+	0xcb, 0x6c, 0xed, 0xee, 0xe0, 0x08, 0xfd, 0x02, 0x09, 0x63, 0xbf, 0x6d, 0xa3, 0x5f, 0x0a, 0x63,
+	0xce, 0x1e, 0xf4, 0x3a, 0x6d, 0x74, 0x6b, 0xff, 0x09, 0xa0, 0x85, 0x03, 0x52, 0x82, 0xb5, 0x41,
+	0xf7, 0xbd, 0xee, 0xf1, 0x17, 0xba, 0xe8, 0x0d, 0x31, 0xe8, 0x59, 0xed, 0x5e, 0xc3, 0x6a, 0x23,
+	0x0d, 0x03, 0xe4, 0x5b, 0xc7, 0x47, 0x47, 0xa6, 0x8d, 0x32, 0xb8, 0x0c, 0x05, 0xeb, 0xb8, 0xd3,
+	0x69, 0x36, 0x5a, 0xef, 0xa1, 0x6c, 0x73, 0x17, 0xaa, 0x6e, 0x34, 0xac, 0x4d, 0xa2, 0x31, 0x1f,
+	0x9f, 0xd2, 0xda, 0x85, 0xcf, 0x29, 0x63, 0xc9, 0xdf, 0xb0, 0xa7, 0x79, 0xf9, 0xf3, 0xe0, 0x3f,
+	0x03, 0x00, 0x63, 0xeb, 0xda, 0xc6, 0xc0, 0x15, 0x00, 0x00,
 }
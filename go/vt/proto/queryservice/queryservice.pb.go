@@ -55,6 +55,27 @@ type QueryClient interface {
 	Commit(ctx context.Context, in *query.CommitRequest, opts ...grpc.CallOption) (*query.CommitResponse, error)
 	// Rollback a transaction.
 	Rollback(ctx context.Context, in *query.RollbackRequest, opts ...grpc.CallOption) (*query.RollbackResponse, error)
+	// Prepare preserves the state of a transaction, so it can be
+	// committed or rolled back later, even after a restart.
+	Prepare(ctx context.Context, in *query.PrepareRequest, opts ...grpc.CallOption) (*query.PrepareResponse, error)
+	// CommitPrepared commits a prepared transaction.
+	CommitPrepared(ctx context.Context, in *query.CommitPreparedRequest, opts ...grpc.CallOption) (*query.CommitPreparedResponse, error)
+	// RollbackPrepared rolls back a prepared transaction, or a
+	// transaction that was not prepared yet.
+	RollbackPrepared(ctx context.Context, in *query.RollbackPreparedRequest, opts ...grpc.CallOption) (*query.RollbackPreparedResponse, error)
+	// CreateTransaction records the metadata of a distributed
+	// transaction on its coordinator.
+	CreateTransaction(ctx context.Context, in *query.CreateTransactionRequest, opts ...grpc.CallOption) (*query.CreateTransactionResponse, error)
+	// StartCommit atomically commits the coordinator's own transaction
+	// and marks the distributed transaction for commit.
+	StartCommit(ctx context.Context, in *query.StartCommitRequest, opts ...grpc.CallOption) (*query.StartCommitResponse, error)
+	// SetRollback marks the distributed transaction for rollback.
+	SetRollback(ctx context.Context, in *query.SetRollbackRequest, opts ...grpc.CallOption) (*query.SetRollbackResponse, error)
+	// ConcludeTransaction deletes the metadata of a distributed
+	// transaction once it's been resolved.
+	ConcludeTransaction(ctx context.Context, in *query.ConcludeTransactionRequest, opts ...grpc.CallOption) (*query.ConcludeTransactionResponse, error)
+	// ReadTransaction returns the metadata of a distributed transaction.
+	ReadTransaction(ctx context.Context, in *query.ReadTransactionRequest, opts ...grpc.CallOption) (*query.ReadTransactionResponse, error)
 	// SplitQuery is the API to facilitate MapReduce-type iterations
 	// over large data sets (like full table dumps).
 	SplitQuery(ctx context.Context, in *query.SplitQueryRequest, opts ...grpc.CallOption) (*query.SplitQueryResponse, error)
@@ -157,6 +178,78 @@ func (c *queryClient) Rollback(ctx context.Context, in *query.RollbackRequest, o
 	return out, nil
 }
 
+func (c *queryClient) Prepare(ctx context.Context, in *query.PrepareRequest, opts ...grpc.CallOption) (*query.PrepareResponse, error) {
+	out := new(query.PrepareResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/Prepare", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) CommitPrepared(ctx context.Context, in *query.CommitPreparedRequest, opts ...grpc.CallOption) (*query.CommitPreparedResponse, error) {
+	out := new(query.CommitPreparedResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/CommitPrepared", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) RollbackPrepared(ctx context.Context, in *query.RollbackPreparedRequest, opts ...grpc.CallOption) (*query.RollbackPreparedResponse, error) {
+	out := new(query.RollbackPreparedResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/RollbackPrepared", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) CreateTransaction(ctx context.Context, in *query.CreateTransactionRequest, opts ...grpc.CallOption) (*query.CreateTransactionResponse, error) {
+	out := new(query.CreateTransactionResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/CreateTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) StartCommit(ctx context.Context, in *query.StartCommitRequest, opts ...grpc.CallOption) (*query.StartCommitResponse, error) {
+	out := new(query.StartCommitResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/StartCommit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) SetRollback(ctx context.Context, in *query.SetRollbackRequest, opts ...grpc.CallOption) (*query.SetRollbackResponse, error) {
+	out := new(query.SetRollbackResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/SetRollback", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) ConcludeTransaction(ctx context.Context, in *query.ConcludeTransactionRequest, opts ...grpc.CallOption) (*query.ConcludeTransactionResponse, error) {
+	out := new(query.ConcludeTransactionResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/ConcludeTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) ReadTransaction(ctx context.Context, in *query.ReadTransactionRequest, opts ...grpc.CallOption) (*query.ReadTransactionResponse, error) {
+	out := new(query.ReadTransactionResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/ReadTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queryClient) SplitQuery(ctx context.Context, in *query.SplitQueryRequest, opts ...grpc.CallOption) (*query.SplitQueryResponse, error) {
 	out := new(query.SplitQueryResponse)
 	err := grpc.Invoke(ctx, "/queryservice.Query/SplitQuery", in, out, c.cc, opts...)
@@ -222,6 +315,27 @@ type QueryServer interface {
 	Commit(context.Context, *query.CommitRequest) (*query.CommitResponse, error)
 	// Rollback a transaction.
 	Rollback(context.Context, *query.RollbackRequest) (*query.RollbackResponse, error)
+	// Prepare preserves the state of a transaction, so it can be
+	// committed or rolled back later, even after a restart.
+	Prepare(context.Context, *query.PrepareRequest) (*query.PrepareResponse, error)
+	// CommitPrepared commits a prepared transaction.
+	CommitPrepared(context.Context, *query.CommitPreparedRequest) (*query.CommitPreparedResponse, error)
+	// RollbackPrepared rolls back a prepared transaction, or a
+	// transaction that was not prepared yet.
+	RollbackPrepared(context.Context, *query.RollbackPreparedRequest) (*query.RollbackPreparedResponse, error)
+	// CreateTransaction records the metadata of a distributed
+	// transaction on its coordinator.
+	CreateTransaction(context.Context, *query.CreateTransactionRequest) (*query.CreateTransactionResponse, error)
+	// StartCommit atomically commits the coordinator's own transaction
+	// and marks the distributed transaction for commit.
+	StartCommit(context.Context, *query.StartCommitRequest) (*query.StartCommitResponse, error)
+	// SetRollback marks the distributed transaction for rollback.
+	SetRollback(context.Context, *query.SetRollbackRequest) (*query.SetRollbackResponse, error)
+	// ConcludeTransaction deletes the metadata of a distributed
+	// transaction once it's been resolved.
+	ConcludeTransaction(context.Context, *query.ConcludeTransactionRequest) (*query.ConcludeTransactionResponse, error)
+	// ReadTransaction returns the metadata of a distributed transaction.
+	ReadTransaction(context.Context, *query.ReadTransactionRequest) (*query.ReadTransactionResponse, error)
 	// SplitQuery is the API to facilitate MapReduce-type iterations
 	// over large data sets (like full table dumps).
 	SplitQuery(context.Context, *query.SplitQueryRequest) (*query.SplitQueryResponse, error)
@@ -327,6 +441,102 @@ func _Query_Rollback_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return out, nil
 }
 
+func _Query_Prepare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(query.PrepareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(QueryServer).Prepare(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _Query_CommitPrepared_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(query.CommitPreparedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(QueryServer).CommitPrepared(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _Query_RollbackPrepared_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(query.RollbackPreparedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(QueryServer).RollbackPrepared(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _Query_CreateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(query.CreateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(QueryServer).CreateTransaction(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _Query_StartCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(query.StartCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(QueryServer).StartCommit(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _Query_SetRollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(query.SetRollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(QueryServer).SetRollback(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _Query_ConcludeTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(query.ConcludeTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(QueryServer).ConcludeTransaction(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _Query_ReadTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(query.ReadTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(QueryServer).ReadTransaction(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func _Query_SplitQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
 	in := new(query.SplitQueryRequest)
 	if err := dec(in); err != nil {
@@ -388,6 +598,38 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Rollback",
 			Handler:    _Query_Rollback_Handler,
 		},
+		{
+			MethodName: "Prepare",
+			Handler:    _Query_Prepare_Handler,
+		},
+		{
+			MethodName: "CommitPrepared",
+			Handler:    _Query_CommitPrepared_Handler,
+		},
+		{
+			MethodName: "RollbackPrepared",
+			Handler:    _Query_RollbackPrepared_Handler,
+		},
+		{
+			MethodName: "CreateTransaction",
+			Handler:    _Query_CreateTransaction_Handler,
+		},
+		{
+			MethodName: "StartCommit",
+			Handler:    _Query_StartCommit_Handler,
+		},
+		{
+			MethodName: "SetRollback",
+			Handler:    _Query_SetRollback_Handler,
+		},
+		{
+			MethodName: "ConcludeTransaction",
+			Handler:    _Query_ConcludeTransaction_Handler,
+		},
+		{
+			MethodName: "ReadTransaction",
+			Handler:    _Query_ReadTransaction_Handler,
+		},
 		{
 			MethodName: "SplitQuery",
 			Handler:    _Query_SplitQuery_Handler,
@@ -408,23 +650,32 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 }
 
 var fileDescriptor0 = []byte{
-	// 281 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x92, 0xdd, 0x4a, 0xc3, 0x40,
-	0x10, 0x85, 0x15, 0x6c, 0x95, 0x31, 0xde, 0x8c, 0xd6, 0x9f, 0xe8, 0x95, 0x0f, 0x50, 0x44, 0x05,
-	0x41, 0xf0, 0xa6, 0x45, 0xb4, 0x08, 0x82, 0xcd, 0x13, 0xa4, 0x71, 0xd0, 0xc5, 0x24, 0x1b, 0xb3,
-	0x53, 0xd1, 0x47, 0xf6, 0x2d, 0xac, 0xd9, 0xcc, 0x26, 0xd9, 0xe6, 0x72, 0xbe, 0x33, 0xe7, 0x70,
-	0xb2, 0x13, 0xc0, 0xcf, 0x25, 0x95, 0x3f, 0x86, 0xca, 0x2f, 0x95, 0xd0, 0xb8, 0x28, 0x35, 0x6b,
-	0x0c, 0xda, 0x2c, 0xdc, 0xad, 0x26, 0x2b, 0x5d, 0xfe, 0x6e, 0xc1, 0xe0, 0xe5, 0x7f, 0xc6, 0x19,
-	0x04, 0x0f, 0xc4, 0x11, 0x19, 0xa3, 0x74, 0x3e, 0x7b, 0xc5, 0x70, 0x6c, 0xf7, 0xda, 0x70, 0x4e,
-	0x2b, 0x66, 0x38, 0x3c, 0xed, 0xd5, 0x4c, 0xa1, 0x73, 0x43, 0xe7, 0x1b, 0x78, 0x0b, 0xdb, 0xf7,
-	0xdf, 0x94, 0x2c, 0x99, 0x70, 0x54, 0x6f, 0xd6, 0xb3, 0x04, 0x1c, 0xfa, 0xd8, 0x79, 0x57, 0x35,
-	0x6a, 0x38, 0x89, 0x39, 0x79, 0x77, 0x35, 0xda, 0xd0, 0xaf, 0xd1, 0xd5, 0x5c, 0xd4, 0x33, 0xec,
-	0x45, 0x5c, 0x52, 0x9c, 0x49, 0x19, 0xd9, 0xef, 0x50, 0x09, 0x3b, 0xeb, 0x17, 0x25, 0xed, 0x62,
-	0x13, 0xaf, 0x61, 0x30, 0xa1, 0x37, 0x95, 0xe3, 0x7e, 0xbd, 0x5a, 0x4d, 0xe2, 0x3f, 0xe8, 0x42,
-	0xd7, 0xe2, 0x06, 0x86, 0x53, 0x9d, 0x65, 0x8a, 0x51, 0x36, 0xec, 0x28, 0xbe, 0x91, 0x47, 0x9d,
-	0xf1, 0x0e, 0x76, 0xe6, 0x3a, 0x4d, 0x17, 0x71, 0xf2, 0x81, 0xf2, 0x5e, 0x02, 0xc4, 0x7c, 0xb4,
-	0xc6, 0x9d, 0x7d, 0x0a, 0x10, 0x15, 0xa9, 0x62, 0x7b, 0xdd, 0x63, 0xf9, 0x3a, 0x87, 0x24, 0xe2,
-	0xa4, 0x47, 0x71, 0x21, 0x4f, 0x10, 0xd8, 0xf7, 0x78, 0xa4, 0x38, 0xe5, 0xe6, 0x1a, 0x6d, 0xe8,
-	0x5f, 0xa3, 0xab, 0x35, 0xef, 0xb7, 0x18, 0x56, 0xbf, 0xdc, 0xd5, 0x5f, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xb4, 0x5c, 0x99, 0x9c, 0xa3, 0x02, 0x00, 0x00,
+	// 426 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x94, 0x5d, 0x8f, 0x93, 0x50,
+	0x10, 0x86, 0xf5, 0xa2, 0x55, 0xa7, 0xf8, 0x75, 0x6a, 0xd5, 0x62, 0xad, 0xda, 0x1f, 0xd0, 0x18,
+	0x35, 0x31, 0x31, 0xf1, 0xa6, 0xc4, 0x8f, 0xc6, 0xc4, 0x0f, 0xd0, 0xc4, 0x2b, 0x93, 0x53, 0x98,
+	0x28, 0x91, 0x72, 0xe8, 0xe1, 0xb0, 0xd9, 0xfd, 0xcd, 0xfb, 0x27, 0x36, 0x5b, 0x98, 0x01, 0x0e,
+	0xb0, 0x97, 0xf3, 0xbe, 0x33, 0x4f, 0x5e, 0x18, 0x18, 0x10, 0x87, 0x02, 0xf5, 0x59, 0x8e, 0xfa,
+	0x24, 0x0e, 0x71, 0x9d, 0x69, 0x65, 0x94, 0x70, 0x9a, 0x9a, 0x3b, 0x39, 0x56, 0xa5, 0xf5, 0xea,
+	0xfc, 0x16, 0x8c, 0x7e, 0x5c, 0xd6, 0x62, 0x0b, 0xce, 0x27, 0x34, 0x01, 0xe6, 0x79, 0xac, 0xd2,
+	0x6d, 0x24, 0xdc, 0x75, 0xd9, 0xd7, 0x14, 0x7d, 0x3c, 0x14, 0x98, 0x1b, 0xf7, 0x49, 0xaf, 0x97,
+	0x67, 0x2a, 0xcd, 0x71, 0x75, 0x4d, 0xbc, 0x83, 0x1b, 0x1f, 0x4e, 0x31, 0x2c, 0x0c, 0x8a, 0x59,
+	0xd5, 0x59, 0xd5, 0x04, 0x78, 0x68, 0xcb, 0x3c, 0xbb, 0x05, 0xa7, 0x12, 0x37, 0xd2, 0x84, 0xff,
+	0x38, 0x46, 0x53, 0xb4, 0x63, 0xb4, 0x3d, 0x46, 0x7d, 0x85, 0xdb, 0x81, 0xd1, 0x28, 0xf7, 0x14,
+	0x86, 0xfa, 0x5b, 0x2a, 0xc1, 0x16, 0xfd, 0x26, 0xd1, 0x5e, 0x5e, 0x17, 0x6f, 0x60, 0xb4, 0xc1,
+	0xbf, 0x71, 0x2a, 0xa6, 0x55, 0xeb, 0xb1, 0xa2, 0xf9, 0x07, 0x6d, 0x91, 0x53, 0xbc, 0x85, 0xb1,
+	0xa7, 0xf6, 0xfb, 0xd8, 0x08, 0xea, 0x28, 0x4b, 0x9a, 0x9b, 0x59, 0x2a, 0x0f, 0xbe, 0x87, 0x9b,
+	0xbe, 0x4a, 0x92, 0x9d, 0x0c, 0xff, 0x0b, 0x7a, 0x5f, 0x24, 0xd0, 0xf0, 0xa3, 0x8e, 0xde, 0x5c,
+	0xc2, 0x77, 0x8d, 0x99, 0xd4, 0xf5, 0x12, 0xaa, 0xda, 0x5e, 0x02, 0xcb, 0x3c, 0xfb, 0x0d, 0xee,
+	0x94, 0x71, 0x2a, 0x2b, 0x12, 0x8b, 0x56, 0x4a, 0x92, 0x89, 0xf4, 0x74, 0xc0, 0x65, 0xe0, 0x2f,
+	0xb8, 0x47, 0x11, 0x19, 0xb9, 0xb4, 0xb2, 0xdb, 0xd0, 0x67, 0x83, 0x3e, 0x63, 0x7f, 0xc3, 0x7d,
+	0x4f, 0xa3, 0x34, 0xf8, 0x53, 0xcb, 0x34, 0x97, 0xa1, 0x89, 0x55, 0x2a, 0x68, 0xae, 0xe3, 0x10,
+	0xf8, 0xf9, 0x70, 0x03, 0x93, 0x3f, 0xc2, 0x24, 0x30, 0x52, 0x9b, 0x6a, 0x75, 0x73, 0xfe, 0x38,
+	0x58, 0x23, 0x9a, 0xdb, 0x67, 0xb5, 0x38, 0x68, 0x78, 0x8f, 0xcc, 0xa9, 0xb5, 0x0e, 0xa7, 0x69,
+	0x31, 0xe7, 0x0f, 0x4c, 0x3d, 0x95, 0x86, 0x49, 0x11, 0xb5, 0x9e, 0xf5, 0x05, 0xbf, 0xf8, 0x8e,
+	0x47, 0xdc, 0xd5, 0x55, 0x2d, 0xcc, 0xf7, 0xe1, 0xae, 0x8f, 0x32, 0x6a, 0xb2, 0x69, 0xa9, 0x96,
+	0x4e, 0xdc, 0xe5, 0x90, 0xcd, 0x4c, 0x0f, 0x20, 0xc8, 0x92, 0xd8, 0x94, 0xf7, 0xe5, 0x31, 0x3d,
+	0x1f, 0x4b, 0x44, 0x9a, 0xf7, 0x38, 0x0c, 0xf9, 0x02, 0x4e, 0xf9, 0x47, 0x7e, 0x46, 0x99, 0x98,
+	0xfa, 0x1e, 0x34, 0x45, 0xfb, 0x1e, 0xb4, 0xbd, 0xfa, 0x0f, 0xde, 0x8d, 0x8f, 0x47, 0xef, 0xf5,
+	0xc5, 0x00, 0x20, 0x06, 0x61, 0x1e, 0x25, 0x05, 0x00, 0x00,
 }
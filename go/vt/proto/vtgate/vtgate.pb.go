@@ -44,6 +44,11 @@ It has these top-level messages:
 	SplitQueryResponse
 	GetSrvKeyspaceRequest
 	GetSrvKeyspaceResponse
+	ExplainRequest
+	QueryPlan
+	ExplainResponse
+	ResolveTransactionRequest
+	ResolveTransactionResponse
 */
 package vtgate
 
@@ -65,6 +70,23 @@ var _ = math.Inf
 type Session struct {
 	InTransaction bool                    `protobuf:"varint,1,opt,name=in_transaction" json:"in_transaction,omitempty"`
 	ShardSessions []*Session_ShardSession `protobuf:"bytes,2,rep,name=shard_sessions" json:"shard_sessions,omitempty"`
+	// keyspace is the default keyspace of the session, set by
+	// USE keyspace. Queries on tables that are not in the VSchema are
+	// sent to it if it is unsharded.
+	Keyspace string `protobuf:"bytes,3,opt,name=keyspace" json:"keyspace,omitempty"`
+	// shard, if set, is the shard all queries are sent to, without
+	// using the VSchema. It is set by USE keyspace:shard.
+	Shard string `protobuf:"bytes,4,opt,name=shard" json:"shard,omitempty"`
+	// tablet_type, if set, overrides the tablet_type of the requests.
+	// It is set by USE keyspace@tablet_type, and by
+	// SET target_tablet_type = 'tablet_type'.
+	TabletType topodata.TabletType `protobuf:"varint,5,opt,name=tablet_type,enum=topodata.TabletType" json:"tablet_type,omitempty"`
+	// no_autocommit is set by SET autocommit = 0. The next query sent
+	// to a master outside of a transaction then starts one.
+	NoAutocommit bool `protobuf:"varint,6,opt,name=no_autocommit" json:"no_autocommit,omitempty"`
+	// workload is set by SET workload. Only oltp is supported for now.
+	// It is only informational.
+	Workload string `protobuf:"bytes,7,opt,name=workload" json:"workload,omitempty"`
 }
 
 func (m *Session) Reset()                    { *m = Session{} }
@@ -102,11 +124,13 @@ type ExecuteRequest struct {
 	// set by the application to further identify the caller.
 	CallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=caller_id" json:"caller_id,omitempty"`
 	// session carries the current transaction data. It is returned by Begin.
-	// Do not fill it in if outside of a transaction.
+	// Do not fill it in if outside of a transaction, unless it
+	// carries the state set by USE and SET statements.
 	Session *Session `protobuf:"bytes,2,opt,name=session" json:"session,omitempty"`
 	// query is the query and bind variables to execute.
 	Query *query.BoundQuery `protobuf:"bytes,3,opt,name=query" json:"query,omitempty"`
 	// tablet_type is the type of tablets that this query is targeted to.
+	// It is overridden by the tablet_type of the session, if set.
 	TabletType topodata.TabletType `protobuf:"varint,4,opt,name=tablet_type,enum=topodata.TabletType" json:"tablet_type,omitempty"`
 	// not_in_transaction is deprecated and should not be used.
 	NotInTransaction bool `protobuf:"varint,5,opt,name=not_in_transaction" json:"not_in_transaction,omitempty"`
@@ -1250,6 +1274,138 @@ func (m *GetSrvKeyspaceResponse) GetSrvKeyspace() *topodata.SrvKeyspace {
 	return nil
 }
 
+// ExplainRequest is the payload to Explain.
+type ExplainRequest struct {
+	// caller_id identifies the caller. This is the effective caller ID,
+	// set by the application to further identify the caller.
+	CallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=caller_id" json:"caller_id,omitempty"`
+	// query is the query to explain. Its bind variables are used to
+	// resolve the shards.
+	Query *query.BoundQuery `protobuf:"bytes,2,opt,name=query" json:"query,omitempty"`
+	// tablet_type is the type of tablets the shards are resolved for.
+	TabletType topodata.TabletType `protobuf:"varint,3,opt,name=tablet_type,enum=topodata.TabletType" json:"tablet_type,omitempty"`
+}
+
+func (m *ExplainRequest) Reset()                    { *m = ExplainRequest{} }
+func (m *ExplainRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExplainRequest) ProtoMessage()               {}
+func (*ExplainRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *ExplainRequest) GetCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.CallerId
+	}
+	return nil
+}
+
+func (m *ExplainRequest) GetQuery() *query.BoundQuery {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+// QueryPlan describes how vtgate routes a query.
+type QueryPlan struct {
+	// plan_id is the name of the plan, like SelectEqual or SelectJoin.
+	// It is NoPlan if vtgate cannot route the query.
+	PlanId string `protobuf:"bytes,1,opt,name=plan_id" json:"plan_id,omitempty"`
+	// reason explains why the plan was chosen, or why there is no plan.
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	// table is the table the plan was built for.
+	Table string `protobuf:"bytes,3,opt,name=table" json:"table,omitempty"`
+	// keyspace is the keyspace the query is sent to.
+	Keyspace string `protobuf:"bytes,4,opt,name=keyspace" json:"keyspace,omitempty"`
+	// vindex is the name of the vindex used to route the query, if any.
+	Vindex string `protobuf:"bytes,5,opt,name=vindex" json:"vindex,omitempty"`
+	// rewritten_sql is the query sent to the shards.
+	RewrittenSql string `protobuf:"bytes,6,opt,name=rewritten_sql" json:"rewritten_sql,omitempty"`
+	// shards are the shards the query is sent to, for the bind variables
+	// of the request.
+	Shards []string `protobuf:"bytes,7,rep,name=shards" json:"shards,omitempty"`
+	// shard_error is set if the shards could not be resolved, for instance
+	// because they depend on the results of another query.
+	ShardError string `protobuf:"bytes,8,opt,name=shard_error" json:"shard_error,omitempty"`
+	// pullouts are the plans of the subqueries vtgate executes before
+	// the query, to supply the values of list bind variables.
+	Pullouts []*QueryPlan `protobuf:"bytes,9,rep,name=pullouts" json:"pullouts,omitempty"`
+	// join_left and join_right are the plans of the queries vtgate joins
+	// for a SelectJoin plan.
+	JoinLeft  *QueryPlan `protobuf:"bytes,10,opt,name=join_left" json:"join_left,omitempty"`
+	JoinRight *QueryPlan `protobuf:"bytes,11,opt,name=join_right" json:"join_right,omitempty"`
+}
+
+func (m *QueryPlan) Reset()                    { *m = QueryPlan{} }
+func (m *QueryPlan) String() string            { return proto.CompactTextString(m) }
+func (*QueryPlan) ProtoMessage()               {}
+func (*QueryPlan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *QueryPlan) GetPullouts() []*QueryPlan {
+	if m != nil {
+		return m.Pullouts
+	}
+	return nil
+}
+
+func (m *QueryPlan) GetJoinLeft() *QueryPlan {
+	if m != nil {
+		return m.JoinLeft
+	}
+	return nil
+}
+
+func (m *QueryPlan) GetJoinRight() *QueryPlan {
+	if m != nil {
+		return m.JoinRight
+	}
+	return nil
+}
+
+// ExplainResponse is the returned value from Explain.
+type ExplainResponse struct {
+	// plan describes how the query is routed.
+	Plan *QueryPlan `protobuf:"bytes,1,opt,name=plan" json:"plan,omitempty"`
+}
+
+func (m *ExplainResponse) Reset()                    { *m = ExplainResponse{} }
+func (m *ExplainResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExplainResponse) ProtoMessage()               {}
+func (*ExplainResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ExplainResponse) GetPlan() *QueryPlan {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+// ResolveTransactionRequest is the payload to ResolveTransaction.
+type ResolveTransactionRequest struct {
+	CallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=caller_id" json:"caller_id,omitempty"`
+	Dtid     string          `protobuf:"bytes,2,opt,name=dtid" json:"dtid,omitempty"`
+}
+
+func (m *ResolveTransactionRequest) Reset()                    { *m = ResolveTransactionRequest{} }
+func (m *ResolveTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResolveTransactionRequest) ProtoMessage()               {}
+func (*ResolveTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ResolveTransactionRequest) GetCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.CallerId
+	}
+	return nil
+}
+
+// ResolveTransactionResponse is the returned value from ResolveTransaction.
+type ResolveTransactionResponse struct {
+}
+
+func (m *ResolveTransactionResponse) Reset()                    { *m = ResolveTransactionResponse{} }
+func (m *ResolveTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*ResolveTransactionResponse) ProtoMessage()               {}
+func (*ResolveTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
 func init() {
 	proto.RegisterType((*Session)(nil), "vtgate.Session")
 	proto.RegisterType((*Session_ShardSession)(nil), "vtgate.Session.ShardSession")
@@ -1291,76 +1447,95 @@ func init() {
 	proto.RegisterType((*SplitQueryResponse_Part)(nil), "vtgate.SplitQueryResponse.Part")
 	proto.RegisterType((*GetSrvKeyspaceRequest)(nil), "vtgate.GetSrvKeyspaceRequest")
 	proto.RegisterType((*GetSrvKeyspaceResponse)(nil), "vtgate.GetSrvKeyspaceResponse")
+	proto.RegisterType((*ExplainRequest)(nil), "vtgate.ExplainRequest")
+	proto.RegisterType((*QueryPlan)(nil), "vtgate.QueryPlan")
+	proto.RegisterType((*ExplainResponse)(nil), "vtgate.ExplainResponse")
+	proto.RegisterType((*ResolveTransactionRequest)(nil), "vtgate.ResolveTransactionRequest")
+	proto.RegisterType((*ResolveTransactionResponse)(nil), "vtgate.ResolveTransactionResponse")
 }
 
 var fileDescriptor0 = []byte{
-	// 1088 bytes of a gzipped FileDescriptorProto
+	// 1304 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xcc, 0x58, 0x4f, 0x6f, 0xe3, 0x44,
-	0x14, 0xd7, 0xc4, 0x69, 0xd2, 0xbc, 0xb8, 0x69, 0xeb, 0x6d, 0x77, 0x83, 0xd9, 0x76, 0x2b, 0x83,
-	0x20, 0x2b, 0xb4, 0x46, 0x04, 0x0e, 0x48, 0x1c, 0x10, 0xed, 0x06, 0x58, 0x71, 0xd9, 0x4d, 0x8a,
-	0x38, 0x9a, 0x69, 0x32, 0xea, 0x5a, 0x9b, 0xd8, 0xc1, 0x9e, 0x84, 0x0d, 0x12, 0x12, 0x5a, 0x0e,
-	0x70, 0xe4, 0xc4, 0x91, 0xaf, 0x80, 0xb8, 0x72, 0xe1, 0xce, 0x81, 0x33, 0xdf, 0x80, 0xfb, 0x7e,
-	0x02, 0xe6, 0x9f, 0xe3, 0x3f, 0x4d, 0xd2, 0x38, 0xca, 0x46, 0x3d, 0xb5, 0x1e, 0xcf, 0xbc, 0xf7,
-	0xfb, 0xf3, 0xfc, 0xe6, 0x29, 0xa0, 0x8f, 0xe9, 0x25, 0xa6, 0xc4, 0x1e, 0x06, 0x3e, 0xf5, 0x8d,
-	0x92, 0x7c, 0x32, 0xab, 0xdf, 0x8c, 0x48, 0x30, 0x91, 0x8b, 0x66, 0x8d, 0xfa, 0x43, 0xbf, 0x87,
-	0x29, 0x56, 0xcf, 0xd5, 0x31, 0x0d, 0x86, 0x5d, 0xf9, 0x60, 0xfd, 0x86, 0xa0, 0xdc, 0x21, 0x61,
-	0xe8, 0xfa, 0x9e, 0x71, 0x1b, 0x6a, 0xae, 0xe7, 0xd0, 0x00, 0x7b, 0x21, 0xee, 0x52, 0xb6, 0x52,
-	0x47, 0x27, 0xa8, 0xb1, 0x6d, 0x7c, 0x00, 0xb5, 0xf0, 0x29, 0x0e, 0x7a, 0x4e, 0x28, 0x37, 0x86,
-	0xf5, 0xc2, 0x89, 0xd6, 0xa8, 0x36, 0xef, 0xda, 0x2a, 0xb9, 0x0a, 0x60, 0x77, 0xf8, 0x2e, 0xf5,
-	0x60, 0xb6, 0x40, 0x4f, 0x3e, 0x1b, 0x47, 0x50, 0xa2, 0x38, 0xb8, 0x24, 0x54, 0x44, 0xad, 0x36,
-	0x77, 0x6c, 0x09, 0xf2, 0x5c, 0x2c, 0xf2, 0xe4, 0x89, 0xcc, 0x8e, 0xdb, 0x63, 0x49, 0x50, 0x43,
-	0xb3, 0xfe, 0x42, 0x50, 0x6b, 0x3d, 0x27, 0xdd, 0x11, 0x25, 0x6d, 0xc2, 0x4e, 0x84, 0xd4, 0xb0,
-	0xa0, 0xd2, 0xc5, 0xfd, 0x3e, 0x09, 0xf8, 0x2e, 0x19, 0x6c, 0xd7, 0x96, 0xa4, 0xce, 0xc4, 0xfa,
-	0xa3, 0x87, 0xc6, 0x09, 0x94, 0x15, 0x5a, 0x11, 0x47, 0xee, 0x48, 0x82, 0x65, 0x3b, 0xb6, 0x04,
-	0x80, 0xba, 0x26, 0xde, 0xef, 0x2b, 0x38, 0xa7, 0xfe, 0xc8, 0xeb, 0x3d, 0xe1, 0xff, 0x1a, 0xf7,
-	0xa1, 0x4a, 0xf1, 0x45, 0x9f, 0x50, 0x87, 0x4e, 0x86, 0xa4, 0x5e, 0x64, 0xfb, 0x6a, 0xcd, 0x03,
-	0x7b, 0x2a, 0xe7, 0xb9, 0x78, 0x79, 0xce, 0xde, 0x19, 0x26, 0x18, 0x9e, 0x4f, 0x9d, 0x8c, 0x7c,
-	0x5b, 0x5c, 0x3e, 0xeb, 0x5b, 0xd8, 0x9d, 0x12, 0x08, 0x87, 0x4c, 0x3e, 0x62, 0x1c, 0xc3, 0x16,
-	0x09, 0x02, 0x3f, 0xc8, 0xa0, 0x6f, 0x3f, 0x3e, 0x6b, 0xf1, 0xe5, 0x25, 0xd0, 0x5b, 0x50, 0x0a,
-	0x48, 0x38, 0xea, 0x53, 0x05, 0xdf, 0x50, 0xf0, 0x05, 0xf2, 0xb6, 0x78, 0x63, 0xfd, 0x87, 0xe0,
-	0x40, 0x65, 0x16, 0x4e, 0x84, 0x9b, 0x16, 0x70, 0x0f, 0xb6, 0x9f, 0x91, 0x49, 0x38, 0xc4, 0x5d,
-	0xa9, 0x5e, 0xc5, 0xa8, 0x41, 0x49, 0x94, 0x52, 0xc8, 0xb4, 0xd1, 0xd8, 0x73, 0x46, 0xe2, 0x52,
-	0x6e, 0x89, 0xcb, 0x42, 0xe2, 0xef, 0xe1, 0x30, 0x43, 0x74, 0xa3, 0x42, 0xbf, 0x44, 0xf0, 0x9a,
-	0xca, 0xff, 0x85, 0xe2, 0xfb, 0xe8, 0x26, 0xa8, 0x7d, 0x00, 0x7a, 0xb4, 0xc2, 0x72, 0x4b, 0xcd,
-	0xf5, 0x75, 0x69, 0xfe, 0x02, 0x81, 0x39, 0x8b, 0xf4, 0x46, 0x95, 0x7f, 0x51, 0x80, 0x3b, 0x31,
-	0x88, 0x36, 0xf6, 0x2e, 0xc9, 0x0d, 0xd0, 0xfd, 0x2d, 0x00, 0xb6, 0xe2, 0x04, 0x02, 0x8e, 0x50,
-	0x9d, 0xa3, 0x9f, 0x0a, 0x1c, 0x21, 0x5d, 0x97, 0x13, 0x3f, 0x20, 0xa8, 0x5f, 0x15, 0x61, 0xa3,
-	0x3e, 0xfc, 0xa2, 0x4d, 0x7d, 0x68, 0x79, 0xd4, 0xa5, 0x93, 0x1b, 0x51, 0xff, 0x4c, 0x34, 0x22,
-	0xd0, 0x38, 0x5d, 0xbf, 0x3f, 0x1a, 0x78, 0x8e, 0x87, 0x07, 0x44, 0x74, 0xe5, 0x8a, 0xd1, 0x82,
-	0x5b, 0xea, 0x5d, 0xea, 0x13, 0x29, 0x09, 0xb3, 0x1a, 0x51, 0xf6, 0x39, 0x9c, 0xec, 0x68, 0x21,
-	0x6b, 0x61, 0x39, 0xb7, 0x85, 0xdb, 0xdc, 0x42, 0xf3, 0x09, 0x6c, 0x4f, 0x43, 0x1e, 0xc1, 0xf6,
-	0x73, 0xb7, 0x27, 0xe3, 0x21, 0x11, 0xaf, 0x1a, 0x5d, 0x95, 0x3c, 0xcc, 0x3e, 0x54, 0xf8, 0xeb,
-	0x31, 0xee, 0x8f, 0x88, 0x10, 0x4b, 0x37, 0x6e, 0x41, 0x35, 0x41, 0x42, 0x28, 0xa4, 0x27, 0xab,
-	0x22, 0x01, 0x7f, 0xa3, 0x55, 0xf1, 0x25, 0xec, 0x0a, 0x7f, 0x44, 0x53, 0x96, 0x26, 0x4d, 0x6d,
-	0x44, 0xcb, 0xd8, 0x58, 0xc8, 0x5c, 0x1a, 0x1a, 0xbf, 0x34, 0xac, 0x7f, 0xe2, 0x76, 0x7b, 0x8a,
-	0x69, 0xf7, 0xe9, 0xab, 0xb8, 0xdc, 0x1a, 0x50, 0xe6, 0xc8, 0x5c, 0x22, 0x93, 0x56, 0x9b, 0x77,
-	0xa2, 0x1d, 0x59, 0x46, 0x39, 0xa6, 0x04, 0x36, 0xe3, 0xe0, 0x70, 0xc6, 0x84, 0xf0, 0x63, 0xdc,
-	0x4a, 0x53, 0x84, 0xd6, 0x66, 0xd6, 0x1b, 0x50, 0x96, 0x66, 0x45, 0x6c, 0x66, 0xb9, 0xf5, 0x35,
-	0x1c, 0x08, 0x6e, 0x71, 0x37, 0x5f, 0xdd, 0xb2, 0xec, 0xcd, 0xc3, 0xb3, 0xea, 0xd6, 0xbf, 0x08,
-	0x8e, 0x93, 0x3c, 0x5f, 0xd9, 0x65, 0xf9, 0x20, 0xeb, 0xde, 0xdd, 0x94, 0x7b, 0x59, 0x86, 0x6b,
-	0xb0, 0xf0, 0x67, 0x04, 0xf7, 0xe6, 0x52, 0xdb, 0xac, 0x8f, 0x3f, 0xb1, 0xb1, 0xaf, 0x43, 0x03,
-	0x82, 0x07, 0x2b, 0xcd, 0xcd, 0xca, 0xec, 0xc2, 0x92, 0x53, 0xb1, 0x36, 0x5f, 0x2c, 0xeb, 0x23,
-	0x38, 0xcc, 0x00, 0x51, 0x4a, 0xc4, 0xcd, 0x03, 0xcd, 0x6d, 0x1e, 0xbf, 0xb3, 0x8f, 0x22, 0x75,
-	0x7a, 0x95, 0xcf, 0xfc, 0x3a, 0x32, 0xc9, 0xca, 0xd5, 0x32, 0xcd, 0xa6, 0x38, 0x6b, 0x42, 0xdd,
-	0x5a, 0x40, 0xf7, 0x13, 0x78, 0x7d, 0x26, 0xe0, 0x1c, 0xa4, 0xff, 0x64, 0x65, 0x94, 0x8a, 0xb1,
-	0xf2, 0x27, 0x92, 0x9f, 0x79, 0xf6, 0x9b, 0x2d, 0xce, 0x9a, 0x16, 0x17, 0xf1, 0xff, 0x14, 0x4e,
-	0xe6, 0x63, 0xcf, 0x21, 0xc2, 0xdf, 0x08, 0x8e, 0xb2, 0x81, 0x56, 0x19, 0xed, 0xf2, 0x4b, 0x90,
-	0x1e, 0xdc, 0x8a, 0xcb, 0x0e, 0x6e, 0x8b, 0x44, 0x79, 0x08, 0xc7, 0xf3, 0xb8, 0xe4, 0x90, 0xa4,
-	0x09, 0xfa, 0x29, 0xb9, 0x74, 0xbd, 0x1c, 0x02, 0x58, 0xef, 0xc1, 0x8e, 0x3a, 0xa3, 0x12, 0x25,
-	0xfa, 0x0b, 0x9a, 0xd9, 0x5f, 0xd8, 0x85, 0xbd, 0x73, 0xe6, 0x0f, 0x06, 0x2e, 0x5d, 0x6b, 0x3b,
-	0xb6, 0xf6, 0xa0, 0x16, 0x85, 0x95, 0x50, 0xac, 0xaf, 0x60, 0xb7, 0xed, 0xf7, 0xfb, 0x17, 0xb8,
-	0xfb, 0x6c, 0xbd, 0xa9, 0x0c, 0xd8, 0x8b, 0x03, 0xab, 0x64, 0xbf, 0x22, 0xd8, 0xef, 0x0c, 0xfb,
-	0x2e, 0x55, 0x8a, 0x2e, 0x9f, 0xef, 0xea, 0xc5, 0x76, 0xfd, 0x18, 0xca, 0x3e, 0xa3, 0x90, 0x27,
-	0x53, 0x33, 0xa7, 0x1a, 0x45, 0xd9, 0x88, 0x16, 0xad, 0x8e, 0x3c, 0x2a, 0x2a, 0x46, 0xb3, 0x5e,
-	0x16, 0xc0, 0x48, 0x02, 0x53, 0x3e, 0xbd, 0xcb, 0x5a, 0x10, 0x5f, 0x0d, 0x19, 0x2c, 0x5e, 0x81,
-	0xf7, 0xa6, 0x24, 0xaf, 0xec, 0xb5, 0x1f, 0xe3, 0x80, 0x9a, 0x9f, 0x83, 0x1e, 0x95, 0x15, 0x7f,
-	0x4e, 0xc1, 0x46, 0x33, 0x0a, 0xbb, 0x30, 0xaf, 0xb0, 0xcd, 0x07, 0x50, 0x11, 0x5d, 0x6b, 0x4e,
-	0x98, 0xb8, 0x39, 0xf2, 0x10, 0x15, 0xf3, 0x0f, 0x04, 0x45, 0xb1, 0xf5, 0xfa, 0x19, 0xe1, 0x63,
-	0xa8, 0x4d, 0x11, 0x38, 0x43, 0x76, 0x46, 0x39, 0xf8, 0xf6, 0x02, 0x72, 0x29, 0x52, 0x1f, 0x02,
-	0xc8, 0x5f, 0xa1, 0xc4, 0x61, 0x29, 0xff, 0x9b, 0x0b, 0x0e, 0xc7, 0x3c, 0x74, 0x28, 0x86, 0xee,
-	0x77, 0xf2, 0x5e, 0xd7, 0xac, 0xfb, 0x70, 0xf8, 0x19, 0xa1, 0x9d, 0x60, 0x1c, 0xb5, 0xa7, 0xa8,
-	0x20, 0xae, 0xd0, 0xb5, 0x5a, 0x70, 0x3b, 0xbb, 0x55, 0x59, 0xf4, 0x0e, 0x33, 0x39, 0x18, 0x3b,
-	0xa9, 0xfd, 0xd5, 0xe6, 0x61, 0xac, 0x68, 0xe2, 0xd0, 0xa9, 0x09, 0xf5, 0xae, 0x3f, 0xb0, 0x27,
-	0xfe, 0x88, 0x8e, 0x2e, 0x88, 0x3d, 0x76, 0x29, 0x2b, 0x56, 0xf9, 0xfb, 0xdb, 0x45, 0x49, 0xfc,
-	0x79, 0xff, 0xff, 0x00, 0x00, 0x00, 0xff, 0xff, 0xcd, 0x1e, 0x62, 0xc0, 0xc8, 0x13, 0x00, 0x00,
+	0x14, 0xd7, 0x24, 0x69, 0xfe, 0x3c, 0x3b, 0x49, 0xeb, 0x26, 0x5d, 0xaf, 0xe9, 0x9f, 0xc8, 0xbb,
+	0x40, 0x56, 0x68, 0x83, 0x08, 0x1c, 0x90, 0x38, 0x20, 0xda, 0x0d, 0xb0, 0x42, 0x48, 0xdd, 0xb4,
+	0x2b, 0x8e, 0x66, 0x9a, 0x0c, 0xad, 0xa9, 0x63, 0xbb, 0x9e, 0x71, 0xda, 0x20, 0x21, 0xc1, 0x72,
+	0x00, 0x89, 0x0b, 0x12, 0x12, 0x5f, 0x03, 0x71, 0xe5, 0xc2, 0x9d, 0x03, 0x67, 0xbe, 0x01, 0xf7,
+	0xfd, 0x04, 0xc8, 0xe3, 0x71, 0x9c, 0x38, 0x7f, 0xda, 0x54, 0xd9, 0xa8, 0xa7, 0xd6, 0x93, 0x37,
+	0xef, 0xbd, 0xdf, 0xef, 0xf7, 0xe6, 0xcd, 0xb3, 0x41, 0xee, 0xb3, 0x53, 0xcc, 0x48, 0xc3, 0xf5,
+	0x1c, 0xe6, 0x28, 0xd9, 0xf0, 0x49, 0x93, 0x2e, 0x7c, 0xe2, 0x0d, 0xc2, 0x45, 0xad, 0xc4, 0x1c,
+	0xd7, 0xe9, 0x62, 0x86, 0xc5, 0xb3, 0xd4, 0x67, 0x9e, 0xdb, 0x09, 0x1f, 0xf4, 0x5f, 0x53, 0x90,
+	0x3b, 0x22, 0x94, 0x9a, 0x8e, 0xad, 0x6c, 0x41, 0xc9, 0xb4, 0x0d, 0xe6, 0x61, 0x9b, 0xe2, 0x0e,
+	0x33, 0x1d, 0x5b, 0x45, 0x35, 0x54, 0xcf, 0x2b, 0xef, 0x41, 0x89, 0x9e, 0x61, 0xaf, 0x6b, 0xd0,
+	0xd0, 0x90, 0xaa, 0xa9, 0x5a, 0xba, 0x2e, 0x35, 0xb7, 0x1b, 0x22, 0xb8, 0x70, 0xd0, 0x38, 0x0a,
+	0xac, 0x22, 0x6f, 0xeb, 0x90, 0x3f, 0x27, 0x03, 0xea, 0xe2, 0x0e, 0x51, 0xd3, 0x35, 0x54, 0x2f,
+	0x28, 0x45, 0x58, 0xe3, 0x7e, 0xd4, 0x0c, 0x7f, 0x7c, 0x04, 0x12, 0xc3, 0x27, 0x16, 0x61, 0x06,
+	0x1b, 0xb8, 0x44, 0x5d, 0xab, 0xa1, 0x7a, 0xa9, 0x59, 0x69, 0x0c, 0xb3, 0x3d, 0xe6, 0x3f, 0x1e,
+	0x0f, 0x5c, 0xa2, 0x54, 0xa1, 0x68, 0x3b, 0x06, 0xf6, 0x99, 0xd3, 0x71, 0x7a, 0x3d, 0x93, 0xa9,
+	0x59, 0x9e, 0xd8, 0x3a, 0xe4, 0x2f, 0x1d, 0xef, 0xdc, 0x72, 0x70, 0x57, 0xcd, 0x05, 0x3e, 0xb5,
+	0x16, 0xc8, 0x63, 0x49, 0xec, 0x40, 0x96, 0x61, 0xef, 0x94, 0x30, 0x0e, 0x45, 0x6a, 0x16, 0x1b,
+	0x21, 0x33, 0xc7, 0x7c, 0x31, 0x40, 0x3c, 0x02, 0xd7, 0x30, 0xbb, 0x6a, 0xaa, 0x86, 0xea, 0x69,
+	0xfd, 0x2f, 0x04, 0xa5, 0xd6, 0x15, 0xe9, 0xf8, 0x8c, 0xb4, 0xc9, 0x85, 0x4f, 0x28, 0x53, 0x74,
+	0x28, 0x74, 0xb0, 0x65, 0x11, 0x2f, 0xb0, 0x0a, 0x9d, 0x95, 0x1b, 0x21, 0x93, 0x07, 0x7c, 0xfd,
+	0xe9, 0x13, 0xa5, 0x06, 0x39, 0x41, 0x91, 0x9a, 0x1a, 0x5a, 0x8c, 0x32, 0xa4, 0xd4, 0x60, 0x8d,
+	0x27, 0xc0, 0x19, 0x91, 0x9a, 0x1b, 0x22, 0x9d, 0x7d, 0xc7, 0xb7, 0xbb, 0xcf, 0x82, 0x7f, 0x93,
+	0xac, 0x64, 0xe6, 0xb0, 0xa2, 0x81, 0x62, 0x3b, 0xcc, 0x48, 0x68, 0x16, 0xf0, 0x98, 0xd7, 0x2f,
+	0xa1, 0x3c, 0x04, 0x40, 0x5d, 0xc7, 0xa6, 0x44, 0xd9, 0x85, 0x35, 0xe2, 0x79, 0x8e, 0x97, 0xc8,
+	0xbe, 0x7d, 0x78, 0xd0, 0x0a, 0x96, 0x6f, 0x90, 0xbd, 0x0e, 0x59, 0x8f, 0x50, 0xdf, 0x62, 0x22,
+	0x7d, 0x45, 0xa4, 0xcf, 0x33, 0x6f, 0xf3, 0x5f, 0xf4, 0xff, 0x10, 0x54, 0x44, 0x64, 0xae, 0x04,
+	0x5d, 0x35, 0x81, 0xa3, 0x75, 0x17, 0x16, 0x5a, 0x09, 0xb2, 0xbc, 0xee, 0xa8, 0xba, 0x56, 0x4b,
+	0x4f, 0x16, 0x5e, 0x76, 0x61, 0x8a, 0x73, 0x9c, 0xe2, 0x6f, 0xa1, 0x9a, 0x00, 0xba, 0x52, 0xa2,
+	0x5f, 0x22, 0xb8, 0x2f, 0xe2, 0x7f, 0x26, 0xf0, 0x3e, 0xbd, 0x0b, 0x6c, 0x57, 0x40, 0x8e, 0x56,
+	0x0c, 0x53, 0x70, 0x2e, 0x2f, 0x8b, 0xf3, 0x17, 0x08, 0xb4, 0x69, 0xa0, 0x57, 0xca, 0xfc, 0x8b,
+	0x14, 0xdc, 0x8b, 0x93, 0x68, 0x63, 0xfb, 0x94, 0xdc, 0x01, 0xde, 0xdf, 0x00, 0x38, 0x27, 0x03,
+	0xc3, 0xe3, 0xe9, 0x70, 0xd6, 0x83, 0xec, 0x87, 0x04, 0x47, 0x99, 0x2e, 0x4b, 0x89, 0xef, 0x10,
+	0xa8, 0x93, 0x24, 0xac, 0x54, 0x87, 0x5f, 0xd2, 0x43, 0x1d, 0x5a, 0x36, 0x33, 0xd9, 0xe0, 0x4e,
+	0xd4, 0xbf, 0x06, 0x0a, 0xe1, 0xd9, 0x18, 0x1d, 0xc7, 0xf2, 0x7b, 0xb6, 0x61, 0xe3, 0x5e, 0x78,
+	0xbb, 0x15, 0x94, 0x16, 0x6c, 0x8a, 0xdf, 0xc6, 0x8e, 0x48, 0x96, 0x8b, 0x55, 0x8f, 0xa2, 0xcf,
+	0xc0, 0xd4, 0x88, 0x16, 0x92, 0x12, 0xe6, 0x16, 0x96, 0x30, 0x1f, 0x48, 0xa8, 0x3d, 0x83, 0xfc,
+	0xd0, 0xe5, 0x0e, 0xe4, 0xaf, 0xcc, 0x6e, 0xe8, 0x0f, 0x71, 0x7f, 0x52, 0x74, 0x55, 0x06, 0x6e,
+	0x36, 0xa0, 0x10, 0xfc, 0xdc, 0xc7, 0x96, 0x4f, 0x38, 0x59, 0xb2, 0xb2, 0x09, 0xd2, 0x08, 0x08,
+	0xce, 0x90, 0x3c, 0x5a, 0x15, 0x23, 0xe9, 0xaf, 0xb4, 0x2a, 0x9e, 0x43, 0x99, 0xeb, 0xc3, 0x9b,
+	0x72, 0x28, 0xd2, 0x50, 0x46, 0x74, 0x13, 0x19, 0x53, 0x89, 0x4b, 0x23, 0x1d, 0x5c, 0x1a, 0xfa,
+	0x3f, 0x71, 0xbb, 0xdd, 0xc7, 0xac, 0x73, 0xf6, 0x2a, 0x2e, 0xb7, 0x3a, 0xe4, 0x82, 0xcc, 0x4c,
+	0x12, 0x06, 0x95, 0x9a, 0xf7, 0x22, 0x8b, 0x24, 0xa2, 0x05, 0xa6, 0x84, 0x2d, 0x28, 0x61, 0x3a,
+	0x65, 0x42, 0xf8, 0x21, 0x6e, 0xa5, 0x63, 0x80, 0x96, 0x26, 0xd6, 0x03, 0xc8, 0x85, 0x62, 0x45,
+	0x68, 0xa6, 0xa9, 0xf5, 0x25, 0x54, 0x38, 0xb6, 0xb8, 0x9b, 0xdf, 0x5e, 0xb2, 0xe4, 0xcd, 0x13,
+	0x44, 0x95, 0xf5, 0x7f, 0x11, 0xec, 0x8e, 0xe2, 0x7c, 0x65, 0x97, 0xe5, 0xe3, 0xa4, 0x7a, 0xdb,
+	0x63, 0xea, 0x25, 0x11, 0x2e, 0x41, 0xc2, 0x9f, 0x10, 0xec, 0xcd, 0x84, 0xb6, 0x5a, 0x1d, 0x7f,
+	0x44, 0x50, 0x39, 0x62, 0x1e, 0xc1, 0xbd, 0x5b, 0xcd, 0xcd, 0x42, 0xec, 0xd4, 0x0d, 0xa7, 0xe2,
+	0xf4, 0x6c, 0xb2, 0xf4, 0x0f, 0xa0, 0x9a, 0x48, 0x44, 0x30, 0x11, 0x37, 0x0f, 0x34, 0xb3, 0x79,
+	0xfc, 0x8e, 0x40, 0x1b, 0xdb, 0x7d, 0x9b, 0x63, 0x7e, 0x1d, 0x98, 0xc9, 0x37, 0xa3, 0xb8, 0xd9,
+	0x64, 0xa6, 0x4d, 0xa8, 0x73, 0x5e, 0x8d, 0xf4, 0x8f, 0xe0, 0xb5, 0xa9, 0x09, 0x2f, 0x00, 0xfa,
+	0x4f, 0x04, 0x7b, 0x63, 0x3e, 0x6e, 0x7d, 0x44, 0x16, 0x47, 0x9e, 0x3c, 0xb3, 0x99, 0x69, 0xd3,
+	0xe2, 0x3c, 0xfc, 0x1f, 0x43, 0x6d, 0x76, 0xee, 0x0b, 0x90, 0xf0, 0x37, 0x82, 0x9d, 0xa4, 0xa3,
+	0xdb, 0x8c, 0x76, 0x8b, 0x53, 0x30, 0x3e, 0xb8, 0x65, 0x6e, 0x3a, 0xb8, 0xcd, 0x23, 0xe5, 0x09,
+	0xec, 0xce, 0xc2, 0xb2, 0x00, 0x25, 0x4d, 0x90, 0xf7, 0xc9, 0xa9, 0x69, 0x2f, 0x40, 0x80, 0xfe,
+	0x0e, 0x14, 0xc5, 0x1e, 0x11, 0x68, 0xa4, 0xbf, 0xa0, 0xa9, 0xfd, 0x45, 0x7f, 0x0e, 0xc5, 0x03,
+	0xfe, 0x56, 0xbf, 0xd4, 0x76, 0xac, 0xaf, 0x43, 0x29, 0x72, 0x1b, 0xa6, 0xa2, 0x7f, 0x01, 0xe5,
+	0xb6, 0x63, 0x59, 0x27, 0xb8, 0x73, 0xbe, 0xdc, 0x50, 0x0a, 0xac, 0xc7, 0x8e, 0x45, 0xb0, 0xdf,
+	0x10, 0x6c, 0x1c, 0xb9, 0x96, 0xc9, 0x04, 0xa3, 0x37, 0x8f, 0x37, 0x79, 0xb1, 0x5d, 0x3f, 0x86,
+	0x56, 0x40, 0xa6, 0x41, 0x30, 0x31, 0x73, 0x8a, 0x51, 0x74, 0x13, 0xa4, 0x68, 0xd5, 0xb7, 0x19,
+	0xaf, 0x98, 0xb4, 0xfe, 0x32, 0x05, 0xca, 0x68, 0x62, 0x42, 0xa7, 0xb7, 0x21, 0xcb, 0x6d, 0xa9,
+	0x8a, 0x78, 0x05, 0xee, 0x0d, 0x41, 0x4e, 0xd8, 0x36, 0x0e, 0xb1, 0xc7, 0xb4, 0x4f, 0x41, 0x8e,
+	0xca, 0x2a, 0x78, 0x1e, 0x4b, 0x1b, 0x4d, 0x29, 0xec, 0xd4, 0xac, 0xc2, 0xd6, 0x1e, 0x43, 0x81,
+	0x77, 0xad, 0x19, 0x6e, 0xe2, 0xe6, 0x18, 0xb8, 0x28, 0x68, 0x7f, 0x20, 0xc8, 0x70, 0xd3, 0xeb,
+	0x67, 0x84, 0x0f, 0xa1, 0x34, 0xcc, 0xc0, 0x70, 0xb1, 0xc7, 0x84, 0x82, 0x6f, 0xce, 0x01, 0x37,
+	0x06, 0xea, 0x7d, 0x00, 0x1e, 0x3b, 0xdc, 0x1c, 0xd2, 0xff, 0x70, 0xce, 0xe6, 0x18, 0x87, 0x0c,
+	0x19, 0x6a, 0x7e, 0x13, 0xde, 0xeb, 0x69, 0xfd, 0x11, 0x54, 0x3f, 0x21, 0xec, 0xc8, 0xeb, 0x47,
+	0xed, 0x29, 0x2a, 0x88, 0x09, 0xb8, 0x7a, 0x0b, 0xb6, 0x92, 0xa6, 0x42, 0xa2, 0xb7, 0x40, 0xa6,
+	0x5e, 0xdf, 0x18, 0xb3, 0x97, 0x9a, 0xd5, 0x98, 0xd1, 0x91, 0x4d, 0xfa, 0xf7, 0xfc, 0x13, 0x96,
+	0x6b, 0xe1, 0x85, 0xce, 0xef, 0x72, 0xaf, 0xe2, 0x9f, 0x53, 0x50, 0xe0, 0x9b, 0x0e, 0x2d, 0x6c,
+	0x2b, 0x65, 0xc8, 0xb9, 0x16, 0xb6, 0xa3, 0xe0, 0x5c, 0x58, 0x8f, 0x60, 0x2a, 0xce, 0x15, 0xff,
+	0x3e, 0xc8, 0x3d, 0x8b, 0xbe, 0x38, 0xf5, 0x43, 0x4e, 0xdf, 0xb4, 0xbb, 0xe4, 0x4a, 0xbc, 0x4e,
+	0x55, 0xa1, 0xe8, 0x91, 0x4b, 0xcf, 0x64, 0x8c, 0xd8, 0x06, 0xbd, 0xb0, 0xd4, 0x6c, 0x64, 0x26,
+	0x0a, 0x26, 0x57, 0x4b, 0x8b, 0x63, 0xc0, 0x45, 0x0c, 0x07, 0xa1, 0x3c, 0x37, 0x7a, 0x00, 0x79,
+	0xd7, 0xb7, 0x2c, 0xc7, 0x67, 0x54, 0x2d, 0xf0, 0xd2, 0xdc, 0x88, 0x74, 0x8d, 0x53, 0x7e, 0x08,
+	0x85, 0xaf, 0x1d, 0xd3, 0x36, 0x2c, 0xf2, 0x15, 0x53, 0xa1, 0x86, 0xa6, 0x5b, 0xbd, 0x0e, 0xc0,
+	0xad, 0x3c, 0xf3, 0xf4, 0x8c, 0xa9, 0xd2, 0x0c, 0x33, 0xbd, 0x09, 0xe5, 0xa1, 0x20, 0x42, 0xd1,
+	0x3d, 0xc8, 0x04, 0x94, 0xa8, 0x68, 0xd6, 0x9e, 0xcf, 0xe1, 0x7e, 0x9b, 0x50, 0xc7, 0xea, 0x93,
+	0xe3, 0x78, 0xfa, 0x5b, 0x44, 0x4f, 0x19, 0x32, 0x5d, 0x26, 0xbe, 0x6b, 0x16, 0xf4, 0x6d, 0xd0,
+	0xa6, 0xb9, 0x0b, 0xb3, 0xd9, 0xd7, 0x40, 0xed, 0x38, 0xbd, 0xc6, 0xc0, 0xf1, 0x99, 0x7f, 0x42,
+	0x1a, 0x7d, 0x93, 0x11, 0x4a, 0xc3, 0xef, 0xc4, 0x27, 0x59, 0xfe, 0xe7, 0xdd, 0xff, 0x07, 0x00,
+	0x0a, 0x12, 0xa6, 0x40, 0x70, 0x16, 0x00, 0x00,
 }
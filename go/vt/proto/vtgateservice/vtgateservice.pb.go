@@ -84,6 +84,10 @@ type VitessClient interface {
 	// Rollback a transaction.
 	// API group: Transactions
 	Rollback(ctx context.Context, in *vtgate.RollbackRequest, opts ...grpc.CallOption) (*vtgate.RollbackResponse, error)
+	// ResolveTransaction resolves a distributed transaction, by
+	// committing or rolling it back according to its recorded state.
+	// API group: Transactions
+	ResolveTransaction(ctx context.Context, in *vtgate.ResolveTransactionRequest, opts ...grpc.CallOption) (*vtgate.ResolveTransactionResponse, error)
 	// Split a query into non-overlapping sub queries
 	// API group: Map Reduce
 	SplitQuery(ctx context.Context, in *vtgate.SplitQueryRequest, opts ...grpc.CallOption) (*vtgate.SplitQueryResponse, error)
@@ -96,6 +100,10 @@ type VitessClient interface {
 	// using custom sharding.
 	// API group: Topology
 	GetSrvKeyspace(ctx context.Context, in *vtgate.GetSrvKeyspaceRequest, opts ...grpc.CallOption) (*vtgate.GetSrvKeyspaceResponse, error)
+	// Explain returns how vtgate routes a query, and the shards it is
+	// sent to for the given bind variables, without executing it.
+	// API group: v3 API (alpha)
+	Explain(ctx context.Context, in *vtgate.ExplainRequest, opts ...grpc.CallOption) (*vtgate.ExplainResponse, error)
 }
 
 type vitessClient struct {
@@ -324,6 +332,15 @@ func (c *vitessClient) Rollback(ctx context.Context, in *vtgate.RollbackRequest,
 	return out, nil
 }
 
+func (c *vitessClient) ResolveTransaction(ctx context.Context, in *vtgate.ResolveTransactionRequest, opts ...grpc.CallOption) (*vtgate.ResolveTransactionResponse, error) {
+	out := new(vtgate.ResolveTransactionResponse)
+	err := grpc.Invoke(ctx, "/vtgateservice.Vitess/ResolveTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vitessClient) SplitQuery(ctx context.Context, in *vtgate.SplitQueryRequest, opts ...grpc.CallOption) (*vtgate.SplitQueryResponse, error) {
 	out := new(vtgate.SplitQueryResponse)
 	err := grpc.Invoke(ctx, "/vtgateservice.Vitess/SplitQuery", in, out, c.cc, opts...)
@@ -342,6 +359,15 @@ func (c *vitessClient) GetSrvKeyspace(ctx context.Context, in *vtgate.GetSrvKeys
 	return out, nil
 }
 
+func (c *vitessClient) Explain(ctx context.Context, in *vtgate.ExplainRequest, opts ...grpc.CallOption) (*vtgate.ExplainResponse, error) {
+	out := new(vtgate.ExplainResponse)
+	err := grpc.Invoke(ctx, "/vtgateservice.Vitess/Explain", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Vitess service
 
 type VitessServer interface {
@@ -395,6 +421,10 @@ type VitessServer interface {
 	// Rollback a transaction.
 	// API group: Transactions
 	Rollback(context.Context, *vtgate.RollbackRequest) (*vtgate.RollbackResponse, error)
+	// ResolveTransaction resolves a distributed transaction, by
+	// committing or rolling it back according to its recorded state.
+	// API group: Transactions
+	ResolveTransaction(context.Context, *vtgate.ResolveTransactionRequest) (*vtgate.ResolveTransactionResponse, error)
 	// Split a query into non-overlapping sub queries
 	// API group: Map Reduce
 	SplitQuery(context.Context, *vtgate.SplitQueryRequest) (*vtgate.SplitQueryResponse, error)
@@ -407,6 +437,10 @@ type VitessServer interface {
 	// using custom sharding.
 	// API group: Topology
 	GetSrvKeyspace(context.Context, *vtgate.GetSrvKeyspaceRequest) (*vtgate.GetSrvKeyspaceResponse, error)
+	// Explain returns how vtgate routes a query, and the shards it is
+	// sent to for the given bind variables, without executing it.
+	// API group: v3 API (alpha)
+	Explain(context.Context, *vtgate.ExplainRequest) (*vtgate.ExplainResponse, error)
 }
 
 func RegisterVitessServer(s *grpc.Server, srv VitessServer) {
@@ -617,6 +651,18 @@ func _Vitess_Rollback_Handler(srv interface{}, ctx context.Context, dec func(int
 	return out, nil
 }
 
+func _Vitess_ResolveTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(vtgate.ResolveTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(VitessServer).ResolveTransaction(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func _Vitess_SplitQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
 	in := new(vtgate.SplitQueryRequest)
 	if err := dec(in); err != nil {
@@ -641,6 +687,18 @@ func _Vitess_GetSrvKeyspace_Handler(srv interface{}, ctx context.Context, dec fu
 	return out, nil
 }
 
+func _Vitess_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(vtgate.ExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(VitessServer).Explain(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 var _Vitess_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "vtgateservice.Vitess",
 	HandlerType: (*VitessServer)(nil),
@@ -685,6 +743,10 @@ var _Vitess_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Rollback",
 			Handler:    _Vitess_Rollback_Handler,
 		},
+		{
+			MethodName: "ResolveTransaction",
+			Handler:    _Vitess_ResolveTransaction_Handler,
+		},
 		{
 			MethodName: "SplitQuery",
 			Handler:    _Vitess_SplitQuery_Handler,
@@ -693,6 +755,10 @@ var _Vitess_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSrvKeyspace",
 			Handler:    _Vitess_GetSrvKeyspace_Handler,
 		},
+		{
+			MethodName: "Explain",
+			Handler:    _Vitess_Explain_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -719,33 +785,35 @@ var _Vitess_serviceDesc = grpc.ServiceDesc{
 }
 
 var fileDescriptor0 = []byte{
-	// 443 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x94, 0x5f, 0x8b, 0xd3, 0x40,
-	0x14, 0xc5, 0xf5, 0xc1, 0x2a, 0x17, 0x2b, 0x32, 0xd5, 0xb6, 0x16, 0x6b, 0xb5, 0x62, 0xeb, 0x53,
-	0x10, 0x05, 0x41, 0x10, 0x84, 0x4a, 0x11, 0x59, 0x58, 0xb6, 0x0d, 0xec, 0x3e, 0xed, 0x43, 0x92,
-	0xbd, 0xa4, 0xa1, 0xf9, 0xd7, 0xcc, 0x24, 0x6c, 0xbe, 0xe9, 0x7e, 0x9c, 0xed, 0x36, 0x99, 0xd9,
-	0x99, 0xc9, 0xa4, 0x7d, 0xdb, 0x39, 0xe7, 0xdc, 0xdf, 0x6c, 0x4f, 0x2e, 0x03, 0xbd, 0x82, 0xf9,
-	0x0e, 0x43, 0x8a, 0x59, 0x11, 0x78, 0x68, 0xa5, 0x59, 0xc2, 0x12, 0xd2, 0x55, 0xc4, 0xd1, 0xcb,
-	0xea, 0x58, 0x99, 0xdf, 0xef, 0x00, 0x3a, 0x97, 0xc1, 0xde, 0xa5, 0xe4, 0x37, 0x3c, 0x5f, 0xde,
-	0xa2, 0x97, 0x33, 0x24, 0x7d, 0xab, 0x0e, 0xd5, 0xc2, 0x1a, 0x77, 0x39, 0x52, 0x36, 0x1a, 0x34,
-	0x74, 0x9a, 0x26, 0x31, 0xc5, 0xe9, 0x13, 0x72, 0x0e, 0xdd, 0x5a, 0xb4, 0x37, 0x4e, 0x76, 0x43,
-	0xc9, 0x7b, 0x2d, 0x5b, 0xc9, 0x9c, 0x34, 0x6e, 0x71, 0x05, 0xef, 0x1a, 0x48, 0x6d, 0x9d, 0x61,
-	0x49, 0x53, 0xc7, 0xc3, 0xff, 0x7b, 0xe8, 0x27, 0x6d, 0x4c, 0xf2, 0x38, 0x79, 0x7a, 0x2c, 0x22,
-	0xf0, 0x57, 0xf0, 0xfa, 0xd1, 0x5f, 0x3b, 0xb1, 0x8f, 0x94, 0x4c, 0x9a, 0x93, 0x95, 0xc3, 0xd1,
-	0x1f, 0xdb, 0x03, 0x06, 0xf0, 0x32, 0x66, 0x01, 0x2b, 0x1f, 0xfe, 0x6b, 0x1d, 0x2c, 0x9c, 0x36,
-	0xb0, 0x14, 0x30, 0x14, 0xb2, 0x70, 0x98, 0xb7, 0xa9, 0x5b, 0xd6, 0x0b, 0x91, 0xbc, 0xb6, 0x42,
-	0x94, 0x88, 0xc0, 0x87, 0x30, 0x90, 0x7d, 0xb9, 0xf4, 0x99, 0x09, 0x60, 0x68, 0x7e, 0x7e, 0x32,
-	0x27, 0x6e, 0xbb, 0x80, 0xae, 0xcd, 0x32, 0x74, 0x22, 0xbe, 0x71, 0x62, 0x5b, 0x14, 0xb9, 0xb1,
-	0x2d, 0x9a, 0xcb, 0x79, 0xdf, 0x9e, 0x12, 0x17, 0x7a, 0x8a, 0x59, 0xf7, 0x33, 0x35, 0x4e, 0xaa,
-	0x05, 0x7d, 0x3e, 0x9a, 0x91, 0xee, 0xd8, 0xc1, 0x50, 0x89, 0xc8, 0x25, 0xcd, 0x8d, 0x10, 0x43,
-	0x4b, 0x5f, 0x4f, 0x07, 0xa5, 0x2b, 0xb7, 0xd0, 0xd7, 0x73, 0xf5, 0xb6, 0x7e, 0x69, 0xe3, 0xa8,
-	0x3b, 0x3b, 0x3b, 0x15, 0x93, 0x2e, 0xfb, 0x09, 0xcf, 0x16, 0xe8, 0x07, 0x31, 0x79, 0xc3, 0x87,
-	0x0e, 0x47, 0x8e, 0x7a, 0xab, 0xa9, 0xe2, 0x6b, 0xfe, 0x82, 0xce, 0xdf, 0x24, 0x8a, 0x02, 0x46,
-	0x44, 0xa4, 0x3a, 0xf3, 0xc9, 0xbe, 0x2e, 0x8b, 0xd1, 0x3f, 0xf0, 0x62, 0x9d, 0x84, 0xa1, 0xeb,
-	0x78, 0x5b, 0x22, 0x5e, 0x17, 0xae, 0xf0, 0xf1, 0x61, 0xd3, 0x10, 0x80, 0x25, 0x80, 0x9d, 0x86,
-	0x01, 0x5b, 0xe5, 0x98, 0x95, 0xe4, 0x9d, 0xf8, 0xb5, 0x42, 0xe3, 0x90, 0x91, 0xc9, 0x12, 0x98,
-	0x15, 0xbc, 0xfa, 0x87, 0xcc, 0xce, 0x0a, 0xfe, 0x21, 0x88, 0xd8, 0x39, 0x55, 0xe7, 0xb8, 0x0f,
-	0x6d, 0x36, 0x47, 0x2e, 0x26, 0x30, 0xf6, 0x92, 0xc8, 0x2a, 0x93, 0x9c, 0xe5, 0x2e, 0x5a, 0xc5,
-	0xe1, 0x95, 0xad, 0x9e, 0x5d, 0xcb, 0xcf, 0x52, 0xcf, 0xed, 0x1c, 0xfe, 0xfe, 0x71, 0x1f, 0x00,
-	0x00, 0xff, 0xff, 0x36, 0x12, 0x3e, 0x98, 0xb6, 0x05, 0x00, 0x00,
+	// 480 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x95, 0xc1, 0x8b, 0xd3, 0x40,
+	0x14, 0xc6, 0xf5, 0x60, 0x95, 0xa7, 0x15, 0x99, 0xd5, 0xee, 0x5a, 0x5c, 0x57, 0x2b, 0xee, 0x7a,
+	0x0a, 0xa2, 0x20, 0x08, 0x82, 0x50, 0x29, 0xb2, 0x08, 0xe2, 0xb6, 0xa2, 0x27, 0x0f, 0xd3, 0xf4,
+	0xd1, 0x1d, 0x36, 0xcd, 0x64, 0x67, 0x26, 0xc1, 0xfe, 0xe1, 0xde, 0x85, 0x64, 0xe6, 0xed, 0x4c,
+	0x32, 0x69, 0x6f, 0xcd, 0xf7, 0x7d, 0xef, 0x37, 0xf0, 0xf5, 0x65, 0x02, 0x07, 0x95, 0x59, 0x73,
+	0x83, 0x1a, 0x55, 0x25, 0x52, 0x4c, 0x0a, 0x25, 0x8d, 0x64, 0xc3, 0x40, 0x1c, 0x3f, 0x68, 0x1e,
+	0x1b, 0xf3, 0xdd, 0xbf, 0xfb, 0x30, 0xf8, 0x25, 0x0c, 0x6a, 0xcd, 0x3e, 0xc1, 0xdd, 0xd9, 0x5f,
+	0x4c, 0x4b, 0x83, 0x6c, 0x94, 0xd8, 0x90, 0x15, 0xe6, 0x78, 0x5d, 0xa2, 0x36, 0xe3, 0xc3, 0x8e,
+	0xae, 0x0b, 0x99, 0x6b, 0x9c, 0xdc, 0x62, 0xdf, 0x61, 0x68, 0xc5, 0xc5, 0x25, 0x57, 0x2b, 0xcd,
+	0x9e, 0xb5, 0xb2, 0x8d, 0xec, 0x48, 0xc7, 0x3d, 0x2e, 0xf1, 0xfe, 0x00, 0xb3, 0xd6, 0x37, 0xdc,
+	0xea, 0x82, 0xa7, 0x78, 0xbe, 0xd2, 0xec, 0x65, 0x6b, 0xcc, 0xf3, 0x1c, 0x79, 0xb2, 0x2b, 0x42,
+	0xf8, 0xdf, 0xf0, 0xe8, 0xc6, 0x9f, 0xf3, 0x7c, 0x8d, 0x9a, 0x9d, 0x74, 0x27, 0x1b, 0xc7, 0xa1,
+	0x5f, 0xf4, 0x07, 0x22, 0xe0, 0x59, 0x6e, 0x84, 0xd9, 0x9e, 0xaf, 0xba, 0x60, 0x72, 0xfa, 0xc0,
+	0x5e, 0x20, 0x52, 0xc8, 0x94, 0x9b, 0xf4, 0xd2, 0xb6, 0xdc, 0x2e, 0xc4, 0xf3, 0xfa, 0x0a, 0x09,
+	0x22, 0x84, 0xcf, 0xe0, 0xd0, 0xf7, 0xfd, 0xd2, 0x4f, 0x63, 0x80, 0x48, 0xf3, 0x67, 0x7b, 0x73,
+	0x74, 0xda, 0x0f, 0x18, 0x2e, 0x8c, 0x42, 0xbe, 0x71, 0x1b, 0x47, 0xdb, 0x12, 0xc8, 0x9d, 0x6d,
+	0x69, 0xb9, 0x8e, 0xf7, 0xf6, 0x36, 0x5b, 0xc2, 0x41, 0x60, 0xda, 0x7e, 0x26, 0xd1, 0xc9, 0xb0,
+	0xa0, 0x57, 0x3b, 0x33, 0xde, 0x19, 0xd7, 0x70, 0x14, 0x44, 0xfc, 0x92, 0xce, 0xa2, 0x90, 0x48,
+	0x4b, 0x6f, 0xf6, 0x07, 0xbd, 0x23, 0xaf, 0x60, 0xd4, 0xce, 0xd9, 0x6d, 0x7d, 0xdd, 0xc7, 0x09,
+	0x77, 0xf6, 0x74, 0x5f, 0xcc, 0x3b, 0xec, 0x03, 0xdc, 0x99, 0xe2, 0x5a, 0xe4, 0xec, 0xb1, 0x1b,
+	0xaa, 0x1f, 0x1d, 0xea, 0x49, 0x4b, 0xa5, 0x7f, 0xf3, 0x23, 0x0c, 0xbe, 0xc8, 0xcd, 0x46, 0x18,
+	0x46, 0x91, 0xe6, 0xd9, 0x4d, 0x8e, 0xda, 0x32, 0x8d, 0x7e, 0x86, 0x7b, 0x73, 0x99, 0x65, 0x4b,
+	0x9e, 0x5e, 0x31, 0xba, 0x5d, 0x9c, 0xe2, 0xc6, 0x8f, 0xba, 0x86, 0xff, 0x5a, 0xcc, 0x51, 0xcb,
+	0xac, 0xc2, 0x9f, 0x8a, 0xe7, 0x9a, 0xa7, 0x46, 0xc8, 0xfc, 0xe6, 0xb5, 0xe8, 0x7a, 0x9d, 0xd7,
+	0x22, 0x16, 0x21, 0xfc, 0x0c, 0x60, 0x51, 0x64, 0xc2, 0x5c, 0x94, 0xa8, 0xb6, 0xec, 0x29, 0x95,
+	0x49, 0x9a, 0xc3, 0x8d, 0x63, 0x16, 0x61, 0x2e, 0xe0, 0xe1, 0x57, 0x34, 0x0b, 0x55, 0xb9, 0xff,
+	0x99, 0xd1, 0x4a, 0x87, 0xba, 0xc3, 0x3d, 0xef, 0xb3, 0x09, 0x59, 0x5f, 0xd7, 0x45, 0xc6, 0x45,
+	0xee, 0x5f, 0xd7, 0xb5, 0x10, 0xb9, 0xae, 0xad, 0xee, 0xa6, 0xa7, 0x27, 0x70, 0x9c, 0xca, 0x4d,
+	0xb2, 0x95, 0xa5, 0x29, 0x97, 0x98, 0x54, 0xf5, 0x27, 0xa0, 0xf9, 0x26, 0x24, 0x6b, 0x55, 0xa4,
+	0xcb, 0x41, 0xfd, 0xfb, 0xfd, 0xff, 0x01, 0x00, 0x64, 0x3a, 0xc4, 0xf9, 0x53, 0x06, 0x00, 0x00,
 }
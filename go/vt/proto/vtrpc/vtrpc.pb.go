@@ -95,6 +95,12 @@ const (
 	// UNAUTHENTICATED errors are returned when a user requests access to something,
 	// and we're unable to verify the user's authentication.
 	ErrorCode_UNAUTHENTICATED ErrorCode = 12
+	// QUOTA_EXCEEDED is returned by VtGate when a request exceeds a quota
+	// of its caller: too many requests in flight or per second, or too
+	// many shards. Unlike RESOURCE_EXHAUSTED, it doesn't mean that the
+	// servers are overloaded: the caller should slow down. Streaming
+	// queries return it as RESOURCE_EXHAUSTED.
+	ErrorCode_QUOTA_EXCEEDED ErrorCode = 13
 )
 
 var ErrorCode_name = map[int32]string{
@@ -111,6 +117,7 @@ var ErrorCode_name = map[int32]string{
 	10: "INTERNAL_ERROR",
 	11: "TRANSIENT_ERROR",
 	12: "UNAUTHENTICATED",
+	13: "QUOTA_EXCEEDED",
 }
 var ErrorCode_value = map[string]int32{
 	"SUCCESS":            0,
@@ -126,6 +133,7 @@ var ErrorCode_value = map[string]int32{
 	"INTERNAL_ERROR":     10,
 	"TRANSIENT_ERROR":    11,
 	"UNAUTHENTICATED":    12,
+	"QUOTA_EXCEEDED":     13,
 }
 
 func (x ErrorCode) String() string {
@@ -184,28 +192,28 @@ func init() {
 }
 
 var fileDescriptor0 = []byte{
-	// 356 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x44, 0x91, 0x49, 0xaf, 0xda, 0x30,
-	0x10, 0x80, 0xcb, 0x4e, 0x86, 0x2d, 0xb8, 0x8b, 0x50, 0x0f, 0x55, 0xc5, 0xa9, 0xea, 0x21, 0x87,
-	0xf6, 0xd8, 0x93, 0x71, 0xa6, 0xc5, 0x6a, 0xea, 0x50, 0x2f, 0x2d, 0x9c, 0x22, 0x48, 0xa3, 0x0a,
-	0x09, 0x48, 0x14, 0x02, 0xd2, 0xfb, 0x05, 0xef, 0x6f, 0x3f, 0x3b, 0x20, 0x71, 0xb2, 0xe6, 0x9b,
-	0xe5, 0xb3, 0xc7, 0x30, 0xb8, 0x56, 0x65, 0x91, 0x06, 0x45, 0x99, 0x57, 0x39, 0xe9, 0xd4, 0xc1,
-	0xfc, 0x3b, 0xf4, 0xd9, 0xf6, 0x70, 0xc8, 0x4a, 0x1e, 0x92, 0x29, 0x78, 0x45, 0xb9, 0x3f, 0xa5,
-	0xfb, 0x62, 0x7b, 0x98, 0x35, 0x3e, 0x36, 0x3e, 0x79, 0x0e, 0xa5, 0xf9, 0xb1, 0xc8, 0x4f, 0xd9,
-	0xa9, 0x9a, 0x35, 0x6b, 0xf4, 0x06, 0x86, 0xe7, 0xcb, 0xee, 0x41, 0x5b, 0x8e, 0xce, 0xbf, 0x41,
-	0x5f, 0xae, 0x18, 0x96, 0x65, 0x5e, 0x92, 0x0f, 0xd0, 0x4e, 0xf3, 0x7f, 0x59, 0x3d, 0x62, 0xfc,
-	0xc5, 0x0f, 0x6e, 0xda, 0x3a, 0xc7, 0x2c, 0x27, 0x13, 0xe8, 0x1d, 0xb3, 0xf3, 0x79, 0xfb, 0x3f,
-	0xbb, 0x8d, 0xfc, 0xfc, 0xdc, 0x04, 0xef, 0x91, 0x1e, 0x40, 0x4f, 0x19, 0xc6, 0x50, 0x29, 0xff,
-	0x15, 0x19, 0x81, 0xc7, 0xa8, 0x60, 0x18, 0x45, 0x18, 0xfa, 0x0d, 0x7b, 0x9f, 0x91, 0x11, 0x3f,
-	0x45, 0xfc, 0x57, 0x24, 0x28, 0x65, 0x2c, 0xfd, 0xa6, 0xab, 0x58, 0xd0, 0x30, 0xe1, 0x62, 0x65,
-	0xb4, 0xdf, 0x22, 0x6f, 0x61, 0x1a, 0x22, 0x0d, 0x23, 0x2e, 0x30, 0xc1, 0x35, 0x43, 0x0c, 0x6d,
-	0x63, 0x9b, 0xbc, 0x86, 0x09, 0x17, 0x1a, 0x7f, 0x48, 0xae, 0x37, 0xf7, 0xd6, 0x8e, 0xab, 0x5d,
-	0xa1, 0xfc, 0xc5, 0x95, 0xe2, 0xb1, 0x48, 0x42, 0x14, 0xdc, 0xd6, 0x76, 0xc9, 0x3b, 0x20, 0x12,
-	0x55, 0x6c, 0x24, 0x73, 0x23, 0x96, 0xd4, 0x28, 0x6d, 0x79, 0xcf, 0xbe, 0xdc, 0xff, 0x6d, 0x50,
-	0x6e, 0x12, 0x11, 0xeb, 0x44, 0xa1, 0xfc, 0x63, 0x69, 0xdf, 0xf9, 0x5d, 0xcc, 0x45, 0xa2, 0xd7,
-	0xbe, 0x47, 0x08, 0x8c, 0x9d, 0x48, 0x0a, 0x1a, 0xdd, 0x3d, 0xe0, 0xe4, 0x5a, 0x52, 0xa1, 0x38,
-	0x0a, 0x7d, 0x87, 0x03, 0x07, 0x8d, 0xa0, 0x46, 0x2f, 0x2d, 0xe4, 0x8c, 0x3a, 0xc5, 0x70, 0xf1,
-	0x1e, 0x66, 0x76, 0xb3, 0xc1, 0x53, 0x7e, 0xa9, 0x2e, 0xbb, 0x2c, 0xb8, 0xee, 0x2b, 0xbb, 0xa8,
-	0xdb, 0x8f, 0xed, 0xba, 0xf5, 0xf1, 0xf5, 0x25, 0x00, 0x00, 0xff, 0xff, 0x95, 0xcb, 0x10, 0x8a,
-	0xc7, 0x01, 0x00, 0x00,
+	// 368 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x44, 0x91, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x86, 0x49, 0xfa, 0x91, 0x78, 0xd2, 0xb4, 0x9b, 0xa5, 0xa0, 0x88, 0x03, 0x42, 0x3d, 0x21,
+	0x0e, 0x39, 0xc0, 0x91, 0xd3, 0x76, 0x77, 0xa0, 0x2b, 0xcc, 0x38, 0xdd, 0x0f, 0x68, 0x4f, 0x56,
+	0xe2, 0x5a, 0x28, 0x52, 0x92, 0xb5, 0x6c, 0x27, 0x12, 0xbf, 0x85, 0x3f, 0x8b, 0xd6, 0x89, 0x94,
+	0xd3, 0x6a, 0x9e, 0x99, 0x79, 0x9f, 0x95, 0x06, 0x46, 0xfb, 0xb6, 0xae, 0x8a, 0x59, 0x55, 0x87,
+	0x36, 0xf0, 0x8b, 0xae, 0xb8, 0xfb, 0x06, 0x43, 0xb9, 0x58, 0xaf, 0xcb, 0x5a, 0x2b, 0x3e, 0x81,
+	0xa4, 0xaa, 0x57, 0xdb, 0x62, 0x55, 0x2d, 0xd6, 0xd3, 0xde, 0x87, 0xde, 0xc7, 0x24, 0xa2, 0x22,
+	0x6c, 0xaa, 0xb0, 0x2d, 0xb7, 0xed, 0xb4, 0xdf, 0xa1, 0x5b, 0xb8, 0x6a, 0x76, 0xcb, 0x13, 0x3d,
+	0x8b, 0xf4, 0xee, 0x2b, 0x0c, 0xcd, 0x5c, 0x62, 0x5d, 0x87, 0x9a, 0xbf, 0x87, 0xf3, 0x22, 0xbc,
+	0x94, 0x5d, 0xc4, 0xf5, 0x67, 0x36, 0x3b, 0x68, 0xbb, 0x9e, 0x0c, 0x2f, 0x25, 0xbf, 0x81, 0xc1,
+	0xa6, 0x6c, 0x9a, 0xc5, 0x9f, 0xf2, 0x10, 0xf9, 0xe9, 0x5f, 0x1f, 0x92, 0x53, 0x7b, 0x04, 0x03,
+	0xeb, 0xa5, 0x44, 0x6b, 0xd9, 0x2b, 0x3e, 0x86, 0x44, 0x0a, 0x92, 0x98, 0xa6, 0xa8, 0x58, 0x8f,
+	0x4f, 0x60, 0xec, 0xe9, 0x07, 0x65, 0xbf, 0x29, 0x47, 0x63, 0x32, 0xc3, 0xfa, 0x71, 0xe2, 0x5e,
+	0xa8, 0x5c, 0xd3, 0xdc, 0x3b, 0x76, 0xc6, 0xdf, 0xc0, 0x44, 0xa1, 0x50, 0xa9, 0x26, 0xcc, 0xf1,
+	0x49, 0x22, 0x2a, 0x54, 0xec, 0x9c, 0xbf, 0x86, 0x1b, 0x4d, 0x0e, 0xbf, 0x1b, 0xed, 0x9e, 0x8f,
+	0xab, 0x17, 0x71, 0x76, 0x8e, 0xe6, 0xa7, 0xb6, 0x56, 0x67, 0x94, 0x2b, 0x24, 0x8d, 0x8a, 0x5d,
+	0xf2, 0xb7, 0xc0, 0x0d, 0xda, 0xcc, 0x1b, 0x19, 0x23, 0x1e, 0x84, 0xb7, 0x0e, 0x15, 0x1b, 0xf0,
+	0x5b, 0x60, 0x8f, 0x1e, 0xcd, 0x73, 0x4e, 0x99, 0xcb, 0x2d, 0x9a, 0x5f, 0xa8, 0xd8, 0x30, 0xfa,
+	0x63, 0xad, 0x29, 0x77, 0x4f, 0x2c, 0xe1, 0x1c, 0xae, 0xa3, 0xc8, 0x90, 0x48, 0x8f, 0x1e, 0x88,
+	0x72, 0x67, 0x04, 0x59, 0x8d, 0xe4, 0x8e, 0x70, 0x14, 0xa1, 0x27, 0xe1, 0xdd, 0x03, 0x92, 0xd3,
+	0x52, 0x44, 0xc5, 0x55, 0xdc, 0x7e, 0xf4, 0x99, 0x13, 0xa7, 0xaf, 0x8f, 0xef, 0xdf, 0xc1, 0xb4,
+	0x08, 0x9b, 0xd9, 0xdf, 0xb0, 0x6b, 0x77, 0xcb, 0x72, 0xb6, 0x5f, 0xb5, 0x65, 0xd3, 0x1c, 0xae,
+	0xb8, 0xbc, 0xec, 0x9e, 0x2f, 0xff, 0x07, 0x00, 0xbe, 0xac, 0x4d, 0x9d, 0xdb, 0x01, 0x00, 0x00,
 }
@@ -20,6 +20,12 @@ const (
 	CacheW    = 2
 )
 
+// Table types
+const (
+	NoType   = 0
+	Sequence = 1
+)
+
 // TableColumn contains info about a table's column.
 type TableColumn struct {
 	Name    string
@@ -35,6 +41,7 @@ type Table struct {
 	Indexes   []*Index
 	PKColumns []int
 	CacheType int
+	Type      int
 
 	// These vars can be accessed concurrently.
 	TableRows   sync2.AtomicInt64
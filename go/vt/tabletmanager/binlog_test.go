@@ -173,6 +173,46 @@ func (ftc *fakeTabletConn) Rollback(ctx context.Context, transactionID int64) er
 	return fmt.Errorf("not implemented in this test")
 }
 
+// Prepare is part of the TabletConn interface
+func (ftc *fakeTabletConn) Prepare(ctx context.Context, transactionID int64, dtid string) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// CommitPrepared is part of the TabletConn interface
+func (ftc *fakeTabletConn) CommitPrepared(ctx context.Context, dtid string) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// RollbackPrepared is part of the TabletConn interface
+func (ftc *fakeTabletConn) RollbackPrepared(ctx context.Context, dtid string, originalID int64) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// CreateTransaction is part of the TabletConn interface
+func (ftc *fakeTabletConn) CreateTransaction(ctx context.Context, dtid string, participants []*querypb.Target) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// StartCommit is part of the TabletConn interface
+func (ftc *fakeTabletConn) StartCommit(ctx context.Context, transactionID int64, dtid string) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// SetRollback is part of the TabletConn interface
+func (ftc *fakeTabletConn) SetRollback(ctx context.Context, dtid string, transactionID int64) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// ConcludeTransaction is part of the TabletConn interface
+func (ftc *fakeTabletConn) ConcludeTransaction(ctx context.Context, dtid string) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// ReadTransaction is part of the TabletConn interface
+func (ftc *fakeTabletConn) ReadTransaction(ctx context.Context, dtid string) (*querypb.TransactionMetadata, error) {
+	return nil, fmt.Errorf("not implemented in this test")
+}
+
 // Execute2 is part of the TabletConn interface
 func (ftc *fakeTabletConn) Execute2(ctx context.Context, query string, bindVars map[string]interface{}, transactionID int64) (*sqltypes.Result, error) {
 	return nil, fmt.Errorf("not implemented in this test")
@@ -57,6 +57,9 @@ func init() {
 	flag.StringVar(&qsConfig.DebugURLPrefix, "debug-url-prefix", DefaultQsConfig.DebugURLPrefix, "debug url prefix, vttablet will report various system debug pages and this config controls the prefix of these debug urls")
 	flag.StringVar(&qsConfig.PoolNamePrefix, "pool-name-prefix", DefaultQsConfig.PoolNamePrefix, "pool name prefix, vttablet has several pools and each of them has a name. This config specifies the prefix of these pool names")
 	flag.BoolVar(&qsConfig.EnableAutoCommit, "enable-autocommit", DefaultQsConfig.EnableAutoCommit, "if the flag is on, a DML outsides a transaction will be auto committed.")
+	flag.BoolVar(&qsConfig.TwoPCEnable, "enable-twopc", DefaultQsConfig.TwoPCEnable, "if the flag is on, the tablet can take part in two-phase commits of distributed transactions, and it keeps a redo log of the prepared transactions in the _vt database.")
+	flag.StringVar(&qsConfig.TwoPCCoordinatorAddress, "twopc-coordinator-address", DefaultQsConfig.TwoPCCoordinatorAddress, "address of the vtgate that resolves the abandoned distributed transactions. It must be specified if enable-twopc is on.")
+	flag.Float64Var(&qsConfig.TwoPCAbandonAge, "twopc-abandon-age", DefaultQsConfig.TwoPCAbandonAge, "time in seconds after which a pending distributed transaction is considered abandoned, and is sent to the coordinator address to be resolved. It must be positive if enable-twopc is on.")
 }
 
 // Init must be called after flag.Parse, and before doing any other operations.
@@ -127,6 +130,10 @@ type Config struct {
 	DebugURLPrefix       string
 	PoolNamePrefix       string
 	TableAclExemptACL    string
+
+	TwoPCEnable             bool
+	TwoPCCoordinatorAddress string
+	TwoPCAbandonAge         float64
 }
 
 // DefaultQsConfig is the default value for the query service config.
@@ -161,6 +168,10 @@ var DefaultQsConfig = Config{
 	DebugURLPrefix:       "/debug",
 	PoolNamePrefix:       "",
 	TableAclExemptACL:    "",
+
+	TwoPCEnable:             false,
+	TwoPCCoordinatorAddress: "",
+	TwoPCAbandonAge:         0,
 }
 
 var qsConfig Config
@@ -130,6 +130,9 @@ create table vitess_fracts(id int, deci decimal(5,2), num numeric(5,2), f float,
 create table vitess_strings(vb varbinary(16), c char(16), vc varchar(16), b binary(4), tb tinyblob, bl blob, ttx tinytext, tx text, en enum('a','b'), s set('a','b'), primary key(vb)) comment 'vitess_nocache';
 create table vitess_misc(id int, b bit(8), d date, dt datetime, t time, primary key(id)) comment 'vitess_nocache';
 
+create table vitess_seq(id int, next_id bigint, cache bigint, primary key(id)) comment 'vitess_sequence';
+insert into vitess_seq values(0, 1, 3);
+
 create table vitess_part1(key1 bigint, key2 bigint, data1 int, primary key(key1, key2));
 create unique index vitess_key2 on vitess_part1(key2);
 create table vitess_part2(key3 bigint, data2 int, primary key(key3));
@@ -178,7 +181,7 @@ var tableACLConfig = `{
     },
     {
       "name": "vitess",
-      "table_names_or_prefixes": ["vitess_a", "vitess_b", "vitess_c", "dual", "vitess_d", "vitess_temp", "vitess_e", "vitess_f", "upsert_test", "vitess_strings", "vitess_fracts", "vitess_ints", "vitess_misc", "vitess_big", "vitess_view"],
+      "table_names_or_prefixes": ["vitess_a", "vitess_b", "vitess_c", "dual", "vitess_d", "vitess_temp", "vitess_e", "vitess_f", "upsert_test", "vitess_strings", "vitess_fracts", "vitess_ints", "vitess_misc", "vitess_big", "vitess_view", "vitess_seq"],
       "readers": ["dev"],
       "writers": ["dev"],
       "admins": ["dev"]
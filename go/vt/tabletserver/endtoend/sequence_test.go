@@ -0,0 +1,83 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package endtoend
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	"github.com/youtube/vitess/go/vt/tabletserver/endtoend/framework"
+)
+
+func TestSequence(t *testing.T) {
+	want := &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name: "nextval",
+			Type: sqltypes.Int64,
+		}},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, nil),
+		}},
+	}
+	client := framework.NewClient()
+	for wantval := int64(1); wantval < 10; wantval += 2 {
+		want.Rows[0][0] = sqltypes.MakeTrusted(sqltypes.Int64, []byte(strconv.FormatInt(wantval, 10)))
+		qr, err := client.Execute("select nextval(2) from vitess_seq", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(qr, want) {
+			t.Errorf("Execute: \n%#v, want \n%#v", qr, want)
+		}
+	}
+
+	// Verify that the sequence table reserves whole blocks.
+	qr, err := client.Execute("select next_id, cache from vitess_seq", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	wantRows := [][]sqltypes.Value{{
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte("13")),
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte("3")),
+	}}
+	if !reflect.DeepEqual(qr.Rows, wantRows) {
+		t.Errorf("Execute: \n%#v, want \n%#v", qr.Rows, wantRows)
+	}
+
+	// Sequence values are reserved outside of transactions,
+	// and are not given back by a rollback.
+	if err := client.Begin(); err != nil {
+		t.Error(err)
+		return
+	}
+	qr, err = client.Execute("select nextval(1) from vitess_seq", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := client.Rollback(); err != nil {
+		t.Error(err)
+		return
+	}
+	want.Rows[0][0] = sqltypes.MakeTrusted(sqltypes.Int64, []byte("11"))
+	if !reflect.DeepEqual(qr, want) {
+		t.Errorf("Execute: \n%#v, want \n%#v", qr, want)
+	}
+	qr, err = client.Execute("select nextval(1) from vitess_seq", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want.Rows[0][0] = sqltypes.MakeTrusted(sqltypes.Int64, []byte("12"))
+	if !reflect.DeepEqual(qr, want) {
+		t.Errorf("Execute: \n%#v, want \n%#v", qr, want)
+	}
+}
@@ -251,6 +251,180 @@ func (conn *gRPCQueryClient) Rollback(ctx context.Context, transactionID int64)
 	return nil
 }
 
+// Prepare prepares the transaction for the distributed transaction dtid.
+func (conn *gRPCQueryClient) Prepare(ctx context.Context, transactionID int64, dtid string) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.PrepareRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		TransactionId:     transactionID,
+		Dtid:              dtid,
+	}
+	_, err := conn.c.Prepare(ctx, req)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// CommitPrepared commits the prepared transaction of dtid.
+func (conn *gRPCQueryClient) CommitPrepared(ctx context.Context, dtid string) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.CommitPreparedRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Dtid:              dtid,
+	}
+	_, err := conn.c.CommitPrepared(ctx, req)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// RollbackPrepared rolls back the prepared transaction of dtid.
+func (conn *gRPCQueryClient) RollbackPrepared(ctx context.Context, dtid string, originalID int64) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.RollbackPreparedRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		TransactionId:     originalID,
+		Dtid:              dtid,
+	}
+	_, err := conn.c.RollbackPrepared(ctx, req)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// CreateTransaction records the distributed transaction dtid on its coordinator.
+func (conn *gRPCQueryClient) CreateTransaction(ctx context.Context, dtid string, participants []*querypb.Target) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.CreateTransactionRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Dtid:              dtid,
+		Participants:      participants,
+	}
+	_, err := conn.c.CreateTransaction(ctx, req)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// StartCommit commits the transaction of the coordinator, and marks
+// the distributed transaction dtid for commit.
+func (conn *gRPCQueryClient) StartCommit(ctx context.Context, transactionID int64, dtid string) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.StartCommitRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		TransactionId:     transactionID,
+		Dtid:              dtid,
+	}
+	_, err := conn.c.StartCommit(ctx, req)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// SetRollback marks the distributed transaction dtid for rollback.
+func (conn *gRPCQueryClient) SetRollback(ctx context.Context, dtid string, transactionID int64) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.SetRollbackRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		TransactionId:     transactionID,
+		Dtid:              dtid,
+	}
+	_, err := conn.c.SetRollback(ctx, req)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// ConcludeTransaction deletes the record of the distributed transaction dtid.
+func (conn *gRPCQueryClient) ConcludeTransaction(ctx context.Context, dtid string) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.ConcludeTransactionRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Dtid:              dtid,
+	}
+	_, err := conn.c.ConcludeTransaction(ctx, req)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// ReadTransaction returns the record of the distributed transaction dtid.
+func (conn *gRPCQueryClient) ReadTransaction(ctx context.Context, dtid string) (*querypb.TransactionMetadata, error) {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return nil, tabletconn.ConnClosed
+	}
+
+	req := &querypb.ReadTransactionRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Dtid:              dtid,
+	}
+	response, err := conn.c.ReadTransaction(ctx, req)
+	if err != nil {
+		return nil, tabletconn.TabletErrorFromGRPC(err)
+	}
+	return response.Metadata, nil
+}
+
 // SplitQuery is the stub for TabletServer.SplitQuery RPC
 func (conn *gRPCQueryClient) SplitQuery(ctx context.Context, query querytypes.BoundQuery, splitColumn string, splitCount int64) (queries []querytypes.QuerySplit, err error) {
 	conn.mu.RLock()
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/vt/schema"
@@ -169,6 +170,17 @@ func analyzeSelect(sel *sqlparser.Select, getTable TableGetter) (plan *ExecPlan,
 		return nil, err
 	}
 
+	// Check if it's a NEXTVAL request.
+	if tableInfo.Type == schema.Sequence {
+		if inc := analyzeNextval(sel); inc != nil {
+			plan.PlanID = PlanNextval
+			plan.FieldQuery = nil
+			plan.FullQuery = nil
+			plan.PKValues = []interface{}{inc}
+			return plan, nil
+		}
+	}
+
 	// There are bind variables in the SELECT list
 	if plan.FieldQuery == nil {
 		plan.Reason = ReasonSelectList
@@ -303,6 +315,38 @@ func analyzeSelectExprs(exprs sqlparser.SelectExprs, table *schema.Table) (selec
 	return selects, nil
 }
 
+// analyzeNextval returns the increment of a select nextval(inc)
+// from a sequence table. It returns nil if sel is not a nextval
+// request.
+func analyzeNextval(sel *sqlparser.Select) interface{} {
+	if len(sel.SelectExprs) != 1 || sel.Distinct != "" || sel.Where != nil ||
+		sel.GroupBy != nil || sel.Having != nil || sel.OrderBy != nil ||
+		sel.Limit != nil || sel.Lock != "" {
+		return nil
+	}
+	expr, ok := sel.SelectExprs[0].(*sqlparser.NonStarExpr)
+	if !ok {
+		return nil
+	}
+	fexpr, ok := expr.Expr.(*sqlparser.FuncExpr)
+	if !ok || strings.ToLower(fexpr.Name) != "nextval" || len(fexpr.Exprs) != 1 {
+		return nil
+	}
+	arg, ok := fexpr.Exprs[0].(*sqlparser.NonStarExpr)
+	if !ok {
+		return nil
+	}
+	val, ok := arg.Expr.(sqlparser.ValExpr)
+	if !ok || !sqlparser.IsValue(val) {
+		return nil
+	}
+	inc, err := sqlparser.AsInterface(val)
+	if err != nil {
+		return nil
+	}
+	return inc
+}
+
 func analyzeFrom(tableExprs sqlparser.TableExprs) (tablename string, hasHints bool) {
 	if len(tableExprs) > 1 {
 		return "", false
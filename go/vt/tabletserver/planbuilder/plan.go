@@ -57,6 +57,8 @@ const (
 	PlanOther
 	// PlanUpsertPK is for insert ... on duplicate key constructs
 	PlanUpsertPK
+	// PlanNextval is for NEXTVAL
+	PlanNextval
 	// NumPlans stores the total number of plans
 	NumPlans
 )
@@ -77,6 +79,7 @@ var planName = []string{
 	"SELECT_STREAM",
 	"OTHER",
 	"UPSERT_PK",
+	"NEXTVAL",
 }
 
 func (pt PlanType) String() string {
@@ -125,6 +128,7 @@ var tableAclRoles = map[PlanType]tableacl.Role{
 	PlanSelectStream:   tableacl.READER,
 	PlanOther:          tableacl.ADMIN,
 	PlanUpsertPK:       tableacl.WRITER,
+	PlanNextval:        tableacl.WRITER,
 }
 
 // ReasonType indicates why a query plan fails to build
@@ -212,6 +216,7 @@ type ExecPlan struct {
 
 	// PlanPKIn, PlanDMLPK: where clause values
 	// PlanInsertPK: values clause
+	// PlanNextval: increment
 	PKValues []interface{} `json:",omitempty"`
 
 	// PK_IN. Limit clause value.
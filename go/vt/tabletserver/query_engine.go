@@ -5,6 +5,7 @@
 package tabletserver
 
 import (
+	"math"
 	"net/http"
 	"sync"
 	"time"
@@ -14,12 +15,15 @@ import (
 
 	"github.com/youtube/vitess/go/stats"
 	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/timer"
 	"github.com/youtube/vitess/go/vt/dbconfigs"
 	"github.com/youtube/vitess/go/vt/dbconnpool"
 	"github.com/youtube/vitess/go/vt/logutil"
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 	"github.com/youtube/vitess/go/vt/tableacl"
 	"github.com/youtube/vitess/go/vt/tableacl/acl"
+	"github.com/youtube/vitess/go/vt/vtgate/vtgateconn"
 )
 
 // spotCheckMultiplier determines the precision of the
@@ -57,6 +61,16 @@ type QueryEngine struct {
 	streamQList  *QueryList
 	tasks        sync.WaitGroup
 
+	// Two-phase commit
+	twopcEnabled       bool
+	coordinatorAddress string
+	abandonAge         time.Duration
+	twoPC              *TwoPC
+	preparedPool       *TxPreparedPool
+	twopcMu            sync.Mutex
+	twopcRunning       bool
+	watchdog           *timer.Timer
+
 	// Vars
 	spotCheckFreq    sync2.AtomicInt64
 	strictMode       sync2.AtomicInt64
@@ -169,6 +183,12 @@ func NewQueryEngine(checker MySQLChecker, config Config) *QueryEngine {
 		qe.queryServiceStats,
 		checker,
 	)
+	qe.twopcEnabled = config.TwoPCEnable
+	qe.coordinatorAddress = config.TwoPCCoordinatorAddress
+	qe.abandonAge = time.Duration(config.TwoPCAbandonAge * 1e9)
+	qe.twoPC = NewTwoPC()
+	qe.preparedPool = NewTxPreparedPool(config.TransactionCap)
+	qe.watchdog = timer.NewTimer(qe.abandonAge / 2)
 	qe.consolidator = sync2.NewConsolidator()
 	http.Handle(config.DebugURLPrefix+"/consolidations", qe.consolidator)
 	qe.streamQList = NewQueryList()
@@ -244,6 +264,14 @@ func (qe *QueryEngine) Open(dbconfigs dbconfigs.DBConfigs, schemaOverrides []Sch
 	if !strictMode && qe.config.RowCache.Enabled {
 		panic(NewTabletError(ErrFatal, vtrpcpb.ErrorCode_INTERNAL_ERROR, "Rowcache cannot be enabled when queryserver-config-strict-mode is false"))
 	}
+	if qe.twopcEnabled {
+		if qe.coordinatorAddress == "" {
+			panic(NewTabletError(ErrFatal, vtrpcpb.ErrorCode_INTERNAL_ERROR, "Coordinator address not specified for two-phase commit"))
+		}
+		if qe.abandonAge <= 0 {
+			panic(NewTabletError(ErrFatal, vtrpcpb.ErrorCode_INTERNAL_ERROR, "Abandon age must be positive for two-phase commit"))
+		}
+	}
 	if qe.config.RowCache.Enabled {
 		qe.cachePool.Open()
 		log.Infof("rowcache is enabled")
@@ -307,6 +335,7 @@ func (qe *QueryEngine) WaitForTxEmpty() {
 // before calling Close.
 func (qe *QueryEngine) Close() {
 	qe.tasks.Wait()
+	qe.StopTwoPC()
 	// Close in reverse order of Open.
 	qe.txPool.Close()
 	qe.streamConnPool.Close()
@@ -318,6 +347,15 @@ func (qe *QueryEngine) Close() {
 // Commit commits the specified transaction.
 func (qe *QueryEngine) Commit(ctx context.Context, logStats *LogStats, transactionID int64) {
 	dirtyTables, err := qe.txPool.SafeCommit(ctx, transactionID)
+	qe.invalidateRows(logStats, dirtyTables)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// invalidateRows deletes the rowcache entries of the rows changed
+// by a committed transaction.
+func (qe *QueryEngine) invalidateRows(logStats *LogStats, dirtyTables map[string]DirtyKeys) {
 	for tableName, invalidList := range dirtyTables {
 		tableInfo := qe.schemaInfo.GetTable(tableName)
 		if tableInfo == nil {
@@ -333,9 +371,6 @@ func (qe *QueryEngine) Commit(ctx context.Context, logStats *LogStats, transacti
 		logStats.CacheInvalidations += invalidations
 		tableInfo.invalidations.Add(invalidations)
 	}
-	if err != nil {
-		panic(err)
-	}
 }
 
 // ClearRowcache invalidates all items in the rowcache.
@@ -353,3 +388,298 @@ func (qe *QueryEngine) ClearRowcache(ctx context.Context) error {
 	}
 	return nil
 }
+
+// StartTwoPC makes the QueryEngine ready for two-phase commit when
+// it becomes the master: it creates the tables of two-phase commit,
+// prepares again the transactions of the redo log, and starts the
+// watchdog that resolves abandoned distributed transactions.
+// It's a no-op if two-phase commit is disabled or already started.
+func (qe *QueryEngine) StartTwoPC() {
+	if !qe.twopcEnabled {
+		return
+	}
+	qe.twopcMu.Lock()
+	defer qe.twopcMu.Unlock()
+	if qe.twopcRunning {
+		return
+	}
+	dbaParams := qe.dbconfigs.App.ConnParams
+	if qe.dbconfigs.Dba.Uname != "" {
+		dbaParams.Uname = qe.dbconfigs.Dba.Uname
+		dbaParams.Pass = qe.dbconfigs.Dba.Pass
+	}
+	if err := qe.twoPC.Init(&dbaParams, qe.queryServiceStats.MySQLStats); err != nil {
+		panic(NewTabletError(ErrFatal, vtrpcpb.ErrorCode_INTERNAL_ERROR, "%v", err))
+	}
+	qe.prepareFromRedo()
+	qe.watchdog.Start(qe.resolveAbandoned)
+	qe.twopcRunning = true
+}
+
+// StopTwoPC stops the watchdog, and rolls back the prepared
+// transactions. They remain in the redo log, and will be
+// prepared again by StartTwoPC, here or on the next master.
+func (qe *QueryEngine) StopTwoPC() {
+	qe.twopcMu.Lock()
+	defer qe.twopcMu.Unlock()
+	if !qe.twopcRunning {
+		return
+	}
+	qe.watchdog.Stop()
+	for _, conn := range qe.preparedPool.FetchAll() {
+		conn.Close()
+		conn.discard(TxClose)
+	}
+	qe.twopcRunning = false
+}
+
+// prepareFromRedo executes again the statements of the transactions
+// of the redo log, and puts them back in the prepared pool. If a
+// transaction can't be prepared again, it's marked as failed.
+func (qe *QueryEngine) prepareFromRedo() {
+	ctx := context.Background()
+	conn := getOrPanic(ctx, qe.connPool)
+	prepared, failed, err := qe.twoPC.ReadAllRedo(ctx, conn)
+	conn.Recycle()
+	if err != nil {
+		panic(err)
+	}
+
+	var failedDtids []string
+	for _, tx := range prepared {
+		if err := qe.prepareTx(ctx, tx); err != nil {
+			log.Errorf("Could not prepare transaction %s again: %v", tx.Dtid, err)
+			failedDtids = append(failedDtids, tx.Dtid)
+		}
+	}
+	for _, dtid := range failedDtids {
+		qe.execLocal(ctx, func(conn *TxConnection) error {
+			return qe.twoPC.UpdateRedo(ctx, conn, dtid, RedoStateFailed)
+		})
+	}
+	if count := len(failed) + len(failedDtids); count != 0 {
+		log.Errorf("There are %d failed prepared transactions in the redo log, they must be resolved manually", count)
+		qe.queryServiceStats.InternalErrors.Add("TwopcResurrection", int64(count))
+	}
+	log.Infof("Prepared %d transactions from the redo log", len(prepared)-len(failedDtids))
+}
+
+func (qe *QueryEngine) prepareTx(ctx context.Context, tx *PreparedTx) (err error) {
+	defer handleError(&err, nil, qe.queryServiceStats)
+
+	transactionID := qe.txPool.Begin(ctx)
+	conn := qe.txPool.Get(transactionID)
+	for _, stmt := range tx.Queries {
+		conn.RecordQuery(stmt)
+		// The DMLs return no rows, but Exec fails if they
+		// affect more rows than the limit: there is none.
+		if _, err := conn.Exec(ctx, stmt, math.MaxInt32, false); err != nil {
+			conn.Recycle()
+			qe.txPool.Rollback(ctx, transactionID)
+			return err
+		}
+		conn.RecordStatement(stmt)
+	}
+	conn.Recycle()
+	conn = qe.txPool.Detach(transactionID)
+	if err := qe.preparedPool.Put(conn, tx.Dtid); err != nil {
+		qe.txPool.RollbackConn(ctx, conn)
+		return err
+	}
+	return nil
+}
+
+// resolveAbandoned asks vtgate to resolve the distributed
+// transactions coordinated by this tablet which have been
+// pending for longer than the abandon age.
+func (qe *QueryEngine) resolveAbandoned() {
+	defer logError(qe.queryServiceStats)
+
+	ctx, cancel := context.WithTimeout(context.Background(), qe.abandonAge/2)
+	defer cancel()
+	conn := getOrPanic(ctx, qe.connPool)
+	dtids, err := qe.twoPC.ReadAbandoned(ctx, conn, time.Now().Add(-qe.abandonAge))
+	conn.Recycle()
+	if err != nil {
+		panic(err)
+	}
+	if len(dtids) == 0 {
+		return
+	}
+
+	vtgConn, err := vtgateconn.Dial(ctx, qe.coordinatorAddress, qe.abandonAge/2)
+	if err != nil {
+		panic(NewTabletError(ErrFail, vtrpcpb.ErrorCode_UNKNOWN_ERROR, "could not connect to vtgate at %s: %v", qe.coordinatorAddress, err))
+	}
+	defer vtgConn.Close()
+	for _, dtid := range dtids {
+		log.Infof("Resolving abandoned distributed transaction %s", dtid)
+		if err := vtgConn.ResolveTransaction(ctx, dtid); err != nil {
+			log.Errorf("Could not resolve distributed transaction %s: %v", dtid, err)
+			qe.queryServiceStats.InternalErrors.Add("WatchdogFail", 1)
+		}
+	}
+}
+
+// execLocal executes f in a new transaction, which is committed
+// if f succeeds, and rolled back otherwise.
+func (qe *QueryEngine) execLocal(ctx context.Context, f func(conn *TxConnection) error) {
+	transactionID := qe.txPool.Begin(ctx)
+	conn := qe.txPool.Get(transactionID)
+	if err := f(conn); err != nil {
+		conn.Recycle()
+		qe.txPool.Rollback(ctx, transactionID)
+		panic(err)
+	}
+	conn.Recycle()
+	if _, err := qe.txPool.SafeCommit(ctx, transactionID); err != nil {
+		panic(err)
+	}
+}
+
+func (qe *QueryEngine) checkTwoPC() {
+	if !qe.twopcEnabled {
+		panic(NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "two-phase commit is not enabled"))
+	}
+}
+
+// Prepare prepares the specified transaction for the distributed
+// transaction dtid: its statements are saved in the redo log, and it
+// moves to the prepared pool, where it waits for CommitPrepared or
+// RollbackPrepared. A transaction that changed no data is simply
+// rolled back, and the later CommitPrepared is a no-op.
+func (qe *QueryEngine) Prepare(ctx context.Context, transactionID int64, dtid string) {
+	qe.checkTwoPC()
+	conn := qe.txPool.Get(transactionID)
+	if len(conn.statements) == 0 {
+		conn.Recycle()
+		qe.txPool.Rollback(ctx, transactionID)
+		return
+	}
+	conn.Recycle()
+
+	conn = qe.txPool.Detach(transactionID)
+	if err := qe.preparedPool.Put(conn, dtid); err != nil {
+		qe.txPool.RollbackConn(ctx, conn)
+		panic(NewTabletError(ErrFail, vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED, "prepare failed for transaction %d: %v", transactionID, err))
+	}
+	defer func() {
+		if x := recover(); x != nil {
+			qe.preparedPool.FetchForRollback(dtid)
+			qe.txPool.RollbackConn(ctx, conn)
+			panic(x)
+		}
+	}()
+	qe.execLocal(ctx, func(localConn *TxConnection) error {
+		return qe.twoPC.SaveRedo(ctx, localConn, dtid, conn.statements)
+	})
+}
+
+// CommitPrepared commits the prepared transaction of dtid, and deletes
+// it from the redo log. It's a no-op if there's no such transaction.
+// If the commit fails, the transaction is marked as failed in the
+// redo log, and is left for an operator to resolve.
+func (qe *QueryEngine) CommitPrepared(ctx context.Context, logStats *LogStats, dtid string) {
+	qe.checkTwoPC()
+	conn, err := qe.preparedPool.FetchForCommit(dtid)
+	if err != nil {
+		panic(NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "cannot commit dtid %s, state: %v", dtid, err))
+	}
+	if conn == nil {
+		return
+	}
+	// The redo log is deleted within the transaction,
+	// so that it goes away atomically with the commit.
+	if err = qe.twoPC.DeleteRedo(ctx, conn, dtid); err == nil {
+		var dirtyTables map[string]DirtyKeys
+		dirtyTables, err = qe.txPool.SafeCommitConn(ctx, conn)
+		qe.invalidateRows(logStats, dirtyTables)
+	} else {
+		qe.txPool.RollbackConn(ctx, conn)
+	}
+	if err != nil {
+		qe.preparedPool.SetFailed(dtid)
+		qe.execLocal(ctx, func(localConn *TxConnection) error {
+			return qe.twoPC.UpdateRedo(ctx, localConn, dtid, RedoStateFailed)
+		})
+		panic(err)
+	}
+	qe.preparedPool.Forget(dtid)
+}
+
+// RollbackPrepared deletes the redo log of dtid, and rolls back its
+// prepared transaction. If originalID is not 0, and the transaction
+// was never prepared, originalID is rolled back instead.
+func (qe *QueryEngine) RollbackPrepared(ctx context.Context, dtid string, originalID int64) {
+	qe.checkTwoPC()
+	defer func() {
+		if conn := qe.preparedPool.FetchForRollback(dtid); conn != nil {
+			qe.txPool.RollbackConn(ctx, conn)
+		}
+		if originalID != 0 {
+			qe.txPool.RollbackIfActive(ctx, originalID)
+		}
+	}()
+	qe.execLocal(ctx, func(conn *TxConnection) error {
+		return qe.twoPC.DeleteRedo(ctx, conn, dtid)
+	})
+}
+
+// CreateTransaction saves the metadata of the distributed transaction
+// dtid on its coordinator, in the PREPARE state.
+func (qe *QueryEngine) CreateTransaction(ctx context.Context, dtid string, participants []*querypb.Target) {
+	qe.checkTwoPC()
+	qe.execLocal(ctx, func(conn *TxConnection) error {
+		return qe.twoPC.CreateTransaction(ctx, conn, dtid, participants)
+	})
+}
+
+// StartCommit moves the distributed transaction dtid to the COMMIT
+// state as part of committing the transaction of the coordinator.
+// Once it succeeds, the distributed transaction can only be committed.
+func (qe *QueryEngine) StartCommit(ctx context.Context, logStats *LogStats, transactionID int64, dtid string) {
+	qe.checkTwoPC()
+	conn := qe.txPool.Get(transactionID)
+	if err := qe.twoPC.Transition(ctx, conn, dtid, querypb.TransactionState_COMMIT); err != nil {
+		conn.Recycle()
+		qe.txPool.Rollback(ctx, transactionID)
+		panic(err)
+	}
+	conn.Recycle()
+	qe.Commit(ctx, logStats, transactionID)
+}
+
+// SetRollback moves the distributed transaction dtid to the ROLLBACK
+// state. If transactionID is not 0, the transaction of the coordinator
+// is rolled back first, if it's still active.
+func (qe *QueryEngine) SetRollback(ctx context.Context, dtid string, transactionID int64) {
+	qe.checkTwoPC()
+	if transactionID != 0 {
+		qe.txPool.RollbackIfActive(ctx, transactionID)
+	}
+	qe.execLocal(ctx, func(conn *TxConnection) error {
+		return qe.twoPC.Transition(ctx, conn, dtid, querypb.TransactionState_ROLLBACK)
+	})
+}
+
+// ConcludeTransaction deletes the metadata of the distributed
+// transaction dtid once all its participants are resolved.
+func (qe *QueryEngine) ConcludeTransaction(ctx context.Context, dtid string) {
+	qe.checkTwoPC()
+	qe.execLocal(ctx, func(conn *TxConnection) error {
+		return qe.twoPC.DeleteTransaction(ctx, conn, dtid)
+	})
+}
+
+// ReadTransaction returns the metadata of the distributed
+// transaction dtid. The metadata is empty if it's not found.
+func (qe *QueryEngine) ReadTransaction(ctx context.Context, dtid string) *querypb.TransactionMetadata {
+	qe.checkTwoPC()
+	conn := getOrPanic(ctx, qe.connPool)
+	defer conn.Recycle()
+	metadata, err := qe.twoPC.ReadTransaction(ctx, conn, dtid)
+	if err != nil {
+		panic(err)
+	}
+	return metadata
+}
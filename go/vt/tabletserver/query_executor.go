@@ -6,6 +6,7 @@ package tabletserver
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/callerid"
 	"github.com/youtube/vitess/go/vt/callinfo"
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 	"github.com/youtube/vitess/go/vt/schema"
 	"github.com/youtube/vitess/go/vt/sqlparser"
@@ -73,8 +75,11 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		return nil, err
 	}
 
-	if qre.plan.PlanID == planbuilder.PlanDDL {
+	switch qre.plan.PlanID {
+	case planbuilder.PlanDDL:
 		return qre.execDDL()
+	case planbuilder.PlanNextval:
+		return qre.execNextval()
 	}
 
 	if qre.transactionID != 0 {
@@ -91,7 +96,7 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 			if qre.qe.strictMode.Get() != 0 {
 				return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "DML too complex")
 			}
-			reply, err = qre.directFetch(conn, qre.plan.FullQuery, qre.bindVars, nil)
+			reply, err = qre.dmlFetch(conn, qre.plan.FullQuery, qre.bindVars, nil)
 		case planbuilder.PlanInsertPK:
 			reply, err = qre.execInsertPK(conn)
 		case planbuilder.PlanInsertSubquery:
@@ -166,6 +171,38 @@ func (qre *QueryExecutor) Stream(sendReply func(*sqltypes.Result) error) error {
 }
 
 func (qre *QueryExecutor) execDmlAutoCommit() (reply *sqltypes.Result, err error) {
+	return qre.execAsTransaction(func(conn *TxConnection) (reply *sqltypes.Result, err error) {
+		conn.RecordQuery(qre.query)
+		var invalidator CacheInvalidator
+		if qre.plan.TableInfo != nil && qre.plan.TableInfo.CacheType != schema.CacheNone {
+			invalidator = conn.DirtyKeys(qre.plan.TableName)
+		}
+		switch qre.plan.PlanID {
+		case planbuilder.PlanPassDML:
+			if qre.qe.strictMode.Get() != 0 {
+				return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "DML too complex")
+			}
+			reply, err = qre.dmlFetch(conn, qre.plan.FullQuery, qre.bindVars, nil)
+		case planbuilder.PlanInsertPK:
+			reply, err = qre.execInsertPK(conn)
+		case planbuilder.PlanInsertSubquery:
+			reply, err = qre.execInsertSubquery(conn)
+		case planbuilder.PlanDMLPK:
+			reply, err = qre.execDMLPK(conn, invalidator)
+		case planbuilder.PlanDMLSubquery:
+			reply, err = qre.execDMLSubquery(conn, invalidator)
+		case planbuilder.PlanUpsertPK:
+			reply, err = qre.execUpsertPK(conn, invalidator)
+		default:
+			return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "unsupported query: %s", qre.query)
+		}
+		return reply, err
+	})
+}
+
+// execAsTransaction executes f in its own transaction, which
+// is committed if f succeeds, and rolled back otherwise.
+func (qre *QueryExecutor) execAsTransaction(f func(conn *TxConnection) (*sqltypes.Result, error)) (reply *sqltypes.Result, err error) {
 	transactionID := qre.qe.txPool.Begin(qre.ctx)
 	qre.logStats.AddRewrittenSQL("begin", time.Now())
 	defer func() {
@@ -183,31 +220,7 @@ func (qre *QueryExecutor) execDmlAutoCommit() (reply *sqltypes.Result, err error
 	}()
 	conn := qre.qe.txPool.Get(transactionID)
 	defer conn.Recycle()
-	conn.RecordQuery(qre.query)
-	var invalidator CacheInvalidator
-	if qre.plan.TableInfo != nil && qre.plan.TableInfo.CacheType != schema.CacheNone {
-		invalidator = conn.DirtyKeys(qre.plan.TableName)
-	}
-	switch qre.plan.PlanID {
-	case planbuilder.PlanPassDML:
-		if qre.qe.strictMode.Get() != 0 {
-			return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "DML too complex")
-		}
-		reply, err = qre.directFetch(conn, qre.plan.FullQuery, qre.bindVars, nil)
-	case planbuilder.PlanInsertPK:
-		reply, err = qre.execInsertPK(conn)
-	case planbuilder.PlanInsertSubquery:
-		reply, err = qre.execInsertSubquery(conn)
-	case planbuilder.PlanDMLPK:
-		reply, err = qre.execDMLPK(conn, invalidator)
-	case planbuilder.PlanDMLSubquery:
-		reply, err = qre.execDMLSubquery(conn, invalidator)
-	case planbuilder.PlanUpsertPK:
-		reply, err = qre.execUpsertPK(conn, invalidator)
-	default:
-		return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "unsupported query: %s", qre.query)
-	}
-	return reply, err
+	return f(conn)
 }
 
 // checkPermissions
@@ -312,6 +325,95 @@ func (qre *QueryExecutor) execDDL() (*sqltypes.Result, error) {
 	return result, nil
 }
 
+// execNextval returns the next value of a sequence, and reserves
+// inc-1 more values after it. Blocks of values are reserved by
+// advancing next_id in the sequence table in a transaction of
+// their own, independent of the caller's transaction. The values
+// of the current block are then handed out from memory. The
+// sequence table must have a single row with id 0, and the
+// columns next_id and cache.
+func (qre *QueryExecutor) execNextval() (*sqltypes.Result, error) {
+	val, err := resolveValue(&schema.TableColumn{Name: "nextval", Type: sqltypes.Int64}, qre.plan.PKValues[0], qre.bindVars)
+	if err != nil {
+		return nil, err
+	}
+	inc, err := val.ParseInt64()
+	if err != nil {
+		return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "%v", err)
+	}
+	if inc < 1 {
+		return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "invalid increment for sequence %s: %d", qre.plan.TableName, inc)
+	}
+
+	t := qre.plan.TableInfo
+	t.Seq.Lock()
+	defer t.Seq.Unlock()
+	if t.NextVal == 0 || t.NextVal+inc > t.LastVal {
+		_, err := qre.execAsTransaction(func(conn *TxConnection) (*sqltypes.Result, error) {
+			query := fmt.Sprintf("select next_id, cache from `%s` where id = 0 for update", qre.plan.TableName)
+			conn.RecordQuery(query)
+			qr, err := qre.execSQL(conn, query, false)
+			if err != nil {
+				return nil, err
+			}
+			if len(qr.Rows) != 1 {
+				return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "unexpected rows from reading sequence %s (possible mis-route): %d", qre.plan.TableName, len(qr.Rows))
+			}
+			nextID, err := qr.Rows[0][0].ParseInt64()
+			if err != nil {
+				return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_INTERNAL_ERROR, "error loading sequence %s: %v", qre.plan.TableName, err)
+			}
+			// If LastVal doesn't match next_id, either this is
+			// the first request, or next_id was changed by someone
+			// else. Either way, the cached values can't be used.
+			if t.LastVal != nextID {
+				if nextID < t.LastVal {
+					log.Warningf("Sequence next_id %v of %s is below the currently cached max %v, updating it to max", nextID, qre.plan.TableName, t.LastVal)
+					nextID = t.LastVal
+				}
+				t.NextVal = nextID
+			}
+			// vtgate treats a generated 0 as no value.
+			if t.NextVal < 1 {
+				return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "invalid next_id for sequence %s: %d, must be at least 1", qre.plan.TableName, nextID)
+			}
+			cache, err := qr.Rows[0][1].ParseInt64()
+			if err != nil {
+				return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_INTERNAL_ERROR, "error loading sequence %s: %v", qre.plan.TableName, err)
+			}
+			if cache < 1 {
+				return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_INTERNAL_ERROR, "invalid cache value for sequence %s: %d", qre.plan.TableName, cache)
+			}
+			newLast := nextID + cache
+			for newLast < t.NextVal+inc {
+				newLast += cache
+			}
+			query = fmt.Sprintf("update `%s` set next_id = %d where id = 0", qre.plan.TableName, newLast)
+			conn.RecordQuery(query)
+			if _, err = qre.execSQL(conn, query, false); err != nil {
+				return nil, err
+			}
+			t.LastVal = newLast
+			return nil, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	ret := t.NextVal
+	t.NextVal += inc
+	return &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name: "nextval",
+			Type: sqltypes.Int64,
+		}},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, strconv.AppendInt(nil, ret, 10)),
+		}},
+	}, nil
+}
+
 func (qre *QueryExecutor) execPKIN() (*sqltypes.Result, error) {
 	pkRows, err := buildValueList(qre.plan.TableInfo, qre.plan.PKValues, qre.bindVars)
 	if err != nil {
@@ -514,7 +616,7 @@ func (qre *QueryExecutor) execInsertSubquery(conn poolConn) (*sqltypes.Result, e
 
 func (qre *QueryExecutor) execInsertPKRows(conn poolConn, pkRows [][]sqltypes.Value) (*sqltypes.Result, error) {
 	bsc := buildStreamComment(qre.plan.TableInfo, pkRows, nil)
-	return qre.directFetch(conn, qre.plan.OuterQuery, qre.bindVars, bsc)
+	return qre.dmlFetch(conn, qre.plan.OuterQuery, qre.bindVars, bsc)
 }
 
 func (qre *QueryExecutor) execUpsertPK(conn poolConn, invalidator CacheInvalidator) (*sqltypes.Result, error) {
@@ -523,7 +625,7 @@ func (qre *QueryExecutor) execUpsertPK(conn poolConn, invalidator CacheInvalidat
 		return nil, err
 	}
 	bsc := buildStreamComment(qre.plan.TableInfo, pkRows, nil)
-	result, err := qre.directFetch(conn, qre.plan.OuterQuery, qre.bindVars, bsc)
+	result, err := qre.dmlFetch(conn, qre.plan.OuterQuery, qre.bindVars, bsc)
 	if err == nil {
 		return result, nil
 	}
@@ -593,7 +695,7 @@ func (qre *QueryExecutor) execDMLPKRows(conn poolConn, query *sqlparser.ParsedQu
 			Columns: qre.plan.TableInfo.Indexes[0].Columns,
 			Rows:    pkRows,
 		}
-		r, err := qre.directFetch(conn, query, qre.bindVars, bsc)
+		r, err := qre.dmlFetch(conn, query, qre.bindVars, bsc)
 		if err != nil {
 			return nil, err
 		}
@@ -711,6 +813,25 @@ func (qre *QueryExecutor) directFetch(conn poolConn, parsedQuery *sqlparser.Pars
 	return qre.execSQL(conn, sql, false)
 }
 
+// dmlFetch sends a statement that changes data. In a transaction,
+// the statement is recorded for the redo log, in case the
+// transaction gets prepared. The selects sent for the DMLs,
+// like the ones of execDMLSubquery, go through directFetch.
+func (qre *QueryExecutor) dmlFetch(conn poolConn, parsedQuery *sqlparser.ParsedQuery, bindVars map[string]interface{}, buildStreamComment []byte) (*sqltypes.Result, error) {
+	sql, err := qre.generateFinalSQL(parsedQuery, bindVars, buildStreamComment)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := qre.execSQL(conn, sql, false)
+	if err != nil {
+		return nil, err
+	}
+	if txc, ok := conn.(*TxConnection); ok {
+		txc.RecordStatement(sql)
+	}
+	return qr, nil
+}
+
 // fullFetch also fetches field info
 func (qre *QueryExecutor) fullFetch(conn poolConn, parsedQuery *sqlparser.ParsedQuery, bindVars map[string]interface{}, buildStreamComment []byte) (*sqltypes.Result, error) {
 	sql, err := qre.generateFinalSQL(parsedQuery, bindVars, buildStreamComment)
@@ -599,6 +599,119 @@ func TestQueryExecutorPlanOther(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorPlanNextval(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	selQuery := "select next_id, cache from `seq` where id = 0 for update"
+	db.AddQuery(selQuery, &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "next_id", Type: sqltypes.Int64},
+			{Name: "cache", Type: sqltypes.Int64},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("3")),
+		}},
+	})
+	updateQuery := "update `seq` set next_id = 4 where id = 0"
+	db.AddQuery(updateQuery, &sqltypes.Result{})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableStrict, db)
+	defer tsv.StopService()
+	qre := newTestQueryExecutor(ctx, tsv, "select nextval(1) from seq", 0)
+	checkPlanID(t, planbuilder.PlanNextval, qre.plan.PlanID)
+	got, err := qre.Execute()
+	if err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	want := &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name: "nextval",
+			Type: sqltypes.Int64,
+		}},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("qre.Execute() = %v, want: %v", got, want)
+	}
+
+	// The next value comes from the cache, without reading
+	// the sequence table.
+	db.DeleteQuery(selQuery)
+	qre = newTestQueryExecutor(ctx, tsv, "select nextval(1) from seq", 0)
+	got, err = qre.Execute()
+	if err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	want.Rows[0][0] = sqltypes.MakeTrusted(sqltypes.Int64, []byte("2"))
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("qre.Execute() = %v, want: %v", got, want)
+	}
+
+	// Only one value is left in the cache. So, reserving
+	// two values fetches a new block.
+	db.AddQuery(selQuery, &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "next_id", Type: sqltypes.Int64},
+			{Name: "cache", Type: sqltypes.Int64},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("4")),
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("3")),
+		}},
+	})
+	updateQuery = "update `seq` set next_id = 7 where id = 0"
+	db.AddQuery(updateQuery, &sqltypes.Result{})
+	qre = newTestQueryExecutor(ctx, tsv, "select nextval(:n) from seq", 0)
+	qre.bindVars["n"] = int64(2)
+	got, err = qre.Execute()
+	if err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	want.Rows[0][0] = sqltypes.MakeTrusted(sqltypes.Int64, []byte("3"))
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("qre.Execute() = %v, want: %v", got, want)
+	}
+	if n := db.GetQueryCalledNum(updateQuery); n != 1 {
+		t.Errorf("update count: %d, want 1", n)
+	}
+
+	qre = newTestQueryExecutor(ctx, tsv, "select nextval(0) from seq", 0)
+	_, err = qre.Execute()
+	wantErr := "error: invalid increment for sequence seq: 0"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("qre.Execute() = %v, want %s", err, wantErr)
+	}
+}
+
+func TestQueryExecutorPlanNextvalZero(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	db.AddQuery("select next_id, cache from `seq` where id = 0 for update", &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "next_id", Type: sqltypes.Int64},
+			{Name: "cache", Type: sqltypes.Int64},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("0")),
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("3")),
+		}},
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableStrict, db)
+	defer tsv.StopService()
+	qre := newTestQueryExecutor(ctx, tsv, "select nextval(1) from seq", 0)
+	_, err := qre.Execute()
+	wantErr := "error: invalid next_id for sequence seq: 0, must be at least 1"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("qre.Execute() = %v, want %s", err, wantErr)
+	}
+}
+
 func TestQueryExecutorTableAcl(t *testing.T) {
 	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int63())
 	tableacl.Register(aclName, &simpleacl.Factory{})
@@ -1099,7 +1212,7 @@ func getQueryExecutorSupportedQueries() map[string]*sqltypes.Result {
 			},
 		},
 		baseShowTables: &sqltypes.Result{
-			RowsAffected: 1,
+			RowsAffected: 2,
 			Rows: [][]sqltypes.Value{
 				[]sqltypes.Value{
 					sqltypes.MakeString([]byte("test_table")),
@@ -1111,6 +1224,16 @@ func getQueryExecutorSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
 				},
+				[]sqltypes.Value{
+					sqltypes.MakeString([]byte("seq")),
+					sqltypes.MakeString([]byte("USER TABLE")),
+					sqltypes.MakeTrusted(sqltypes.Int32, []byte("1427325875")),
+					sqltypes.MakeString([]byte("vitess_sequence")),
+					sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+					sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
+					sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
+					sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
+				},
 			},
 		},
 		"select * from `test_table` where 1 != 1": &sqltypes.Result{
@@ -1178,6 +1301,61 @@ func getQueryExecutorSupportedQueries() map[string]*sqltypes.Result {
 				},
 			},
 		},
+		"select * from `seq` where 1 != 1": &sqltypes.Result{
+			Fields: []*querypb.Field{{
+				Name: "id",
+				Type: sqltypes.Int32,
+			}, {
+				Name: "next_id",
+				Type: sqltypes.Int64,
+			}, {
+				Name: "cache",
+				Type: sqltypes.Int64,
+			}},
+		},
+		"describe `seq`": &sqltypes.Result{
+			RowsAffected: 3,
+			Rows: [][]sqltypes.Value{
+				[]sqltypes.Value{
+					sqltypes.MakeString([]byte("id")),
+					sqltypes.MakeString([]byte("int")),
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte("0")),
+					sqltypes.MakeString([]byte{}),
+				},
+				[]sqltypes.Value{
+					sqltypes.MakeString([]byte("next_id")),
+					sqltypes.MakeString([]byte("bigint")),
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte("0")),
+					sqltypes.MakeString([]byte{}),
+				},
+				[]sqltypes.Value{
+					sqltypes.MakeString([]byte("cache")),
+					sqltypes.MakeString([]byte("bigint")),
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte("0")),
+					sqltypes.MakeString([]byte{}),
+				},
+			},
+		},
+		"show index from `seq`": &sqltypes.Result{
+			RowsAffected: 1,
+			Rows: [][]sqltypes.Value{
+				[]sqltypes.Value{
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte("PRIMARY")),
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte("id")),
+					sqltypes.MakeString([]byte{}),
+					sqltypes.MakeString([]byte("1")),
+				},
+			},
+		},
 		"begin":  &sqltypes.Result{},
 		"commit": &sqltypes.Result{},
 		baseShowTables + " and table_name = 'test_table'": &sqltypes.Result{
@@ -14,6 +14,7 @@ import (
 
 	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/vt/logz"
 )
 
 var (
@@ -46,7 +47,7 @@ var (
 	`)
 	querylogzFuncMap = template.FuncMap{
 		"stampMicro":   func(t time.Time) string { return t.Format(time.StampMicro) },
-		"cssWrappable": wrappable,
+		"cssWrappable": logz.Wrappable,
 		"unquote":      func(s string) string { return strings.Trim(s, "\"") },
 	}
 	querylogzTmpl = template.Must(template.New("example").Funcs(querylogzFuncMap).Parse(`
@@ -91,9 +92,9 @@ func querylogzHandler(ch chan interface{}, w http.ResponseWriter, r *http.Reques
 		acl.SendError(w, err)
 		return
 	}
-	timeout, limit := parseTimeoutLimitParams(r)
-	startHTMLTable(w)
-	defer endHTMLTable(w)
+	timeout, limit := logz.ParseTimeoutLimitParams(r)
+	logz.StartHTMLTable(w)
+	defer logz.EndHTMLTable(w)
 	w.Write(querylogzHeader)
 
 	tmr := time.NewTimer(timeout)
@@ -34,6 +34,35 @@ type QueryService interface {
 	// Rollback aborts the current transaction
 	Rollback(ctx context.Context, target *querypb.Target, sessionID, transactionID int64) error
 
+	// Two-phase commit of distributed transactions
+
+	// Prepare prepares the specified transaction for the distributed transaction dtid
+	Prepare(ctx context.Context, target *querypb.Target, transactionID int64, dtid string) error
+
+	// CommitPrepared commits the prepared transaction of dtid
+	CommitPrepared(ctx context.Context, target *querypb.Target, dtid string) error
+
+	// RollbackPrepared rolls back the prepared transaction of dtid,
+	// or originalID if it was not prepared yet
+	RollbackPrepared(ctx context.Context, target *querypb.Target, dtid string, originalID int64) error
+
+	// CreateTransaction records the distributed transaction dtid on its coordinator
+	CreateTransaction(ctx context.Context, target *querypb.Target, dtid string, participants []*querypb.Target) error
+
+	// StartCommit commits the transaction of the coordinator, and
+	// marks the distributed transaction for commit
+	StartCommit(ctx context.Context, target *querypb.Target, transactionID int64, dtid string) error
+
+	// SetRollback marks the distributed transaction for rollback,
+	// after rolling back the transaction of the coordinator
+	SetRollback(ctx context.Context, target *querypb.Target, dtid string, transactionID int64) error
+
+	// ConcludeTransaction deletes the record of a resolved distributed transaction
+	ConcludeTransaction(ctx context.Context, target *querypb.Target, dtid string) error
+
+	// ReadTransaction returns the record of a distributed transaction
+	ReadTransaction(ctx context.Context, target *querypb.Target, dtid string) (*querypb.TransactionMetadata, error)
+
 	// Query execution
 
 	Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID, transactionID int64) (*sqltypes.Result, error)
@@ -80,6 +109,46 @@ func (e *ErrorQueryService) Rollback(ctx context.Context, target *querypb.Target
 	return fmt.Errorf("ErrorQueryService does not implement any method")
 }
 
+// Prepare is part of QueryService interface
+func (e *ErrorQueryService) Prepare(ctx context.Context, target *querypb.Target, transactionID int64, dtid string) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// CommitPrepared is part of QueryService interface
+func (e *ErrorQueryService) CommitPrepared(ctx context.Context, target *querypb.Target, dtid string) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// RollbackPrepared is part of QueryService interface
+func (e *ErrorQueryService) RollbackPrepared(ctx context.Context, target *querypb.Target, dtid string, originalID int64) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// CreateTransaction is part of QueryService interface
+func (e *ErrorQueryService) CreateTransaction(ctx context.Context, target *querypb.Target, dtid string, participants []*querypb.Target) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// StartCommit is part of QueryService interface
+func (e *ErrorQueryService) StartCommit(ctx context.Context, target *querypb.Target, transactionID int64, dtid string) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// SetRollback is part of QueryService interface
+func (e *ErrorQueryService) SetRollback(ctx context.Context, target *querypb.Target, dtid string, transactionID int64) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// ConcludeTransaction is part of QueryService interface
+func (e *ErrorQueryService) ConcludeTransaction(ctx context.Context, target *querypb.Target, dtid string) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// ReadTransaction is part of QueryService interface
+func (e *ErrorQueryService) ReadTransaction(ctx context.Context, target *querypb.Target, dtid string) (*querypb.TransactionMetadata, error) {
+	return nil, fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
 // Execute is part of QueryService interface
 func (e *ErrorQueryService) Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID, transactionID int64) (*sqltypes.Result, error) {
 	return nil, fmt.Errorf("ErrorQueryService does not implement any method")
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/sqltypes"
@@ -22,6 +23,14 @@ import (
 type TableInfo struct {
 	*schema.Table
 	Cache *RowCache
+
+	// Seq must be locked before accessing the sequence vars.
+	// NextVal is the next value to hand out. Values up to
+	// LastVal (excluded) are reserved in the sequence table.
+	Seq     sync.Mutex
+	NextVal int64
+	LastVal int64
+
 	// rowcache stats updated by query_executor.go and query_engine.go.
 	hits, absent, misses, invalidations sync2.AtomicInt64
 }
@@ -32,6 +41,12 @@ func NewTableInfo(conn *DBConn, tableName string, tableType string, comment stri
 	if err != nil {
 		return nil, err
 	}
+	if strings.Contains(comment, "vitess_sequence") {
+		// Sequence tables are updated by vttablet outside
+		// of transactions. So, they're never cached.
+		ti.Type = schema.Sequence
+		return ti, nil
+	}
 	ti.initRowCache(conn, tableType, comment, cachePool)
 	return ti, nil
 }
@@ -94,6 +94,16 @@ type TabletConn interface {
 	Commit(ctx context.Context, transactionId int64) error
 	Rollback(ctx context.Context, transactionId int64) error
 
+	// Two-phase commit support
+	Prepare(ctx context.Context, transactionID int64, dtid string) error
+	CommitPrepared(ctx context.Context, dtid string) error
+	RollbackPrepared(ctx context.Context, dtid string, originalID int64) error
+	CreateTransaction(ctx context.Context, dtid string, participants []*querypb.Target) error
+	StartCommit(ctx context.Context, transactionID int64, dtid string) error
+	SetRollback(ctx context.Context, dtid string, transactionID int64) error
+	ConcludeTransaction(ctx context.Context, dtid string) error
+	ReadTransaction(ctx context.Context, dtid string) (*querypb.TransactionMetadata, error)
+
 	// Close must be called for releasing resources.
 	Close()
 
@@ -261,6 +261,229 @@ func testRollbackPanics(t *testing.T, conn tabletconn.TabletConn) {
 	}
 }
 
+// Prepare is part of the queryservice.QueryService interface
+func (f *FakeQueryService) Prepare(ctx context.Context, target *querypb.Target, transactionID int64, dtid string) error {
+	if f.hasError {
+		return testTabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkSessionTargetCallerID(ctx, "Prepare", target, 0)
+	if transactionID != prepareTransactionID {
+		f.t.Errorf("Prepare: invalid TransactionId: got %v expected %v", transactionID, prepareTransactionID)
+	}
+	if dtid != testDTID {
+		f.t.Errorf("Prepare: invalid Dtid: got %v expected %v", dtid, testDTID)
+	}
+	return nil
+}
+
+// CommitPrepared is part of the queryservice.QueryService interface
+func (f *FakeQueryService) CommitPrepared(ctx context.Context, target *querypb.Target, dtid string) error {
+	if f.hasError {
+		return testTabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkSessionTargetCallerID(ctx, "CommitPrepared", target, 0)
+	if dtid != testDTID {
+		f.t.Errorf("CommitPrepared: invalid Dtid: got %v expected %v", dtid, testDTID)
+	}
+	return nil
+}
+
+// RollbackPrepared is part of the queryservice.QueryService interface
+func (f *FakeQueryService) RollbackPrepared(ctx context.Context, target *querypb.Target, dtid string, originalID int64) error {
+	if f.hasError {
+		return testTabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkSessionTargetCallerID(ctx, "RollbackPrepared", target, 0)
+	if dtid != testDTID {
+		f.t.Errorf("RollbackPrepared: invalid Dtid: got %v expected %v", dtid, testDTID)
+	}
+	if originalID != rollbackTransactionID {
+		f.t.Errorf("RollbackPrepared: invalid OriginalId: got %v expected %v", originalID, rollbackTransactionID)
+	}
+	return nil
+}
+
+// CreateTransaction is part of the queryservice.QueryService interface
+func (f *FakeQueryService) CreateTransaction(ctx context.Context, target *querypb.Target, dtid string, participants []*querypb.Target) error {
+	if f.hasError {
+		return testTabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkSessionTargetCallerID(ctx, "CreateTransaction", target, 0)
+	if dtid != testDTID {
+		f.t.Errorf("CreateTransaction: invalid Dtid: got %v expected %v", dtid, testDTID)
+	}
+	if !reflect.DeepEqual(participants, testParticipants) {
+		f.t.Errorf("CreateTransaction: invalid participants: got %v expected %v", participants, testParticipants)
+	}
+	return nil
+}
+
+// StartCommit is part of the queryservice.QueryService interface
+func (f *FakeQueryService) StartCommit(ctx context.Context, target *querypb.Target, transactionID int64, dtid string) error {
+	if f.hasError {
+		return testTabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkSessionTargetCallerID(ctx, "StartCommit", target, 0)
+	if transactionID != commitTransactionID {
+		f.t.Errorf("StartCommit: invalid TransactionId: got %v expected %v", transactionID, commitTransactionID)
+	}
+	if dtid != testDTID {
+		f.t.Errorf("StartCommit: invalid Dtid: got %v expected %v", dtid, testDTID)
+	}
+	return nil
+}
+
+// SetRollback is part of the queryservice.QueryService interface
+func (f *FakeQueryService) SetRollback(ctx context.Context, target *querypb.Target, dtid string, transactionID int64) error {
+	if f.hasError {
+		return testTabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkSessionTargetCallerID(ctx, "SetRollback", target, 0)
+	if dtid != testDTID {
+		f.t.Errorf("SetRollback: invalid Dtid: got %v expected %v", dtid, testDTID)
+	}
+	if transactionID != rollbackTransactionID {
+		f.t.Errorf("SetRollback: invalid TransactionId: got %v expected %v", transactionID, rollbackTransactionID)
+	}
+	return nil
+}
+
+// ConcludeTransaction is part of the queryservice.QueryService interface
+func (f *FakeQueryService) ConcludeTransaction(ctx context.Context, target *querypb.Target, dtid string) error {
+	if f.hasError {
+		return testTabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkSessionTargetCallerID(ctx, "ConcludeTransaction", target, 0)
+	if dtid != testDTID {
+		f.t.Errorf("ConcludeTransaction: invalid Dtid: got %v expected %v", dtid, testDTID)
+	}
+	return nil
+}
+
+// ReadTransaction is part of the queryservice.QueryService interface
+func (f *FakeQueryService) ReadTransaction(ctx context.Context, target *querypb.Target, dtid string) (*querypb.TransactionMetadata, error) {
+	if f.hasError {
+		return nil, testTabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkSessionTargetCallerID(ctx, "ReadTransaction", target, 0)
+	if dtid != testDTID {
+		f.t.Errorf("ReadTransaction: invalid Dtid: got %v expected %v", dtid, testDTID)
+	}
+	return testMetadata, nil
+}
+
+const prepareTransactionID int64 = 999046
+
+const testDTID = "aa"
+
+var testParticipants = []*querypb.Target{{
+	Keyspace:   "ks0",
+	Shard:      "0",
+	TabletType: topodatapb.TabletType_MASTER,
+}, {
+	Keyspace:   "ks1",
+	Shard:      "1",
+	TabletType: topodatapb.TabletType_MASTER,
+}}
+
+var testMetadata = &querypb.TransactionMetadata{
+	Dtid:         testDTID,
+	State:        querypb.TransactionState_PREPARE,
+	TimeCreated:  1,
+	Participants: testParticipants,
+}
+
+// testTwoPC tests the two-phase commit calls. They're only
+// supported with a target.
+func testTwoPC(t *testing.T, conn tabletconn.TabletConn) {
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, testCallerID, testVTGateCallerID)
+	if err := conn.Prepare(ctx, prepareTransactionID, testDTID); err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if err := conn.CommitPrepared(ctx, testDTID); err != nil {
+		t.Fatalf("CommitPrepared failed: %v", err)
+	}
+	if err := conn.RollbackPrepared(ctx, testDTID, rollbackTransactionID); err != nil {
+		t.Fatalf("RollbackPrepared failed: %v", err)
+	}
+	if err := conn.CreateTransaction(ctx, testDTID, testParticipants); err != nil {
+		t.Fatalf("CreateTransaction failed: %v", err)
+	}
+	if err := conn.StartCommit(ctx, commitTransactionID, testDTID); err != nil {
+		t.Fatalf("StartCommit failed: %v", err)
+	}
+	if err := conn.SetRollback(ctx, testDTID, rollbackTransactionID); err != nil {
+		t.Fatalf("SetRollback failed: %v", err)
+	}
+	if err := conn.ConcludeTransaction(ctx, testDTID); err != nil {
+		t.Fatalf("ConcludeTransaction failed: %v", err)
+	}
+	got, err := conn.ReadTransaction(ctx, testDTID)
+	if err != nil {
+		t.Fatalf("ReadTransaction failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, testMetadata) {
+		t.Errorf("ReadTransaction: %v, want %v", got, testMetadata)
+	}
+}
+
+func testTwoPCError(t *testing.T, conn tabletconn.TabletConn) {
+	ctx := context.Background()
+	verifyError(t, conn.Prepare(ctx, prepareTransactionID, testDTID), "Prepare")
+	verifyError(t, conn.CommitPrepared(ctx, testDTID), "CommitPrepared")
+	verifyError(t, conn.RollbackPrepared(ctx, testDTID, rollbackTransactionID), "RollbackPrepared")
+	verifyError(t, conn.CreateTransaction(ctx, testDTID, testParticipants), "CreateTransaction")
+	verifyError(t, conn.StartCommit(ctx, commitTransactionID, testDTID), "StartCommit")
+	verifyError(t, conn.SetRollback(ctx, testDTID, rollbackTransactionID), "SetRollback")
+	verifyError(t, conn.ConcludeTransaction(ctx, testDTID), "ConcludeTransaction")
+	_, err := conn.ReadTransaction(ctx, testDTID)
+	verifyError(t, err, "ReadTransaction")
+}
+
+func testTwoPCPanics(t *testing.T, conn tabletconn.TabletConn) {
+	ctx := context.Background()
+	errs := map[string]error{
+		"Prepare":             conn.Prepare(ctx, prepareTransactionID, testDTID),
+		"CommitPrepared":      conn.CommitPrepared(ctx, testDTID),
+		"RollbackPrepared":    conn.RollbackPrepared(ctx, testDTID, rollbackTransactionID),
+		"CreateTransaction":   conn.CreateTransaction(ctx, testDTID, testParticipants),
+		"StartCommit":         conn.StartCommit(ctx, commitTransactionID, testDTID),
+		"SetRollback":         conn.SetRollback(ctx, testDTID, rollbackTransactionID),
+		"ConcludeTransaction": conn.ConcludeTransaction(ctx, testDTID),
+	}
+	_, errs["ReadTransaction"] = conn.ReadTransaction(ctx, testDTID)
+	for name, err := range errs {
+		if err == nil || !strings.Contains(err.Error(), "caught test panic") {
+			t.Errorf("unexpected %s panic error: %v", name, err)
+		}
+	}
+}
+
 // Execute is part of the queryservice.QueryService interface
 func (f *FakeQueryService) Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID, transactionID int64) (*sqltypes.Result, error) {
 	if f.hasError {
@@ -852,6 +1075,7 @@ func TestSuite(t *testing.T, protocol string, endPoint *topodatapb.EndPoint, fak
 	testBegin(t, conn)
 	testCommit(t, conn)
 	testRollback(t, conn)
+	testTwoPC(t, conn)
 	testExecute(t, conn)
 	testStreamExecute(t, conn)
 	testExecuteBatch(t, conn)
@@ -863,6 +1087,7 @@ func TestSuite(t *testing.T, protocol string, endPoint *topodatapb.EndPoint, fak
 	testBeginError(t, conn)
 	testCommitError(t, conn)
 	testRollbackError(t, conn)
+	testTwoPCError(t, conn)
 	testExecuteError(t, conn)
 	testStreamExecuteError(t, conn, fake)
 	testExecuteBatchError(t, conn)
@@ -874,6 +1099,7 @@ func TestSuite(t *testing.T, protocol string, endPoint *topodatapb.EndPoint, fak
 	testBeginPanics(t, conn)
 	testCommitPanics(t, conn)
 	testRollbackPanics(t, conn)
+	testTwoPCPanics(t, conn)
 	testExecutePanics(t, conn)
 	testStreamExecutePanics(t, conn, fake)
 	testExecuteBatchPanics(t, conn)
@@ -377,6 +377,11 @@ func (tsv *TabletServer) serveNewType() (err error) {
 	} else {
 		tsv.invalidator.Close()
 	}
+	if tsv.target.TabletType == topodatapb.TabletType_MASTER {
+		tsv.qe.StartTwoPC()
+	} else {
+		tsv.qe.StopTwoPC()
+	}
 	tsv.sessionID = Rand()
 	log.Infof("Session id: %d", tsv.sessionID)
 	tsv.transition(StateServing)
@@ -394,6 +399,7 @@ func (tsv *TabletServer) needInvalidator(target querypb.Target) bool {
 func (tsv *TabletServer) gracefulStop() {
 	defer close(tsv.setTimeBomb())
 	tsv.waitForShutdown()
+	tsv.qe.StopTwoPC()
 	tsv.transition(StateNotServing)
 }
 
@@ -610,6 +616,175 @@ func (tsv *TabletServer) Rollback(ctx context.Context, target *querypb.Target, s
 	return nil
 }
 
+// Prepare prepares the specified transaction for the distributed
+// transaction dtid. It's allowed during a graceful shutdown, so that
+// the pending distributed transactions can complete.
+func (tsv *TabletServer) Prepare(ctx context.Context, target *querypb.Target, transactionID int64, dtid string) (err error) {
+	logStats := newLogStats("Prepare", ctx)
+	logStats.OriginalSQL = "prepare"
+	logStats.TransactionID = transactionID
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func() {
+		tsv.qe.queryServiceStats.QueryStats.Record("PREPARE", time.Now())
+		cancel()
+		tsv.endRequest(false)
+	}()
+
+	tsv.qe.Prepare(ctx, transactionID, dtid)
+	return nil
+}
+
+// CommitPrepared commits the prepared transaction of dtid.
+func (tsv *TabletServer) CommitPrepared(ctx context.Context, target *querypb.Target, dtid string) (err error) {
+	logStats := newLogStats("CommitPrepared", ctx)
+	logStats.OriginalSQL = "commit prepared"
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func() {
+		tsv.qe.queryServiceStats.QueryStats.Record("COMMIT_PREPARED", time.Now())
+		cancel()
+		tsv.endRequest(false)
+	}()
+
+	tsv.qe.CommitPrepared(ctx, logStats, dtid)
+	return nil
+}
+
+// RollbackPrepared rolls back the prepared transaction of dtid. If the
+// transaction was not prepared yet, originalID is rolled back instead.
+func (tsv *TabletServer) RollbackPrepared(ctx context.Context, target *querypb.Target, dtid string, originalID int64) (err error) {
+	logStats := newLogStats("RollbackPrepared", ctx)
+	logStats.OriginalSQL = "rollback prepared"
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func() {
+		tsv.qe.queryServiceStats.QueryStats.Record("ROLLBACK_PREPARED", time.Now())
+		cancel()
+		tsv.endRequest(false)
+	}()
+
+	tsv.qe.RollbackPrepared(ctx, dtid, originalID)
+	return nil
+}
+
+// CreateTransaction records the distributed transaction dtid and its
+// participants on this tablet, which is its coordinator.
+func (tsv *TabletServer) CreateTransaction(ctx context.Context, target *querypb.Target, dtid string, participants []*querypb.Target) (err error) {
+	logStats := newLogStats("CreateTransaction", ctx)
+	logStats.OriginalSQL = "create transaction"
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func() {
+		tsv.qe.queryServiceStats.QueryStats.Record("CREATE_TRANSACTION", time.Now())
+		cancel()
+		tsv.endRequest(false)
+	}()
+
+	tsv.qe.CreateTransaction(ctx, dtid, participants)
+	return nil
+}
+
+// StartCommit commits the transaction of the coordinator, and atomically
+// marks the distributed transaction dtid for commit.
+func (tsv *TabletServer) StartCommit(ctx context.Context, target *querypb.Target, transactionID int64, dtid string) (err error) {
+	logStats := newLogStats("StartCommit", ctx)
+	logStats.OriginalSQL = "start commit"
+	logStats.TransactionID = transactionID
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func() {
+		tsv.qe.queryServiceStats.QueryStats.Record("START_COMMIT", time.Now())
+		cancel()
+		tsv.endRequest(false)
+	}()
+
+	tsv.qe.StartCommit(ctx, logStats, transactionID, dtid)
+	return nil
+}
+
+// SetRollback marks the distributed transaction dtid for rollback,
+// after rolling back the transaction of the coordinator if it's not 0.
+func (tsv *TabletServer) SetRollback(ctx context.Context, target *querypb.Target, dtid string, transactionID int64) (err error) {
+	logStats := newLogStats("SetRollback", ctx)
+	logStats.OriginalSQL = "set rollback"
+	logStats.TransactionID = transactionID
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func() {
+		tsv.qe.queryServiceStats.QueryStats.Record("SET_ROLLBACK", time.Now())
+		cancel()
+		tsv.endRequest(false)
+	}()
+
+	tsv.qe.SetRollback(ctx, dtid, transactionID)
+	return nil
+}
+
+// ConcludeTransaction deletes the record of the distributed transaction
+// dtid once it's resolved.
+func (tsv *TabletServer) ConcludeTransaction(ctx context.Context, target *querypb.Target, dtid string) (err error) {
+	logStats := newLogStats("ConcludeTransaction", ctx)
+	logStats.OriginalSQL = "conclude transaction"
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func() {
+		tsv.qe.queryServiceStats.QueryStats.Record("CONCLUDE_TRANSACTION", time.Now())
+		cancel()
+		tsv.endRequest(false)
+	}()
+
+	tsv.qe.ConcludeTransaction(ctx, dtid)
+	return nil
+}
+
+// ReadTransaction returns the record of the distributed transaction dtid.
+func (tsv *TabletServer) ReadTransaction(ctx context.Context, target *querypb.Target, dtid string) (metadata *querypb.TransactionMetadata, err error) {
+	logStats := newLogStats("ReadTransaction", ctx)
+	logStats.OriginalSQL = "read transaction"
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, true); err != nil {
+		return nil, err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func() {
+		tsv.qe.queryServiceStats.QueryStats.Record("READ_TRANSACTION", time.Now())
+		cancel()
+		tsv.endRequest(false)
+	}()
+
+	return tsv.qe.ReadTransaction(ctx, dtid), nil
+}
+
 // handleExecError handles panics during query execution and sets
 // the supplied error return value.
 func (tsv *TabletServer) handleExecError(sql string, bindVariables map[string]interface{}, err *error, logStats *LogStats) {
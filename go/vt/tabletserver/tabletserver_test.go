@@ -6,9 +6,13 @@ package tabletserver
 
 import (
 	"expvar"
+	"flag"
+	"fmt"
 	"math/rand"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,6 +21,7 @@ import (
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+	"github.com/youtube/vitess/go/vt/vtgate/vtgateconn"
 	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
 	"golang.org/x/net/context"
 )
@@ -594,6 +599,334 @@ func TestTabletServerRollback(t *testing.T) {
 	}
 }
 
+func TestTabletServerPrepare(t *testing.T) {
+	db, config := setUpTwoPCTest()
+	// Only the DMLs are saved in the redo log,
+	// not the selects sent for them.
+	updateSQL := "update test_table set addr = 3 where name = 1 limit 1000"
+	db.AddQuery("select pk from test_table where name = 1 limit 1000 for update", &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeTrusted(sqltypes.Int32, []byte("1"))},
+		},
+	})
+	updateRewritten := "update test_table set addr = 3 where pk in (1) /* _stream test_table (pk ) (1 ); */"
+	db.AddQuery(updateRewritten, &sqltypes.Result{RowsAffected: 1})
+	db.AddQuery("select * from test_table limit 1000", &sqltypes.Result{})
+	tsv := startTwoPCTabletServer(t, db, config)
+	defer tsv.StopService()
+	ctx := context.Background()
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	transactionID, err := tsv.Begin(ctx, &target, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	for _, sql := range []string{"select * from test_table limit 1000", twopcTestInsert, updateSQL} {
+		if _, err := tsv.Execute(ctx, &target, sql, nil, tsv.sessionID, transactionID); err != nil {
+			t.Fatalf("Execute(%s) failed: %v", sql, err)
+		}
+	}
+	if err := tsv.Prepare(ctx, &target, transactionID, "aa"); err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	saveRedo := fmt.Sprintf("insert into _vt.redo_statement(dtid, id, statement) values ('aa', 1, '%s'), ('aa', 2, '%s')", twopcTestInsertRewritten, updateRewritten)
+	if got := db.GetQueryCalledNum(saveRedo); got != 1 {
+		t.Errorf("redo statements saved %d times, want 1", got)
+	}
+	conn := tsv.qe.preparedPool.conns["aa"]
+	if conn == nil {
+		t.Fatalf("aa is not in the prepared pool")
+	}
+	if want := []string{twopcTestInsertRewritten, updateRewritten}; !reflect.DeepEqual(conn.statements, want) {
+		t.Errorf("prepared statements: %v, want %v", conn.statements, want)
+	}
+
+	// A transaction can't be prepared twice for the same dtid.
+	transactionID, err = tsv.Begin(ctx, &target, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if _, err := tsv.Execute(ctx, &target, twopcTestInsert, nil, tsv.sessionID, transactionID); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	want := "prepare failed for transaction"
+	if err := tsv.Prepare(ctx, &target, transactionID, "aa"); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Prepare: %v, must contain %s", err, want)
+	}
+}
+
+func TestTabletServerPrepareReadOnly(t *testing.T) {
+	db, config := setUpTwoPCTest()
+	db.AddQuery("select * from test_table limit 1000", &sqltypes.Result{})
+	tsv := startTwoPCTabletServer(t, db, config)
+	defer tsv.StopService()
+	ctx := context.Background()
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	transactionID, err := tsv.Begin(ctx, &target, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if _, err := tsv.Execute(ctx, &target, "select * from test_table limit 1000", nil, tsv.sessionID, transactionID); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	rollbacks := db.GetQueryCalledNum("rollback")
+	// A transaction that changed no data is rolled back,
+	// and is not saved in the redo log.
+	if err := tsv.Prepare(ctx, &target, transactionID, "aa"); err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+	if got := db.GetQueryCalledNum("rollback"); got != rollbacks+1 {
+		t.Errorf("rollback executed %d times, want %d", got, rollbacks+1)
+	}
+	if _, ok := tsv.qe.preparedPool.conns["aa"]; ok {
+		t.Errorf("aa must not be in the prepared pool")
+	}
+	if err := tsv.CommitPrepared(ctx, &target, "aa"); err != nil {
+		t.Errorf("CommitPrepared failed: %v", err)
+	}
+}
+
+func TestTabletServerCommitPrepared(t *testing.T) {
+	db, config := setUpTwoPCTest()
+	tsv := startTwoPCTabletServer(t, db, config)
+	defer tsv.StopService()
+	ctx := context.Background()
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	prepareTwoPCTestTransaction(t, tsv, "aa")
+	commits := db.GetQueryCalledNum("commit")
+	if err := tsv.CommitPrepared(ctx, &target, "aa"); err != nil {
+		t.Fatalf("CommitPrepared failed: %v", err)
+	}
+	if got := db.GetQueryCalledNum("delete from _vt.redo_state where dtid = 'aa'"); got != 1 {
+		t.Errorf("redo log deleted %d times, want 1", got)
+	}
+	if got := db.GetQueryCalledNum("commit"); got != commits+1 {
+		t.Errorf("commit executed %d times, want %d", got, commits+1)
+	}
+	if _, ok := tsv.qe.preparedPool.conns["aa"]; ok {
+		t.Errorf("aa must not be in the prepared pool")
+	}
+	// Committing again is a no-op.
+	if err := tsv.CommitPrepared(ctx, &target, "aa"); err != nil {
+		t.Errorf("CommitPrepared failed: %v", err)
+	}
+	if got := db.GetQueryCalledNum("commit"); got != commits+1 {
+		t.Errorf("commit executed %d times, want %d", got, commits+1)
+	}
+}
+
+func TestTabletServerRollbackPrepared(t *testing.T) {
+	db, config := setUpTwoPCTest()
+	tsv := startTwoPCTabletServer(t, db, config)
+	defer tsv.StopService()
+	ctx := context.Background()
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	prepareTwoPCTestTransaction(t, tsv, "aa")
+	rollbacks := db.GetQueryCalledNum("rollback")
+	if err := tsv.RollbackPrepared(ctx, &target, "aa", 0); err != nil {
+		t.Fatalf("RollbackPrepared failed: %v", err)
+	}
+	if got := db.GetQueryCalledNum("delete from _vt.redo_state where dtid = 'aa'"); got != 1 {
+		t.Errorf("redo log deleted %d times, want 1", got)
+	}
+	if got := db.GetQueryCalledNum("rollback"); got != rollbacks+1 {
+		t.Errorf("rollback executed %d times, want %d", got, rollbacks+1)
+	}
+	if _, ok := tsv.qe.preparedPool.conns["aa"]; ok {
+		t.Errorf("aa must not be in the prepared pool")
+	}
+
+	// A transaction that was never prepared is rolled back
+	// through its original id.
+	transactionID, err := tsv.Begin(ctx, &target, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := tsv.RollbackPrepared(ctx, &target, "bb", transactionID); err != nil {
+		t.Fatalf("RollbackPrepared failed: %v", err)
+	}
+	if got := db.GetQueryCalledNum("rollback"); got != rollbacks+2 {
+		t.Errorf("rollback executed %d times, want %d", got, rollbacks+2)
+	}
+}
+
+func TestTabletServerStartCommit(t *testing.T) {
+	db, config := setUpTwoPCTest()
+	tsv := startTwoPCTabletServer(t, db, config)
+	defer tsv.StopService()
+	ctx := context.Background()
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	commitTransition := "update _vt.dt_state set state = 2 where dtid = 'aa' and state = 1"
+	db.AddQuery(commitTransition, &sqltypes.Result{RowsAffected: 1})
+	transactionID, err := tsv.Begin(ctx, &target, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	commits := db.GetQueryCalledNum("commit")
+	if err := tsv.StartCommit(ctx, &target, transactionID, "aa"); err != nil {
+		t.Fatalf("StartCommit failed: %v", err)
+	}
+	if got := db.GetQueryCalledNum("commit"); got != commits+1 {
+		t.Errorf("commit executed %d times, want %d", got, commits+1)
+	}
+
+	// The transaction is rolled back if the distributed
+	// transaction is no longer in the PREPARE state.
+	db.AddQuery(commitTransition, &sqltypes.Result{})
+	transactionID, err = tsv.Begin(ctx, &target, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	rollbacks := db.GetQueryCalledNum("rollback")
+	want := "could not transition to COMMIT: aa"
+	if err := tsv.StartCommit(ctx, &target, transactionID, "aa"); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("StartCommit: %v, must contain %s", err, want)
+	}
+	if got := db.GetQueryCalledNum("rollback"); got != rollbacks+1 {
+		t.Errorf("rollback executed %d times, want %d", got, rollbacks+1)
+	}
+}
+
+func TestTabletServerSetRollback(t *testing.T) {
+	db, config := setUpTwoPCTest()
+	tsv := startTwoPCTabletServer(t, db, config)
+	defer tsv.StopService()
+	ctx := context.Background()
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	rollbackTransition := "update _vt.dt_state set state = 3 where dtid = 'aa' and state = 1"
+	db.AddQuery(rollbackTransition, &sqltypes.Result{RowsAffected: 1})
+	transactionID, err := tsv.Begin(ctx, &target, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	rollbacks := db.GetQueryCalledNum("rollback")
+	if err := tsv.SetRollback(ctx, &target, "aa", transactionID); err != nil {
+		t.Fatalf("SetRollback failed: %v", err)
+	}
+	if got := db.GetQueryCalledNum("rollback"); got != rollbacks+1 {
+		t.Errorf("rollback executed %d times, want %d", got, rollbacks+1)
+	}
+	if got := db.GetQueryCalledNum(rollbackTransition); got != 1 {
+		t.Errorf("transition executed %d times, want 1", got)
+	}
+
+	db.AddQuery(rollbackTransition, &sqltypes.Result{})
+	want := "could not transition to ROLLBACK: aa"
+	if err := tsv.SetRollback(ctx, &target, "aa", 0); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("SetRollback: %v, must contain %s", err, want)
+	}
+}
+
+func TestTabletServerPrepareFromRedo(t *testing.T) {
+	db, config := setUpTwoPCTest()
+	tsv := startTwoPCTabletServer(t, db, config)
+	ctx := context.Background()
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	prepareTwoPCTestTransaction(t, tsv, "aa")
+
+	// The prepared transactions are rolled back when the
+	// tablet stops serving, and prepared again from the
+	// redo log when it restarts.
+	tsv.StopService()
+	if got := len(tsv.qe.preparedPool.conns); got != 0 {
+		t.Errorf("%d transactions in the prepared pool, want 0", got)
+	}
+	// The statements are replayed without a row limit.
+	updateStatement := "update test_table set name = 2 where pk in (1, 2) /* _stream test_table (pk ) (1 ) (2 ); */"
+	db.AddQuery(updateStatement, &sqltypes.Result{RowsAffected: 2})
+	failedStatement := "update test_table set name = 2 where pk = 1 /* _stream test_table (pk ) (1 ); */"
+	db.AddRejectedQuery(failedStatement, errRejected)
+	db.AddQuery(NewTwoPC().readAllRedo, &sqltypes.Result{
+		Rows: [][]sqltypes.Value{
+			twopcTestRedoRow("aa", RedoStatePrepared, twopcTestInsertRewritten),
+			twopcTestRedoRow("aa", RedoStatePrepared, updateStatement),
+			twopcTestRedoRow("bb", RedoStateFailed, twopcTestInsertRewritten),
+			twopcTestRedoRow("cc", RedoStatePrepared, twopcTestInsertRewritten),
+			twopcTestRedoRow("cc", RedoStatePrepared, failedStatement),
+		},
+	})
+	db.AddQuery("update _vt.redo_state set state = 0 where dtid = 'cc'", &sqltypes.Result{})
+	failures := tsv.qe.queryServiceStats.InternalErrors.Counts()["TwopcResurrection"]
+	dbconfigs := newTestUtils().newDBConfigs(db)
+	if err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, newTestUtils().newMysqld(&dbconfigs)); err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+
+	conn := tsv.qe.preparedPool.conns["aa"]
+	if conn == nil {
+		t.Fatalf("aa is not in the prepared pool")
+	}
+	if want := []string{twopcTestInsertRewritten, updateStatement}; !reflect.DeepEqual(conn.statements, want) {
+		t.Errorf("prepared statements: %v, want %v", conn.statements, want)
+	}
+	for _, dtid := range []string{"bb", "cc"} {
+		if _, ok := tsv.qe.preparedPool.conns[dtid]; ok {
+			t.Errorf("%s must not be in the prepared pool", dtid)
+		}
+	}
+	if got := db.GetQueryCalledNum("update _vt.redo_state set state = 0 where dtid = 'cc'"); got != 1 {
+		t.Errorf("cc marked as failed %d times, want 1", got)
+	}
+	if got := tsv.qe.queryServiceStats.InternalErrors.Counts()["TwopcResurrection"]; got != failures+2 {
+		t.Errorf("TwopcResurrection: %d, want %d", got, failures+2)
+	}
+	if err := tsv.CommitPrepared(ctx, &target, "aa"); err != nil {
+		t.Errorf("CommitPrepared failed: %v", err)
+	}
+}
+
+// fakeCoordinator is a vtgateconn.Impl that records the
+// distributed transactions it's asked to resolve.
+type fakeCoordinator struct {
+	vtgateconn.Impl
+	mu       sync.Mutex
+	resolved []string
+}
+
+func (fc *fakeCoordinator) ResolveTransaction(ctx context.Context, dtid string) error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.resolved = append(fc.resolved, dtid)
+	if dtid == "bb" {
+		return errRejected
+	}
+	return nil
+}
+
+func (fc *fakeCoordinator) Close() {}
+
+func TestTabletServerResolveAbandoned(t *testing.T) {
+	db, config := setUpTwoPCTest()
+	coordinator := &fakeCoordinator{}
+	vtgateconn.RegisterDialer("fake_twopc", func(ctx context.Context, address string, timeout time.Duration) (vtgateconn.Impl, error) {
+		if address != config.TwoPCCoordinatorAddress {
+			return nil, fmt.Errorf("unexpected coordinator address: %s", address)
+		}
+		return coordinator, nil
+	})
+	protocol := flag.Lookup("vtgate_protocol").Value.String()
+	flag.Set("vtgate_protocol", "fake_twopc")
+	defer flag.Set("vtgate_protocol", protocol)
+	tsv := startTwoPCTabletServer(t, db, config)
+	defer tsv.StopService()
+
+	db.AddQueryPattern(`select dtid from _vt\.dt_state where time_created < [0-9]+`, &sqltypes.Result{
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeString([]byte("aa"))},
+			{sqltypes.MakeString([]byte("bb"))},
+		},
+	})
+	failures := tsv.qe.queryServiceStats.InternalErrors.Counts()["WatchdogFail"]
+	tsv.qe.resolveAbandoned()
+	if want := []string{"aa", "bb"}; !reflect.DeepEqual(coordinator.resolved, want) {
+		t.Errorf("resolved: %v, want %v", coordinator.resolved, want)
+	}
+	if got := tsv.qe.queryServiceStats.InternalErrors.Counts()["WatchdogFail"]; got != failures+1 {
+		t.Errorf("WatchdogFail: %d, want %d", got, failures+1)
+	}
+}
+
 func TestTabletServerStreamExecute(t *testing.T) {
 	db := setUpTabletServerTest()
 	testUtils := newTestUtils()
@@ -1241,6 +1574,72 @@ func checkTabletServerState(t *testing.T, tsv *TabletServer, expectState int64)
 	}
 }
 
+// twopcTestInsert is the statement of the transaction prepared by
+// prepareTwoPCTestTransaction, and twopcTestInsertRewritten is the
+// statement sent to MySQL and saved in the redo log.
+const (
+	twopcTestInsert          = "insert into test_table values (1)"
+	twopcTestInsertRewritten = "insert into test_table values (1) /* _stream test_table (pk ) (1 ); */"
+)
+
+// setUpTwoPCTest returns a fake db that supports the queries of
+// two-phase commit, and the config of a tablet server that has
+// it enabled.
+func setUpTwoPCTest() (*fakesqldb.DB, Config) {
+	db := setUpTabletServerTest()
+	for _, query := range twopcSchema {
+		db.AddQuery(query, &sqltypes.Result{})
+	}
+	db.AddQuery(NewTwoPC().readAllRedo, &sqltypes.Result{})
+	db.AddQuery(twopcTestInsertRewritten, &sqltypes.Result{RowsAffected: 1})
+	db.AddQueryPattern(`insert into _vt\.redo_state\(dtid, state, time_created\) values \('[a-z]+', 1, [0-9]+\)`, &sqltypes.Result{})
+	db.AddQueryPattern(`insert into _vt\.redo_statement\(dtid, id, statement\) values .*`, &sqltypes.Result{})
+	db.AddQueryPattern(`delete from _vt\.redo_(state|statement) where dtid = '[a-z]+'`, &sqltypes.Result{})
+	config := newTestUtils().newQueryServiceConfig()
+	config.TwoPCEnable = true
+	config.TwoPCCoordinatorAddress = "fake"
+	config.TwoPCAbandonAge = 10
+	return db, config
+}
+
+func startTwoPCTabletServer(t *testing.T, db *fakesqldb.DB, config Config) *TabletServer {
+	testUtils := newTestUtils()
+	tsv := NewTabletServer(config)
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	if err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs)); err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	return tsv
+}
+
+// prepareTwoPCTestTransaction prepares a transaction
+// that executes twopcTestInsert for dtid.
+func prepareTwoPCTestTransaction(t *testing.T, tsv *TabletServer, dtid string) {
+	ctx := context.Background()
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	transactionID, err := tsv.Begin(ctx, &target, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if _, err := tsv.Execute(ctx, &target, twopcTestInsert, nil, tsv.sessionID, transactionID); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if err := tsv.Prepare(ctx, &target, transactionID, dtid); err != nil {
+		t.Fatalf("Prepare failed: %v", err)
+	}
+}
+
+// twopcTestRedoRow returns a row of the readAllRedo query.
+func twopcTestRedoRow(dtid string, state int, statement string) []sqltypes.Value {
+	return []sqltypes.Value{
+		sqltypes.MakeString([]byte(dtid)),
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte(strconv.Itoa(state))),
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte("1427325875")),
+		sqltypes.MakeString([]byte(statement)),
+	}
+}
+
 func getSupportedQueries() map[string]*sqltypes.Result {
 	return map[string]*sqltypes.Result{
 		// queries for schema info
@@ -0,0 +1,366 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/youtube/vitess/go/sqldb"
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/vt/dbconnpool"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+	"golang.org/x/net/context"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+// These are the states of a transaction in the redo log.
+const (
+	// RedoStateFailed means the transaction could not be
+	// committed, or could not be prepared again after a restart.
+	// It's left for an operator to resolve.
+	RedoStateFailed = 0
+	// RedoStatePrepared means the transaction is prepared.
+	RedoStatePrepared = 1
+)
+
+// twopcSchema contains the statements that create the tables
+// used by two-phase commit. The redo tables hold the statements
+// of the transactions prepared on this tablet. The dt tables hold
+// the metadata of the distributed transactions this tablet
+// coordinates. They're all regular tables, which means that they
+// get replicated along with the data.
+var twopcSchema = []string{
+	"create database if not exists _vt",
+	`create table if not exists _vt.redo_state(
+  dtid varbinary(512),
+  state bigint,
+  time_created bigint,
+  primary key(dtid)
+) engine=InnoDB`,
+	`create table if not exists _vt.redo_statement(
+  dtid varbinary(512),
+  id bigint,
+  statement mediumblob,
+  primary key(dtid, id)
+) engine=InnoDB`,
+	`create table if not exists _vt.dt_state(
+  dtid varbinary(512),
+  state bigint,
+  time_created bigint,
+  primary key(dtid)
+) engine=InnoDB`,
+	`create table if not exists _vt.dt_participant(
+  dtid varbinary(512),
+  id bigint,
+  keyspace varchar(256),
+  shard varchar(256),
+  primary key(dtid, id)
+) engine=InnoDB`,
+}
+
+// TwoPC performs the reads and writes on the tables
+// of two-phase commit. The writes are done within the
+// transaction of the supplied connection.
+type TwoPC struct {
+	insertRedoTx       *sqlparser.ParsedQuery
+	insertRedoStmt     *sqlparser.ParsedQuery
+	updateRedoTx       *sqlparser.ParsedQuery
+	deleteRedoTx       *sqlparser.ParsedQuery
+	deleteRedoStmt     *sqlparser.ParsedQuery
+	readAllRedo        string
+	insertTransaction  *sqlparser.ParsedQuery
+	insertParticipants *sqlparser.ParsedQuery
+	transition         *sqlparser.ParsedQuery
+	deleteTransaction  *sqlparser.ParsedQuery
+	deleteParticipants *sqlparser.ParsedQuery
+	readTransaction    *sqlparser.ParsedQuery
+	readParticipants   *sqlparser.ParsedQuery
+	readAbandoned      *sqlparser.ParsedQuery
+}
+
+// NewTwoPC creates a TwoPC.
+func NewTwoPC() *TwoPC {
+	return &TwoPC{
+		insertRedoTx: buildParsedQuery(
+			"insert into _vt.redo_state(dtid, state, time_created) values (%a, %a, %a)",
+			":dtid", ":state", ":time_created"),
+		insertRedoStmt: buildParsedQuery(
+			"insert into _vt.redo_statement(dtid, id, statement) values %a",
+			":vals"),
+		updateRedoTx: buildParsedQuery(
+			"update _vt.redo_state set state = %a where dtid = %a",
+			":state", ":dtid"),
+		deleteRedoTx: buildParsedQuery(
+			"delete from _vt.redo_state where dtid = %a",
+			":dtid"),
+		deleteRedoStmt: buildParsedQuery(
+			"delete from _vt.redo_statement where dtid = %a",
+			":dtid"),
+		readAllRedo: "select t.dtid, t.state, t.time_created, s.statement " +
+			"from _vt.redo_state t join _vt.redo_statement s on t.dtid = s.dtid " +
+			"order by t.dtid, s.id",
+		insertTransaction: buildParsedQuery(
+			"insert into _vt.dt_state(dtid, state, time_created) values (%a, %a, %a)",
+			":dtid", ":state", ":time_created"),
+		insertParticipants: buildParsedQuery(
+			"insert into _vt.dt_participant(dtid, id, keyspace, shard) values %a",
+			":vals"),
+		transition: buildParsedQuery(
+			"update _vt.dt_state set state = %a where dtid = %a and state = %a",
+			":state", ":dtid", ":prepare"),
+		deleteTransaction: buildParsedQuery(
+			"delete from _vt.dt_state where dtid = %a",
+			":dtid"),
+		deleteParticipants: buildParsedQuery(
+			"delete from _vt.dt_participant where dtid = %a",
+			":dtid"),
+		readTransaction: buildParsedQuery(
+			"select dtid, state, time_created from _vt.dt_state where dtid = %a",
+			":dtid"),
+		readParticipants: buildParsedQuery(
+			"select keyspace, shard from _vt.dt_participant where dtid = %a order by id",
+			":dtid"),
+		readAbandoned: buildParsedQuery(
+			"select dtid from _vt.dt_state where time_created < %a",
+			":time_created"),
+	}
+}
+
+func buildParsedQuery(in string, vars ...interface{}) *sqlparser.ParsedQuery {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf(in, vars...)
+	return buf.ParsedQuery()
+}
+
+// Init creates the two-phase commit tables if they don't exist.
+func (tpc *TwoPC) Init(dbaParams *sqldb.ConnParams, mysqlStats *stats.Timings) error {
+	conn, err := dbconnpool.NewDBConnection(dbaParams, mysqlStats)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	for _, sql := range twopcSchema {
+		if _, err := conn.ExecuteFetch(sql, 1, false); err != nil {
+			return fmt.Errorf("could not create two-phase commit tables: %v", err)
+		}
+	}
+	return nil
+}
+
+// SaveRedo saves the statements of a prepared transaction
+// in the redo log.
+func (tpc *TwoPC) SaveRedo(ctx context.Context, conn *TxConnection, dtid string, statements []string) error {
+	bindVars := map[string]interface{}{
+		"dtid":         dtid,
+		"state":        RedoStatePrepared,
+		"time_created": time.Now().UnixNano(),
+	}
+	if _, err := tpc.exec(ctx, conn, tpc.insertRedoTx, bindVars); err != nil {
+		return err
+	}
+
+	rows := make([][]sqltypes.Value, len(statements))
+	for i, statement := range statements {
+		rows[i] = []sqltypes.Value{
+			sqltypes.MakeString([]byte(dtid)),
+			sqltypes.MakeTrusted(sqltypes.Int64, strconv.AppendInt(nil, int64(i+1), 10)),
+			sqltypes.MakeString([]byte(statement)),
+		}
+	}
+	_, err := tpc.exec(ctx, conn, tpc.insertRedoStmt, map[string]interface{}{"vals": rows})
+	return err
+}
+
+// UpdateRedo changes the state of a transaction in the redo log.
+func (tpc *TwoPC) UpdateRedo(ctx context.Context, conn *TxConnection, dtid string, state int) error {
+	bindVars := map[string]interface{}{
+		"dtid":  dtid,
+		"state": state,
+	}
+	_, err := tpc.exec(ctx, conn, tpc.updateRedoTx, bindVars)
+	return err
+}
+
+// DeleteRedo deletes a transaction from the redo log.
+func (tpc *TwoPC) DeleteRedo(ctx context.Context, conn *TxConnection, dtid string) error {
+	bindVars := map[string]interface{}{
+		"dtid": dtid,
+	}
+	if _, err := tpc.exec(ctx, conn, tpc.deleteRedoTx, bindVars); err != nil {
+		return err
+	}
+	_, err := tpc.exec(ctx, conn, tpc.deleteRedoStmt, bindVars)
+	return err
+}
+
+// PreparedTx is a transaction read from the redo log.
+type PreparedTx struct {
+	Dtid    string
+	Queries []string
+	Time    time.Time
+}
+
+// ReadAllRedo returns the transactions of the redo log,
+// split by their state.
+func (tpc *TwoPC) ReadAllRedo(ctx context.Context, conn poolConn) (prepared, failed []*PreparedTx, err error) {
+	qr, err := conn.Exec(ctx, tpc.readAllRedo, 10000, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var curTx *PreparedTx
+	for _, row := range qr.Rows {
+		dtid := row[0].String()
+		if curTx == nil || dtid != curTx.Dtid {
+			st, err := row[1].ParseInt64()
+			if err != nil {
+				return nil, nil, err
+			}
+			tcreated, err := row[2].ParseInt64()
+			if err != nil {
+				return nil, nil, err
+			}
+			curTx = &PreparedTx{
+				Dtid: dtid,
+				Time: time.Unix(0, tcreated),
+			}
+			if st == RedoStatePrepared {
+				prepared = append(prepared, curTx)
+			} else {
+				failed = append(failed, curTx)
+			}
+		}
+		curTx.Queries = append(curTx.Queries, row[3].String())
+	}
+	return prepared, failed, nil
+}
+
+// CreateTransaction saves the metadata of a distributed
+// transaction in the PREPARE state.
+func (tpc *TwoPC) CreateTransaction(ctx context.Context, conn *TxConnection, dtid string, participants []*querypb.Target) error {
+	bindVars := map[string]interface{}{
+		"dtid":         dtid,
+		"state":        int64(querypb.TransactionState_PREPARE),
+		"time_created": time.Now().UnixNano(),
+	}
+	if _, err := tpc.exec(ctx, conn, tpc.insertTransaction, bindVars); err != nil {
+		return err
+	}
+
+	rows := make([][]sqltypes.Value, len(participants))
+	for i, participant := range participants {
+		rows[i] = []sqltypes.Value{
+			sqltypes.MakeString([]byte(dtid)),
+			sqltypes.MakeTrusted(sqltypes.Int64, strconv.AppendInt(nil, int64(i+1), 10)),
+			sqltypes.MakeString([]byte(participant.Keyspace)),
+			sqltypes.MakeString([]byte(participant.Shard)),
+		}
+	}
+	_, err := tpc.exec(ctx, conn, tpc.insertParticipants, map[string]interface{}{"vals": rows})
+	return err
+}
+
+// Transition moves a distributed transaction from the PREPARE
+// state to the specified state. It fails if the transaction is
+// no longer in the PREPARE state.
+func (tpc *TwoPC) Transition(ctx context.Context, conn *TxConnection, dtid string, state querypb.TransactionState) error {
+	bindVars := map[string]interface{}{
+		"dtid":    dtid,
+		"state":   int64(state),
+		"prepare": int64(querypb.TransactionState_PREPARE),
+	}
+	qr, err := tpc.exec(ctx, conn, tpc.transition, bindVars)
+	if err != nil {
+		return err
+	}
+	if qr.RowsAffected != 1 {
+		return NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "could not transition to %v: %s", state, dtid)
+	}
+	return nil
+}
+
+// DeleteTransaction deletes the metadata of a distributed transaction.
+func (tpc *TwoPC) DeleteTransaction(ctx context.Context, conn *TxConnection, dtid string) error {
+	bindVars := map[string]interface{}{
+		"dtid": dtid,
+	}
+	if _, err := tpc.exec(ctx, conn, tpc.deleteTransaction, bindVars); err != nil {
+		return err
+	}
+	_, err := tpc.exec(ctx, conn, tpc.deleteParticipants, bindVars)
+	return err
+}
+
+// ReadTransaction returns the metadata of a distributed transaction.
+// It returns an empty TransactionMetadata if it's not found.
+func (tpc *TwoPC) ReadTransaction(ctx context.Context, conn poolConn, dtid string) (*querypb.TransactionMetadata, error) {
+	result := &querypb.TransactionMetadata{}
+	bindVars := map[string]interface{}{
+		"dtid": dtid,
+	}
+	qr, err := tpc.exec(ctx, conn, tpc.readTransaction, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	if len(qr.Rows) == 0 {
+		return result, nil
+	}
+	result.Dtid = qr.Rows[0][0].String()
+	st, err := qr.Rows[0][1].ParseInt64()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := querypb.TransactionState_name[int32(st)]; !ok {
+		return nil, NewTabletError(ErrFatal, vtrpcpb.ErrorCode_INTERNAL_ERROR, "unexpected state for dtid %s: %v", dtid, st)
+	}
+	result.State = querypb.TransactionState(st)
+	if result.TimeCreated, err = qr.Rows[0][2].ParseInt64(); err != nil {
+		return nil, err
+	}
+
+	qr, err = tpc.exec(ctx, conn, tpc.readParticipants, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range qr.Rows {
+		result.Participants = append(result.Participants, &querypb.Target{
+			Keyspace:   row[0].String(),
+			Shard:      row[1].String(),
+			TabletType: topodatapb.TabletType_MASTER,
+		})
+	}
+	return result, nil
+}
+
+// ReadAbandoned returns the dtids of the distributed transactions
+// that were created before abandonTime.
+func (tpc *TwoPC) ReadAbandoned(ctx context.Context, conn poolConn, abandonTime time.Time) ([]string, error) {
+	bindVars := map[string]interface{}{
+		"time_created": abandonTime.UnixNano(),
+	}
+	qr, err := tpc.exec(ctx, conn, tpc.readAbandoned, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	dtids := make([]string, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		dtids = append(dtids, row[0].String())
+	}
+	return dtids, nil
+}
+
+func (tpc *TwoPC) exec(ctx context.Context, conn poolConn, pq *sqlparser.ParsedQuery, bindVars map[string]interface{}) (*sqltypes.Result, error) {
+	b, err := pq.GenerateQuery(bindVars)
+	if err != nil {
+		return nil, NewTabletError(ErrFail, vtrpcpb.ErrorCode_BAD_INPUT, "%s", err)
+	}
+	return conn.Exec(ctx, string(b), 10000, false)
+}
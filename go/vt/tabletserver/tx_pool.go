@@ -177,6 +177,13 @@ func (axp *TxPool) SafeCommit(ctx context.Context, transactionID int64) (invalid
 	defer handleError(&err, nil, axp.queryServiceStats)
 
 	conn := axp.Get(transactionID)
+	return axp.SafeCommitConn(ctx, conn)
+}
+
+// SafeCommitConn commits the transaction of conn, which doesn't have
+// to be active. Like SafeCommit, it returns an error on failure and
+// frees the connection.
+func (axp *TxPool) SafeCommitConn(ctx context.Context, conn *TxConnection) (invalidList map[string]DirtyKeys, err error) {
 	defer conn.discard(TxCommit)
 	// Assign this upfront to make sure we always return the invalidList.
 	invalidList = conn.dirtyTables
@@ -191,6 +198,23 @@ func (axp *TxPool) SafeCommit(ctx context.Context, transactionID int64) (invalid
 // Rollback rolls back the specified transaction.
 func (axp *TxPool) Rollback(ctx context.Context, transactionID int64) {
 	conn := axp.Get(transactionID)
+	axp.RollbackConn(ctx, conn)
+}
+
+// RollbackIfActive rolls back the specified transaction if it's still
+// active. It's used when the transaction may have already been
+// concluded by a two-phase commit.
+func (axp *TxPool) RollbackIfActive(ctx context.Context, transactionID int64) {
+	v, err := axp.activePool.Get(transactionID, "for rollback")
+	if err != nil {
+		return
+	}
+	axp.RollbackConn(ctx, v.(*TxConnection))
+}
+
+// RollbackConn rolls back the transaction of conn, which doesn't
+// have to be active.
+func (axp *TxPool) RollbackConn(ctx context.Context, conn *TxConnection) {
 	defer conn.discard(TxRollback)
 	axp.txStats.Add("Aborted", time.Now().Sub(conn.StartTime))
 	if _, err := conn.Exec(ctx, "rollback", 1, false); err != nil {
@@ -199,6 +223,16 @@ func (axp *TxPool) Rollback(ctx context.Context, transactionID int64) {
 	}
 }
 
+// Detach removes the specified transaction from the active pool, and
+// returns its connection. The transaction is then no longer subject
+// to the transaction timeout, and isn't waited for by WaitForEmpty.
+// It must be concluded with SafeCommitConn or RollbackConn.
+func (axp *TxPool) Detach(transactionID int64) *TxConnection {
+	conn := axp.Get(transactionID)
+	axp.activePool.Unregister(transactionID)
+	return conn
+}
+
 // Get fetches the connection associated to the transactionID.
 // You must call Recycle on TxConnection once done.
 func (axp *TxPool) Get(transactionID int64) (conn *TxConnection) {
@@ -248,6 +282,7 @@ type TxConnection struct {
 	EndTime           time.Time
 	dirtyTables       map[string]DirtyKeys
 	Queries           []string
+	statements        []string
 	Conclusion        string
 	LogToFile         sync2.AtomicInt32
 	ImmediateCallerID *querypb.VTGateCallerID
@@ -306,6 +341,13 @@ func (txc *TxConnection) RecordQuery(query string) {
 	txc.Queries = append(txc.Queries, query)
 }
 
+// RecordStatement records a statement that changed data in this
+// transaction. The statements are saved in the redo log if the
+// transaction gets prepared.
+func (txc *TxConnection) RecordStatement(statement string) {
+	txc.statements = append(txc.statements, statement)
+}
+
 func (txc *TxConnection) discard(conclusion string) {
 	txc.Conclusion = conclusion
 	txc.EndTime = time.Now()
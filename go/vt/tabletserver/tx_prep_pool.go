@@ -0,0 +1,124 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	errPrepCommitting = errors.New("committing")
+	errPrepFailed     = errors.New("failed")
+)
+
+// TxPreparedPool holds the connections of the prepared
+// transactions, keyed by their dtid. A prepared transaction
+// is no longer in the TxPool, which means that it's not
+// subject to the transaction timeout.
+type TxPreparedPool struct {
+	mu    sync.Mutex
+	conns map[string]*TxConnection
+	// reserved contains the dtids of the transactions that are
+	// being committed, or whose commit failed.
+	reserved map[string]error
+	capacity int
+}
+
+// NewTxPreparedPool creates a new TxPreparedPool.
+func NewTxPreparedPool(capacity int) *TxPreparedPool {
+	if capacity < 0 {
+		// If capacity is 0 all prepares will fail.
+		capacity = 0
+	}
+	return &TxPreparedPool{
+		conns:    make(map[string]*TxConnection, capacity),
+		reserved: make(map[string]error),
+		capacity: capacity,
+	}
+}
+
+// Put adds the connection to the pool. It returns an error
+// if the pool is full or if the dtid is already in use.
+func (pp *TxPreparedPool) Put(c *TxConnection, dtid string) error {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	if _, ok := pp.reserved[dtid]; ok {
+		return errors.New("duplicate DTID in Prepare: " + dtid)
+	}
+	if _, ok := pp.conns[dtid]; ok {
+		return errors.New("duplicate DTID in Prepare: " + dtid)
+	}
+	if len(pp.conns) >= pp.capacity {
+		return fmt.Errorf("prepared transactions exceeded limit: %d", pp.capacity)
+	}
+	pp.conns[dtid] = c
+	return nil
+}
+
+// FetchForRollback removes the connection from the pool and
+// returns it. It returns nil if the dtid is not found. If the
+// dtid is reserved, which means that a commit failed, the
+// reservation is dropped, and nil is returned.
+func (pp *TxPreparedPool) FetchForRollback(dtid string) *TxConnection {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	if _, ok := pp.reserved[dtid]; ok {
+		delete(pp.reserved, dtid)
+		return nil
+	}
+	c := pp.conns[dtid]
+	delete(pp.conns, dtid)
+	return c
+}
+
+// FetchForCommit removes the connection from the pool and
+// returns it, reserving its dtid as committing. It returns
+// an error if the dtid is already reserved, and nil if the
+// dtid is not found. Once the commit is done, Forget must be
+// called if it succeeded, or SetFailed if it didn't.
+func (pp *TxPreparedPool) FetchForCommit(dtid string) (*TxConnection, error) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	if err, ok := pp.reserved[dtid]; ok {
+		return nil, err
+	}
+	c, ok := pp.conns[dtid]
+	if ok {
+		delete(pp.conns, dtid)
+		pp.reserved[dtid] = errPrepCommitting
+	}
+	return c, nil
+}
+
+// SetFailed marks the reserved dtid as failed. Later commits of
+// the dtid fail, but a rollback drops the reservation.
+func (pp *TxPreparedPool) SetFailed(dtid string) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	pp.reserved[dtid] = errPrepFailed
+}
+
+// Forget drops the reservation of the dtid.
+func (pp *TxPreparedPool) Forget(dtid string) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	delete(pp.reserved, dtid)
+}
+
+// FetchAll removes all the connections from the pool and
+// returns them. The reservations are dropped too.
+func (pp *TxPreparedPool) FetchAll() []*TxConnection {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	conns := make([]*TxConnection, 0, len(pp.conns))
+	for _, c := range pp.conns {
+		conns = append(conns, c)
+	}
+	pp.conns = make(map[string]*TxConnection, pp.capacity)
+	pp.reserved = make(map[string]error)
+	return conns
+}
@@ -0,0 +1,118 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"testing"
+)
+
+func TestPrepPut(t *testing.T) {
+	pp := NewTxPreparedPool(2)
+	err := pp.Put(nil, "aa")
+	if err != nil {
+		t.Error(err)
+	}
+	err = pp.Put(nil, "bb")
+	if err != nil {
+		t.Error(err)
+	}
+	want := "prepared transactions exceeded limit: 2"
+	err = pp.Put(nil, "cc")
+	if err == nil || err.Error() != want {
+		t.Errorf("Put err: %v, want %s", err, want)
+	}
+	err = pp.Put(nil, "aa")
+	want = "duplicate DTID in Prepare: aa"
+	if err == nil || err.Error() != want {
+		t.Errorf("Put err: %v, want %s", err, want)
+	}
+	_, err = pp.FetchForCommit("aa")
+	if err != nil {
+		t.Error(err)
+	}
+	err = pp.Put(nil, "aa")
+	if err == nil || err.Error() != want {
+		t.Errorf("Put err: %v, want %s", err, want)
+	}
+	pp.Forget("aa")
+	err = pp.Put(nil, "aa")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrepFetchForRollback(t *testing.T) {
+	pp := NewTxPreparedPool(2)
+	conn := &TxConnection{}
+	pp.Put(conn, "aa")
+	got := pp.FetchForRollback("bb")
+	if got != nil {
+		t.Errorf("Get(bb): %v, want nil", got)
+	}
+	got = pp.FetchForRollback("aa")
+	if got != conn {
+		t.Errorf("pp.Get(aa): %p, want %p", got, conn)
+	}
+	got = pp.FetchForRollback("aa")
+	if got != nil {
+		t.Errorf("Get(aa): %v, want nil", got)
+	}
+}
+
+func TestPrepFetchForCommit(t *testing.T) {
+	pp := NewTxPreparedPool(2)
+	conn := &TxConnection{}
+	got, err := pp.FetchForCommit("aa")
+	if err != nil || got != nil {
+		t.Errorf("Get(aa): %v, %v, want nil, nil", got, err)
+	}
+	pp.Put(conn, "aa")
+	got, err = pp.FetchForCommit("aa")
+	if err != nil || got != conn {
+		t.Errorf("pp.Get(aa): %p, %v, want %p, nil", got, err, conn)
+	}
+	_, err = pp.FetchForCommit("aa")
+	want := "committing"
+	if err == nil || err.Error() != want {
+		t.Errorf("FetchForCommit err: %v, want %s", err, want)
+	}
+	pp.SetFailed("aa")
+	_, err = pp.FetchForCommit("aa")
+	want = "failed"
+	if err == nil || err.Error() != want {
+		t.Errorf("FetchForCommit err: %v, want %s", err, want)
+	}
+	// A rollback drops the failed reservation.
+	if got := pp.FetchForRollback("aa"); got != nil {
+		t.Errorf("FetchForRollback(aa): %v, want nil", got)
+	}
+	got, err = pp.FetchForCommit("aa")
+	if err != nil || got != nil {
+		t.Errorf("Get(aa): %v, %v, want nil, nil", got, err)
+	}
+	pp.Put(conn, "bb")
+	pp.FetchForCommit("bb")
+	pp.Forget("bb")
+	got, err = pp.FetchForCommit("bb")
+	if err != nil || got != nil {
+		t.Errorf("Get(bb): %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestPrepFetchAll(t *testing.T) {
+	pp := NewTxPreparedPool(2)
+	conn1 := &TxConnection{}
+	conn2 := &TxConnection{}
+	pp.Put(conn1, "aa")
+	pp.Put(conn2, "bb")
+	pp.FetchForCommit("bb")
+	got := pp.FetchAll()
+	if len(got) != 1 || got[0] != conn1 {
+		t.Errorf("FetchAll: %v, want [%p]", got, conn1)
+	}
+	if len(pp.conns) != 0 || len(pp.reserved) != 0 {
+		t.Errorf("pp is not empty: %v, %v", pp.conns, pp.reserved)
+	}
+}
@@ -802,20 +802,26 @@ func (tee *Tee) UnlockShardForAction(ctx context.Context, keyspace, shard, lockP
 }
 
 // SaveVSchema is part of the topo.Server interface
-func (tee *Tee) SaveVSchema(ctx context.Context, contents string) error {
-	err := tee.primary.SaveVSchema(ctx, contents)
+func (tee *Tee) SaveVSchema(ctx context.Context, keyspace, contents string) error {
+	err := tee.primary.SaveVSchema(ctx, keyspace, contents)
 	if err != nil {
 		return err
 	}
 
-	if err := tee.secondary.SaveVSchema(ctx, contents); err != nil {
+	if err := tee.secondary.SaveVSchema(ctx, keyspace, contents); err != nil {
 		// not critical enough to fail
-		log.Warningf("secondary.SaveVSchema() failed: %v", err)
+		log.Warningf("secondary.SaveVSchema(%v) failed: %v", keyspace, err)
 	}
 	return err
 }
 
 // GetVSchema is part of the topo.Server interface
-func (tee *Tee) GetVSchema(ctx context.Context) (string, error) {
-	return tee.readFrom.GetVSchema(ctx)
+func (tee *Tee) GetVSchema(ctx context.Context, keyspace string) (string, error) {
+	return tee.readFrom.GetVSchema(ctx, keyspace)
+}
+
+// WatchVSchema is part of the topo.Server interface.
+// We only watch for changes on the primary.
+func (tee *Tee) WatchVSchema(ctx context.Context, keyspace string) (<-chan string, chan<- struct{}, error) {
+	return tee.primary.WatchVSchema(ctx, keyspace)
 }
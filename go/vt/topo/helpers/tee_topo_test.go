@@ -78,6 +78,12 @@ func TestWatchSrvKeyspace(t *testing.T) {
 	test.CheckWatchSrvKeyspace(context.Background(), t, ts)
 }
 
+func TestWatchVSchema(t *testing.T) {
+	zktopo.WatchSleepDuration = 2 * time.Millisecond
+	ts := newFakeTeeServer(t)
+	test.CheckWatchVSchema(context.Background(), t, ts)
+}
+
 func TestShardReplication(t *testing.T) {
 	ctx := context.Background()
 	ts := newFakeTeeServer(t)
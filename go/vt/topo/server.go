@@ -311,16 +311,28 @@ type Impl interface {
 	UnlockShardForAction(ctx context.Context, keyspace, shard, lockPath, results string) error
 
 	//
-	// V3 Schema management, global
+	// V3 Schema management, per keyspace
 	//
 
-	// SaveVSchema saves the provided schema in the topo server.
-	SaveVSchema(context.Context, string) error
+	// SaveVSchema saves the JSON vschema of a keyspace in the
+	// topo server. It returns ErrNoNode if the keyspace doesn't exist.
+	SaveVSchema(ctx context.Context, keyspace, vschema string) error
 
-	// GetVSchema retrieves the schema from the topo server.
+	// GetVSchema retrieves the JSON vschema of a keyspace from
+	// the topo server.
 	//
 	// If no schema has been previously saved, it should return "{}"
-	GetVSchema(ctx context.Context) (string, error)
+	GetVSchema(ctx context.Context, keyspace string) (string, error)
+
+	// WatchVSchema returns a channel that receives notifications
+	// every time the schema of the keyspace changes. It should
+	// receive a notification with the initial value fairly quickly
+	// after this is set. If no schema has been saved, the value
+	// is "{}". To stop watching, close the stopWatching channel.
+	// If the underlying topo.Server encounters an error watching the node,
+	// it should retry on a regular basis until it can succeed.
+	// Mutiple notifications with the same contents may be sent.
+	WatchVSchema(ctx context.Context, keyspace string) (notifications <-chan string, stopWatching chan<- struct{}, err error)
 }
 
 // Server is a wrapper type that can have extra methods.
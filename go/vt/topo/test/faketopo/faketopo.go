@@ -230,11 +230,16 @@ func (ft FakeTopo) UnlockShardForAction(ctx context.Context, keyspace, shard, lo
 }
 
 // SaveVSchema implements topo.Server.
-func (ft FakeTopo) SaveVSchema(context.Context, string) error {
+func (ft FakeTopo) SaveVSchema(ctx context.Context, keyspace, vschema string) error {
 	return errNotImplemented
 }
 
 // GetVSchema implements topo.Server.
-func (ft FakeTopo) GetVSchema(ctx context.Context) (string, error) {
+func (ft FakeTopo) GetVSchema(ctx context.Context, keyspace string) (string, error) {
 	return "", errNotImplemented
 }
+
+// WatchVSchema implements topo.Server.
+func (ft FakeTopo) WatchVSchema(ctx context.Context, keyspace string) (<-chan string, chan<- struct{}, error) {
+	return nil, nil, errNotImplemented
+}
@@ -10,11 +10,23 @@ import (
 
 	"github.com/youtube/vitess/go/vt/topo"
 	"golang.org/x/net/context"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 )
 
 // CheckVSchema runs the tests on the VSchema part of the API
 func CheckVSchema(ctx context.Context, t *testing.T, ts topo.Impl) {
-	got, err := ts.GetVSchema(ctx)
+	if err := ts.SaveVSchema(ctx, "test_keyspace", `{ "Sharded": false}`); err != topo.ErrNoNode {
+		t.Errorf("SaveVSchema(missing keyspace): %v, want %v", err, topo.ErrNoNode)
+	}
+	if err := ts.CreateKeyspace(ctx, "test_keyspace", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace: %v", err)
+	}
+	if err := ts.CreateKeyspace(ctx, "test_keyspace2", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace: %v", err)
+	}
+
+	got, err := ts.GetVSchema(ctx, "test_keyspace")
 	if err != nil {
 		t.Error(err)
 	}
@@ -23,37 +35,128 @@ func CheckVSchema(ctx context.Context, t *testing.T, ts topo.Impl) {
 		t.Errorf("GetVSchema: %s, want %s", got, want)
 	}
 
-	err = ts.SaveVSchema(ctx, `{ "Keyspaces": {}}`)
+	err = ts.SaveVSchema(ctx, "test_keyspace", `{ "Sharded": false}`)
 	if err != nil {
 		t.Error(err)
 	}
 
-	got, err = ts.GetVSchema(ctx)
+	got, err = ts.GetVSchema(ctx, "test_keyspace")
 	if err != nil {
 		t.Error(err)
 	}
-	want = `{ "Keyspaces": {}}`
+	want = `{ "Sharded": false}`
 	if got != want {
 		t.Errorf("GetVSchema: %s, want %s", got, want)
 	}
 
-	err = ts.SaveVSchema(ctx, `{ "Keyspaces": { "aa": { "Sharded": false}}}`)
+	err = ts.SaveVSchema(ctx, "test_keyspace", `{ "Sharded": false, "Tables": { "t1": ""}}`)
 	if err != nil {
 		t.Error(err)
 	}
 
-	got, err = ts.GetVSchema(ctx)
+	got, err = ts.GetVSchema(ctx, "test_keyspace")
 	if err != nil {
 		t.Error(err)
 	}
-	want = `{ "Keyspaces": { "aa": { "Sharded": false}}}`
+	want = `{ "Sharded": false, "Tables": { "t1": ""}}`
 	if got != want {
 		t.Errorf("GetVSchema: %s, want %s", got, want)
 	}
 
-	err = ts.SaveVSchema(ctx, "invalid")
+	// the vschema of the other keyspace is unaffected
+	got, err = ts.GetVSchema(ctx, "test_keyspace2")
+	if err != nil {
+		t.Error(err)
+	}
+	want = "{}"
+	if got != want {
+		t.Errorf("GetVSchema(test_keyspace2): %s, want %s", got, want)
+	}
+
+	err = ts.SaveVSchema(ctx, "test_keyspace", "invalid")
 	want = "Unmarshal failed:"
 	if err == nil || !strings.HasPrefix(err.Error(), want) {
 		t.Errorf("SaveVSchema: %v, must start with %s", err, want)
 	}
+
+	// the vschema goes away with the keyspace
+	if err := ts.DeleteKeyspace(ctx, "test_keyspace"); err != nil {
+		t.Fatalf("DeleteKeyspace: %v", err)
+	}
+	got, err = ts.GetVSchema(ctx, "test_keyspace")
+	if err != nil {
+		t.Error(err)
+	}
+	want = "{}"
+	if got != want {
+		t.Errorf("GetVSchema(deleted keyspace): %s, want %s", got, want)
+	}
+}
+
+// CheckWatchVSchema makes sure WatchVSchema works as expected
+func CheckWatchVSchema(ctx context.Context, t *testing.T, ts topo.Impl) {
+	if err := ts.CreateKeyspace(ctx, "test_keyspace", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace: %v", err)
+	}
+
+	// start watching, should get the empty schema first
+	notifications, stopWatching, err := ts.WatchVSchema(ctx, "test_keyspace")
+	if err != nil {
+		t.Fatalf("WatchVSchema failed: %v", err)
+	}
+	vschema, ok := <-notifications
+	if !ok || vschema != "{}" {
+		t.Fatalf("first value is wrong: %v %v", vschema, ok)
+	}
+
+	// save the schema, should get a notification
+	want := `{ "Sharded": false}`
+	if err := ts.SaveVSchema(ctx, "test_keyspace", want); err != nil {
+		t.Fatalf("SaveVSchema failed: %v", err)
+	}
+	for {
+		vschema, ok := <-notifications
+		if !ok {
+			t.Fatalf("watch channel is closed???")
+		}
+		if vschema == "{}" {
+			// duplicate notification of the first value, that's OK
+			continue
+		}
+		// non-empty value, that one should be ours
+		if vschema != want {
+			t.Fatalf("value is wrong: got %v expected %v", vschema, want)
+		}
+		break
+	}
+
+	// save it again, a bit different, should get a notification
+	want = `{ "Sharded": false, "Tables": { "t1": ""}}`
+	if err := ts.SaveVSchema(ctx, "test_keyspace", want); err != nil {
+		t.Fatalf("SaveVSchema failed: %v", err)
+	}
+	for {
+		vschema, ok := <-notifications
+		if !ok {
+			t.Fatalf("watch channel is closed???")
+		}
+		if vschema != want {
+			// duplicate notification of the previous value, that's OK
+			continue
+		}
+		break
+	}
+
+	// close the stopWatching channel, should eventually get a closed
+	// notifications channel too
+	close(stopWatching)
+	for {
+		vschema, ok := <-notifications
+		if !ok {
+			break
+		}
+		if vschema != want {
+			t.Fatalf("duplicate notification value is bad: %v", vschema)
+		}
+	}
 }
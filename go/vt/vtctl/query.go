@@ -51,6 +51,11 @@ func init() {
 		commandVtGateSplitQuery,
 		"-server <vtgate> -keyspace <keyspace> [-split_column <split_column>] -split_count <split_count> [-bind_variables <JSON map>] [-connect_timeout <connect timeout>] <sql>",
 		"Executes the SplitQuery computation for the given SQL query with the provided bound variables against the vtgate server (this is the base query for Map-Reduce workloads, and is provided here for debug / test purposes)."})
+	addCommand(queriesGroupName, command{
+		"VtGateExplain",
+		commandVtGateExplain,
+		"-server <vtgate> [-bind_variables <JSON map>] [-connect_timeout <connect timeout>] [-tablet_type <tablet type>] <sql>",
+		"Displays how the vtgate server routes the given SQL query, and the shards it is sent to for the provided bound variables. The query is not executed."})
 
 	// VtTablet commands
 	addCommand(queriesGroupName, command{
@@ -249,6 +254,34 @@ func commandVtGateSplitQuery(ctx context.Context, wr *wrangler.Wrangler, subFlag
 	return printJSON(wr, r)
 }
 
+func commandVtGateExplain(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	server := subFlags.String("server", "", "VtGate server to connect to")
+	bindVariables := newBindvars(subFlags)
+	connectTimeout := subFlags.Duration("connect_timeout", 30*time.Second, "Connection timeout for vtgate client")
+	tabletType := subFlags.String("tablet_type", "master", "tablet type to query")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <sql> argument is required for the VtGateExplain command")
+	}
+	t, err := parseTabletType(*tabletType, []topodatapb.TabletType{topodatapb.TabletType_MASTER, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY})
+	if err != nil {
+		return err
+	}
+
+	vtgateConn, err := vtgateconn.Dial(ctx, *server, *connectTimeout)
+	if err != nil {
+		return fmt.Errorf("error connecting to vtgate '%v': %v", *server, err)
+	}
+	defer vtgateConn.Close()
+	plan, err := vtgateConn.Explain(ctx, subFlags.Arg(0), *bindVariables, t)
+	if err != nil {
+		return fmt.Errorf("Explain failed: %v", err)
+	}
+	return printJSON(wr, plan)
+}
+
 func commandVtTabletExecute(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	transactionID := subFlags.Int("transaction_id", 0, "transaction id to use, if inside a transaction.")
 	bindVariables := newBindvars(subFlags)
@@ -332,11 +332,11 @@ var commands = []commandGroup{
 				"Validates that the master permissions from shard 0 match those of all of the other tablets in the keyspace."},
 
 			command{"GetVSchema", commandGetVSchema,
-				"",
-				"Displays the VTGate routing schema."},
+				"<keyspace>",
+				"Displays the VTGate routing schema of a keyspace."},
 			command{"ApplyVSchema", commandApplyVSchema,
-				"{-vschema=<vschema> || -vschema_file=<vschema file>}",
-				"Applies the VTGate routing schema."},
+				"-keyspace=<keyspace> {-vschema=<vschema> || -vschema_file=<vschema file>}",
+				"Validates and applies the VTGate routing schema of a keyspace."},
 		},
 	},
 	commandGroup{
@@ -1964,10 +1964,10 @@ func commandGetVSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *fla
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
-	if subFlags.NArg() != 0 {
-		return fmt.Errorf("The GetVSchema command does not support any arguments.")
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("The <keyspace> argument is required for the GetVSchema command.")
 	}
-	schema, err := wr.TopoServer().GetVSchema(ctx)
+	schema, err := wr.TopoServer().GetVSchema(ctx, subFlags.Arg(0))
 	if err != nil {
 		return err
 	}
@@ -1976,11 +1976,15 @@ func commandGetVSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *fla
 }
 
 func commandApplyVSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	keyspace := subFlags.String("keyspace", "", "Specifies the keyspace whose VTGate routing schema is applied")
 	vschema := subFlags.String("vschema", "", "Identifies the VTGate routing schema")
 	vschemaFile := subFlags.String("vschema_file", "", "Identifies the VTGate routing schema file")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
+	if *keyspace == "" {
+		return fmt.Errorf("The keyspace flag must be specified when calling the ApplyVSchema command.")
+	}
 	if (*vschema == "") == (*vschemaFile == "") {
 		return fmt.Errorf("Either the vschema or vschemaFile flag must be specified when calling the ApplyVSchema command.")
 	}
@@ -1992,7 +1996,7 @@ func commandApplyVSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *f
 		}
 		s = string(schema)
 	}
-	return wr.TopoServer().SaveVSchema(ctx, s)
+	return wr.ApplyVSchema(ctx, *keyspace, s)
 }
 
 func commandGetSrvKeyspace(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
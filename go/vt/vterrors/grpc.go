@@ -74,7 +74,7 @@ func ErrorCodeToGRPCCode(code vtrpcpb.ErrorCode) codes.Code {
 		return codes.AlreadyExists
 	case vtrpcpb.ErrorCode_PERMISSION_DENIED:
 		return codes.PermissionDenied
-	case vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED:
+	case vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED, vtrpcpb.ErrorCode_QUOTA_EXCEEDED:
 		return codes.ResourceExhausted
 	case vtrpcpb.ErrorCode_QUERY_NOT_SERVED:
 		return codes.FailedPrecondition
@@ -0,0 +1,314 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+// This is a V3 file. Do not intermix with V2.
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+// avgScaleIncrement is the number of decimal places added to
+// the scale of the sum when computing an average. It matches
+// the MySQL default for div_precision_increment.
+const avgScaleIncrement = 4
+
+// aggregateRows combines the partial aggregates of a multi-shard
+// result into one row per group. The columns added by the planner
+// are left in place so they can still be used by ORDER BY. They're
+// removed by truncateColumns.
+func aggregateRows(qr *sqltypes.Result, aggr *planbuilder.Aggregate) error {
+	var rows [][]sqltypes.Value
+	groups := make(map[string]int)
+	for _, row := range qr.Rows {
+		key := groupKey(row, aggr.Keys)
+		i, ok := groups[key]
+		if !ok {
+			groups[key] = len(rows)
+			rows = append(rows, append([]sqltypes.Value(nil), row...))
+			continue
+		}
+		if err := mergeRow(rows[i], row, aggr.Aggregates); err != nil {
+			return err
+		}
+	}
+	if len(rows) == 0 && len(aggr.Keys) == 0 {
+		// Like MySQL, an aggregate without GROUP BY returns
+		// a row even if no shard returned one.
+		rows = append(rows, emptyAggregateRow(len(qr.Fields), aggr))
+	}
+	for _, row := range rows {
+		if err := finalizeRow(row, aggr.Aggregates); err != nil {
+			return err
+		}
+	}
+	qr.Fields = aggregateFields(qr.Fields, aggr.Aggregates)
+	qr.Rows = rows
+	qr.RowsAffected = uint64(len(rows))
+	return nil
+}
+
+// emptyAggregateRow returns the row of an aggregate over no rows:
+// COUNT is 0, and the other columns are NULL. If no shard was
+// queried, there are no fields, and the width of the row is the
+// one of the rewritten select list.
+func emptyAggregateRow(width int, aggr *planbuilder.Aggregate) []sqltypes.Value {
+	if width == 0 {
+		width = aggr.Columns
+		for _, params := range aggr.Aggregates {
+			if params.Index >= width {
+				width = params.Index + 1
+			}
+			if params.Opcode == planbuilder.AggregateAvg && params.CountIndex >= width {
+				width = params.CountIndex + 1
+			}
+		}
+	}
+	row := make([]sqltypes.Value, width)
+	zero := sqltypes.MakeTrusted(sqltypes.Int64, []byte("0"))
+	for _, params := range aggr.Aggregates {
+		switch params.Opcode {
+		case planbuilder.AggregateCount:
+			row[params.Index] = zero
+		case planbuilder.AggregateAvg:
+			row[params.CountIndex] = zero
+		}
+	}
+	return row
+}
+
+// groupKey builds a key that uniquely identifies the values
+// of the grouping columns of a row. Like in MySQL, the text
+// values that are equal for the collation are in the same group.
+func groupKey(row []sqltypes.Value, keys []int) string {
+	var buf bytes.Buffer
+	for _, k := range keys {
+		if row[k].IsNull() {
+			buf.WriteByte(0)
+			continue
+		}
+		key := collationKey(row[k])
+		buf.WriteByte(1)
+		buf.WriteString(strconv.Itoa(len(key)))
+		buf.WriteByte(':')
+		buf.Write(key)
+	}
+	return buf.String()
+}
+
+// mergeRow combines the aggregates of row into acc.
+func mergeRow(acc, row []sqltypes.Value, aggregates []planbuilder.AggregateParams) error {
+	for _, params := range aggregates {
+		var err error
+		switch params.Opcode {
+		case planbuilder.AggregateCount, planbuilder.AggregateSum:
+			acc[params.Index], err = addValues(acc[params.Index], row[params.Index])
+		case planbuilder.AggregateMin, planbuilder.AggregateMax:
+			acc[params.Index], err = pickValue(acc[params.Index], row[params.Index], params.Opcode == planbuilder.AggregateMin)
+		case planbuilder.AggregateAvg:
+			acc[params.Index], err = addValues(acc[params.Index], row[params.Index])
+			if err != nil {
+				break
+			}
+			acc[params.CountIndex], err = addValues(acc[params.CountIndex], row[params.CountIndex])
+		default:
+			err = fmt.Errorf("unexpected aggregate opcode: %v", params.Opcode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finalizeRow computes the aggregates that can only be
+// evaluated once all the rows of a group are merged.
+func finalizeRow(row []sqltypes.Value, aggregates []planbuilder.AggregateParams) error {
+	for _, params := range aggregates {
+		if params.Opcode != planbuilder.AggregateAvg {
+			continue
+		}
+		avg, err := divideValues(row[params.Index], row[params.CountIndex])
+		if err != nil {
+			return err
+		}
+		row[params.Index] = avg
+	}
+	return nil
+}
+
+// aggregateFields returns the fields of the combined result. The
+// SUM columns that were computed for AVG are renamed back to the
+// column names that the caller expects.
+func aggregateFields(fields []*querypb.Field, aggregates []planbuilder.AggregateParams) []*querypb.Field {
+	var newFields []*querypb.Field
+	for _, params := range aggregates {
+		if params.Opcode != planbuilder.AggregateAvg || params.Index >= len(fields) {
+			continue
+		}
+		if newFields == nil {
+			newFields = append([]*querypb.Field(nil), fields...)
+		}
+		field := *newFields[params.Index]
+		field.Name = params.Name
+		if !sqltypes.IsFloat(field.Type) {
+			field.Type = sqltypes.Decimal
+		}
+		newFields[params.Index] = &field
+	}
+	if newFields == nil {
+		return fields
+	}
+	return newFields
+}
+
+// truncateColumns removes the columns that were added to the
+// select list by the planner.
+func truncateColumns(qr *sqltypes.Result, columns int) {
+	if len(qr.Fields) > columns {
+		qr.Fields = qr.Fields[:columns]
+	}
+	for i, row := range qr.Rows {
+		if len(row) > columns {
+			qr.Rows[i] = row[:columns]
+		}
+	}
+}
+
+// addValues adds two partial COUNT or SUM values. NULL values
+// are ignored, like they are by MySQL.
+func addValues(v1, v2 sqltypes.Value) (sqltypes.Value, error) {
+	switch {
+	case v1.IsNull():
+		return v2, nil
+	case v2.IsNull():
+		return v1, nil
+	}
+	switch {
+	case v1.IsSigned() && v2.IsSigned():
+		n1, err := v1.ParseInt64()
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		n2, err := v2.ParseInt64()
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		sum := n1 + n2
+		if (sum > n1) == (n2 > 0) {
+			// Justification: the value is the canonical
+			// representation of the type of v1.
+			return sqltypes.MakeTrusted(v1.Type(), strconv.AppendInt(nil, sum, 10)), nil
+		}
+		// The sum overflowed: fall back to exact arithmetic.
+	case v1.IsUnsigned() && v2.IsUnsigned():
+		u1, err := v1.ParseUint64()
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		u2, err := v2.ParseUint64()
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		if sum := u1 + u2; sum >= u1 {
+			// Justification: the value is the canonical
+			// representation of the type of v1.
+			return sqltypes.MakeTrusted(v1.Type(), strconv.AppendUint(nil, sum, 10)), nil
+		}
+	case v1.IsFloat() || v2.IsFloat():
+		f1, err := v1.ParseFloat64()
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		f2, err := v2.ParseFloat64()
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		return sqltypes.BuildValue(f1 + f2)
+	}
+	r1, scale1, err := parseDecimal(v1)
+	if err != nil {
+		return sqltypes.NULL, err
+	}
+	r2, scale2, err := parseDecimal(v2)
+	if err != nil {
+		return sqltypes.NULL, err
+	}
+	if scale2 > scale1 {
+		scale1 = scale2
+	}
+	// Justification: FloatString returns a valid decimal number.
+	return sqltypes.MakeTrusted(sqltypes.Decimal, []byte(r1.Add(r1, r2).FloatString(scale1))), nil
+}
+
+// divideValues computes the average from the combined sum and count.
+// The average of an empty set is NULL.
+func divideValues(sum, count sqltypes.Value) (sqltypes.Value, error) {
+	if sum.IsNull() || count.IsNull() {
+		return sqltypes.NULL, nil
+	}
+	n, err := count.ParseUint64()
+	if err != nil {
+		return sqltypes.NULL, err
+	}
+	if n == 0 {
+		return sqltypes.NULL, nil
+	}
+	if sum.IsFloat() {
+		f, err := sum.ParseFloat64()
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		return sqltypes.BuildValue(f / float64(n))
+	}
+	r, scale, err := parseDecimal(sum)
+	if err != nil {
+		return sqltypes.NULL, err
+	}
+	r.Quo(r, new(big.Rat).SetInt(new(big.Int).SetUint64(n)))
+	// Justification: FloatString returns a valid decimal number.
+	return sqltypes.MakeTrusted(sqltypes.Decimal, []byte(r.FloatString(scale+avgScaleIncrement))), nil
+}
+
+// parseDecimal parses an exact numeric value and returns
+// it along with the number of digits after the decimal point.
+func parseDecimal(v sqltypes.Value) (*big.Rat, int, error) {
+	r, ok := new(big.Rat).SetString(v.String())
+	if !ok {
+		return nil, 0, fmt.Errorf("could not parse value: %v as a number", v)
+	}
+	scale := 0
+	if i := bytes.IndexByte(v.Raw(), '.'); i != -1 {
+		scale = v.Len() - i - 1
+	}
+	return r, scale, nil
+}
+
+// pickValue returns the smaller of the two values if min is
+// true, or the bigger one otherwise. NULL values are ignored.
+func pickValue(v1, v2 sqltypes.Value, min bool) (sqltypes.Value, error) {
+	switch {
+	case v1.IsNull():
+		return v2, nil
+	case v2.IsNull():
+		return v1, nil
+	}
+	cmp, err := compareValues(v1, v2)
+	if err != nil {
+		return sqltypes.NULL, err
+	}
+	if (cmp > 0) == min {
+		return v2, nil
+	}
+	return v1, nil
+}
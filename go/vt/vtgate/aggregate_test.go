@@ -0,0 +1,204 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+func TestAddValues(t *testing.T) {
+	testcases := []struct {
+		v1, v2 sqltypes.Value
+		want   sqltypes.Value
+	}{{
+		v1:   sqltypes.NULL,
+		v2:   sqltypes.NULL,
+		want: sqltypes.NULL,
+	}, {
+		v1:   sqltypes.NULL,
+		v2:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		want: sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("-3")),
+		want: sqltypes.MakeTrusted(sqltypes.Int64, []byte("-2")),
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("9223372036854775807")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		want: sqltypes.MakeTrusted(sqltypes.Decimal, []byte("9223372036854775808")),
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Uint64, []byte("1")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Uint64, []byte("2")),
+		want: sqltypes.MakeTrusted(sqltypes.Uint64, []byte("3")),
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Float64, []byte("1.5")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		want: sqltypes.MakeTrusted(sqltypes.Float64, []byte("2.5")),
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Decimal, []byte("1.25")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Decimal, []byte("2.1")),
+		want: sqltypes.MakeTrusted(sqltypes.Decimal, []byte("3.35")),
+	}}
+	for _, tcase := range testcases {
+		got, err := addValues(tcase.v1, tcase.v2)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tcase.want) {
+			t.Errorf("addValues(%v, %v): %v, want %v", tcase.v1, tcase.v2, got, tcase.want)
+		}
+	}
+
+	_, err := addValues(sqltypes.MakeString([]byte("a")), sqltypes.MakeString([]byte("b")))
+	want := "could not parse value: a as a number"
+	if err == nil || err.Error() != want {
+		t.Errorf("addValues: %v, want %s", err, want)
+	}
+}
+
+func TestAggregateRows(t *testing.T) {
+	int64Value := func(val string) sqltypes.Value {
+		return sqltypes.MakeTrusted(sqltypes.Int64, []byte(val))
+	}
+	// select a, count(*), min(b), max(b), avg(b) from t group by a
+	// is sent to the shards as
+	// select a, count(*), min(b), max(b), sum(b), count(b) from t group by a
+	qr := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "a", Type: sqltypes.VarChar},
+			{Name: "count(*)", Type: sqltypes.Int64},
+			{Name: "min(b)", Type: sqltypes.Int64},
+			{Name: "max(b)", Type: sqltypes.Int64},
+			{Name: "sum(b)", Type: sqltypes.Decimal},
+			{Name: "count(b)", Type: sqltypes.Int64},
+		},
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeString([]byte("x")), int64Value("2"), int64Value("3"), int64Value("5"), sqltypes.MakeTrusted(sqltypes.Decimal, []byte("8")), int64Value("2")},
+			{sqltypes.NULL, int64Value("1"), sqltypes.NULL, sqltypes.NULL, sqltypes.NULL, int64Value("0")},
+			{sqltypes.MakeString([]byte("x")), int64Value("1"), int64Value("1"), int64Value("1"), sqltypes.MakeTrusted(sqltypes.Decimal, []byte("1")), int64Value("1")},
+			{sqltypes.NULL, int64Value("2"), sqltypes.NULL, sqltypes.NULL, sqltypes.NULL, int64Value("0")},
+		},
+	}
+	aggr := &planbuilder.Aggregate{
+		Keys: []int{0},
+		Aggregates: []planbuilder.AggregateParams{
+			{Opcode: planbuilder.AggregateCount, Index: 1},
+			{Opcode: planbuilder.AggregateMin, Index: 2},
+			{Opcode: planbuilder.AggregateMax, Index: 3},
+			{Opcode: planbuilder.AggregateAvg, Index: 4, CountIndex: 5, Name: "avg(b)"},
+		},
+		Columns: 5,
+	}
+	if err := aggregateRows(qr, aggr); err != nil {
+		t.Fatal(err)
+	}
+	truncateColumns(qr, aggr.Columns)
+	want := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "a", Type: sqltypes.VarChar},
+			{Name: "count(*)", Type: sqltypes.Int64},
+			{Name: "min(b)", Type: sqltypes.Int64},
+			{Name: "max(b)", Type: sqltypes.Int64},
+			{Name: "avg(b)", Type: sqltypes.Decimal},
+		},
+		RowsAffected: 2,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeString([]byte("x")), int64Value("3"), int64Value("1"), int64Value("5"), sqltypes.MakeTrusted(sqltypes.Decimal, []byte("3.0000"))},
+			{sqltypes.NULL, int64Value("3"), sqltypes.NULL, sqltypes.NULL, sqltypes.NULL},
+		},
+	}
+	if !reflect.DeepEqual(qr, want) {
+		t.Errorf("aggregateRows: %+v, want %+v", qr, want)
+	}
+}
+
+func TestAggregateRowsEmpty(t *testing.T) {
+	// select count(*), sum(b), avg(b) from t where id in (null)
+	// is not sent to any shard. Its shard query would be
+	// select count(*), sum(b), sum(b), count(b) from t
+	aggr := &planbuilder.Aggregate{
+		Aggregates: []planbuilder.AggregateParams{
+			{Opcode: planbuilder.AggregateCount, Index: 0},
+			{Opcode: planbuilder.AggregateSum, Index: 1},
+			{Opcode: planbuilder.AggregateAvg, Index: 2, CountIndex: 3, Name: "avg(b)"},
+		},
+		Columns: 3,
+	}
+	qr := &sqltypes.Result{}
+	if err := aggregateRows(qr, aggr); err != nil {
+		t.Fatal(err)
+	}
+	truncateColumns(qr, aggr.Columns)
+	want := &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeTrusted(sqltypes.Int64, []byte("0")), sqltypes.NULL, sqltypes.NULL},
+		},
+	}
+	if !reflect.DeepEqual(qr, want) {
+		t.Errorf("aggregateRows: %+v, want %+v", qr, want)
+	}
+
+	// With GROUP BY, there are no groups.
+	aggr.Keys = []int{4}
+	qr = &sqltypes.Result{}
+	if err := aggregateRows(qr, aggr); err != nil {
+		t.Fatal(err)
+	}
+	if len(qr.Rows) != 0 {
+		t.Errorf("aggregateRows: %v, want no rows", qr.Rows)
+	}
+}
+
+func TestAggregateRowsCollation(t *testing.T) {
+	varChar := func(val string) sqltypes.Value {
+		return sqltypes.MakeTrusted(sqltypes.VarChar, []byte(val))
+	}
+	varBinary := func(val string) sqltypes.Value {
+		return sqltypes.MakeTrusted(sqltypes.VarBinary, []byte(val))
+	}
+	int64Value := func(val string) sqltypes.Value {
+		return sqltypes.MakeTrusted(sqltypes.Int64, []byte(val))
+	}
+	// select a, b, count(*) from t group by a, b
+	qr := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "a", Type: sqltypes.VarChar},
+			{Name: "b", Type: sqltypes.VarBinary},
+			{Name: "count(*)", Type: sqltypes.Int64},
+		},
+		Rows: [][]sqltypes.Value{
+			{varChar("a"), varBinary("b"), int64Value("1")},
+			{varChar("A "), varBinary("b"), int64Value("2")},
+			{varChar("a"), varBinary("B"), int64Value("4")},
+		},
+	}
+	aggr := &planbuilder.Aggregate{
+		Keys: []int{0, 1},
+		Aggregates: []planbuilder.AggregateParams{
+			{Opcode: planbuilder.AggregateCount, Index: 2},
+		},
+		Columns: 3,
+	}
+	if err := aggregateRows(qr, aggr); err != nil {
+		t.Fatal(err)
+	}
+	// The text values are grouped with the collation,
+	// but not the binary ones.
+	want := [][]sqltypes.Value{
+		{varChar("a"), varBinary("b"), int64Value("3")},
+		{varChar("a"), varBinary("B"), int64Value("4")},
+	}
+	if !reflect.DeepEqual(qr.Rows, want) {
+		t.Errorf("aggregateRows: %v, want %v", qr.Rows, want)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/ratelimiter"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/vterrors"
+
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	callerQuotaConfig = flag.String("caller_quota_config", "", "JSON file with the quotas of the callers, keyed by effective caller principal. The requests that exceed them fail with QUOTA_EXCEEDED")
+
+	quotaRejections = stats.NewMultiCounters("VtgateQuotaRejections", []string{"Principal", "Keyspace", "Quota"})
+)
+
+// quotaAllKeyspaces is the Keyspace label of the quotas of a caller
+// that apply to all its requests.
+const quotaAllKeyspaces = "All"
+
+// Quota limits the requests of a caller. Zero values mean no limit.
+type Quota struct {
+	// MaxConcurrent is the max number of requests of the
+	// caller in flight.
+	MaxConcurrent int
+	// MaxQPS is the max number of requests of the caller per second.
+	MaxQPS int
+	// MaxShards is the max number of shards a single request of
+	// the caller is sent to, across all its queries.
+	MaxShards int
+	// Keyspaces has the quotas of the requests of the caller to a
+	// keyspace. They apply in addition to the ones of the caller,
+	// to the requests that name the keyspace, like ExecuteShards,
+	// and to the V3 requests whose plan goes to it.
+	Keyspaces map[string]*Quota
+}
+
+// QuotaConfig is the format of the -caller_quota_config file.
+type QuotaConfig struct {
+	// Default is the quota of each caller that isn't in Callers.
+	// Nil means that these callers have no limit.
+	Default *Quota
+	// Callers maps effective caller principals to their quota.
+	Callers map[string]*Quota
+}
+
+// LoadQuotaConfig reads a QuotaConfig from a JSON file.
+func LoadQuotaConfig(filename string) (*QuotaConfig, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("ReadFile failed: %v %v", filename, err)
+	}
+	config := &QuotaConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("Unmarshal failed: %s %v", data, err)
+	}
+	return config, nil
+}
+
+// quotaLimiter enforces the Quota of a caller, or of a caller
+// in a keyspace.
+type quotaLimiter struct {
+	principal   string
+	keyspace    string
+	quota       *Quota
+	inFlight    sync2.AtomicInt64
+	rateLimiter *ratelimiter.RateLimiter
+	keyspaces   map[string]*quotaLimiter
+}
+
+func newQuotaLimiter(principal, keyspace string, quota *Quota) *quotaLimiter {
+	ql := &quotaLimiter{
+		principal: principal,
+		keyspace:  keyspace,
+		quota:     quota,
+		keyspaces: make(map[string]*quotaLimiter),
+	}
+	if quota.MaxQPS > 0 {
+		ql.rateLimiter = ratelimiter.NewRateLimiter(quota.MaxQPS, time.Second)
+	}
+	for ks, ksQuota := range quota.Keyspaces {
+		ql.keyspaces[ks] = newQuotaLimiter(principal, ks, ksQuota)
+	}
+	return ql
+}
+
+// acquire counts a new request, and returns an error if it
+// exceeds the quota. release must be called after a successful
+// acquire, once the request is done.
+func (ql *quotaLimiter) acquire() error {
+	n := ql.inFlight.Add(1)
+	if 0 < ql.quota.MaxConcurrent && int64(ql.quota.MaxConcurrent) < n {
+		ql.inFlight.Add(-1)
+		return ql.reject("MaxConcurrent", ql.quota.MaxConcurrent)
+	}
+	if ql.rateLimiter != nil && !ql.rateLimiter.Allow() {
+		ql.inFlight.Add(-1)
+		return ql.reject("MaxQPS", ql.quota.MaxQPS)
+	}
+	return nil
+}
+
+func (ql *quotaLimiter) release() {
+	ql.inFlight.Add(-1)
+}
+
+func (ql *quotaLimiter) reject(name string, limit int) error {
+	quotaRejections.Add([]string{ql.principal, ql.keyspace, name}, 1)
+	scope := ""
+	if ql.keyspace != quotaAllKeyspaces {
+		scope = " in keyspace " + ql.keyspace
+	}
+	return vterrors.FromError(
+		vtrpcpb.ErrorCode_QUOTA_EXCEEDED,
+		fmt.Errorf("caller_quota_exceeded: caller %q exceeded its %s quota of %d%s", ql.principal, name, limit, scope),
+	)
+}
+
+// callerQuotas enforces the quotas of a QuotaConfig. The callers
+// without a quota of their own get their own limiter with the
+// default quota the first time they're seen.
+type callerQuotas struct {
+	dflt *Quota
+
+	// mu protects limiters.
+	mu       sync.Mutex
+	limiters map[string]*quotaLimiter
+}
+
+func newCallerQuotas(config *QuotaConfig) *callerQuotas {
+	cq := &callerQuotas{
+		dflt:     config.Default,
+		limiters: make(map[string]*quotaLimiter),
+	}
+	for principal, quota := range config.Callers {
+		cq.limiters[principal] = newQuotaLimiter(principal, quotaAllKeyspaces, quota)
+	}
+	return cq
+}
+
+// initCallerQuotas loads the -caller_quota_config file, and
+// exports the requests in flight of each quota. It returns nil
+// if the flag is not set.
+func initCallerQuotas() *callerQuotas {
+	if *callerQuotaConfig == "" {
+		return nil
+	}
+	config, err := LoadQuotaConfig(*callerQuotaConfig)
+	if err != nil {
+		log.Fatalf("cannot load -caller_quota_config: %v", err)
+	}
+	cq := newCallerQuotas(config)
+	stats.NewMultiCountersFunc("VtgateQuotaInFlight", []string{"Principal", "Keyspace"}, cq.inFlightCounts)
+	return cq
+}
+
+// limiter returns the limiter of principal, or nil if it has
+// no quota.
+func (cq *callerQuotas) limiter(principal string) *quotaLimiter {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	if ql, ok := cq.limiters[principal]; ok {
+		return ql
+	}
+	if cq.dflt == nil {
+		return nil
+	}
+	ql := newQuotaLimiter(principal, quotaAllKeyspaces, cq.dflt)
+	cq.limiters[principal] = ql
+	return ql
+}
+
+// acquire checks the quotas of the effective caller of ctx, for a
+// request to keyspaces (none if the request doesn't name one). If the
+// request is allowed, it returns a context that carries the fan-out
+// limit of the request, and release must be called once the request
+// is done. acquire allows all requests on a nil callerQuotas.
+func (cq *callerQuotas) acquire(ctx context.Context, keyspaces ...string) (context.Context, func(), error) {
+	if cq == nil {
+		return ctx, func() {}, nil
+	}
+	ql := cq.limiter(callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx)))
+	if ql == nil {
+		return ctx, func() {}, nil
+	}
+	if err := ql.acquire(); err != nil {
+		return ctx, nil, err
+	}
+	limiters := []*quotaLimiter{ql}
+	release := func() {
+		for _, l := range limiters {
+			l.release()
+		}
+	}
+	for ks := range unique(keyspaces) {
+		ksl, ok := ql.keyspaces[ks]
+		if !ok {
+			continue
+		}
+		if err := ksl.acquire(); err != nil {
+			release()
+			return ctx, nil, err
+		}
+		limiters = append(limiters, ksl)
+	}
+
+	// The tightest fan-out limit applies.
+	var fanOut *quotaLimiter
+	for _, l := range limiters {
+		if l.quota.MaxShards > 0 && (fanOut == nil || l.quota.MaxShards < fanOut.quota.MaxShards) {
+			fanOut = l
+		}
+	}
+	if fanOut != nil {
+		ctx = context.WithValue(ctx, fanOutKey(0), &requestFanOut{
+			limiter: fanOut,
+			shards:  make(map[string]bool),
+		})
+	}
+	return ctx, release, nil
+}
+
+// inFlightCounts returns the requests in flight of each quota.
+func (cq *callerQuotas) inFlightCounts() map[string]int64 {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	counts := make(map[string]int64)
+	for principal, ql := range cq.limiters {
+		counts[principal+"."+quotaAllKeyspaces] = ql.inFlight.Get()
+		for ks, ksl := range ql.keyspaces {
+			counts[principal+"."+ks] = ksl.inFlight.Get()
+		}
+	}
+	return counts
+}
+
+// fanOutKey is the context key of the requestFanOut of a request.
+type fanOutKey int
+
+// requestFanOut has the shards a request was sent to so far, and
+// the quotaLimiter whose MaxShards applies to them. A request, like
+// a join or a batch, can send several queries to the shards.
+type requestFanOut struct {
+	limiter *quotaLimiter
+
+	// mu protects shards.
+	mu     sync.Mutex
+	shards map[string]bool
+}
+
+// checkFanOut adds the shards of a query of the request of ctx to
+// the ones it was sent to, and returns an error if they exceed the
+// fan-out quota of its caller. The shards are named keyspace/shard.
+func checkFanOut(ctx context.Context, shards []string) error {
+	fo, ok := ctx.Value(fanOutKey(0)).(*requestFanOut)
+	if !ok {
+		return nil
+	}
+	fo.mu.Lock()
+	defer fo.mu.Unlock()
+	count := len(fo.shards)
+	for shard := range unique(shards) {
+		if !fo.shards[shard] {
+			count++
+		}
+	}
+	if count > fo.limiter.quota.MaxShards {
+		return fo.limiter.reject("MaxShards", fo.limiter.quota.MaxShards)
+	}
+	for _, shard := range shards {
+		fo.shards[shard] = true
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/vterrors"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+func callerContext(principal string) context.Context {
+	return callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID(principal, "", ""), nil)
+}
+
+func TestLoadQuotaConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "caller_quota")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString(`{
+		"Default": {"MaxConcurrent": 100},
+		"Callers": {
+			"batch": {"MaxQPS": 10, "MaxShards": 4, "Keyspaces": {"user": {"MaxConcurrent": 2}}}
+		}
+	}`)
+	f.Close()
+	config, err := LoadQuotaConfig(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &QuotaConfig{
+		Default: &Quota{MaxConcurrent: 100},
+		Callers: map[string]*Quota{
+			"batch": {MaxQPS: 10, MaxShards: 4, Keyspaces: map[string]*Quota{"user": {MaxConcurrent: 2}}},
+		},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("LoadQuotaConfig: %+v, want %+v", config, want)
+	}
+}
+
+func TestCallerQuotasConcurrent(t *testing.T) {
+	cq := newCallerQuotas(&QuotaConfig{
+		Callers: map[string]*Quota{
+			"batch":  {MaxConcurrent: 1, Keyspaces: map[string]*Quota{"user": {MaxConcurrent: 1}}},
+			"online": {MaxConcurrent: 2},
+		},
+	})
+	ctx := callerContext("batch")
+	_, release, err := cq.acquire(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejections := quotaRejections.Counts()["batch.All.MaxConcurrent"]
+	_, _, err = cq.acquire(ctx)
+	want := `caller_quota_exceeded: caller "batch" exceeded its MaxConcurrent quota of 1`
+	if err == nil || err.Error() != want {
+		t.Errorf("acquire: %v, want %s", err, want)
+	}
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_QUOTA_EXCEEDED {
+		t.Errorf("error code: %v, want QUOTA_EXCEEDED", code)
+	}
+	if got := quotaRejections.Counts()["batch.All.MaxConcurrent"]; got != rejections+1 {
+		t.Errorf("quotaRejections: %d, want %d", got, rejections+1)
+	}
+	// Other callers have their own quota, and the callers
+	// without a quota are not limited.
+	if _, _, err := cq.acquire(callerContext("online")); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := cq.acquire(callerContext("unknown")); err != nil {
+		t.Error(err)
+	}
+	wantCounts := map[string]int64{"batch.All": 1, "batch.user": 1, "online.All": 1}
+	if got := cq.inFlightCounts(); !reflect.DeepEqual(got, wantCounts) {
+		t.Errorf("inFlightCounts: %v, want %v", got, wantCounts)
+	}
+
+	release()
+	if _, _, err := cq.acquire(ctx); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCallerQuotasKeyspace(t *testing.T) {
+	cq := newCallerQuotas(&QuotaConfig{
+		Default: &Quota{MaxQPS: 100, Keyspaces: map[string]*Quota{"user": {MaxQPS: 1}}},
+	})
+	ctx := callerContext("batch")
+	if _, _, err := cq.acquire(ctx, "user"); err != nil {
+		t.Fatal(err)
+	}
+	want := `caller_quota_exceeded: caller "batch" exceeded its MaxQPS quota of 1 in keyspace user`
+	if _, _, err := cq.acquire(ctx, "user"); err == nil || err.Error() != want {
+		t.Errorf("acquire: %v, want %s", err, want)
+	}
+	// The quota of the keyspace doesn't apply to the other keyspaces,
+	// and each caller has its own default quota.
+	if _, _, err := cq.acquire(ctx, "lookup"); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := cq.acquire(callerContext("other"), "user"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCallerQuotasFanOut(t *testing.T) {
+	sandbox := createSandbox("TestCallerQuotasFanOut")
+	sandbox.MapTestConn("-20", &sandboxConn{})
+	sandbox.MapTestConn("20-40", &sandboxConn{})
+	rpcVTGate.quotas = newCallerQuotas(&QuotaConfig{
+		Callers: map[string]*Quota{
+			"batch": {MaxShards: 4, Keyspaces: map[string]*Quota{"TestCallerQuotasFanOut": {MaxShards: 1}}},
+		},
+	})
+	defer func() { rpcVTGate.quotas = nil }()
+
+	execute := func(principal string) error {
+		_, err := rpcVTGate.ExecuteShards(callerContext(principal),
+			"query",
+			nil,
+			"TestCallerQuotasFanOut",
+			[]string{"-20", "20-40"},
+			topodatapb.TabletType_REPLICA,
+			nil,
+			false)
+		return err
+	}
+	ch := QueryLogger.Subscribe("test")
+	defer QueryLogger.Unsubscribe(ch)
+	err := execute("batch")
+	want := `caller "batch" exceeded its MaxShards quota of 1 in keyspace TestCallerQuotasFanOut`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("ExecuteShards: %v, want %s", err, want)
+	}
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_QUOTA_EXCEEDED {
+		t.Errorf("error code: %v, want QUOTA_EXCEEDED", code)
+	}
+	if logStats := (<-ch).(*LogStats); logStats.Error != err {
+		t.Errorf("logStats.Error: %v, want %v", logStats.Error, err)
+	}
+	if err := execute("online"); err != nil {
+		t.Errorf("ExecuteShards: %v, want nil", err)
+	}
+}
+
+func TestCallerQuotasRejectionStats(t *testing.T) {
+	sandbox := createSandbox(KsTestUnsharded)
+	sandbox.MapTestConn("0", &sandboxConn{})
+	rpcVTGate.quotas = newCallerQuotas(&QuotaConfig{
+		Callers: map[string]*Quota{
+			"batch": {MaxQPS: 1},
+		},
+	})
+	defer func() { rpcVTGate.quotas = nil }()
+	ch := QueryLogger.Subscribe("test")
+	defer QueryLogger.Unsubscribe(ch)
+
+	ctx := callerContext("batch")
+	if _, err := rpcVTGate.Execute(ctx, "select * from t1", nil, topodatapb.TabletType_RDONLY, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+	errors := normalErrors.Counts()["Execute.Any.rdonly"]
+	_, err := rpcVTGate.Execute(ctx, "select * from t1", nil, topodatapb.TabletType_RDONLY, nil, false)
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_QUOTA_EXCEEDED {
+		t.Fatalf("Execute: %v, want QUOTA_EXCEEDED", err)
+	}
+	if logStats := (<-ch).(*LogStats); logStats.Error != err {
+		t.Errorf("logStats.Error: %v, want %v", logStats.Error, err)
+	}
+	if got := normalErrors.Counts()["Execute.Any.rdonly"]; got != errors+1 {
+		t.Errorf("normalErrors: %d, want %d", got, errors+1)
+	}
+}
+
+func TestCallerQuotasV3Keyspace(t *testing.T) {
+	sandbox := createSandbox(KsTestUnsharded)
+	sandbox.MapTestConn("0", &sandboxConn{})
+	rpcVTGate.quotas = newCallerQuotas(&QuotaConfig{
+		Callers: map[string]*Quota{
+			"batch": {Keyspaces: map[string]*Quota{KsTestUnsharded: {MaxQPS: 1}}},
+		},
+	})
+	defer func() { rpcVTGate.quotas = nil }()
+
+	ctx := callerContext("batch")
+	if _, err := rpcVTGate.Execute(ctx, "select * from t1", nil, topodatapb.TabletType_RDONLY, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	rejections := quotaRejections.Counts()["batch.TestUnsharded.MaxQPS"]
+	_, err := rpcVTGate.Execute(ctx, "select * from t1", nil, topodatapb.TabletType_RDONLY, nil, false)
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_QUOTA_EXCEEDED {
+		t.Errorf("Execute: %v, want QUOTA_EXCEEDED", err)
+	}
+	err = rpcVTGate.StreamExecute(ctx, "select * from t1", nil, topodatapb.TabletType_RDONLY, func(*sqltypes.Result) error { return nil })
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_QUOTA_EXCEEDED {
+		t.Errorf("StreamExecute: %v, want QUOTA_EXCEEDED", err)
+	}
+	// Both were rejected by the quota of the keyspace.
+	if got := quotaRejections.Counts()["batch.TestUnsharded.MaxQPS"]; got != rejections+2 {
+		t.Errorf("quotaRejections: %d, want %d", got, rejections+2)
+	}
+}
+
+func TestRouterKeyspaces(t *testing.T) {
+	router, _, _, _ := createRouterEnv()
+	testcases := []struct {
+		sql     string
+		session *vtgatepb.Session
+		want    []string
+	}{{
+		sql:  "select * from user where id = 1",
+		want: []string{"TestRouter"},
+	}, {
+		sql:  "select user.id, user_extra.id from user join user_extra on user.col = user_extra.user_id",
+		want: []string{"TestRouter"},
+	}, {
+		sql:  "select * from user where id in (select music_id from music_user_map)",
+		want: []string{"TestRouter", KsTestUnsharded},
+	}, {
+		sql:     "select * from user where id = 1",
+		session: &vtgatepb.Session{Keyspace: KsTestUnsharded, Shard: "0"},
+		want:    []string{KsTestUnsharded},
+	}, {
+		sql:     "select * from unknown",
+		session: &vtgatepb.Session{Keyspace: KsTestUnsharded},
+		want:    []string{KsTestUnsharded},
+	}, {
+		sql: "select * from unknown",
+	}}
+	for _, tc := range testcases {
+		// The keyspaces can be repeated.
+		if got := router.keyspaces(tc.sql, tc.session); !reflect.DeepEqual(unique(got), unique(tc.want)) {
+			t.Errorf("keyspaces(%q, %v): %v, want %v", tc.sql, tc.session, got, tc.want)
+		}
+	}
+
+	// keyspaces doesn't change the plan cache, nor its hits.
+	if _, ok := router.planner.plans.Peek("select * from unknown"); ok {
+		t.Errorf("keyspaces cached the plan of select * from unknown")
+	}
+	router.planner.GetPlan("select * from user where id = 1")
+	router.keyspaces("select * from user where id = 1", nil)
+	result, _ := router.planner.plans.Peek("select * from user where id = 1")
+	if hits := result.(*cachedPlan).hits.Get(); hits != 0 {
+		t.Errorf("hits: %d, want 0", hits)
+	}
+}
+
+func TestCallerQuotasFanOutPerRequest(t *testing.T) {
+	router, sbc1, _, _ := createRouterEnv()
+	sbc1.setResults([]*sqltypes.Result{joinLeftResult})
+	cq := newCallerQuotas(&QuotaConfig{
+		Callers: map[string]*Quota{
+			"batch": {MaxShards: 1},
+		},
+	})
+	ctx, release, err := cq.acquire(callerContext("batch"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	// Each side of the join is sent to a single shard, but
+	// the request is sent to two.
+	_, err = router.Execute(ctx,
+		"select user.id, user_extra.id from user join user_extra on user.col = user_extra.user_id where user.id = 1",
+		nil,
+		topodatapb.TabletType_MASTER,
+		nil,
+		false)
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_QUOTA_EXCEEDED {
+		t.Errorf("Execute: %v, want QUOTA_EXCEEDED", err)
+	}
+}
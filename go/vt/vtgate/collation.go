@@ -0,0 +1,43 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+// This is a V3 file. Do not intermix with V2.
+
+import (
+	"bytes"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/vtgate/vindexes"
+)
+
+// VTGate doesn't know the collation of the columns it merges,
+// so it assumes the default case-insensitive collation of MySQL
+// for all text columns: like MySQL, the text values are compared
+// regardless of their case, accents and trailing spaces. The
+// collation is the one of the unicode_loose_md5 vindex. Binary
+// values, and text values that are not valid UTF-8, are compared
+// byte-wise.
+
+// compareText compares two text values with the collation.
+func compareText(v1, v2 sqltypes.Value) int {
+	if sqltypes.IsText(v1.Type()) && sqltypes.IsText(v2.Type()) {
+		if cmp, err := vindexes.CollationCompare(v1.Raw(), v2.Raw()); err == nil {
+			return cmp
+		}
+	}
+	return bytes.Compare(v1.Raw(), v2.Raw())
+}
+
+// collationKey returns a key of v such that two values have the
+// same key if and only if they're equal for the collation.
+func collationKey(v sqltypes.Value) []byte {
+	if sqltypes.IsText(v.Type()) {
+		if key, err := vindexes.CollationKey(v.Raw()); err == nil {
+			return key
+		}
+	}
+	return v.Raw()
+}
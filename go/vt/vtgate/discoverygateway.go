@@ -7,7 +7,7 @@ package vtgate
 import (
 	"flag"
 	"fmt"
-	"math/rand"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,6 +22,7 @@ import (
 	"github.com/youtube/vitess/go/vt/topo"
 	"github.com/youtube/vitess/go/vt/vterrors"
 
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 )
@@ -30,6 +31,10 @@ var (
 	cellsToWatch        = flag.String("cells_to_watch", "", "comma-separated list of cells for watching endpoints")
 	refreshInterval     = flag.Duration("endpoint_refresh_interval", 1*time.Minute, "endpoint refresh interval")
 	topoReadConcurrency = flag.Int("topo_read_concurrency", 32, "concurrent topo reads")
+	fallbackCells       = flag.String("fallback_cells", "", "comma-separated list of cells, in order of preference, that serve the non-master requests when no endpoint is healthy in the local cell")
+	errorLatency        = flag.Duration("tablet_picker_error_latency", 1*time.Second, "the latency the tablet picker records for a request that failed because of its tablet, if it took less: the failing tablets get fewer requests")
+
+	crossCellFallbacks = stats.NewMultiCounters("CrossCellFallbacks", []string{"Keyspace", "ShardName", "Cell"})
 )
 
 const (
@@ -46,12 +51,28 @@ func createDiscoveryGateway(hc discovery.HealthCheck, topoServer topo.Server, se
 		topoServer:        topoServer,
 		srvTopoServer:     serv,
 		localCell:         cell,
+		cellPreference:    cellPreference(cell, *fallbackCells),
+		picker:            GetTabletPickerCreator()(),
 		retryCount:        retryCount,
 		tabletTypesToWait: tabletTypesToWait,
 		tabletsWatchers:   make([]*discovery.TopologyWatcher, 0, 1),
 	}
+	if *enableHedging {
+		dg.latencies = newLatencyTracker(*hedgingPercentile, *hedgingSamples)
+	}
+	if *enableMasterBuffer {
+		dg.buffer = newMasterBuffer(*masterBufferWindow, *masterBufferMaxSize)
+	}
 	dg.hc.SetListener(dg)
-	for _, c := range strings.Split(*cellsToWatch, ",") {
+	cells := strings.Split(*cellsToWatch, ",")
+	// The fallback cells are watched too, even if they're
+	// not part of cells_to_watch.
+	for _, c := range dg.cellPreference[1:] {
+		if !containsString(cells, c) {
+			cells = append(cells, c)
+		}
+	}
+	for _, c := range cells {
 		if c == "" {
 			continue
 		}
@@ -70,6 +91,10 @@ type discoveryGateway struct {
 	topoServer        topo.Server
 	srvTopoServer     topo.SrvTopoServer
 	localCell         string
+	cellPreference    []string
+	picker            TabletPicker
+	latencies         *latencyTracker
+	buffer            *masterBuffer
 	retryCount        int
 	tabletTypesToWait []topodatapb.TabletType
 
@@ -99,6 +124,9 @@ func (dg *discoveryGateway) InitializeConnections(ctx context.Context) error {
 
 // Execute executes the non-streaming query for the specified keyspace, shard, and tablet type.
 func (dg *discoveryGateway) Execute(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, query string, bindVars map[string]interface{}, transactionID int64) (qr *sqltypes.Result, err error) {
+	if dg.latencies != nil && transactionID == 0 && tabletType != topodatapb.TabletType_MASTER {
+		return dg.hedgedExecute(ctx, keyspace, shard, tabletType, query, bindVars)
+	}
 	err = dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
 		var innerErr error
 		qr, innerErr = conn.Execute(ctx, query, bindVars, transactionID)
@@ -162,6 +190,65 @@ func (dg *discoveryGateway) Rollback(ctx context.Context, keyspace, shard string
 	}, transactionID, false)
 }
 
+// Prepare prepares the specified transaction for the distributed transaction dtid.
+func (dg *discoveryGateway) Prepare(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, transactionID int64, dtid string) error {
+	return dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+		return conn.Prepare(ctx, transactionID, dtid)
+	}, transactionID, false)
+}
+
+// CommitPrepared commits the prepared transaction of dtid.
+func (dg *discoveryGateway) CommitPrepared(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string) error {
+	return dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+		return conn.CommitPrepared(ctx, dtid)
+	}, 0, false)
+}
+
+// RollbackPrepared rolls back the prepared transaction of dtid, or originalID if it was not prepared.
+func (dg *discoveryGateway) RollbackPrepared(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string, originalID int64) error {
+	return dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+		return conn.RollbackPrepared(ctx, dtid, originalID)
+	}, 0, false)
+}
+
+// CreateTransaction records the distributed transaction dtid on its coordinator.
+func (dg *discoveryGateway) CreateTransaction(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string, participants []*querypb.Target) error {
+	return dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+		return conn.CreateTransaction(ctx, dtid, participants)
+	}, 0, false)
+}
+
+// StartCommit commits the transaction of the coordinator, and marks the distributed transaction dtid for commit.
+func (dg *discoveryGateway) StartCommit(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, transactionID int64, dtid string) error {
+	return dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+		return conn.StartCommit(ctx, transactionID, dtid)
+	}, transactionID, false)
+}
+
+// SetRollback marks the distributed transaction dtid for rollback.
+func (dg *discoveryGateway) SetRollback(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string, transactionID int64) error {
+	return dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+		return conn.SetRollback(ctx, dtid, transactionID)
+	}, 0, false)
+}
+
+// ConcludeTransaction deletes the record of the distributed transaction dtid.
+func (dg *discoveryGateway) ConcludeTransaction(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string) error {
+	return dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+		return conn.ConcludeTransaction(ctx, dtid)
+	}, 0, false)
+}
+
+// ReadTransaction returns the record of the distributed transaction dtid.
+func (dg *discoveryGateway) ReadTransaction(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string) (metadata *querypb.TransactionMetadata, err error) {
+	err = dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+		var innerErr error
+		metadata, innerErr = conn.ReadTransaction(ctx, dtid)
+		return innerErr
+	}, 0, false)
+	return metadata, err
+}
+
 // SplitQuery splits a query into sub-queries for the specified keyspace, shard, and tablet type.
 func (dg *discoveryGateway) SplitQuery(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64) (queries []querytypes.QuerySplit, err error) {
 	err = dg.withRetry(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
@@ -184,7 +271,11 @@ func (dg *discoveryGateway) Close(ctx context.Context) error {
 }
 
 // StatsUpdate receives updates about target and realtime stats changes.
-func (dg *discoveryGateway) StatsUpdate(*discovery.EndPointStats) {
+// They tell the master buffer when the masters stop and start serving.
+func (dg *discoveryGateway) StatsUpdate(eps *discovery.EndPointStats) {
+	if dg.buffer != nil {
+		dg.buffer.StatsUpdate(eps)
+	}
 }
 
 // withRetry gets available connections and executes the action. If there are retryable errors,
@@ -193,47 +284,82 @@ func (dg *discoveryGateway) StatsUpdate(*discovery.EndPointStats) {
 // a resharding event, and set the re-resolve bit and let the upper layers
 // re-resolve and retry.
 func (dg *discoveryGateway) withRetry(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, action func(conn tabletconn.TabletConn) error, transactionID int64, isStreaming bool) error {
+	if dg.buffer == nil || tabletType != topodatapb.TabletType_MASTER || transactionID != 0 {
+		return dg.withRetryAvoiding(ctx, keyspace, shard, tabletType, action, transactionID, isStreaming, newEndPointSet())
+	}
+
+	// Master requests outside of a transaction are held while
+	// the shard fails over, and replayed once it's done, like
+	// in ShardConn.withRetry.
+	if err := dg.buffer.wait(ctx, keyspace, shard); err != nil {
+		return WrapError(err, keyspace, shard, tabletType, nil, false)
+	}
+	failingOver := false
+	err := dg.withRetryAvoiding(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+		err := action(conn)
+		if isFailoverError(err, isStreaming) {
+			failingOver = true
+		}
+		return err
+	}, transactionID, isStreaming, newEndPointSet())
+	if err != nil && failingOver {
+		dg.buffer.startFailover(keyspace, shard)
+		if err := dg.buffer.wait(ctx, keyspace, shard); err != nil {
+			return WrapError(err, keyspace, shard, tabletType, nil, false)
+		}
+		err = dg.withRetryAvoiding(ctx, keyspace, shard, tabletType, action, transactionID, isStreaming, newEndPointSet())
+	}
+	return err
+}
+
+// withRetryAvoiding is like withRetry, but it doesn't use the
+// endpoints of tried, and adds the ones it uses to it.
+func (dg *discoveryGateway) withRetryAvoiding(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, action func(conn tabletconn.TabletConn) error, transactionID int64, isStreaming bool, tried *endPointSet) error {
 	var endPointLastUsed *topodatapb.EndPoint
 	var err error
 	inTransaction := (transactionID != 0)
-	invalidEndPoints := make(map[string]bool)
 
 	for i := 0; i < dg.retryCount+1; i++ {
-		var endPoint *topodatapb.EndPoint
-		endPoints := dg.getEndPoints(keyspace, shard, tabletType)
-		if len(endPoints) == 0 {
+		epsList := dg.getEndPoints(keyspace, shard, tabletType)
+		if len(epsList) == 0 {
 			// fail fast if there is no endpoint
 			err = vterrors.FromError(vtrpcpb.ErrorCode_INTERNAL_ERROR, fmt.Errorf("no valid endpoint"))
 			break
 		}
-		shuffleEndPoints(endPoints)
 
-		// skip endpoints we tried before
-		for _, ep := range endPoints {
-			if _, ok := invalidEndPoints[discovery.EndPointToMapKey(ep)]; !ok {
-				endPoint = ep
-				break
+		// skip endpoints we tried before, they either failed
+		// or are used by the other attempt of a hedged request
+		candidates := make([]*discovery.EndPointStats, 0, len(epsList))
+		for _, eps := range epsList {
+			if !tried.contains(eps.EndPoint) {
+				candidates = append(candidates, eps)
 			}
 		}
-		if endPoint == nil {
+		if len(candidates) == 0 {
 			if err == nil {
 				// do not override error from last attempt.
 				err = vterrors.FromError(vtrpcpb.ErrorCode_INTERNAL_ERROR, fmt.Errorf("no available connection"))
 			}
 			break
 		}
+		endPoint := dg.picker.Pick(candidates).EndPoint
+		tried.add(endPoint)
 
 		// execute
 		endPointLastUsed = endPoint
 		conn := dg.hc.GetConnection(endPoint)
 		if conn == nil {
 			err = vterrors.FromError(vtrpcpb.ErrorCode_INTERNAL_ERROR, fmt.Errorf("no connection for %+v", endPoint))
-			invalidEndPoints[discovery.EndPointToMapKey(endPoint)] = true
 			continue
 		}
+		startTime := time.Now()
 		err = action(conn)
+		if !isStreaming {
+			// The latency of a streaming query depends on
+			// the size of its results, it's not recorded.
+			dg.recordLatency(ctx, endPoint, time.Now().Sub(startTime), err)
+		}
 		if dg.canRetry(ctx, err, transactionID, isStreaming) {
-			invalidEndPoints[discovery.EndPointToMapKey(endPoint)] = true
 			continue
 		}
 		break
@@ -241,6 +367,21 @@ func (dg *discoveryGateway) withRetry(ctx context.Context, keyspace, shard strin
 	return WrapError(err, keyspace, shard, tabletType, endPointLastUsed, inTransaction)
 }
 
+// recordLatency records the latency of a request in the tablet
+// picker. A request that failed because of its tablet counts as
+// taking at least -tablet_picker_error_latency, as a tablet that
+// fails fast would otherwise look fast. The errors of the query
+// itself, and the requests canceled by the caller, count as is.
+func (dg *discoveryGateway) recordLatency(ctx context.Context, endPoint *topodatapb.EndPoint, latency time.Duration, err error) {
+	if err != nil && ctx.Err() == nil && latency < *errorLatency {
+		serverError, ok := err.(*tabletconn.ServerError)
+		if !ok || (serverError.Code != tabletconn.ERR_NORMAL && serverError.Code != tabletconn.ERR_NOT_IN_TX) {
+			latency = *errorLatency
+		}
+	}
+	dg.picker.RecordLatency(endPoint, latency)
+}
+
 // canRetry determines whether a query can be retried or not.
 // OperationalErrors like retry/fatal are retryable if query is not in a txn.
 // All other errors are non-retryable.
@@ -279,57 +420,110 @@ func (dg *discoveryGateway) canRetry(ctx context.Context, err error, transaction
 	return false
 }
 
-func shuffleEndPoints(endPoints []*topodatapb.EndPoint) {
-	index := 0
-	length := len(endPoints)
-	for i := length - 1; i > 0; i-- {
-		index = rand.Intn(i + 1)
-		endPoints[i], endPoints[index] = endPoints[index], endPoints[i]
-	}
-}
-
 // getEndPoints gets all available endpoints from HealthCheck,
 // and selects the usable ones based several rules:
 // master - return one from any cells with latest reparent timestamp;
-// replica - return all from local cell.
+// replica - return all from the first cell of the cell preference
+// list that has healthy ones, starting with the local cell.
 // TODO(liang): select replica by replication lag.
-func (dg *discoveryGateway) getEndPoints(keyspace, shard string, tabletType topodatapb.TabletType) []*topodatapb.EndPoint {
+func (dg *discoveryGateway) getEndPoints(keyspace, shard string, tabletType topodatapb.TabletType) []*discovery.EndPointStats {
 	epsList := dg.hc.GetEndPointStatsFromTarget(keyspace, shard, tabletType)
 	// for master, use any cells and return the one with max reparent timestamp.
 	if tabletType == topodatapb.TabletType_MASTER {
 		var maxTimestamp int64
-		var ep *topodatapb.EndPoint
+		var master *discovery.EndPointStats
 		for _, eps := range epsList {
 			if eps.LastError != nil || !eps.Serving {
 				continue
 			}
 			if eps.TabletExternallyReparentedTimestamp >= maxTimestamp {
 				maxTimestamp = eps.TabletExternallyReparentedTimestamp
-				ep = eps.EndPoint
+				master = eps
 			}
 		}
-		if ep == nil {
+		if master == nil {
 			return nil
 		}
-		return []*topodatapb.EndPoint{ep}
+		return []*discovery.EndPointStats{master}
 	}
-	// for non-master, use only endpoints from local cell and filter by replication lag.
-	list := make([]*discovery.EndPointStats, 0, len(epsList))
-	for _, eps := range epsList {
-		if eps.LastError != nil || !eps.Serving {
+	// for non-master, use the endpoints from the first cell that has
+	// healthy ones, and filter them by replication lag.
+	cellHealth := discovery.AggregateByCell(epsList)
+	for _, cell := range dg.cellPreference {
+		if ch, ok := cellHealth[cell]; !ok || ch.Healthy == 0 {
 			continue
 		}
-		if dg.localCell != eps.Cell {
+		list := make([]*discovery.EndPointStats, 0, len(epsList))
+		for _, eps := range epsList {
+			if eps.LastError != nil || !eps.Serving {
+				continue
+			}
+			if cell != eps.Cell {
+				continue
+			}
+			list = append(list, eps)
+		}
+		list = discovery.FilterByReplicationLag(list)
+		if len(list) == 0 {
 			continue
 		}
-		list = append(list, eps)
+		if cell != dg.localCell {
+			crossCellFallbacks.Add([]string{keyspace, shard, cell}, 1)
+		}
+		return list
 	}
-	list = discovery.FilterByReplicationLag(list)
-	epList := make([]*topodatapb.EndPoint, 0, len(list))
-	for _, eps := range list {
-		epList = append(epList, eps.EndPoint)
+	return nil
+}
+
+// tabletWeights returns the current weights of the non-master
+// tablets, as seen by the tablet picker.
+func (dg *discoveryGateway) tabletWeights() []*TabletWeight {
+	var res []*TabletWeight
+	for _, epcs := range dg.hc.CacheStatus() {
+		if epcs.Target.TabletType == topodatapb.TabletType_MASTER {
+			continue
+		}
+		sort.Sort(epcs.EndPointsStats)
+		for _, eps := range epcs.EndPointsStats {
+			tw := &TabletWeight{
+				Cell:     eps.Cell,
+				Name:     eps.Name,
+				Target:   eps.Target,
+				EndPoint: eps.EndPoint,
+				Latency:  dg.picker.Latency(eps.EndPoint),
+				Weight:   dg.picker.Weight(eps),
+			}
+			if eps.Stats != nil {
+				tw.CPUUsage = eps.Stats.CpuUsage
+				tw.SecondsBehindMaster = eps.Stats.SecondsBehindMaster
+			}
+			res = append(res, tw)
+		}
 	}
-	return epList
+	return res
+}
+
+// cellPreference returns the cells where the non-master requests
+// go, in order of preference: the local cell first, then the
+// comma-separated fallback cells.
+func cellPreference(localCell, fallbackCells string) []string {
+	cells := []string{localCell}
+	for _, c := range strings.Split(fallbackCells, ",") {
+		if c == "" || containsString(cells, c) {
+			continue
+		}
+		cells = append(cells, c)
+	}
+	return cells
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
 }
 
 // WrapError returns ShardConnError which preserves the original error code if possible,
@@ -2,6 +2,8 @@ package vtgate
 
 import (
 	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -82,7 +84,30 @@ func TestDiscoveryGatewayGetEndPoints(t *testing.T) {
 	hc.addTestEndPoint("remote", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
 	ep1 := hc.addTestEndPoint("local", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
 	eps := dg.getEndPoints(keyspace, shard, topodatapb.TabletType_REPLICA)
-	if len(eps) != 1 || !topo.EndPointEquality(eps[0], ep1) {
+	if len(eps) != 1 || !topo.EndPointEquality(eps[0].EndPoint, ep1) {
+		t.Errorf("want %+v, got %+v", ep1, eps)
+	}
+
+	// replica should fall back to the first cell with healthy ones
+	dg.cellPreference = cellPreference("local", "remote1,remote2")
+	hc.Reset()
+	hc.addTestEndPoint("local", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, false, 10, nil, nil)
+	hc.addTestEndPoint("remote1", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, fmt.Errorf("no connection"), nil)
+	ep1 = hc.addTestEndPoint("remote2", "3.3.3.3", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	hc.addTestEndPoint("remote3", "4.4.4.4", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	fallbacks := crossCellFallbacks.Counts()["ks.0.remote2"]
+	eps = dg.getEndPoints(keyspace, shard, topodatapb.TabletType_REPLICA)
+	if len(eps) != 1 || !topo.EndPointEquality(eps[0].EndPoint, ep1) {
+		t.Errorf("want %+v, got %+v", ep1, eps)
+	}
+	if got := crossCellFallbacks.Counts()["ks.0.remote2"]; got != fallbacks+1 {
+		t.Errorf("crossCellFallbacks: %d, want %d", got, fallbacks+1)
+	}
+
+	// replica should prefer the local ones when they're healthy
+	ep1 = hc.addTestEndPoint("local", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	eps = dg.getEndPoints(keyspace, shard, topodatapb.TabletType_REPLICA)
+	if len(eps) != 1 || !topo.EndPointEquality(eps[0].EndPoint, ep1) {
 		t.Errorf("want %+v, got %+v", ep1, eps)
 	}
 
@@ -91,11 +116,77 @@ func TestDiscoveryGatewayGetEndPoints(t *testing.T) {
 	hc.addTestEndPoint("remote", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_MASTER, true, 5, nil, nil)
 	ep1 = hc.addTestEndPoint("remote", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_MASTER, true, 10, nil, nil)
 	eps = dg.getEndPoints(keyspace, shard, topodatapb.TabletType_MASTER)
-	if len(eps) != 1 || !topo.EndPointEquality(eps[0], ep1) {
+	if len(eps) != 1 || !topo.EndPointEquality(eps[0].EndPoint, ep1) {
 		t.Errorf("want %+v, got %+v", ep1, eps)
 	}
 }
 
+func TestDiscoveryGatewayMasterBuffer(t *testing.T) {
+	keyspace := "ks"
+	shard := "0"
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "cell", time.Millisecond, 2, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+	dg.buffer = newMasterBuffer(10*time.Second, 10)
+	upStats := func(ep *topodatapb.EndPoint) *discovery.EndPointStats {
+		eps := *hc.items[discovery.EndPointToMapKey(ep)].eps
+		eps.Up = true
+		return &eps
+	}
+
+	// The master stops serving: the request is buffered,
+	// and replayed once the new master is serving.
+	oldMaster := &sandboxConn{mustFailNotServing: 1}
+	ep := hc.addTestEndPoint("cell", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_MASTER, true, 10, nil, oldMaster)
+	dg.StatsUpdate(upStats(ep))
+	newMaster := &sandboxConn{}
+	go func() {
+		for !dg.buffer.inFailover(keyspace, shard) {
+			time.Sleep(time.Millisecond)
+		}
+		ep := hc.addTestEndPoint("cell", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_MASTER, true, 20, nil, newMaster)
+		dg.StatsUpdate(upStats(ep))
+	}()
+	if _, err := dg.Execute(context.Background(), keyspace, shard, topodatapb.TabletType_MASTER, "query", nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if execCount := newMaster.ExecCount.Get(); execCount != 1 {
+		t.Errorf("newMaster.ExecCount: %d, want 1", execCount)
+	}
+
+	// Requests in a transaction are not buffered.
+	newMaster.mustFailNotServing = 1
+	if _, err := dg.Execute(context.Background(), keyspace, shard, topodatapb.TabletType_MASTER, "query", nil, 1); err == nil {
+		t.Errorf("Execute: nil, want error")
+	}
+	if dg.buffer.inFailover(keyspace, shard) {
+		t.Errorf("inFailover: true, want false")
+	}
+
+	// A query rejected by a query rule fails, and the
+	// other requests are not buffered.
+	newMaster.mustFailRetry = 1
+	if _, err := dg.Execute(context.Background(), keyspace, shard, topodatapb.TabletType_MASTER, "query", nil, 0); err == nil {
+		t.Errorf("Execute: nil, want error")
+	}
+	if dg.buffer.inFailover(keyspace, shard) {
+		t.Errorf("inFailover: true, want false")
+	}
+	if _, err := dg.Execute(context.Background(), keyspace, shard, topodatapb.TabletType_MASTER, "query", nil, 0); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCellPreference(t *testing.T) {
+	want := []string{"local", "remote1", "remote2"}
+	if got := cellPreference("local", "remote1,local,,remote2,remote1"); !reflect.DeepEqual(got, want) {
+		t.Errorf("cellPreference: %v, want %v", got, want)
+	}
+	want = []string{"local"}
+	if got := cellPreference("local", ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("cellPreference: %v, want %v", got, want)
+	}
+}
+
 func testDiscoveryGatewayGeneric(t *testing.T, streaming bool, f func(dg Gateway, keyspace, shard string, tabletType topodatapb.TabletType) error) {
 	keyspace := "ks"
 	shard := "0"
@@ -247,6 +338,9 @@ type fhcItem struct {
 type fakeHealthCheck struct {
 	items map[string]*fhcItem
 
+	// mu protects the stats, as hedged requests use the
+	// fake concurrently.
+	mu sync.Mutex
 	// stats
 	GetStatsFromTargetCounter        int
 	GetStatsFromKeyspaceShardCounter int
@@ -298,7 +392,9 @@ func (fhc *fakeHealthCheck) GetEndPointStatsFromKeyspaceShard(keyspace, shard st
 
 // GetEndPointStatsFromTarget returns all EndPointStats for the given target.
 func (fhc *fakeHealthCheck) GetEndPointStatsFromTarget(keyspace, shard string, tabletType topodatapb.TabletType) []*discovery.EndPointStats {
+	fhc.mu.Lock()
 	fhc.GetStatsFromTargetCounter++
+	fhc.mu.Unlock()
 	var res []*discovery.EndPointStats
 	for _, item := range fhc.items {
 		if item.eps.Target == nil {
@@ -348,6 +348,11 @@ func (conn *FakeVTGateConn) Rollback2(ctx context.Context, session interface{})
 	return conn.Rollback(ctx, session)
 }
 
+// ResolveTransaction please see vtgateconn.Impl.ResolveTransaction
+func (conn *FakeVTGateConn) ResolveTransaction(ctx context.Context, dtid string) error {
+	return fmt.Errorf("NYI")
+}
+
 // SplitQuery please see vtgateconn.Impl.SplitQuery
 func (conn *FakeVTGateConn) SplitQuery(ctx context.Context, keyspace string, query string, bindVars map[string]interface{}, splitColumn string, splitCount int64) ([]*vtgatepb.SplitQueryResponse_Part, error) {
 	response, ok := conn.splitQueryMap[getSplitQueryKey(keyspace, query, splitColumn, splitCount)]
@@ -361,6 +366,11 @@ func (conn *FakeVTGateConn) SplitQuery(ctx context.Context, keyspace string, que
 	return reply, nil
 }
 
+// Explain please see vtgateconn.Impl.Explain
+func (conn *FakeVTGateConn) Explain(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	return nil, fmt.Errorf("NYI")
+}
+
 // GetSrvKeyspace please see vtgateconn.Impl.SplitQuery
 func (conn *FakeVTGateConn) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	return nil, fmt.Errorf("NYI")
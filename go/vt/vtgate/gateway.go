@@ -18,6 +18,7 @@ import (
 	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
 	"github.com/youtube/vitess/go/vt/topo"
 
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 )
 
@@ -51,6 +52,30 @@ type Gateway interface {
 	// Rollback rolls back the current transaction for the specified keyspace, shard, and tablet type.
 	Rollback(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, transactionID int64) error
 
+	// Prepare prepares the specified transaction for the distributed transaction dtid.
+	Prepare(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, transactionID int64, dtid string) error
+
+	// CommitPrepared commits the prepared transaction of dtid.
+	CommitPrepared(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string) error
+
+	// RollbackPrepared rolls back the prepared transaction of dtid, or originalID if it was not prepared.
+	RollbackPrepared(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string, originalID int64) error
+
+	// CreateTransaction records the distributed transaction dtid on its coordinator.
+	CreateTransaction(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string, participants []*querypb.Target) error
+
+	// StartCommit commits the transaction of the coordinator, and marks the distributed transaction dtid for commit.
+	StartCommit(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, transactionID int64, dtid string) error
+
+	// SetRollback marks the distributed transaction dtid for rollback.
+	SetRollback(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string, transactionID int64) error
+
+	// ConcludeTransaction deletes the record of the distributed transaction dtid.
+	ConcludeTransaction(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string) error
+
+	// ReadTransaction returns the record of the distributed transaction dtid.
+	ReadTransaction(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, dtid string) (*querypb.TransactionMetadata, error)
+
 	// SplitQuery splits a query into sub-queries for the specified keyspace, shard, and tablet type.
 	SplitQuery(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64) ([]querytypes.QuerySplit, error)
 
@@ -184,11 +184,40 @@ type RollbackResponse struct {
 	Err *mproto.RPCError
 }
 
+// ResolveTransactionRequest is the BSON implementation of the proto3 vtgate.ResolveTransactionRequest
+type ResolveTransactionRequest struct {
+	CallerID *gorpccallerid.CallerID
+	Dtid     string
+}
+
+// ResolveTransactionResponse is the BSON implementation of the proto3 vtgate.ResolveTransactionResponse
+type ResolveTransactionResponse struct {
+	// Err is named 'Err' instead of 'Error' (as the proto3 version is) to remain
+	// consistent with other BSON structs.
+	Err *mproto.RPCError
+}
+
 // GetSrvKeyspaceRequest is the payload to GetSrvRequest
 type GetSrvKeyspaceRequest struct {
 	Keyspace string
 }
 
+// ExplainRequest is the BSON implementation of the proto3 vtgate.ExplainRequest
+type ExplainRequest struct {
+	CallerID      *gorpccallerid.CallerID
+	Sql           string
+	BindVariables map[string]interface{}
+	TabletType    topodatapb.TabletType
+}
+
+// ExplainResponse is the BSON implementation of the proto3 vtgate.ExplainResponse
+type ExplainResponse struct {
+	Plan *vtgatepb.QueryPlan
+	// Err is named 'Err' instead of 'Error' (as the proto3 version is) to remain
+	// consistent with other BSON structs.
+	Err *mproto.RPCError
+}
+
 // SplitQueryResult is the response from SplitQueryRequest
 type SplitQueryResult struct {
 	Splits []*vtgatepb.SplitQueryResponse_Part
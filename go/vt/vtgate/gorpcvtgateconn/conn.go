@@ -474,6 +474,18 @@ func (conn *vtgateConn) Rollback2(ctx context.Context, session interface{}) erro
 	return vterrors.FromRPCError(reply.Err)
 }
 
+func (conn *vtgateConn) ResolveTransaction(ctx context.Context, dtid string) error {
+	request := &gorpcvtgatecommon.ResolveTransactionRequest{
+		CallerID: getEffectiveCallerID(ctx),
+		Dtid:     dtid,
+	}
+	reply := new(gorpcvtgatecommon.ResolveTransactionResponse)
+	if err := conn.rpcConn.Call(ctx, "VTGate.ResolveTransaction", request, reply); err != nil {
+		return err
+	}
+	return vterrors.FromRPCError(reply.Err)
+}
+
 func (conn *vtgateConn) SplitQuery(ctx context.Context, keyspace string, query string, bindVars map[string]interface{}, splitColumn string, splitCount int64) ([]*vtgatepb.SplitQueryResponse_Part, error) {
 	request := &gorpcvtgatecommon.SplitQueryRequest{
 		CallerID: getEffectiveCallerID(ctx),
@@ -506,6 +518,23 @@ func (conn *vtgateConn) GetSrvKeyspace(ctx context.Context, keyspace string) (*t
 	return result, nil
 }
 
+func (conn *vtgateConn) Explain(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	request := &gorpcvtgatecommon.ExplainRequest{
+		CallerID:      getEffectiveCallerID(ctx),
+		Sql:           query,
+		BindVariables: bindVars,
+		TabletType:    tabletType,
+	}
+	result := &gorpcvtgatecommon.ExplainResponse{}
+	if err := conn.rpcConn.Call(ctx, "VTGate.Explain", request, result); err != nil {
+		return nil, err
+	}
+	if err := vterrors.FromRPCError(result.Err); err != nil {
+		return nil, err
+	}
+	return result.Plan, nil
+}
+
 func (conn *vtgateConn) Close() {
 	conn.rpcConn.Close()
 }
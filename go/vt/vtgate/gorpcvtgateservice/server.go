@@ -475,6 +475,19 @@ func (vtg *VTGate) Rollback2(ctx context.Context, request *gorpcvtgatecommon.Rol
 	return nil
 }
 
+// ResolveTransaction is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) ResolveTransaction(ctx context.Context, request *gorpcvtgatecommon.ResolveTransactionRequest, reply *gorpcvtgatecommon.ResolveTransactionResponse) (err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(*rpcTimeout))
+	defer cancel()
+	ctx = callerid.NewContext(ctx,
+		gorpccallerid.GoRPCEffectiveCallerID(request.CallerID),
+		callerid.NewImmediateCallerID("gorpc client"))
+	vtgErr := vtg.server.ResolveTransaction(ctx, request.Dtid)
+	reply.Err = vterrors.RPCErrFromVtError(vtgErr)
+	return nil
+}
+
 // SplitQuery is the RPC version of vtgateservice.VTGateService method
 func (vtg *VTGate) SplitQuery(ctx context.Context, request *gorpcvtgatecommon.SplitQueryRequest, reply *gorpcvtgatecommon.SplitQueryResult) (err error) {
 	defer vtg.server.HandlePanic(&err)
@@ -507,6 +520,23 @@ func (vtg *VTGate) GetSrvKeyspace(ctx context.Context, request *gorpcvtgatecommo
 	return nil
 }
 
+// Explain is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) Explain(ctx context.Context, request *gorpcvtgatecommon.ExplainRequest, reply *gorpcvtgatecommon.ExplainResponse) (err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(*rpcTimeout))
+	defer cancel()
+	ctx = callerid.NewContext(ctx,
+		gorpccallerid.GoRPCEffectiveCallerID(request.CallerID),
+		callerid.NewImmediateCallerID("gorpc client"))
+	var vtgErr error
+	reply.Plan, vtgErr = vtg.server.Explain(ctx,
+		request.Sql,
+		request.BindVariables,
+		request.TabletType)
+	reply.Err = vterrors.RPCErrFromVtError(vtgErr)
+	return nil
+}
+
 // New returns a new VTGate service
 func New(vtGate vtgateservice.VTGateService) *VTGate {
 	return &VTGate{vtGate}
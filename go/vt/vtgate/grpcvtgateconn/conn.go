@@ -443,6 +443,15 @@ func (conn *vtgateConn) Rollback2(ctx context.Context, session interface{}) erro
 	return conn.Rollback(ctx, session)
 }
 
+func (conn *vtgateConn) ResolveTransaction(ctx context.Context, dtid string) error {
+	request := &vtgatepb.ResolveTransactionRequest{
+		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		Dtid:     dtid,
+	}
+	_, err := conn.c.ResolveTransaction(ctx, request)
+	return vterrors.FromGRPCError(err)
+}
+
 func (conn *vtgateConn) SplitQuery(ctx context.Context, keyspace string, query string, bindVars map[string]interface{}, splitColumn string, splitCount int64) ([]*vtgatepb.SplitQueryResponse_Part, error) {
 	q, err := querytypes.BoundQueryToProto3(query, bindVars)
 	if err != nil {
@@ -463,6 +472,24 @@ func (conn *vtgateConn) SplitQuery(ctx context.Context, keyspace string, query s
 	return response.Splits, nil
 }
 
+func (conn *vtgateConn) Explain(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	q, err := querytypes.BoundQueryToProto3(query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &vtgatepb.ExplainRequest{
+		CallerId:   callerid.EffectiveCallerIDFromContext(ctx),
+		Query:      q,
+		TabletType: tabletType,
+	}
+	response, err := conn.c.Explain(ctx, request)
+	if err != nil {
+		return nil, vterrors.FromGRPCError(err)
+	}
+	return response.Plan, nil
+}
+
 func (conn *vtgateConn) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	request := &vtgatepb.GetSrvKeyspaceRequest{
 		Keyspace: keyspace,
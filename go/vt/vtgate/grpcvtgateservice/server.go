@@ -322,6 +322,20 @@ func (vtg *VTGate) Rollback(ctx context.Context, request *vtgatepb.RollbackReque
 	return nil, vterrors.ToGRPCError(vtgErr)
 }
 
+// ResolveTransaction is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) ResolveTransaction(ctx context.Context, request *vtgatepb.ResolveTransactionRequest) (response *vtgatepb.ResolveTransactionResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.CallerId,
+		callerid.NewImmediateCallerID("grpc client"))
+	vtgErr := vtg.server.ResolveTransaction(ctx, request.Dtid)
+	response = &vtgatepb.ResolveTransactionResponse{}
+	if vtgErr == nil {
+		return response, nil
+	}
+	return nil, vterrors.ToGRPCError(vtgErr)
+}
+
 // SplitQuery is the RPC version of vtgateservice.VTGateService method
 func (vtg *VTGate) SplitQuery(ctx context.Context, request *vtgatepb.SplitQueryRequest) (response *vtgatepb.SplitQueryResponse, err error) {
 
@@ -347,6 +361,28 @@ func (vtg *VTGate) SplitQuery(ctx context.Context, request *vtgatepb.SplitQueryR
 	}, nil
 }
 
+// Explain is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) Explain(ctx context.Context, request *vtgatepb.ExplainRequest) (response *vtgatepb.ExplainResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.CallerId,
+		callerid.NewImmediateCallerID("grpc client"))
+	bv, err := querytypes.Proto3ToBindVariables(request.Query.BindVariables)
+	if err != nil {
+		return nil, vterrors.ToGRPCError(err)
+	}
+	plan, vtgErr := vtg.server.Explain(ctx,
+		string(request.Query.Sql),
+		bv,
+		request.TabletType)
+	if vtgErr != nil {
+		return nil, vterrors.ToGRPCError(vtgErr)
+	}
+	return &vtgatepb.ExplainResponse{
+		Plan: plan,
+	}, nil
+}
+
 // GetSrvKeyspace is the RPC version of vtgateservice.VTGateService method
 func (vtg *VTGate) GetSrvKeyspace(ctx context.Context, request *vtgatepb.GetSrvKeyspaceRequest) (response *vtgatepb.GetSrvKeyspaceResponse, err error) {
 	defer vtg.server.HandlePanic(&err)
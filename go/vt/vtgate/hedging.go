@@ -0,0 +1,200 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/vt/discovery"
+	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var (
+	enableHedging     = flag.Bool("enable_hedging", false, "with discoverygateway, send the non-transactional non-master Execute requests to a second tablet when the first one is slower than usual, and use the first answer")
+	hedgingPercentile = flag.Float64("hedging_percentile", 95, "the percentile of the recent latencies of a target after which a request is sent to a second tablet")
+	hedgingSamples    = flag.Int("hedging_samples", 1000, "the number of recent latencies kept per target to compute the hedging delay")
+
+	hedgedRequests = stats.NewMultiCounters("HedgedRequests", []string{"Keyspace", "ShardName", "DbType"})
+	hedgedWins     = stats.NewMultiCounters("HedgedWins", []string{"Keyspace", "ShardName", "DbType"})
+)
+
+const (
+	// hedgingMinSamples is the number of latencies a target
+	// needs before its requests are hedged.
+	hedgingMinSamples = 20
+	// hedgingRefresh is the number of latencies after which
+	// the hedging delay of a target is computed again.
+	hedgingRefresh = 10
+)
+
+// latencyTracker keeps the recent latencies of the requests of
+// each target, and the hedging delay they lead to.
+type latencyTracker struct {
+	percentile float64
+	samples    int
+
+	// mu protects targets.
+	mu      sync.Mutex
+	targets map[string]*targetLatencies
+}
+
+// targetLatencies are the recent latencies of a target.
+type targetLatencies struct {
+	ring  *stats.RingInt64
+	count int
+	delay time.Duration
+}
+
+func newLatencyTracker(percentile float64, samples int) *latencyTracker {
+	return &latencyTracker{
+		percentile: percentile,
+		samples:    samples,
+		targets:    make(map[string]*targetLatencies),
+	}
+}
+
+func latencyKey(keyspace, shard string, tabletType topodatapb.TabletType) string {
+	return keyspace + "/" + shard + "/" + strings.ToLower(tabletType.String())
+}
+
+// record adds the latency of a request to the ones of its target.
+func (lt *latencyTracker) record(keyspace, shard string, tabletType topodatapb.TabletType, latency time.Duration) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	key := latencyKey(keyspace, shard, tabletType)
+	tl, ok := lt.targets[key]
+	if !ok {
+		tl = &targetLatencies{ring: stats.NewRingInt64(lt.samples)}
+		lt.targets[key] = tl
+	}
+	tl.ring.Add(int64(latency))
+	tl.count++
+	if tl.count >= hedgingMinSamples && tl.count%hedgingRefresh == 0 {
+		tl.delay = lt.computeDelay(tl.ring.Values())
+	}
+}
+
+// computeDelay returns the configured percentile of latencies.
+func (lt *latencyTracker) computeDelay(latencies []int64) time.Duration {
+	sort.Sort(int64Slice(latencies))
+	i := int(float64(len(latencies)) * lt.percentile / 100)
+	if i >= len(latencies) {
+		i = len(latencies) - 1
+	}
+	return time.Duration(latencies[i])
+}
+
+// delay returns the time after which a request of the target is
+// hedged. It returns false if the target doesn't have enough
+// latencies yet.
+func (lt *latencyTracker) delay(keyspace, shard string, tabletType topodatapb.TabletType) (time.Duration, bool) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	tl, ok := lt.targets[latencyKey(keyspace, shard, tabletType)]
+	if !ok || tl.delay == 0 {
+		return 0, false
+	}
+	return tl.delay, true
+}
+
+type int64Slice []int64
+
+func (s int64Slice) Len() int           { return len(s) }
+func (s int64Slice) Less(i, j int) bool { return s[i] < s[j] }
+func (s int64Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// endPointSet is the set of endpoints tried by a request. It's
+// shared by the two attempts of a hedged request, so they don't
+// use the same tablet.
+type endPointSet struct {
+	mu sync.Mutex
+	m  map[string]bool
+}
+
+func newEndPointSet() *endPointSet {
+	return &endPointSet{m: make(map[string]bool)}
+}
+
+func (s *endPointSet) add(endPoint *topodatapb.EndPoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[discovery.EndPointToMapKey(endPoint)] = true
+}
+
+func (s *endPointSet) contains(endPoint *topodatapb.EndPoint) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.m[discovery.EndPointToMapKey(endPoint)]
+}
+
+// hedgedExecute executes a non-transactional query on a tablet. If
+// the tablet doesn't answer within the hedging delay of the target,
+// the query is also sent to a second tablet. The first successful
+// answer is used, and the other request is canceled.
+func (dg *discoveryGateway) hedgedExecute(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, query string, bindVars map[string]interface{}) (*sqltypes.Result, error) {
+	type result struct {
+		qr     *sqltypes.Result
+		err    error
+		hedged bool
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	tried := newEndPointSet()
+	results := make(chan result, 2)
+	execute := func(hedged bool) {
+		var qr *sqltypes.Result
+		err := dg.withRetryAvoiding(ctx, keyspace, shard, tabletType, func(conn tabletconn.TabletConn) error {
+			startTime := time.Now()
+			var innerErr error
+			qr, innerErr = conn.Execute(ctx, query, bindVars, 0)
+			// The request that loses is canceled: its elapsed
+			// time is recorded, as the least of its latency.
+			// Without it, the slow requests would not count.
+			if innerErr == nil || ctx.Err() != nil {
+				dg.latencies.record(keyspace, shard, tabletType, time.Now().Sub(startTime))
+			}
+			return innerErr
+		}, 0, false, tried)
+		results <- result{qr, err, hedged}
+	}
+
+	delay, ok := dg.latencies.delay(keyspace, shard, tabletType)
+	if !ok {
+		execute(false)
+		r := <-results
+		return r.qr, r.err
+	}
+	go execute(false)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case r := <-results:
+		return r.qr, r.err
+	case <-timer.C:
+	}
+
+	statsKey := []string{keyspace, shard, strings.ToLower(tabletType.String())}
+	hedgedRequests.Add(statsKey, 1)
+	go execute(true)
+	r := <-results
+	if r.err != nil {
+		// The other request may still succeed.
+		r = <-results
+	}
+	if r.err == nil && r.hedged {
+		hedgedWins.Add(statsKey, 1)
+	}
+	return r.qr, r.err
+}
@@ -0,0 +1,141 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/discovery"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	lt := newLatencyTracker(95, 100)
+	for i := 1; i < hedgingMinSamples; i++ {
+		lt.record("ks", "0", topodatapb.TabletType_REPLICA, time.Duration(i)*time.Millisecond)
+	}
+	if _, ok := lt.delay("ks", "0", topodatapb.TabletType_REPLICA); ok {
+		t.Errorf("delay: true, want false before %d samples", hedgingMinSamples)
+	}
+	lt.record("ks", "0", topodatapb.TabletType_REPLICA, time.Duration(hedgingMinSamples)*time.Millisecond)
+	delay, ok := lt.delay("ks", "0", topodatapb.TabletType_REPLICA)
+	if !ok || delay != 20*time.Millisecond {
+		t.Errorf("delay: %v, %v, want 20ms, true", delay, ok)
+	}
+	if _, ok := lt.delay("ks", "0", topodatapb.TabletType_RDONLY); ok {
+		t.Errorf("delay(rdonly): true, want false")
+	}
+}
+
+// lowestHostPicker always picks the endpoint with the lowest host,
+// so the tests know which tablet a request goes to first.
+type lowestHostPicker struct {
+	tabletWeigher
+}
+
+func (lp *lowestHostPicker) Pick(epsList []*discovery.EndPointStats) *discovery.EndPointStats {
+	res := epsList[0]
+	for _, eps := range epsList[1:] {
+		if eps.EndPoint.Host < res.EndPoint.Host {
+			res = eps
+		}
+	}
+	return res
+}
+
+func TestHedgedExecute(t *testing.T) {
+	*enableHedging = true
+	defer func() { *enableHedging = false }()
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "cell", time.Millisecond, 2, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+	dg.picker = &lowestHostPicker{newTabletWeigher()}
+	slow := &sandboxConn{}
+	fast := &sandboxConn{}
+	hc.addTestEndPoint("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil, slow)
+	hc.addTestEndPoint("cell", "2.2.2.2", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil, fast)
+
+	// Without enough latencies, the requests are not hedged.
+	hedged := hedgedRequests.Counts()["ks.0.replica"]
+	if _, err := dg.Execute(context.Background(), "ks", "0", topodatapb.TabletType_REPLICA, "query", nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := hedgedRequests.Counts()["ks.0.replica"]; got != hedged {
+		t.Errorf("hedgedRequests: %d, want %d", got, hedged)
+	}
+
+	for i := 0; i < hedgingMinSamples; i++ {
+		dg.latencies.record("ks", "0", topodatapb.TabletType_REPLICA, time.Millisecond)
+	}
+	slow.mustDelay = 100 * time.Millisecond
+
+	// Requests in a transaction are not hedged.
+	if _, err := dg.Execute(context.Background(), "ks", "0", topodatapb.TabletType_REPLICA, "query", nil, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := hedgedRequests.Counts()["ks.0.replica"]; got != hedged {
+		t.Errorf("hedgedRequests: %d, want %d", got, hedged)
+	}
+
+	wins := hedgedWins.Counts()["ks.0.replica"]
+	start := time.Now()
+	if _, err := dg.Execute(context.Background(), "ks", "0", topodatapb.TabletType_REPLICA, "query", nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Now().Sub(start); elapsed >= 100*time.Millisecond {
+		t.Errorf("Execute took %v, want the answer of the fast tablet", elapsed)
+	}
+	if execCount := fast.ExecCount.Get(); execCount != 1 {
+		t.Errorf("fast.ExecCount: %d, want 1", execCount)
+	}
+	if got := hedgedRequests.Counts()["ks.0.replica"]; got != hedged+1 {
+		t.Errorf("hedgedRequests: %d, want %d", got, hedged+1)
+	}
+	if got := hedgedWins.Counts()["ks.0.replica"]; got != wins+1 {
+		t.Errorf("hedgedWins: %d, want %d", got, wins+1)
+	}
+}
+
+func TestHedgedExecuteRecordsLoser(t *testing.T) {
+	*enableHedging = true
+	defer func() { *enableHedging = false }()
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "cell", time.Millisecond, 2, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+	dg.picker = &lowestHostPicker{newTabletWeigher()}
+	slow := &sandboxConn{}
+	fast := &sandboxConn{}
+	hc.addTestEndPoint("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil, slow)
+	hc.addTestEndPoint("cell", "2.2.2.2", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil, fast)
+	for i := 0; i < hedgingMinSamples; i++ {
+		dg.latencies.record("ks", "0", topodatapb.TabletType_REPLICA, time.Millisecond)
+	}
+
+	// The slow tablet fails once it's canceled,
+	// after the fast one answered.
+	slow.mustDelay = 50 * time.Millisecond
+	slow.mustFailConn = 1
+	if _, err := dg.Execute(context.Background(), "ks", "0", topodatapb.TabletType_REPLICA, "query", nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	key := latencyKey("ks", "0", topodatapb.TabletType_REPLICA)
+	timeout := time.After(5 * time.Second)
+	for {
+		dg.latencies.mu.Lock()
+		latencies := dg.latencies.targets[key].ring.Values()
+		dg.latencies.mu.Unlock()
+		if last := latencies[len(latencies)-1]; time.Duration(last) >= 50*time.Millisecond {
+			break
+		}
+		select {
+		case <-timeout:
+			t.Fatalf("latencies: %v, want the one of the slow tablet", latencies)
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+// This is a V3 file. Do not intermix with V2.
+
+import (
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+// execJoin executes a SelectJoin plan as a nested loop. The left
+// query is executed first, and the right query is then executed
+// once for every left row.
+func (rtr *Router) execJoin(vcursor *requestContext, plan *planbuilder.Plan) (*sqltypes.Result, error) {
+	join := plan.Join
+	lresult, err := rtr.Execute(vcursor.ctx, join.Left.Original, vcursor.bindVariables, vcursor.tabletType, vcursor.session, vcursor.notInTransaction)
+	if err != nil {
+		return nil, err
+	}
+	result := &sqltypes.Result{}
+	rfields, err := rtr.joinRows(vcursor, join, lresult.Rows, true, result)
+	if err != nil {
+		return nil, err
+	}
+	result.Fields = joinFields(join, lresult.Fields, rfields)
+	result.RowsAffected = uint64(len(result.Rows))
+	return result, nil
+}
+
+// streamJoin streams the results of a SelectJoin plan. The left
+// query is streamed, and every batch of left rows is joined with
+// the results of the right query before being sent.
+func (rtr *Router) streamJoin(vcursor *requestContext, plan *planbuilder.Plan, sendReply func(*sqltypes.Result) error) error {
+	join := plan.Join
+	var lfields []*querypb.Field
+	fieldsSent := false
+	return rtr.StreamExecute(vcursor.ctx, join.Left.Original, vcursor.bindVariables, vcursor.tabletType, func(lresult *sqltypes.Result) error {
+		if lresult.Fields != nil {
+			lfields = lresult.Fields
+		}
+		result := &sqltypes.Result{}
+		rfields, err := rtr.joinRows(vcursor, join, lresult.Rows, !fieldsSent, result)
+		if err != nil {
+			return err
+		}
+		if !fieldsSent {
+			result.Fields = joinFields(join, lfields, rfields)
+			fieldsSent = true
+		}
+		result.RowsAffected = uint64(len(result.Rows))
+		return sendReply(result)
+	})
+}
+
+// joinRows executes the right query for each of the left rows,
+// and appends the joined rows to result. It returns the fields
+// of the right query. If there are no left rows and wantFields
+// is true, the right query is executed with NULL join values
+// to obtain its fields.
+func (rtr *Router) joinRows(vcursor *requestContext, join *planbuilder.Join, lrows [][]sqltypes.Value, wantFields bool, result *sqltypes.Result) ([]*querypb.Field, error) {
+	var rfields []*querypb.Field
+	if len(lrows) == 0 && wantFields {
+		rresult, err := rtr.executeRight(vcursor, join, nil)
+		if err != nil {
+			return nil, err
+		}
+		return rresult.Fields, nil
+	}
+	for _, lrow := range lrows {
+		rresult, err := rtr.executeRight(vcursor, join, lrow)
+		if err != nil {
+			return nil, err
+		}
+		if rfields == nil {
+			rfields = rresult.Fields
+		}
+		for _, rrow := range rresult.Rows {
+			result.Rows = append(result.Rows, joinRow(join, lrow, rrow))
+		}
+		if len(rresult.Rows) == 0 && join.IsLeft {
+			result.Rows = append(result.Rows, joinRow(join, lrow, nil))
+		}
+	}
+	return rfields, nil
+}
+
+// executeRight executes the right query of join with the join
+// values supplied by lrow. A nil lrow supplies NULL values.
+func (rtr *Router) executeRight(vcursor *requestContext, join *planbuilder.Join, lrow []sqltypes.Value) (*sqltypes.Result, error) {
+	bindVars := make(map[string]interface{}, len(vcursor.bindVariables)+len(join.Vars))
+	for k, v := range vcursor.bindVariables {
+		bindVars[k] = v
+	}
+	for k, col := range join.Vars {
+		if lrow == nil {
+			bindVars[k] = nil
+			continue
+		}
+		bindVars[k] = lrow[col].ToNative()
+	}
+	return rtr.Execute(vcursor.ctx, join.Right.Original, bindVars, vcursor.tabletType, vcursor.session, vcursor.notInTransaction)
+}
+
+// joinRow builds a joined row out of a left and a right row.
+// A nil rrow produces NULL values for the right columns.
+func joinRow(join *planbuilder.Join, lrow, rrow []sqltypes.Value) []sqltypes.Value {
+	row := make([]sqltypes.Value, len(join.Cols))
+	for i, col := range join.Cols {
+		switch {
+		case col < 0:
+			row[i] = lrow[-col-1]
+		case rrow != nil:
+			row[i] = rrow[col-1]
+		default:
+			row[i] = sqltypes.NULL
+		}
+	}
+	return row
+}
+
+// joinFields builds the fields of the joined rows.
+func joinFields(join *planbuilder.Join, lfields, rfields []*querypb.Field) []*querypb.Field {
+	if lfields == nil || rfields == nil {
+		return nil
+	}
+	fields := make([]*querypb.Field, len(join.Cols))
+	for i, col := range join.Cols {
+		if col < 0 {
+			fields[i] = lfields[-col-1]
+			continue
+		}
+		fields[i] = rfields[col-1]
+	}
+	return fields
+}
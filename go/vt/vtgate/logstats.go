@@ -0,0 +1,264 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"net/url"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/streamlog"
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/callinfo"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+var (
+	queryLogHandler = flag.String("vtgate_query_log_stream_handler", "/debug/querylog", "URL handler for streaming the vtgate queries log")
+	querylogzURL    = flag.String("vtgate_querylogz_handler", "/querylogz", "URL handler for the HTML snapshot of the vtgate queries log")
+)
+
+// QueryLogger is the stream logger of the vtgate queries.
+var QueryLogger = streamlog.New("VTGate", 50)
+
+// LogStats records the stats for a single vtgate query.
+// PlanTime, ExecuteTime and CommitTime are the time spent
+// planning the query, waiting for the shards to answer, and
+// committing the transactions vtgate opened for the query.
+type LogStats struct {
+	Method        string
+	Keyspace      string
+	TabletType    string
+	SQL           string
+	BindVariables map[string]interface{}
+	PlanID        string
+	StartTime     time.Time
+	EndTime       time.Time
+	RowsReturned  int
+	RowsAffected  uint64
+	PlanTime      time.Duration
+	Error         error
+	ctx           context.Context
+
+	// shardQueries, executeTime and commitTime are updated by
+	// the concurrent shard calls of the query.
+	shardQueries sync2.AtomicInt64
+	executeTime  sync2.AtomicDuration
+	commitTime   sync2.AtomicDuration
+}
+
+// NewLogStats returns the LogStats of a query that starts now.
+func NewLogStats(ctx context.Context, methodName, sql string, bindVars map[string]interface{}) *LogStats {
+	return &LogStats{
+		Method:        methodName,
+		SQL:           sql,
+		BindVariables: bindVars,
+		StartTime:     time.Now(),
+		ctx:           ctx,
+	}
+}
+
+// Send finalizes a record and sends it
+func (stats *LogStats) Send() {
+	stats.EndTime = time.Now()
+	QueryLogger.Send(stats)
+}
+
+// AddResult adds the rows of a result to the ones of the query.
+// It can be called for each result of a batch or of a stream.
+func (stats *LogStats) AddResult(qr *sqltypes.Result) {
+	if qr == nil {
+		return
+	}
+	stats.RowsReturned += len(qr.Rows)
+	stats.RowsAffected += qr.RowsAffected
+}
+
+// The following methods are called by the router and ScatterConn
+// on the LogStats found in the context. The LogStats may be nil,
+// for instance for the queries sent by Commit.
+
+// recordPlan records the plan of the query, and adds planTime to the
+// time spent planning. The router also plans the queries it sends
+// for the query, like the sides of a join or the lookup queries, but
+// it does so after it planned the query: only the first plan is the
+// one of the query.
+func (stats *LogStats) recordPlan(id planbuilder.PlanID, planTime time.Duration) {
+	if stats == nil {
+		return
+	}
+	if stats.PlanID == "" {
+		stats.PlanID = id.String()
+	}
+	stats.PlanTime += planTime
+}
+
+func (stats *LogStats) addShardQueries(n int, executeTime time.Duration) {
+	if stats == nil {
+		return
+	}
+	stats.shardQueries.Add(int64(n))
+	stats.executeTime.Add(executeTime)
+}
+
+func (stats *LogStats) addCommitTime(commitTime time.Duration) {
+	if stats == nil {
+		return
+	}
+	stats.commitTime.Add(commitTime)
+}
+
+// ShardQueries returns the number of queries sent to the shards.
+func (stats *LogStats) ShardQueries() int64 {
+	return stats.shardQueries.Get()
+}
+
+// ExecuteTime returns the time spent waiting for the shards.
+func (stats *LogStats) ExecuteTime() time.Duration {
+	return stats.executeTime.Get()
+}
+
+// CommitTime returns the time spent committing the transactions
+// vtgate opened for the query.
+func (stats *LogStats) CommitTime() time.Duration {
+	return stats.commitTime.Get()
+}
+
+// ImmediateCaller returns the immediate caller stored in LogStats.ctx
+func (stats *LogStats) ImmediateCaller() string {
+	return callerid.GetUsername(callerid.ImmediateCallerIDFromContext(stats.ctx))
+}
+
+// EffectiveCaller returns the effective caller stored in LogStats.ctx
+func (stats *LogStats) EffectiveCaller() string {
+	return callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(stats.ctx))
+}
+
+// EventTime returns the time the event was created.
+func (stats *LogStats) EventTime() time.Time {
+	return stats.EndTime
+}
+
+// TotalTime returns how long this query has been running
+func (stats *LogStats) TotalTime() time.Duration {
+	return stats.EndTime.Sub(stats.StartTime)
+}
+
+// FmtBindVariables returns the map of bind variables as JSON. For
+// values that are strings or byte slices it only reports their type
+// and length, unless full is set.
+func (stats *LogStats) FmtBindVariables(full bool) string {
+	var out map[string]interface{}
+	if full {
+		out = stats.BindVariables
+	} else {
+		out = make(map[string]interface{})
+		for k, v := range stats.BindVariables {
+			switch val := v.(type) {
+			case string:
+				out[k] = fmt.Sprintf("string %v", len(val))
+			case []byte:
+				out[k] = fmt.Sprintf("bytes %v", len(val))
+			default:
+				out[k] = v
+			}
+		}
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		log.Warningf("could not marshal %q", stats.BindVariables)
+		return ""
+	}
+	return string(b)
+}
+
+// ContextHTML returns the HTML version of the context that was used, or "".
+func (stats *LogStats) ContextHTML() template.HTML {
+	return callinfo.HTMLFromContext(stats.ctx)
+}
+
+// ErrorStr returns the error string or ""
+func (stats *LogStats) ErrorStr() string {
+	if stats.Error != nil {
+		return stats.Error.Error()
+	}
+	return ""
+}
+
+// RemoteAddrUsername returns some parts of CallInfo if set
+func (stats *LogStats) RemoteAddrUsername() (string, string) {
+	ci, ok := callinfo.FromContext(stats.ctx)
+	if !ok {
+		return "", ""
+	}
+	return ci.RemoteAddr(), ci.Username()
+}
+
+// Format returns a tab separated list of logged fields.
+func (stats *LogStats) Format(params url.Values) string {
+	_, fullBindParams := params["full"]
+
+	remoteAddr, username := stats.RemoteAddrUsername()
+	return fmt.Sprintf(
+		"%v\t%v\t%v\t'%v'\t'%v'\t%v\t%v\t%.6f\t%.6f\t%.6f\t%.6f\t%v\t%v\t%v\t%q\t%v\t%v\t%v\t%v\t%q\t\n",
+		stats.Method,
+		remoteAddr,
+		username,
+		stats.ImmediateCaller(),
+		stats.EffectiveCaller(),
+		stats.StartTime.Format(time.StampMicro),
+		stats.EndTime.Format(time.StampMicro),
+		stats.TotalTime().Seconds(),
+		stats.PlanTime.Seconds(),
+		stats.ExecuteTime().Seconds(),
+		stats.CommitTime().Seconds(),
+		stats.Keyspace,
+		stats.TabletType,
+		stats.PlanID,
+		stats.SQL,
+		stats.FmtBindVariables(fullBindParams),
+		stats.ShardQueries(),
+		stats.RowsReturned,
+		stats.RowsAffected,
+		stats.ErrorStr(),
+	)
+}
+
+// logStatsKey is the context key of the LogStats of a query.
+type logStatsKey int
+
+// newContextWithLogStats returns a context that carries logStats
+// down to the router and ScatterConn.
+func newContextWithLogStats(ctx context.Context, logStats *LogStats) context.Context {
+	return context.WithValue(ctx, logStatsKey(0), logStats)
+}
+
+// logStatsFromContext returns the LogStats of ctx, or nil.
+func logStatsFromContext(ctx context.Context) *LogStats {
+	logStats, _ := ctx.Value(logStatsKey(0)).(*LogStats)
+	return logStats
+}
+
+func buildFmter(logger *streamlog.StreamLogger) func(url.Values, interface{}) string {
+	type formatter interface {
+		Format(url.Values) string
+	}
+
+	return func(params url.Values, val interface{}) string {
+		fmter, ok := val.(formatter)
+		if !ok {
+			return fmt.Sprintf("Error: unexpected value of type %T in %s!", val, logger.Name())
+		}
+		return fmter.Format(params)
+	}
+}
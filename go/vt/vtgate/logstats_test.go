@@ -0,0 +1,110 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestLogStatsFormat(t *testing.T) {
+	ctx := callerid.NewContext(
+		context.Background(),
+		callerid.NewEffectiveCallerID("effective-caller", "component", "subcomponent"),
+		callerid.NewImmediateCallerID("immediate-caller"),
+	)
+	logStats := NewLogStats(ctx, "Execute", "select * from t where id = :id", map[string]interface{}{"id": "abc"})
+	logStats.recordPlan(planbuilder.SelectEqual, time.Millisecond)
+	logStats.addShardQueries(2, 2*time.Millisecond)
+	logStats.EndTime = logStats.StartTime.Add(3 * time.Millisecond)
+
+	got := logStats.Format(url.Values{})
+	for _, want := range []string{"\t'immediate-caller'\t'effective-caller'\t", "\t0.003000\t0.001000\t0.002000\t0.000000\t", "\tSelectEqual\t", `{"id":"string 3"}`, "\t2\t"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Format: %q, want it to contain %q", got, want)
+		}
+	}
+	got = logStats.Format(url.Values{"full": nil})
+	if want := `{"id":"abc"}`; !strings.Contains(got, want) {
+		t.Errorf("Format(full): %q, want it to contain %q", got, want)
+	}
+}
+
+func TestLogStatsNil(t *testing.T) {
+	// The LogStats methods called by ScatterConn and the
+	// router work without a LogStats in the context.
+	logStats := logStatsFromContext(context.Background())
+	logStats.recordPlan(planbuilder.SelectEqual, time.Millisecond)
+	logStats.addShardQueries(1, time.Millisecond)
+	logStats.addCommitTime(time.Millisecond)
+}
+
+func TestLogStatsExecuteShards(t *testing.T) {
+	sandbox := createSandbox("TestLogStatsExecuteShards")
+	sandbox.MapTestConn("-20", &sandboxConn{})
+	sandbox.MapTestConn("20-40", &sandboxConn{})
+	ch := QueryLogger.Subscribe("test")
+	defer QueryLogger.Unsubscribe(ch)
+
+	_, err := rpcVTGate.ExecuteShards(context.Background(),
+		"query",
+		nil,
+		"TestLogStatsExecuteShards",
+		[]string{"-20", "20-40"},
+		topodatapb.TabletType_REPLICA,
+		nil,
+		false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logStats := (<-ch).(*LogStats)
+	if logStats.Method != "ExecuteShards" || logStats.SQL != "query" || logStats.Keyspace != "TestLogStatsExecuteShards" || logStats.TabletType != "replica" {
+		t.Errorf("logStats: %s %q %s %s, want ExecuteShards \"query\" TestLogStatsExecuteShards replica", logStats.Method, logStats.SQL, logStats.Keyspace, logStats.TabletType)
+	}
+	if got := logStats.ShardQueries(); got != 2 {
+		t.Errorf("ShardQueries: %d, want 2", got)
+	}
+	if logStats.RowsReturned != 2 {
+		t.Errorf("RowsReturned: %d, want 2", logStats.RowsReturned)
+	}
+	if logStats.Error != nil {
+		t.Errorf("Error: %v, want nil", logStats.Error)
+	}
+}
+
+func TestLogStatsJoin(t *testing.T) {
+	router, sbc1, _, _ := createRouterEnv()
+	sbc1.setResults([]*sqltypes.Result{joinLeftResult})
+	logStats := NewLogStats(context.Background(), "Execute", "", nil)
+	ctx := newContextWithLogStats(context.Background(), logStats)
+
+	// The sides of the join are planned and executed after
+	// the join, and they don't replace its plan.
+	_, err := router.Execute(ctx,
+		"select user.id, user_extra.id from user join user_extra on user.col = user_extra.user_id where user.id = 1",
+		nil,
+		topodatapb.TabletType_MASTER,
+		nil,
+		false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logStats.PlanID != "SelectJoin" {
+		t.Errorf("PlanID: %s, want SelectJoin", logStats.PlanID)
+	}
+	if got := logStats.ShardQueries(); got != 2 {
+		t.Errorf("ShardQueries: %d, want 2", got)
+	}
+}
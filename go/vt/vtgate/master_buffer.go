@@ -0,0 +1,227 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/vt/discovery"
+	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
+	"github.com/youtube/vitess/go/vt/vterrors"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	enableMasterBuffer  = flag.Bool("enable_master_buffer", false, "buffer the master requests of a shard while it fails over, e.g. during a planned reparent, and replay them against the new master")
+	masterBufferWindow  = flag.Duration("master_buffer_window", 10*time.Second, "max time the master requests of a shard are buffered during a failover")
+	masterBufferMaxSize = flag.Int("master_buffer_max_size", 1000, "max number of master requests that can be buffered at the same time, across all shards")
+
+	masterBufferFailovers      = stats.NewMultiCounters("MasterBufferFailovers", []string{"Keyspace", "ShardName"})
+	masterBufferRequests       = stats.NewMultiCounters("MasterBufferRequests", []string{"Keyspace", "ShardName"})
+	masterBufferFull           = stats.NewMultiCounters("MasterBufferFull", []string{"Keyspace", "ShardName"})
+	masterBufferWindowExceeded = stats.NewMultiCounters("MasterBufferWindowExceeded", []string{"Keyspace", "ShardName"})
+	masterBufferSize           = stats.NewInt("MasterBufferSize")
+)
+
+// masterBuffer holds the master requests of the shards that
+// are failing over. A failover starts when the health check
+// reports that the master of a shard stopped serving, or that a
+// new master that doesn't serve yet replaced it, or when a master
+// request fails because the tablet doesn't serve queries any more
+// (see isFailoverError).
+// It ends when the health check reports a serving master for the
+// shard, or when the buffering window is exceeded.
+type masterBuffer struct {
+	window  time.Duration
+	maxSize int
+
+	// mu protects the following fields.
+	mu        sync.Mutex
+	size      int
+	failovers map[string]*failover
+	masters   map[string]*shardMaster
+}
+
+// failover is an ongoing failover of a shard. done is closed
+// when it ends.
+type failover struct {
+	start time.Time
+	done  chan struct{}
+}
+
+// shardMaster is the last master the health check reported
+// for a shard.
+type shardMaster struct {
+	// endPoint is the map key of the endpoint of the master.
+	endPoint string
+	// reparented is its TabletExternallyReparentedTimestamp.
+	reparented int64
+	serving    bool
+}
+
+func newMasterBuffer(window time.Duration, maxSize int) *masterBuffer {
+	return &masterBuffer{
+		window:    window,
+		maxSize:   maxSize,
+		failovers: make(map[string]*failover),
+		masters:   make(map[string]*shardMaster),
+	}
+}
+
+// StatsUpdate is part of the discovery.HealthCheckStatsListener interface.
+// Only the updates of the current master of a shard, or of a master
+// that replaces it, count: the ones of a master that was replaced by
+// a newer one are ignored, and so are the health check errors, which
+// don't mean that the shard is failing over.
+func (mb *masterBuffer) StatsUpdate(eps *discovery.EndPointStats) {
+	if eps.Target == nil || eps.EndPoint == nil || !eps.Up || eps.LastError != nil {
+		return
+	}
+	keyspace, shard := eps.Target.Keyspace, eps.Target.Shard
+	key := keyspace + "/" + shard
+	endPoint := discovery.EndPointToMapKey(eps.EndPoint)
+	isMaster := eps.Target.TabletType == topodatapb.TabletType_MASTER
+
+	mb.mu.Lock()
+	master, ok := mb.masters[key]
+	if !ok || master.endPoint != endPoint {
+		if !isMaster || (ok && eps.TabletExternallyReparentedTimestamp < master.reparented) {
+			// A replica, or an old master.
+			mb.mu.Unlock()
+			return
+		}
+		// A new master: it takes over the serving
+		// state of the one it replaces.
+		master = &shardMaster{
+			endPoint: endPoint,
+			serving:  ok && master.serving,
+		}
+		mb.masters[key] = master
+	}
+	if isMaster {
+		master.reparented = eps.TabletExternallyReparentedTimestamp
+	}
+	// The master stops serving if it's demoted.
+	serving := isMaster && eps.Serving
+	wasServing := master.serving
+	master.serving = serving
+	mb.mu.Unlock()
+
+	switch {
+	case serving:
+		mb.endFailover(keyspace, shard, nil)
+	case wasServing:
+		mb.startFailover(keyspace, shard)
+	}
+}
+
+// startFailover starts buffering the master requests of the shard,
+// if it's not already the case.
+func (mb *masterBuffer) startFailover(keyspace, shard string) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	key := keyspace + "/" + shard
+	if _, ok := mb.failovers[key]; ok {
+		return
+	}
+	mb.failovers[key] = &failover{
+		start: time.Now(),
+		done:  make(chan struct{}),
+	}
+	masterBufferFailovers.Add([]string{keyspace, shard}, 1)
+	log.Infof("Buffering master requests of %v during failover", key)
+}
+
+// endFailover stops buffering the master requests of the shard, and
+// releases the buffered ones. If fo is not nil, the failover is ended
+// only if it's still the current one. It returns true if it ended it.
+func (mb *masterBuffer) endFailover(keyspace, shard string, fo *failover) bool {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	key := keyspace + "/" + shard
+	current, ok := mb.failovers[key]
+	if !ok || (fo != nil && fo != current) {
+		return false
+	}
+	delete(mb.failovers, key)
+	close(current.done)
+	log.Infof("Stopped buffering master requests of %v after %v", key, time.Now().Sub(current.start))
+	return true
+}
+
+// wait blocks while the shard is failing over, until the failover
+// ends or the buffering window is exceeded. It doesn't block if the
+// buffer is full. It returns an error only if ctx is done first.
+func (mb *masterBuffer) wait(ctx context.Context, keyspace, shard string) error {
+	statsKey := []string{keyspace, shard}
+	mb.mu.Lock()
+	fo, ok := mb.failovers[keyspace+"/"+shard]
+	if !ok {
+		mb.mu.Unlock()
+		return nil
+	}
+	if mb.size >= mb.maxSize {
+		mb.mu.Unlock()
+		masterBufferFull.Add(statsKey, 1)
+		return nil
+	}
+	mb.size++
+	mb.mu.Unlock()
+	masterBufferRequests.Add(statsKey, 1)
+	masterBufferSize.Add(1)
+	defer func() {
+		mb.mu.Lock()
+		mb.size--
+		mb.mu.Unlock()
+		masterBufferSize.Add(-1)
+	}()
+
+	timer := time.NewTimer(fo.start.Add(mb.window).Sub(time.Now()))
+	defer timer.Stop()
+	select {
+	case <-fo.done:
+		return nil
+	case <-timer.C:
+		// The failover is taking too long: the requests
+		// are replayed, and fail if there's still no master.
+		if mb.endFailover(keyspace, shard, fo) {
+			masterBufferWindowExceeded.Add(statsKey, 1)
+		}
+		return nil
+	case <-ctx.Done():
+		return vterrors.FromError(
+			vtrpcpb.ErrorCode_DEADLINE_EXCEEDED,
+			fmt.Errorf("master request buffered during failover: %v", ctx.Err()),
+		)
+	}
+}
+
+// isFailoverError returns true if err means that the tablet
+// doesn't serve master requests any more: it's not serving, or
+// MySQL is read-only. vttablet returns QUERY_NOT_SERVED for other
+// reasons too, like the query rules that blacklist tables, which
+// don't mean that the shard fails over. A stream that failed after
+// it sent rows, which vttablet reports as a FATAL error, can't be
+// replayed.
+func isFailoverError(err error, isStreaming bool) bool {
+	serverError, ok := err.(*tabletconn.ServerError)
+	if !ok || serverError.ServerCode != vtrpcpb.ErrorCode_QUERY_NOT_SERVED {
+		return false
+	}
+	if isStreaming && serverError.Code != tabletconn.ERR_RETRY {
+		return false
+	}
+	return strings.Contains(serverError.Err, "operation not allowed in state") || strings.Contains(serverError.Err, "read-only")
+}
@@ -0,0 +1,281 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/discovery"
+	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+// masterStats returns the health check update of the master
+// at host, which was reparented at the reparented timestamp.
+func masterStats(keyspace, shard, host string, reparented int64, serving bool) *discovery.EndPointStats {
+	return &discovery.EndPointStats{
+		EndPoint: topo.NewEndPoint(0, host),
+		Target: &querypb.Target{
+			Keyspace:   keyspace,
+			Shard:      shard,
+			TabletType: topodatapb.TabletType_MASTER,
+		},
+		Up:                                  true,
+		Serving:                             serving,
+		TabletExternallyReparentedTimestamp: reparented,
+	}
+}
+
+func (mb *masterBuffer) inFailover(keyspace, shard string) bool {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	_, ok := mb.failovers[keyspace+"/"+shard]
+	return ok
+}
+
+func TestMasterBufferFailover(t *testing.T) {
+	mb := newMasterBuffer(10*time.Second, 10)
+	if err := mb.wait(context.Background(), "ks", "0"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A replica that's not serving doesn't start a failover.
+	eps := masterStats("ks", "0", "1.1.1.1", 0, false)
+	eps.Target.TabletType = topodatapb.TabletType_REPLICA
+	mb.StatsUpdate(eps)
+	if mb.inFailover("ks", "0") {
+		t.Fatalf("inFailover: true, want false")
+	}
+
+	// The master stops serving.
+	mb.StatsUpdate(masterStats("ks", "0", "2.2.2.2", 10, true))
+	mb.StatsUpdate(masterStats("ks", "0", "2.2.2.2", 10, false))
+	if !mb.inFailover("ks", "0") {
+		t.Fatalf("inFailover: false, want true")
+	}
+	done := make(chan error)
+	go func() {
+		done <- mb.wait(context.Background(), "ks", "0")
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("wait returned during failover: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	// Other shards are not buffered.
+	if err := mb.wait(context.Background(), "ks", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The new master serves.
+	mb.StatsUpdate(masterStats("ks", "0", "1.1.1.1", 20, true))
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if mb.inFailover("ks", "0") {
+		t.Errorf("inFailover: true, want false")
+	}
+	if mb.size != 0 {
+		t.Errorf("size: %d, want 0", mb.size)
+	}
+}
+
+func TestMasterBufferReparentSignals(t *testing.T) {
+	mb := newMasterBuffer(10*time.Second, 10)
+
+	// A master that's not serving when it's first seen,
+	// or a health check error, doesn't start a failover.
+	mb.StatsUpdate(masterStats("ks", "0", "1.1.1.1", 10, false))
+	if mb.inFailover("ks", "0") {
+		t.Fatalf("inFailover: true, want false")
+	}
+	mb.StatsUpdate(masterStats("ks", "0", "1.1.1.1", 10, true))
+	eps := masterStats("ks", "0", "1.1.1.1", 10, false)
+	eps.LastError = fmt.Errorf("health check error")
+	mb.StatsUpdate(eps)
+	if mb.inFailover("ks", "0") {
+		t.Fatalf("inFailover: true, want false")
+	}
+
+	// The master is demoted.
+	eps = masterStats("ks", "0", "1.1.1.1", 10, true)
+	eps.Target.TabletType = topodatapb.TabletType_REPLICA
+	mb.StatsUpdate(eps)
+	if !mb.inFailover("ks", "0") {
+		t.Fatalf("inFailover: false, want true")
+	}
+	mb.StatsUpdate(masterStats("ks", "0", "2.2.2.2", 20, true))
+	if mb.inFailover("ks", "0") {
+		t.Fatalf("inFailover: true, want false")
+	}
+
+	// The updates of the old master are ignored.
+	mb.StatsUpdate(masterStats("ks", "0", "1.1.1.1", 10, false))
+	if mb.inFailover("ks", "0") {
+		t.Fatalf("inFailover: true, want false")
+	}
+
+	// A new master that's not serving yet replaces the current one.
+	mb.StatsUpdate(masterStats("ks", "0", "3.3.3.3", 30, false))
+	if !mb.inFailover("ks", "0") {
+		t.Fatalf("inFailover: false, want true")
+	}
+	mb.StatsUpdate(masterStats("ks", "0", "3.3.3.3", 30, true))
+	if mb.inFailover("ks", "0") {
+		t.Fatalf("inFailover: true, want false")
+	}
+}
+
+func TestMasterBufferWindow(t *testing.T) {
+	mb := newMasterBuffer(10*time.Millisecond, 10)
+	mb.startFailover("ks", "0")
+	start := time.Now()
+	if err := mb.wait(context.Background(), "ks", "0"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Now().Sub(start); elapsed < 10*time.Millisecond {
+		t.Errorf("wait returned after %v, want at least 10ms", elapsed)
+	}
+	if mb.inFailover("ks", "0") {
+		t.Errorf("inFailover: true, want false")
+	}
+}
+
+func TestMasterBufferFull(t *testing.T) {
+	mb := newMasterBuffer(10*time.Second, 0)
+	mb.startFailover("ks", "0")
+	if err := mb.wait(context.Background(), "ks", "0"); err != nil {
+		t.Fatal(err)
+	}
+	if !mb.inFailover("ks", "0") {
+		t.Errorf("inFailover: false, want true")
+	}
+}
+
+func TestMasterBufferContextDone(t *testing.T) {
+	mb := newMasterBuffer(10*time.Second, 10)
+	mb.startFailover("ks", "0")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	want := "master request buffered during failover: context deadline exceeded"
+	if err := mb.wait(ctx, "ks", "0"); err == nil || err.Error() != want {
+		t.Errorf("wait: %v, want %s", err, want)
+	}
+}
+
+func TestShardConnMasterBuffer(t *testing.T) {
+	s := createSandbox("TestShardConnMasterBuffer")
+	sbc := &sandboxConn{}
+	s.MapTestConn("0", sbc)
+	sdc := NewShardConn(context.Background(), new(sandboxTopo), "aa", "TestShardConnMasterBuffer", "0", topodatapb.TabletType_MASTER, retryDelay, retryCount, connTimeoutTotal, connTimeoutPerConn, connLife, connectTimings)
+	sdc.buffer = newMasterBuffer(10*time.Second, 10)
+
+	// The master stops serving: the request is buffered after
+	// the retries, and replayed once the new master is serving.
+	sbc.mustFailNotServing = retryCount + 1
+	newMaster := &sandboxConn{}
+	go func() {
+		for !sdc.buffer.inFailover("TestShardConnMasterBuffer", "0") {
+			time.Sleep(time.Millisecond)
+		}
+		s.MapTestConn("0", newMaster)
+		s.DeleteTestConn("0", sbc)
+		sdc.buffer.StatsUpdate(masterStats("TestShardConnMasterBuffer", "0", "1.1.1.1", 20, true))
+	}()
+	if _, err := sdc.Execute(context.Background(), "query", nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if execCount := newMaster.ExecCount.Get(); execCount != 1 {
+		t.Errorf("newMaster.ExecCount: %d, want 1", execCount)
+	}
+
+	// Requests in a transaction are not buffered.
+	newMaster.mustFailNotServing = 1
+	if _, err := sdc.Execute(context.Background(), "query", nil, 1); err == nil {
+		t.Errorf("Execute: nil, want error")
+	}
+	if sdc.buffer.inFailover("TestShardConnMasterBuffer", "0") {
+		t.Errorf("inFailover: true, want false")
+	}
+
+	// A query rejected by a query rule fails, and doesn't
+	// start a failover that would buffer the other requests.
+	newMaster.mustFailRetry = retryCount + 1
+	if _, err := sdc.Execute(context.Background(), "query", nil, 0); err == nil {
+		t.Errorf("Execute: nil, want error")
+	}
+	if sdc.buffer.inFailover("TestShardConnMasterBuffer", "0") {
+		t.Errorf("inFailover: true, want false")
+	}
+}
+
+func TestIsFailoverError(t *testing.T) {
+	testcases := []struct {
+		err         error
+		isStreaming bool
+		want        bool
+	}{{
+		err: &tabletconn.ServerError{
+			Code:       tabletconn.ERR_RETRY,
+			Err:        "retry: operation not allowed in state NOT_SERVING",
+			ServerCode: vtrpcpb.ErrorCode_QUERY_NOT_SERVED,
+		},
+		want: true,
+	}, {
+		err: &tabletconn.ServerError{
+			Code:       tabletconn.ERR_RETRY,
+			Err:        "retry: The MySQL server is running with the --read-only option so it cannot execute this statement",
+			ServerCode: vtrpcpb.ErrorCode_QUERY_NOT_SERVED,
+		},
+		want: true,
+	}, {
+		err: &tabletconn.ServerError{
+			Code:       tabletconn.ERR_RETRY,
+			Err:        "retry: Query disallowed due to rule: enforce blacklisted tables",
+			ServerCode: vtrpcpb.ErrorCode_QUERY_NOT_SERVED,
+		},
+		want: false,
+	}, {
+		err: &tabletconn.ServerError{
+			Code:       tabletconn.ERR_RETRY,
+			Err:        "retry: Invalid tablet type: MASTER, want: REPLICA or []",
+			ServerCode: vtrpcpb.ErrorCode_QUERY_NOT_SERVED,
+		},
+		want: false,
+	}, {
+		err: &tabletconn.ServerError{
+			Code:       tabletconn.ERR_RETRY,
+			Err:        "retry: operation not allowed in state NOT_SERVING",
+			ServerCode: vtrpcpb.ErrorCode_QUERY_NOT_SERVED,
+		},
+		isStreaming: true,
+		want:        true,
+	}, {
+		// The stream broke after it sent rows.
+		err: &tabletconn.ServerError{
+			Code:       tabletconn.ERR_FATAL,
+			Err:        "fatal: operation not allowed in state NOT_SERVING",
+			ServerCode: vtrpcpb.ErrorCode_QUERY_NOT_SERVED,
+		},
+		isStreaming: true,
+		want:        false,
+	}, {
+		err:  tabletconn.OperationalError("operation not allowed in state NOT_SERVING"),
+		want: false,
+	}}
+	for _, tc := range testcases {
+		if got := isFailoverError(tc.err, tc.isStreaming); got != tc.want {
+			t.Errorf("isFailoverError(%v, %v): %v, want %v", tc.err, tc.isStreaming, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+// This is a V3 file. Do not intermix with V2.
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+// getLimits returns the offset and row count of a multi-shard plan.
+// The row count is -1 if the plan has no LIMIT. If the LIMIT sent to
+// the shards can only be computed at execution time, it's added to
+// bindVars as planbuilder.LimitVarName. Aggregate queries are sent
+// to the shards without a LIMIT.
+func getLimits(plan *planbuilder.Plan, bindVars map[string]interface{}) (offset, rowcount int64, err error) {
+	if plan.Limit == nil {
+		return 0, -1, nil
+	}
+	if plan.Offset != nil {
+		offset, err = getLimitValue(plan.Offset, bindVars)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	rowcount, err = getLimitValue(plan.Limit, bindVars)
+	if err != nil {
+		return 0, 0, err
+	}
+	_, offsetIsNumber := plan.Offset.(int64)
+	_, limitIsNumber := plan.Limit.(int64)
+	if plan.Offset != nil && plan.Aggregate == nil && !(offsetIsNumber && limitIsNumber) {
+		bindVars[planbuilder.LimitVarName] = offset + rowcount
+	}
+	return offset, rowcount, nil
+}
+
+func getLimitValue(val interface{}, bindVars map[string]interface{}) (int64, error) {
+	switch val := val.(type) {
+	case int64:
+		return val, nil
+	case string:
+		bv, ok := bindVars[val[1:]]
+		if !ok {
+			return 0, fmt.Errorf("invalid limit: could not find bind var %s", val)
+		}
+		v, err := sqltypes.BuildValue(bv)
+		if err != nil {
+			return 0, fmt.Errorf("invalid limit: %v", err)
+		}
+		if !v.IsIntegral() {
+			return 0, fmt.Errorf("invalid limit: %v is not an integer", bv)
+		}
+		n, err := v.ParseInt64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid limit: %v", err)
+		}
+		if n < 0 {
+			return 0, fmt.Errorf("invalid limit: negative value %d", n)
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("invalid limit: unexpected value %v", val)
+}
+
+// orderByColumn specifies how merged rows are compared on a
+// column. It's built from the planbuilder.OrderByParams of a plan,
+// so that ScatterConn doesn't depend on the planner.
+type orderByColumn struct {
+	Col   string
+	Index int
+	Desc  bool
+}
+
+func newOrderByColumns(orderBy []planbuilder.OrderByParams) []orderByColumn {
+	if orderBy == nil {
+		return nil
+	}
+	columns := make([]orderByColumn, len(orderBy))
+	for i, params := range orderBy {
+		columns[i] = orderByColumn{Col: params.Col, Index: params.Index, Desc: params.Desc}
+	}
+	return columns
+}
+
+// resolveOrderBy returns a copy of orderBy where every column
+// has its position in fields resolved.
+func resolveOrderBy(orderBy []orderByColumn, fields []*querypb.Field) ([]orderByColumn, error) {
+	resolved := make([]orderByColumn, len(orderBy))
+	for i, params := range orderBy {
+		resolved[i] = params
+		if params.Index != -1 {
+			if params.Index >= len(fields) {
+				return nil, fmt.Errorf("order by column number out of range: %d", params.Index+1)
+			}
+			continue
+		}
+		for j, field := range fields {
+			if strings.EqualFold(field.Name, params.Col) {
+				resolved[i].Index = j
+				break
+			}
+		}
+		if resolved[i].Index == -1 {
+			return nil, fmt.Errorf("order by column %s not found in result", params.Col)
+		}
+	}
+	return resolved, nil
+}
+
+// compareRows compares two rows using the resolved orderBy.
+func compareRows(row1, row2 []sqltypes.Value, orderBy []orderByColumn) (int, error) {
+	for _, params := range orderBy {
+		cmp, err := compareValues(row1[params.Index], row2[params.Index])
+		if err != nil {
+			return 0, err
+		}
+		if cmp == 0 {
+			continue
+		}
+		if params.Desc {
+			return -cmp, nil
+		}
+		return cmp, nil
+	}
+	return 0, nil
+}
+
+// compareValues compares two values for ordering. NULL is smaller
+// than any other value, numbers are compared numerically, text
+// values are compared with the collation, and all other values are
+// compared byte-wise.
+func compareValues(v1, v2 sqltypes.Value) (int, error) {
+	switch {
+	case v1.IsNull() && v2.IsNull():
+		return 0, nil
+	case v1.IsNull():
+		return -1, nil
+	case v2.IsNull():
+		return 1, nil
+	}
+	if isNumber(v1) && isNumber(v2) {
+		return compareNumbers(v1, v2)
+	}
+	if sqltypes.IsText(v1.Type()) && sqltypes.IsText(v2.Type()) {
+		return compareText(v1, v2), nil
+	}
+	return bytes.Compare(v1.Raw(), v2.Raw()), nil
+}
+
+func isNumber(v sqltypes.Value) bool {
+	return v.IsIntegral() || v.IsFloat() || v.Type() == sqltypes.Decimal
+}
+
+func compareNumbers(v1, v2 sqltypes.Value) (int, error) {
+	if v1.IsSigned() && v2.IsSigned() {
+		n1, err := v1.ParseInt64()
+		if err != nil {
+			return 0, err
+		}
+		n2, err := v2.ParseInt64()
+		if err != nil {
+			return 0, err
+		}
+		return compareInt64(n1, n2), nil
+	}
+	if v1.IsIntegral() && v2.IsIntegral() {
+		// At least one of the values is unsigned. A negative
+		// signed value is smaller than any unsigned value.
+		if v1.IsSigned() {
+			if n1, err := v1.ParseInt64(); err != nil || n1 < 0 {
+				return -1, err
+			}
+		}
+		if v2.IsSigned() {
+			if n2, err := v2.ParseInt64(); err != nil || n2 < 0 {
+				return 1, err
+			}
+		}
+		u1, err := v1.ParseUint64()
+		if err != nil {
+			return 0, err
+		}
+		u2, err := v2.ParseUint64()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case u1 < u2:
+			return -1, nil
+		case u1 > u2:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	f1, err := v1.ParseFloat64()
+	if err != nil {
+		return 0, err
+	}
+	f2, err := v2.ParseFloat64()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case f1 < f2:
+		return -1, nil
+	case f1 > f2:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func compareInt64(n1, n2 int64) int {
+	switch {
+	case n1 < n2:
+		return -1
+	case n1 > n2:
+		return 1
+	}
+	return 0
+}
+
+// rowSorter sorts rows using a resolved orderBy.
+// The first comparison error is saved in err.
+type rowSorter struct {
+	rows    [][]sqltypes.Value
+	orderBy []orderByColumn
+	err     error
+}
+
+func (rs *rowSorter) Len() int      { return len(rs.rows) }
+func (rs *rowSorter) Swap(i, j int) { rs.rows[i], rs.rows[j] = rs.rows[j], rs.rows[i] }
+func (rs *rowSorter) Less(i, j int) bool {
+	if rs.err != nil {
+		return false
+	}
+	cmp, err := compareRows(rs.rows[i], rs.rows[j], rs.orderBy)
+	if err != nil {
+		rs.err = err
+		return false
+	}
+	return cmp < 0
+}
+
+// sortRows sorts the rows of a merged multi-shard result.
+func sortRows(qr *sqltypes.Result, orderBy []orderByColumn) error {
+	if len(orderBy) == 0 || len(qr.Rows) == 0 {
+		return nil
+	}
+	resolved, err := resolveOrderBy(orderBy, qr.Fields)
+	if err != nil {
+		return err
+	}
+	sorter := &rowSorter{rows: qr.Rows, orderBy: resolved}
+	sort.Stable(sorter)
+	return sorter.err
+}
+
+// limitRows applies the offset and row count to a merged
+// multi-shard result. A rowcount of -1 means no limit.
+func limitRows(qr *sqltypes.Result, offset, rowcount int64) {
+	if offset >= int64(len(qr.Rows)) {
+		qr.Rows = nil
+	} else {
+		qr.Rows = qr.Rows[offset:]
+	}
+	if rowcount != -1 && int64(len(qr.Rows)) > rowcount {
+		qr.Rows = qr.Rows[:rowcount]
+	}
+	qr.RowsAffected = uint64(len(qr.Rows))
+}
+
+// shardResult is a partial result streamed by a shard. A result
+// with a nil qr marks the end of the stream for the shard, and
+// err is set if the stream failed.
+type shardResult struct {
+	shard string
+	qr    *sqltypes.Result
+	err   error
+}
+
+// streamMerger performs a k-way merge of the rows streamed by
+// multiple shards. The shards are expected to return their rows
+// in the order specified by orderBy. If orderBy is empty, rows
+// are sent in the order they're received.
+type streamMerger struct {
+	orderBy   []orderByColumn
+	offset    int64
+	rowcount  int64
+	sendReply func(*sqltypes.Result) error
+
+	fields  []*querypb.Field
+	queues  map[string][][]sqltypes.Value
+	pending map[string]bool
+	out     [][]sqltypes.Value
+	skipped int64
+	sent    int64
+}
+
+func newStreamMerger(shards []string, orderBy []orderByColumn, offset, rowcount int64, sendReply func(*sqltypes.Result) error) *streamMerger {
+	sm := &streamMerger{
+		orderBy:   orderBy,
+		offset:    offset,
+		rowcount:  rowcount,
+		sendReply: sendReply,
+		queues:    make(map[string][][]sqltypes.Value, len(shards)),
+		pending:   make(map[string]bool, len(shards)),
+	}
+	for _, shard := range shards {
+		sm.pending[shard] = true
+	}
+	return sm
+}
+
+// run merges the shard results until all streams are done or
+// the row count is reached, in which case it returns true. It
+// returns early if any of the shards fails, leaving the caller
+// to report the shard error.
+func (sm *streamMerger) run(results <-chan interface{}) (stopped bool, err error) {
+	if sm.rowcount == 0 {
+		return true, nil
+	}
+	for {
+		for sm.waiting() {
+			// Send what we have before blocking on the shards.
+			if err := sm.flush(); err != nil {
+				return false, err
+			}
+			r, ok := <-results
+			if !ok {
+				// The shards that didn't end their stream failed.
+				return false, nil
+			}
+			sr := r.(*shardResult)
+			if sr.err != nil {
+				return false, nil
+			}
+			if err := sm.add(sr); err != nil {
+				return false, err
+			}
+		}
+		shard, err := sm.next()
+		if err != nil {
+			return false, err
+		}
+		if shard == "" {
+			return false, sm.flush()
+		}
+		sm.emit(shard)
+		if sm.rowcount != -1 && sm.sent == sm.rowcount {
+			return true, sm.flush()
+		}
+	}
+}
+
+// waiting returns true if more results are needed before the
+// next row can be picked. Rows can be sent in any order if there
+// is no ORDER BY. Otherwise, every shard that's still streaming
+// must have a queued row.
+func (sm *streamMerger) waiting() bool {
+	if len(sm.orderBy) == 0 {
+		for _, rows := range sm.queues {
+			if len(rows) != 0 {
+				return false
+			}
+		}
+		return len(sm.pending) != 0
+	}
+	for shard := range sm.pending {
+		if len(sm.queues[shard]) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (sm *streamMerger) add(sr *shardResult) error {
+	if sr.qr == nil {
+		delete(sm.pending, sr.shard)
+		return nil
+	}
+	if sm.fields == nil && len(sr.qr.Fields) != 0 {
+		sm.fields = sr.qr.Fields
+		if len(sm.orderBy) != 0 {
+			resolved, err := resolveOrderBy(sm.orderBy, sm.fields)
+			if err != nil {
+				return err
+			}
+			sm.orderBy = resolved
+		}
+		if err := sm.sendReply(&sqltypes.Result{Fields: sm.fields}); err != nil {
+			return err
+		}
+	}
+	if len(sr.qr.Rows) != 0 {
+		sm.queues[sr.shard] = append(sm.queues[sr.shard], sr.qr.Rows...)
+	}
+	return nil
+}
+
+// next returns the shard that has the next row to send,
+// or "" if there are no more rows.
+func (sm *streamMerger) next() (string, error) {
+	var best string
+	for shard, rows := range sm.queues {
+		if len(rows) == 0 {
+			continue
+		}
+		if best == "" {
+			best = shard
+			continue
+		}
+		cmp, err := compareRows(rows[0], sm.queues[best][0], sm.orderBy)
+		if err != nil {
+			return "", err
+		}
+		// Break ties by shard name to make the order deterministic.
+		if cmp < 0 || (cmp == 0 && shard < best) {
+			best = shard
+		}
+	}
+	return best, nil
+}
+
+func (sm *streamMerger) emit(shard string) {
+	row := sm.queues[shard][0]
+	sm.queues[shard] = sm.queues[shard][1:]
+	if sm.skipped < sm.offset {
+		sm.skipped++
+		return
+	}
+	sm.out = append(sm.out, row)
+	sm.sent++
+}
+
+func (sm *streamMerger) flush() error {
+	if len(sm.out) == 0 {
+		return nil
+	}
+	qr := &sqltypes.Result{Rows: sm.out}
+	sm.out = nil
+	return sm.sendReply(qr)
+}
@@ -0,0 +1,242 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+func TestCompareValues(t *testing.T) {
+	testcases := []struct {
+		v1, v2 sqltypes.Value
+		want   int
+	}{{
+		v1:   sqltypes.NULL,
+		v2:   sqltypes.NULL,
+		want: 0,
+	}, {
+		v1:   sqltypes.NULL,
+		v2:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		want: -1,
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		v2:   sqltypes.NULL,
+		want: 1,
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("2")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("10")),
+		want: -1,
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("-1")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Uint64, []byte("1")),
+		want: -1,
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Uint64, []byte("18446744073709551615")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		want: 1,
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Float64, []byte("1.5")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		want: 1,
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.Decimal, []byte("1.50")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Float64, []byte("1.5")),
+		want: 0,
+	}, {
+		v1:   sqltypes.MakeString([]byte("abc")),
+		v2:   sqltypes.MakeString([]byte("abd")),
+		want: -1,
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.VarChar, []byte("abc")),
+		v2:   sqltypes.MakeTrusted(sqltypes.VarChar, []byte("ABC ")),
+		want: 0,
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.VarChar, []byte("b")),
+		v2:   sqltypes.MakeTrusted(sqltypes.VarChar, []byte("A")),
+		want: 1,
+	}, {
+		v1:   sqltypes.MakeTrusted(sqltypes.VarBinary, []byte("abc")),
+		v2:   sqltypes.MakeTrusted(sqltypes.VarBinary, []byte("ABC")),
+		want: 1,
+	}, {
+		v1:   sqltypes.MakeString([]byte("10")),
+		v2:   sqltypes.MakeTrusted(sqltypes.Int64, []byte("2")),
+		want: -1,
+	}}
+	for _, tcase := range testcases {
+		got, err := compareValues(tcase.v1, tcase.v2)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got != tcase.want {
+			t.Errorf("compareValues(%v, %v): %d, want %d", tcase.v1, tcase.v2, got, tcase.want)
+		}
+	}
+}
+
+func TestSortRows(t *testing.T) {
+	qr := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "a", Type: sqltypes.Int64},
+			{Name: "b", Type: sqltypes.VarChar},
+		},
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")), sqltypes.MakeString([]byte("x"))},
+			{sqltypes.MakeTrusted(sqltypes.Int64, []byte("2")), sqltypes.MakeString([]byte("y"))},
+			{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")), sqltypes.MakeString([]byte("z"))},
+		},
+	}
+	orderBy := []orderByColumn{
+		{Col: "a", Index: -1},
+		{Col: "b", Index: 1, Desc: true},
+	}
+	if err := sortRows(qr, orderBy); err != nil {
+		t.Fatal(err)
+	}
+	want := [][]sqltypes.Value{
+		{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")), sqltypes.MakeString([]byte("z"))},
+		{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")), sqltypes.MakeString([]byte("x"))},
+		{sqltypes.MakeTrusted(sqltypes.Int64, []byte("2")), sqltypes.MakeString([]byte("y"))},
+	}
+	if !reflect.DeepEqual(qr.Rows, want) {
+		t.Errorf("sortRows: %v, want %v", qr.Rows, want)
+	}
+
+	err := sortRows(qr, []orderByColumn{{Col: "c", Index: -1}})
+	wantErr := "order by column c not found in result"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("sortRows: %v, want %s", err, wantErr)
+	}
+}
+
+func TestLimitRows(t *testing.T) {
+	testcases := []struct {
+		offset, rowcount int64
+		want             int
+	}{
+		{0, -1, 5},
+		{0, 2, 2},
+		{3, 10, 2},
+		{5, 1, 0},
+		{7, -1, 0},
+	}
+	for _, tcase := range testcases {
+		qr := &sqltypes.Result{Rows: make([][]sqltypes.Value, 5)}
+		limitRows(qr, tcase.offset, tcase.rowcount)
+		if len(qr.Rows) != tcase.want || qr.RowsAffected != uint64(tcase.want) {
+			t.Errorf("limitRows(%d, %d): %d rows, want %d", tcase.offset, tcase.rowcount, len(qr.Rows), tcase.want)
+		}
+	}
+}
+
+func TestGetLimits(t *testing.T) {
+	bv := map[string]interface{}{
+		"off": 10,
+	}
+	plan := &planbuilder.Plan{Offset: ":off", Limit: int64(5)}
+	offset, rowcount, err := getLimits(plan, bv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset != 10 || rowcount != 5 {
+		t.Errorf("getLimits: %d, %d, want 10, 5", offset, rowcount)
+	}
+	if got := bv[planbuilder.LimitVarName]; got != int64(15) {
+		t.Errorf("bv[%s]: %v, want 15", planbuilder.LimitVarName, got)
+	}
+
+	bv = map[string]interface{}{}
+	_, rowcount, err = getLimits(&planbuilder.Plan{}, bv)
+	if err != nil || rowcount != -1 {
+		t.Errorf("getLimits: %d, %v, want -1, nil", rowcount, err)
+	}
+	_, _, err = getLimits(&planbuilder.Plan{Offset: int64(1), Limit: int64(2)}, bv)
+	if err != nil {
+		t.Error(err)
+	}
+	if _, ok := bv[planbuilder.LimitVarName]; ok {
+		t.Errorf("bv has %s, want none", planbuilder.LimitVarName)
+	}
+
+	_, _, err = getLimits(&planbuilder.Plan{Limit: ":a"}, bv)
+	want := "invalid limit: could not find bind var :a"
+	if err == nil || err.Error() != want {
+		t.Errorf("getLimits: %v, want %s", err, want)
+	}
+	bv["a"] = "abc"
+	_, _, err = getLimits(&planbuilder.Plan{Limit: ":a"}, bv)
+	want = "invalid limit: abc is not an integer"
+	if err == nil || err.Error() != want {
+		t.Errorf("getLimits: %v, want %s", err, want)
+	}
+	_, _, err = getLimits(&planbuilder.Plan{Limit: 1.5}, bv)
+	want = "invalid limit: unexpected value 1.5"
+	if err == nil || err.Error() != want {
+		t.Errorf("getLimits: %v, want %s", err, want)
+	}
+}
+
+func TestStreamMerger(t *testing.T) {
+	fields := []*querypb.Field{{Name: "a", Type: sqltypes.Int64}}
+	row := func(val string) []sqltypes.Value {
+		return []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte(val))}
+	}
+	results := make(chan interface{}, 10)
+	results <- &shardResult{shard: "-80", qr: &sqltypes.Result{Fields: fields, Rows: [][]sqltypes.Value{row("1"), row("4")}}}
+	results <- &shardResult{shard: "80-", qr: &sqltypes.Result{Fields: fields, Rows: [][]sqltypes.Value{row("2")}}}
+	results <- &shardResult{shard: "80-", qr: &sqltypes.Result{Rows: [][]sqltypes.Value{row("3"), row("5")}}}
+	results <- &shardResult{shard: "-80"}
+	results <- &shardResult{shard: "80-"}
+	close(results)
+
+	var got [][]sqltypes.Value
+	var gotFields []*querypb.Field
+	sm := newStreamMerger([]string{"-80", "80-"}, []orderByColumn{{Col: "a", Index: -1}}, 1, 3, func(qr *sqltypes.Result) error {
+		if qr.Fields != nil {
+			gotFields = qr.Fields
+		}
+		got = append(got, qr.Rows...)
+		return nil
+	})
+	stopped, err := sm.run(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stopped {
+		t.Errorf("run: stopped = false, want true")
+	}
+	if !reflect.DeepEqual(gotFields, fields) {
+		t.Errorf("fields: %v, want %v", gotFields, fields)
+	}
+	want := [][]sqltypes.Value{row("2"), row("3"), row("4")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows: %v, want %v", got, want)
+	}
+
+	results = make(chan interface{}, 10)
+	results <- &shardResult{shard: "-80", qr: &sqltypes.Result{Fields: fields, Rows: [][]sqltypes.Value{row("1")}}}
+	results <- &shardResult{shard: "80-", err: errors.New("shard failed")}
+	close(results)
+	got = nil
+	sm = newStreamMerger([]string{"-80", "80-"}, []orderByColumn{{Col: "a", Index: -1}}, 0, -1, func(qr *sqltypes.Result) error {
+		got = append(got, qr.Rows...)
+		return nil
+	})
+	stopped, err = sm.run(results)
+	if stopped || err != nil {
+		t.Errorf("run: %v, %v, want false, nil", stopped, err)
+	}
+	if got != nil {
+		t.Errorf("rows: %v, want nil", got)
+	}
+}
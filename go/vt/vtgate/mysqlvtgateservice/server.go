@@ -0,0 +1,161 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mysqlvtgateservice serves vtgate over the MySQL
+// client/server protocol, so MySQL clients can connect to it.
+package mysqlvtgateservice
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/mysqlconn"
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/servenv"
+	"github.com/youtube/vitess/go/vt/vtgate"
+	"github.com/youtube/vitess/go/vt/vtgate/vtgateservice"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+var (
+	mysqlServerPort = flag.Int("mysql_server_port", 0, "If set, also listen for MySQL binary protocol connections on this port.")
+)
+
+// vtgateHandler implements mysqlconn.Handler on top of a
+// vtgateservice.VTGateService. Each connection has its own
+// vtgatepb.Session, stored in its ClientData.
+type vtgateHandler struct {
+	vtg vtgateservice.VTGateService
+}
+
+func newVtgateHandler(vtg vtgateservice.VTGateService) *vtgateHandler {
+	return &vtgateHandler{
+		vtg: vtg,
+	}
+}
+
+// NewConnection is part of the mysqlconn.Handler interface.
+func (vh *vtgateHandler) NewConnection(c *mysqlconn.Conn) {
+	c.ClientData = &vtgatepb.Session{}
+}
+
+// ConnectionClosed is part of the mysqlconn.Handler interface.
+// It rolls back the transaction the connection left open, if any.
+func (vh *vtgateHandler) ConnectionClosed(c *mysqlconn.Conn) {
+	session, _ := c.ClientData.(*vtgatepb.Session)
+	if session == nil || !session.InTransaction {
+		return
+	}
+	if err := vh.vtg.Rollback(vh.newContext(c), session); err != nil {
+		log.Warningf("Cannot rollback the transaction of closed connection %v: %v", c.ConnectionID, err)
+	}
+}
+
+// ComQuery is part of the mysqlconn.Handler interface.
+func (vh *vtgateHandler) ComQuery(c *mysqlconn.Conn, query string) (*sqltypes.Result, error) {
+	ctx := vh.newContext(c)
+	session, _ := c.ClientData.(*vtgatepb.Session)
+	if session == nil {
+		return nil, fmt.Errorf("connection %v has no session", c.ConnectionID)
+	}
+
+	// The transaction statements are handled here, as the
+	// router doesn't know about them.
+	switch strings.ToLower(strings.TrimRight(strings.TrimSpace(query), "; \t\r\n")) {
+	case "begin", "start transaction":
+		if session.InTransaction {
+			// Like MySQL, BEGIN commits the current
+			// transaction first.
+			if err := vh.commit(ctx, c, session); err != nil {
+				return nil, err
+			}
+		}
+		newSession, err := vh.vtg.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// The session keeps the state set by USE and SET.
+		session.InTransaction = newSession.InTransaction
+		session.ShardSessions = newSession.ShardSessions
+		c.StatusFlags |= mysqlconn.ServerStatusInTrans
+		return &sqltypes.Result{}, nil
+	case "commit":
+		return &sqltypes.Result{}, vh.commit(ctx, c, session)
+	case "rollback":
+		if !session.InTransaction {
+			return &sqltypes.Result{}, nil
+		}
+		err := vh.vtg.Rollback(ctx, session)
+		vh.endTransaction(c, session)
+		return &sqltypes.Result{}, err
+	}
+
+	// The tablet type is overridden by the one of the session, if
+	// set by USE or SET. The router can also start a transaction,
+	// if autocommit is off.
+	result, err := vh.vtg.Execute(ctx, query, make(map[string]interface{}), topodatapb.TabletType_MASTER, session, false /* notInTransaction */)
+	vh.updateStatusFlags(c, session)
+	return result, err
+}
+
+// updateStatusFlags reflects the state of the session in the status
+// flags of the connection.
+func (vh *vtgateHandler) updateStatusFlags(c *mysqlconn.Conn, session *vtgatepb.Session) {
+	c.StatusFlags &^= mysqlconn.ServerStatusInTrans | mysqlconn.ServerStatusAutocommit
+	if session.InTransaction {
+		c.StatusFlags |= mysqlconn.ServerStatusInTrans
+	}
+	if !session.NoAutocommit {
+		c.StatusFlags |= mysqlconn.ServerStatusAutocommit
+	}
+}
+
+// commit commits the current transaction of the connection, if any.
+func (vh *vtgateHandler) commit(ctx context.Context, c *mysqlconn.Conn, session *vtgatepb.Session) error {
+	if !session.InTransaction {
+		return nil
+	}
+	err := vh.vtg.Commit(ctx, session)
+	vh.endTransaction(c, session)
+	return err
+}
+
+// endTransaction resets the session after a commit or a rollback,
+// which end the transaction even if they fail.
+func (vh *vtgateHandler) endTransaction(c *mysqlconn.Conn, session *vtgatepb.Session) {
+	session.InTransaction = false
+	session.ShardSessions = nil
+	vh.updateStatusFlags(c, session)
+}
+
+// newContext returns the context of a query. The caller id is the
+// user name the client sent in the handshake.
+func (vh *vtgateHandler) newContext(c *mysqlconn.Conn) context.Context {
+	return callerid.NewContext(context.Background(),
+		callerid.NewEffectiveCallerID(c.User, "" /* component */, "" /* subComponent */),
+		callerid.NewImmediateCallerID("mysql client"))
+}
+
+func init() {
+	vtgate.RegisterVTGates = append(vtgate.RegisterVTGates, func(vtGate vtgateservice.VTGateService) {
+		if *mysqlServerPort == 0 {
+			return
+		}
+		listener, err := mysqlconn.NewListener("tcp", net.JoinHostPort("", fmt.Sprintf("%v", *mysqlServerPort)), newVtgateHandler(vtGate))
+		if err != nil {
+			log.Fatalf("mysqlconn.NewListener failed: %v", err)
+		}
+		log.Infof("Listening for MySQL protocol connections on %v", listener.Addr())
+		go listener.Accept()
+		servenv.OnTerm(listener.Close)
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mysqlvtgateservice
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/mysqlconn"
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/vtgate/vtgateservice"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+// fakeVTGate records the calls the handler makes. The methods it
+// doesn't implement panic.
+type fakeVTGate struct {
+	vtgateservice.VTGateService
+	calls []string
+}
+
+func (f *fakeVTGate) Execute(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
+	f.calls = append(f.calls, callerid.EffectiveCallerIDFromContext(ctx).Principal+":"+sql)
+	if session.InTransaction {
+		session.ShardSessions = append(session.ShardSessions, &vtgatepb.Session_ShardSession{TransactionId: 1})
+	}
+	return &sqltypes.Result{RowsAffected: 1}, nil
+}
+
+func (f *fakeVTGate) Begin(ctx context.Context) (*vtgatepb.Session, error) {
+	f.calls = append(f.calls, "Begin")
+	return &vtgatepb.Session{InTransaction: true}, nil
+}
+
+func (f *fakeVTGate) Commit(ctx context.Context, session *vtgatepb.Session) error {
+	f.calls = append(f.calls, "Commit")
+	return nil
+}
+
+func (f *fakeVTGate) Rollback(ctx context.Context, session *vtgatepb.Session) error {
+	f.calls = append(f.calls, "Rollback")
+	return nil
+}
+
+func TestVtgateHandler(t *testing.T) {
+	f := &fakeVTGate{}
+	vh := newVtgateHandler(f)
+	c := &mysqlconn.Conn{User: "user1", StatusFlags: mysqlconn.ServerStatusAutocommit}
+	vh.NewConnection(c)
+	session := c.ClientData.(*vtgatepb.Session)
+
+	run := func(query string) {
+		if _, err := vh.ComQuery(c, query); err != nil {
+			t.Fatalf("ComQuery(%v) failed: %v", query, err)
+		}
+	}
+	checkTransaction := func(desc string, want bool) {
+		if session.InTransaction != want || (c.StatusFlags&mysqlconn.ServerStatusInTrans != 0) != want {
+			t.Errorf("%v: InTransaction: %v, StatusFlags: %v, want in transaction: %v", desc, session.InTransaction, c.StatusFlags, want)
+		}
+	}
+
+	run("insert into t1 values(1)")
+	checkTransaction("autocommit", false)
+
+	run("BEGIN")
+	checkTransaction("begin", true)
+	run("insert into t1 values(2)")
+	if len(session.ShardSessions) != 1 {
+		t.Errorf("ShardSessions: %v, want 1", session.ShardSessions)
+	}
+	run(" commit; ")
+	checkTransaction("commit", false)
+	if len(session.ShardSessions) != 0 {
+		t.Errorf("ShardSessions after commit: %v, want none", session.ShardSessions)
+	}
+
+	// BEGIN commits the open transaction, COMMIT and ROLLBACK
+	// without a transaction do nothing.
+	run("start transaction")
+	run("begin")
+	run("rollback")
+	checkTransaction("rollback", false)
+	run("commit")
+	run("rollback")
+
+	// BEGIN keeps the state set by USE and SET, and the status
+	// flags follow the session.
+	session.Keyspace = "ks"
+	session.NoAutocommit = true
+	run("begin")
+	if session.Keyspace != "ks" || !session.NoAutocommit {
+		t.Errorf("session after begin: %+v, want the USE and SET state", session)
+	}
+	run("rollback")
+	if c.StatusFlags&mysqlconn.ServerStatusAutocommit != 0 {
+		t.Errorf("StatusFlags: %v, want no autocommit", c.StatusFlags)
+	}
+
+	// Closing a connection rolls back its transaction.
+	run("begin")
+	vh.ConnectionClosed(c)
+
+	want := []string{
+		"user1:insert into t1 values(1)",
+		"Begin",
+		"user1:insert into t1 values(2)",
+		"Commit",
+		"Begin",
+		"Commit",
+		"Begin",
+		"Rollback",
+		"Begin",
+		"Rollback",
+		"Begin",
+		"Rollback",
+	}
+	if !reflect.DeepEqual(f.calls, want) {
+		t.Errorf("calls:\n%v\nwant:\n%v", f.calls, want)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/youtube/vitess/go/vt/sqlparser"
+)
+
+// AggregateOpcode is the aggregation function that VTGate
+// applies to combine the partial aggregates from each shard.
+type AggregateOpcode int
+
+// The following constants define all the AggregateOpcode values.
+const (
+	AggregateCount = AggregateOpcode(iota)
+	AggregateSum
+	AggregateMin
+	AggregateMax
+	AggregateAvg
+	NumAggregateOpcodes
+)
+
+// Must exactly match order of opcode constants.
+var aggregateName = [NumAggregateOpcodes]string{
+	"count",
+	"sum",
+	"min",
+	"max",
+	"avg",
+}
+
+func (code AggregateOpcode) String() string {
+	if code < 0 || code >= NumAggregateOpcodes {
+		return ""
+	}
+	return aggregateName[code]
+}
+
+// MarshalJSON serializes the opcode as a JSON string.
+func (code AggregateOpcode) MarshalJSON() ([]byte, error) {
+	return ([]byte)(fmt.Sprintf("\"%s\"", code.String())), nil
+}
+
+// Aggregate specifies how VTGate combines the partial
+// aggregates returned by the shards of a multi-shard SELECT.
+// Rows that have the same values for Keys are merged into
+// one row. Columns that are neither keys nor aggregates get
+// the value of the first row in the group.
+type Aggregate struct {
+	// Keys are the positions of the grouping columns
+	// in the rewritten select list.
+	Keys []int `json:",omitempty"`
+	// Aggregates lists the columns that need to be combined.
+	Aggregates []AggregateParams `json:",omitempty"`
+	// Columns is the number of columns in the original select
+	// list. Columns added by the rewrite are removed from
+	// the final result.
+	Columns int
+}
+
+// AggregateParams specifies how to combine an aggregate column.
+type AggregateParams struct {
+	Opcode AggregateOpcode
+	Index  int
+	// CountIndex and Name are used only by AggregateAvg.
+	// The shards return SUM at Index and COUNT at CountIndex.
+	// Name is the name of the column expected by the caller.
+	CountIndex int    `json:",omitempty"`
+	Name       string `json:",omitempty"`
+}
+
+// buildAggregate rewrites a multi-shard SELECT that has aggregates,
+// GROUP BY or DISTINCT such that each shard returns partial
+// aggregates, and fills plan.Aggregate with the instructions
+// to combine them. AVG is rewritten as SUM, and a COUNT is
+// added to the end of the select list. GROUP BY columns that are
+// not in the select list are also added to the end.
+func buildAggregate(sel *sqlparser.Select, plan *Plan) error {
+	if sel.Having != nil {
+		return errors.New("unsupported: having clause in multi-shard aggregate")
+	}
+	aggr := &Aggregate{Columns: len(sel.SelectExprs)}
+	var extra sqlparser.SelectExprs
+	for i, selectExpr := range sel.SelectExprs {
+		switch selectExpr := selectExpr.(type) {
+		case *sqlparser.StarExpr:
+			return errors.New("unsupported: '*' in multi-shard aggregate")
+		case *sqlparser.NonStarExpr:
+			funcExpr, ok := selectExpr.Expr.(*sqlparser.FuncExpr)
+			if !ok || !funcExpr.IsAggregate() {
+				if exprHasAggregates(selectExpr.Expr) {
+					return fmt.Errorf("unsupported: complex aggregate expression: %s", sqlparser.String(selectExpr.Expr))
+				}
+				continue
+			}
+			if funcExpr.Distinct && funcExpr.Name != "min" && funcExpr.Name != "max" {
+				return fmt.Errorf("unsupported: distinct aggregate in multi-shard query: %s", sqlparser.String(funcExpr))
+			}
+			params := AggregateParams{Index: i}
+			switch funcExpr.Name {
+			case "count":
+				params.Opcode = AggregateCount
+			case "sum":
+				params.Opcode = AggregateSum
+			case "min":
+				params.Opcode = AggregateMin
+			case "max":
+				params.Opcode = AggregateMax
+			case "avg":
+				params.Opcode = AggregateAvg
+				params.CountIndex = len(sel.SelectExprs) + len(extra)
+				params.Name = string(selectExpr.As)
+				if params.Name == "" {
+					params.Name = sqlparser.String(funcExpr)
+				}
+				extra = append(extra, &sqlparser.NonStarExpr{
+					Expr: &sqlparser.FuncExpr{Name: "count", Exprs: funcExpr.Exprs},
+				})
+				selectExpr.Expr = &sqlparser.FuncExpr{Name: "sum", Exprs: funcExpr.Exprs}
+			default:
+				return fmt.Errorf("unsupported: aggregate function %s in multi-shard query", funcExpr.Name)
+			}
+			aggr.Aggregates = append(aggr.Aggregates, params)
+		}
+	}
+	if sel.Distinct != "" {
+		if len(aggr.Aggregates) != 0 {
+			return errors.New("unsupported: distinct with aggregates in multi-shard query")
+		}
+		// SELECT DISTINCT is equivalent to grouping by
+		// all the columns of the select list.
+		for i := range sel.SelectExprs {
+			aggr.Keys = append(aggr.Keys, i)
+		}
+	} else {
+		for _, expr := range sel.GroupBy {
+			index, err := findGroupByColumn(sel.SelectExprs, expr)
+			if err != nil {
+				return err
+			}
+			if index == -1 {
+				index = len(sel.SelectExprs) + len(extra)
+				extra = append(extra, &sqlparser.NonStarExpr{Expr: expr})
+			}
+			aggr.Keys = append(aggr.Keys, index)
+		}
+	}
+	sel.SelectExprs = append(sel.SelectExprs, extra...)
+	plan.Aggregate = aggr
+	return nil
+}
+
+// findGroupByColumn returns the position of the GROUP BY
+// expression in the select list, or -1 if it's a column
+// that's not in the select list.
+func findGroupByColumn(selectExprs sqlparser.SelectExprs, expr sqlparser.ValExpr) (int, error) {
+	switch expr := expr.(type) {
+	case sqlparser.NumVal:
+		pos, err := strconv.ParseInt(string(expr), 0, 64)
+		if err != nil || pos < 1 || pos > int64(len(selectExprs)) {
+			return 0, fmt.Errorf("invalid group by column number: %s", sqlparser.String(expr))
+		}
+		return int(pos - 1), nil
+	case *sqlparser.ColName:
+		for i, selectExpr := range selectExprs {
+			if selectExpr, ok := selectExpr.(*sqlparser.NonStarExpr); ok && selectExprMatches(selectExpr, expr) {
+				return i, nil
+			}
+		}
+		return -1, nil
+	}
+	return 0, fmt.Errorf("unsupported: complex group by expression: %s", sqlparser.String(expr))
+}
@@ -7,6 +7,7 @@ package planbuilder
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/youtube/vitess/go/vt/sqlparser"
 )
@@ -26,28 +27,113 @@ func buildUpdatePlan(upd *sqlparser.Update, schema *Schema) *Plan {
 		return plan
 	}
 
-	getWhereRouting(upd.Where, plan, true)
+	getWhereRouting(upd.Where, plan, singleTable(plan.Table), true)
+	// An IN clause on the vindex is rewritten as a list bind var.
+	plan.Rewritten = generateQuery(upd)
 	switch plan.ID {
 	case SelectEqual:
 		plan.ID = UpdateEqual
-	case SelectIN, SelectScatter, SelectKeyrange:
+	case SelectIN, SelectScatter:
+		if upd.Limit != nil {
+			plan.ID = NoPlan
+			plan.Reason = "unsupported: limit in multi-shard update"
+			return plan
+		}
+		if plan.ID == SelectIN {
+			plan.ID = UpdateIN
+		} else {
+			plan.ID = UpdateScatter
+		}
+	case SelectKeyrange:
 		plan.ID = NoPlan
-		plan.Reason = "update has multi-shard where clause"
+		plan.Reason = "keyrange not allowed in update"
+		return plan
+	case NoPlan:
 		return plan
 	default:
 		panic("unexpected")
 	}
 	if isIndexChanging(upd.Exprs, plan.Table.ColVindexes) {
-		plan.ID = NoPlan
-		plan.Reason = "index is changing"
+		buildVindexChangePlan(upd, plan)
 	}
 	return plan
 }
 
+// buildVindexChangePlan sets up the plan of an update that changes
+// a vindex column. If a primary vindex column changes, the rows are
+// moved: they're deleted from their shard, and inserted back with the
+// new values, which is where they now belong. For this, all the new
+// values must be known to VTGate. Otherwise, the rows stay in their
+// shard: only the entries of the changed lookup vindexes are updated,
+// before the update is sent as is. In both cases, the rows must come
+// from a single keyspace id. A LIMIT is not supported, because the
+// rows VTGate fetches to update the vindexes could then differ from
+// the ones of the update.
+func buildVindexChangePlan(upd *sqlparser.Update, plan *Plan) {
+	if plan.ID != UpdateEqual {
+		plan.ID = NoPlan
+		plan.Reason = "unsupported: multi-shard update that changes a vindex column"
+		return
+	}
+	if upd.Limit != nil {
+		plan.ID = NoPlan
+		plan.Reason = "unsupported: limit in update that changes a vindex column"
+		return
+	}
+	if !isIndexChanging(upd.Exprs, plan.Table.ColVindexes[:1]) {
+		buildLookupChangePlan(upd, plan)
+		return
+	}
+	changes := make(map[string]interface{}, len(upd.Exprs))
+	for _, assignment := range upd.Exprs {
+		val, err := asInterface(assignment.Expr)
+		if err != nil {
+			plan.ID = NoPlan
+			plan.Reason = fmt.Sprintf("unsupported: update that changes a vindex column: %v", err)
+			return
+		}
+		changes[string(assignment.Name.Name)] = val
+	}
+	plan.ID = UpdateVindexChange
+	plan.Changes = changes
+	where := sqlparser.String(upd.Where) + sqlparser.String(upd.OrderBy)
+	plan.Subquery = fmt.Sprintf("select * from %s%s for update", plan.Table.Name, where)
+	plan.Rewritten = fmt.Sprintf("delete from %s%s", plan.Table.Name, where)
+}
+
+// buildLookupChangePlan sets up the plan of an update that changes
+// the columns of lookup vindexes only. The new values of these
+// columns must be known to VTGate. The Subquery fetches the current
+// values of all the columns of the changed vindexes.
+func buildLookupChangePlan(upd *sqlparser.Update, plan *Plan) {
+	changes := make(map[string]interface{})
+	for _, assignment := range upd.Exprs {
+		if !isIndexChanging(sqlparser.UpdateExprs{assignment}, plan.Table.ColVindexes) {
+			continue
+		}
+		val, err := asInterface(assignment.Expr)
+		if err != nil {
+			plan.ID = NoPlan
+			plan.Reason = fmt.Sprintf("unsupported: update that changes a vindex column: %v", err)
+			return
+		}
+		changes[string(assignment.Name.Name)] = val
+	}
+	var cols []string
+	for _, colVindex := range plan.Table.ColVindexes[1:] {
+		if isIndexChanging(upd.Exprs, []*ColVindex{colVindex}) {
+			cols = append(cols, colVindex.Cols...)
+		}
+	}
+	plan.ID = UpdateLookupChange
+	plan.Changes = changes
+	plan.Subquery = fmt.Sprintf("select %s from %s%s for update", strings.Join(cols, ", "), plan.Table.Name, sqlparser.String(upd.Where))
+}
+
 func isIndexChanging(setClauses sqlparser.UpdateExprs, colVindexes []*ColVindex) bool {
-	vindexCols := make([]string, len(colVindexes))
-	for i, index := range colVindexes {
-		vindexCols[i] = index.Col
+	var vindexCols []string
+	for _, index := range colVindexes {
+		vindexCols = append(vindexCols, index.Cols...)
 	}
 	for _, assignment := range setClauses {
 		if sqlparser.StringIn(string(assignment.Name.Name), vindexCols...) {
@@ -72,21 +158,42 @@ func buildDeletePlan(del *sqlparser.Delete, schema *Schema) *Plan {
 		return plan
 	}
 
-	getWhereRouting(del.Where, plan, true)
+	getWhereRouting(del.Where, plan, singleTable(plan.Table), true)
+	// An IN clause on the vindex is rewritten as a list bind var.
+	plan.Rewritten = generateQuery(del)
 	switch plan.ID {
 	case SelectEqual:
 		plan.ID = DeleteEqual
-		plan.Subquery = generateDeleteSubquery(del, plan.Table)
-	case SelectIN, SelectScatter, SelectKeyrange:
+		plan.Subquery = generateDeleteSubquery(del, plan.Table, false)
+	case SelectIN, SelectScatter:
+		if del.Limit != nil {
+			plan.ID = NoPlan
+			plan.Reason = "unsupported: limit in multi-shard delete"
+			return plan
+		}
+		if plan.ID == SelectIN {
+			plan.ID = DeleteIN
+		} else {
+			plan.ID = DeleteScatter
+		}
+		plan.Subquery = generateDeleteSubquery(del, plan.Table, true)
+	case SelectKeyrange:
 		plan.ID = NoPlan
-		plan.Reason = "delete has multi-shard where clause"
+		plan.Reason = "keyrange not allowed in delete"
+	case NoPlan:
 	default:
 		panic("unexpected")
 	}
 	return plan
 }
 
-func generateDeleteSubquery(del *sqlparser.Delete, table *Table) string {
+// generateDeleteSubquery builds the query that fetches the values
+// of the owned vindex columns of the rows to be deleted. If multiShard
+// is set, the values of the primary vindex columns follow them. The
+// ORDER BY and LIMIT of the delete are kept, so that the query fetches
+// the rows the delete removes from a single shard. A multi-shard delete
+// can't have a LIMIT.
+func generateDeleteSubquery(del *sqlparser.Delete, table *Table, multiShard bool) string {
 	if len(table.Owned) == 0 {
 		return ""
 	}
@@ -98,8 +205,16 @@ func generateDeleteSubquery(del *sqlparser.Delete, table *Table) string {
 		buf.WriteString(cv.Col)
 		prefix = ", "
 	}
+	if multiShard {
+		for _, col := range table.ColVindexes[0].Cols {
+			buf.WriteString(prefix)
+			buf.WriteString(col)
+		}
+	}
 	fmt.Fprintf(buf, " from %s", table.Name)
 	buf.WriteString(sqlparser.String(del.Where))
+	buf.WriteString(sqlparser.String(del.OrderBy))
+	buf.WriteString(sqlparser.String(del.Limit))
 	buf.WriteString(" for update")
 	return buf.String()
 }
@@ -0,0 +1,208 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planbuilder
+
+import (
+	"fmt"
+
+	"github.com/youtube/vitess/go/vt/sqlparser"
+)
+
+// tableAlias is a table referenced by the FROM clause.
+type tableAlias struct {
+	// Name is the name that the columns of the table can be
+	// qualified with: the alias if there is one, or the table name.
+	Name  string
+	Table *Table
+	// Implicit is true if the columns of the table can also be
+	// referenced without a qualifier. This is the case only if
+	// the statement has a single table.
+	Implicit bool
+}
+
+// singleTable returns the FROM clause of a statement
+// that references only table, like a DML.
+func singleTable(table *Table) []*tableAlias {
+	return []*tableAlias{{Name: table.Name, Table: table, Implicit: true}}
+}
+
+// analyzeFrom returns the tables of the FROM clause in the order
+// in which they appear, along with the conditions of the ON clauses.
+// If the tables can't be resolved, it returns a reason instead.
+func analyzeFrom(tableExprs sqlparser.TableExprs, schema *Schema) (from []*tableAlias, on []sqlparser.BoolExpr, reason string) {
+	from, on, reason = analyzeTableExprs(tableExprs, schema, nil, nil)
+	if reason != "" {
+		return nil, nil, reason
+	}
+	if len(from) == 1 {
+		from[0].Implicit = true
+	}
+	return from, on, ""
+}
+
+func analyzeTableExprs(tableExprs sqlparser.TableExprs, schema *Schema, from []*tableAlias, on []sqlparser.BoolExpr) ([]*tableAlias, []sqlparser.BoolExpr, string) {
+	for _, tableExpr := range tableExprs {
+		var reason string
+		from, on, reason = analyzeTableExpr(tableExpr, schema, from, on)
+		if reason != "" {
+			return nil, nil, reason
+		}
+	}
+	return from, on, ""
+}
+
+func analyzeTableExpr(tableExpr sqlparser.TableExpr, schema *Schema, from []*tableAlias, on []sqlparser.BoolExpr) ([]*tableAlias, []sqlparser.BoolExpr, string) {
+	switch tableExpr := tableExpr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		table, reason := schema.FindTable(sqlparser.GetTableName(tableExpr.Expr))
+		if reason != "" {
+			return nil, nil, reason
+		}
+		name := string(tableExpr.As)
+		if name == "" {
+			name = table.Name
+		}
+		for _, ta := range from {
+			if ta.Name == name {
+				return nil, nil, fmt.Sprintf("duplicate table alias %s", name)
+			}
+		}
+		return append(from, &tableAlias{Name: name, Table: table}), on, ""
+	case *sqlparser.ParenTableExpr:
+		return analyzeTableExprs(tableExpr.Exprs, schema, from, on)
+	case *sqlparser.JoinTableExpr:
+		var reason string
+		from, on, reason = analyzeTableExpr(tableExpr.LeftExpr, schema, from, on)
+		if reason != "" {
+			return nil, nil, reason
+		}
+		from, on, reason = analyzeTableExpr(tableExpr.RightExpr, schema, from, on)
+		if reason != "" {
+			return nil, nil, reason
+		}
+		if tableExpr.On != nil {
+			on = append(on, tableExpr.On)
+		}
+		return from, on, ""
+	}
+	return nil, nil, "complex table expression"
+}
+
+// checkJoin verifies that the join of the tables in from can be
+// served by sending the query as is to the shards. All the tables
+// must belong to the same keyspace. If the keyspace is sharded, the
+// tables must share the same primary vindex, and every table must be
+// joined to the others on its primary vindex column by an equality
+// of the ON or WHERE clause. This guarantees that matching rows live
+// in the same shard. It returns a reason if the join can't be served.
+func checkJoin(from []*tableAlias, on []sqlparser.BoolExpr, where *sqlparser.Where) string {
+	if len(from) == 1 {
+		return ""
+	}
+	keyspace := from[0].Table.Keyspace
+	for _, ta := range from[1:] {
+		if ta.Table.Keyspace.Name != keyspace.Name {
+			if allUnsharded(from) {
+				return "unsupported: join across unsharded keyspaces"
+			}
+			return "unsupported: join across keyspaces"
+		}
+	}
+	if !keyspace.Sharded {
+		return ""
+	}
+	var conds []sqlparser.BoolExpr
+	for _, cond := range on {
+		conds = splitAndExpression(conds, cond)
+	}
+	if where != nil {
+		conds = splitAndExpression(conds, where.Expr)
+	}
+	// groups tracks which tables are known to be in the same
+	// shard, by mapping each table to a representative table.
+	groups := make([]int, len(from))
+	for i := range groups {
+		groups[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if groups[i] != i {
+			groups[i] = find(groups[i])
+		}
+		return groups[i]
+	}
+	for _, cond := range conds {
+		comparison, ok := cond.(*sqlparser.ComparisonExpr)
+		if !ok || comparison.Operator != sqlparser.EqualStr {
+			continue
+		}
+		left := findPrimaryVindexTable(from, comparison.Left)
+		right := findPrimaryVindexTable(from, comparison.Right)
+		if left == -1 || right == -1 {
+			continue
+		}
+		if from[left].Table.ColVindexes[0].Name != from[right].Table.ColVindexes[0].Name {
+			continue
+		}
+		groups[find(left)] = find(right)
+	}
+	for i := range from {
+		if find(i) != find(0) {
+			return "unsupported: cross-shard join"
+		}
+	}
+	return ""
+}
+
+// allUnsharded returns true if all the tables of from belong
+// to unsharded keyspaces.
+func allUnsharded(from []*tableAlias) bool {
+	for _, ta := range from {
+		if ta.Table.Keyspace.Sharded {
+			return false
+		}
+	}
+	return true
+}
+
+// findPrimaryVindexTable returns the position in from of the table
+// whose primary vindex column is referenced by node, or -1 if there
+// is no such table. Only qualified column names are considered.
+// Multi-column primary vindexes are not considered, because a single
+// column doesn't determine their keyspace id.
+func findPrimaryVindexTable(from []*tableAlias, node sqlparser.ValExpr) int {
+	colname, ok := node.(*sqlparser.ColName)
+	if !ok || colname.Qualifier == "" {
+		return -1
+	}
+	for i, ta := range from {
+		if string(colname.Qualifier) != ta.Name || len(ta.Table.ColVindexes) == 0 {
+			continue
+		}
+		if len(ta.Table.ColVindexes[0].Cols) != 1 {
+			return -1
+		}
+		if string(colname.Name) == ta.Table.ColVindexes[0].Col {
+			return i
+		}
+		return -1
+	}
+	return -1
+}
+
+// splitAndExpression breaks up the BoolExpr into AND-separated
+// conditions and appends them to filters.
+func splitAndExpression(filters []sqlparser.BoolExpr, node sqlparser.BoolExpr) []sqlparser.BoolExpr {
+	switch node := node.(type) {
+	case *sqlparser.AndExpr:
+		filters = splitAndExpression(filters, node.Left)
+		return splitAndExpression(filters, node.Right)
+	case *sqlparser.ParenBoolExpr:
+		if node, ok := node.Expr.(*sqlparser.AndExpr); ok {
+			return splitAndExpression(filters, node)
+		}
+	}
+	return append(filters, node)
+}
@@ -10,6 +10,10 @@ import (
 	"github.com/youtube/vitess/go/vt/sqlparser"
 )
 
+// SeqVarName is the prefix of the bind vars that hold
+// the values of the auto-increment column, one per row.
+const SeqVarName = "__seq"
+
 func buildInsertPlan(ins *sqlparser.Insert, schema *Schema) *Plan {
 	plan := &Plan{
 		ID:        NoPlan,
@@ -39,53 +43,132 @@ func buildInsertPlan(ins *sqlparser.Insert, schema *Schema) *Plan {
 	default:
 		panic("unexpected")
 	}
-	if len(values) != 1 {
-		plan.Reason = "multi-row inserts not supported"
-		return plan
-	}
-	switch values[0].(type) {
-	case *sqlparser.Subquery:
-		plan.Reason = "subqueries not allowed"
-		return plan
-	}
-	row := values[0].(sqlparser.ValTuple)
-	if len(ins.Columns) != len(row) {
-		plan.Reason = "column list doesn't match values"
-		return plan
+	for _, row := range values {
+		switch row := row.(type) {
+		case *sqlparser.Subquery:
+			plan.Reason = "subqueries not allowed"
+			return plan
+		case sqlparser.ValTuple:
+			if len(ins.Columns) != len(row) {
+				plan.Reason = "column list doesn't match values"
+				return plan
+			}
+		}
 	}
-	colVindexes := schema.Tables[tablename].ColVindexes
 	plan.ID = InsertSharded
-	plan.Values = make([]interface{}, 0, len(colVindexes))
-	for _, index := range colVindexes {
-		if err := buildIndexPlan(ins, tablename, index, plan); err != nil {
+	if plan.Table.Autoinc != nil {
+		if err := buildAutoincPlan(ins, plan.Table.Autoinc, plan); err != nil {
 			plan.ID = NoPlan
 			plan.Reason = err.Error()
 			return plan
 		}
 	}
+	colVindexes := schema.Tables[tablename].ColVindexes
+	rowValues := make([]interface{}, 0, len(values))
+	for rowNum := range values {
+		vals := make([]interface{}, 0, len(colVindexes))
+		for _, index := range colVindexes {
+			val, err := buildIndexValue(ins, rowNum, index)
+			if err != nil {
+				plan.ID = NoPlan
+				plan.Reason = err.Error()
+				return plan
+			}
+			vals = append(vals, val)
+		}
+		rowValues = append(rowValues, vals)
+	}
+	plan.Values = rowValues
 	plan.Rewritten = generateQuery(ins)
+	buildInsertParts(ins, plan)
 	return plan
 }
 
-func buildIndexPlan(ins *sqlparser.Insert, tablename string, colVindex *ColVindex, plan *Plan) error {
-	pos := -1
-	for i, column := range ins.Columns {
-		if colVindex.Col == sqlparser.GetColName(column.(*sqlparser.NonStarExpr).Expr) {
-			pos = i
-			break
+// buildAutoincPlan sets up the plan to generate the values of the
+// auto-increment column from its sequence. The value of each row
+// is replaced with a :__seq bind var suffixed by the row number,
+// which is set to the supplied or generated value at execution time.
+// If the column is also a vindex column, its vindex picks up the
+// bind var like any other value.
+func buildAutoincPlan(ins *sqlparser.Insert, autoinc *Autoinc, plan *Plan) error {
+	pos := findOrAddColumn(ins, autoinc.Col)
+	values := ins.Rows.(sqlparser.Values)
+	vals := make([]interface{}, 0, len(values))
+	for rowNum, row := range values {
+		row := row.(sqlparser.ValTuple)
+		val, err := asInterface(row[pos])
+		if err != nil {
+			return fmt.Errorf("could not convert val: %s, pos: %d: %v", sqlparser.String(row[pos]), pos, err)
 		}
+		vals = append(vals, val)
+		row[pos] = sqlparser.ValArg([]byte(fmt.Sprintf(":%s%d", SeqVarName, rowNum)))
+	}
+	plan.Generate = &Generate{
+		Query:  fmt.Sprintf("select nextval(:n) from %s", autoinc.Sequence.Name),
+		Values: vals,
+	}
+	return nil
+}
+
+// buildIndexValue returns the value of colVindex for the row
+// rowNum of the insert.
+func buildIndexValue(ins *sqlparser.Insert, rowNum int, colVindex *ColVindex) (interface{}, error) {
+	if len(colVindex.Cols) == 1 {
+		return buildColumnValue(ins, rowNum, colVindex.Col)
 	}
-	if pos == -1 {
-		pos = len(ins.Columns)
-		ins.Columns = append(ins.Columns, &sqlparser.NonStarExpr{Expr: &sqlparser.ColName{Name: sqlparser.SQLName(colVindex.Col)}})
-		ins.Rows.(sqlparser.Values)[0] = append(ins.Rows.(sqlparser.Values)[0].(sqlparser.ValTuple), &sqlparser.NullVal{})
+	// The value of a multi-column vindex is the list
+	// of the values of its columns.
+	vals := make([]interface{}, 0, len(colVindex.Cols))
+	for _, col := range colVindex.Cols {
+		val, err := buildColumnValue(ins, rowNum, col)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
 	}
-	row := ins.Rows.(sqlparser.Values)[0].(sqlparser.ValTuple)
+	return vals, nil
+}
+
+// buildColumnValue returns the value inserted in col for the
+// row rowNum, and replaces it with the :_col bind var suffixed
+// by the row number. If col is not in the column list, it's
+// added with a NULL value.
+func buildColumnValue(ins *sqlparser.Insert, rowNum int, col string) (interface{}, error) {
+	pos := findOrAddColumn(ins, col)
+	row := ins.Rows.(sqlparser.Values)[rowNum].(sqlparser.ValTuple)
 	val, err := asInterface(row[pos])
 	if err != nil {
-		return fmt.Errorf("could not convert val: %s, pos: %d: %v", sqlparser.String(row[pos]), pos, err)
+		return nil, fmt.Errorf("could not convert val: %s, pos: %d: %v", sqlparser.String(row[pos]), pos, err)
 	}
-	plan.Values = append(plan.Values.([]interface{}), val)
-	row[pos] = sqlparser.ValArg([]byte(fmt.Sprintf(":_%s", colVindex.Col)))
-	return nil
+	row[pos] = sqlparser.ValArg([]byte(fmt.Sprintf(":_%s%d", col, rowNum)))
+	return val, nil
+}
+
+// findOrAddColumn returns the position of col in the column
+// list of the insert. If col is not in the list, it's added
+// with a NULL value for every row.
+func findOrAddColumn(ins *sqlparser.Insert, col string) int {
+	for i, column := range ins.Columns {
+		if col == sqlparser.GetColName(column.(*sqlparser.NonStarExpr).Expr) {
+			return i
+		}
+	}
+	ins.Columns = append(ins.Columns, &sqlparser.NonStarExpr{Expr: &sqlparser.ColName{Name: sqlparser.SQLName(col)}})
+	values := ins.Rows.(sqlparser.Values)
+	for i, row := range values {
+		values[i] = append(row.(sqlparser.ValTuple), &sqlparser.NullVal{})
+	}
+	return len(ins.Columns) - 1
+}
+
+// buildInsertParts splits the rewritten insert into the parts
+// that are used to build the insert for each shard.
+func buildInsertParts(ins *sqlparser.Insert, plan *Plan) {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("insert %v%sinto %v%v values ", ins.Comments, ins.Ignore, ins.Table, ins.Columns)
+	plan.Prefix = buf.String()
+	for _, row := range ins.Rows.(sqlparser.Values) {
+		plan.Mid = append(plan.Mid, sqlparser.String(row))
+	}
+	plan.Suffix = sqlparser.String(ins.OnDup)
 }
@@ -0,0 +1,371 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/youtube/vitess/go/vt/sqlparser"
+)
+
+// Join is the plan for a join whose tables can't be served by
+// the same shards. VTGate executes it as a nested loop: it first
+// executes Left, and then executes Right for every row returned
+// by Left, with the join columns of the row as bind variables.
+type Join struct {
+	// IsLeft is true for a LEFT JOIN. The left rows that
+	// have no match are returned with NULL right columns.
+	IsLeft bool `json:",omitempty"`
+	// Left and Right are the plans for the queries that fetch
+	// the rows of each table.
+	Left, Right *Plan
+	// Cols specifies the source of each column of the result.
+	// A negative value -n refers to column n-1 of Left, and
+	// a positive value n refers to column n-1 of Right.
+	Cols []int
+	// Vars maps the bind variables used by Right to the
+	// positions of the Left columns that supply their values.
+	Vars map[string]int `json:",omitempty"`
+}
+
+// buildJoinPlan builds a Join plan for a SELECT that joins
+// two tables that can't be served by the same shards.
+func buildJoinPlan(sel *sqlparser.Select, schema *Schema, from []*tableAlias) *Plan {
+	plan := &Plan{ID: NoPlan, Table: from[0].Table}
+	join, err := buildJoin(sel, schema, from)
+	if err != nil {
+		plan.Reason = err.Error()
+		return plan
+	}
+	plan.ID = SelectJoin
+	plan.Join = join
+	return plan
+}
+
+// The sides of a join that an expression can reference.
+const (
+	sideNone = iota
+	sideLeft
+	sideRight
+	sideBoth
+)
+
+// joinBuilder splits a join into the queries for each side.
+type joinBuilder struct {
+	left, right *tableAlias
+	leftExprs   sqlparser.SelectExprs
+	rightExprs  sqlparser.SelectExprs
+	leftConds   []sqlparser.BoolExpr
+	rightConds  []sqlparser.BoolExpr
+	join        *Join
+}
+
+func buildJoin(sel *sqlparser.Select, schema *Schema, from []*tableAlias) (*Join, error) {
+	if hasPostProcessing(sel) {
+		return nil, errors.New("unsupported: aggregates or grouping in cross-shard join")
+	}
+	if sel.Limit != nil {
+		return nil, errors.New("unsupported: limit in cross-shard join")
+	}
+	if sel.Where != nil && hasSubquery(sel.Where.Expr) {
+		return nil, errors.New("unsupported: subquery in cross-shard join")
+	}
+	leftExpr, rightExpr, isLeft, on, err := splitJoin(sel.From)
+	if err != nil {
+		return nil, err
+	}
+	jb := &joinBuilder{
+		left:  findTableAlias(from, leftExpr),
+		right: findTableAlias(from, rightExpr),
+		join:  &Join{IsLeft: isLeft, Vars: make(map[string]int)},
+	}
+	if err := jb.splitSelectExprs(sel.SelectExprs); err != nil {
+		return nil, err
+	}
+	var onConds, whereConds []sqlparser.BoolExpr
+	if on != nil {
+		onConds = splitAndExpression(nil, on)
+	}
+	if sel.Where != nil {
+		whereConds = splitAndExpression(nil, sel.Where.Expr)
+	}
+	if isLeft {
+		// The ON clause of a LEFT JOIN only decides which right
+		// rows match. It can't filter the left rows.
+		for _, cond := range onConds {
+			jb.rightConds = append(jb.rightConds, jb.normalizeEquality(cond))
+		}
+	} else {
+		whereConds = append(onConds, whereConds...)
+	}
+	if err := jb.splitConds(whereConds); err != nil {
+		return nil, err
+	}
+	orderBy, err := jb.leftOrderBy(sel.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jb.rightExprs) == 0 {
+		// The right query must still return a row for each match.
+		jb.rightExprs = sqlparser.SelectExprs{&sqlparser.NonStarExpr{Expr: sqlparser.NumVal("1")}}
+	}
+	rightSel := &sqlparser.Select{
+		SelectExprs: jb.rightExprs,
+		From:        sqlparser.TableExprs{rightExpr},
+		Where:       sqlparser.NewWhere(sqlparser.WhereStr, joinConds(jb.rightConds)),
+		Lock:        sel.Lock,
+	}
+	// The right query must be generated first because
+	// it adds the join columns to the left select list.
+	buf := sqlparser.NewTrackedBuffer(jb.formatRight)
+	buf.Myprintf("%v", rightSel)
+	rightQuery := buf.String()
+	if len(jb.leftExprs) == 0 {
+		jb.leftExprs = sqlparser.SelectExprs{&sqlparser.NonStarExpr{Expr: sqlparser.NumVal("1")}}
+	}
+	leftSel := &sqlparser.Select{
+		SelectExprs: jb.leftExprs,
+		From:        sqlparser.TableExprs{leftExpr},
+		Where:       sqlparser.NewWhere(sqlparser.WhereStr, joinConds(jb.leftConds)),
+		OrderBy:     orderBy,
+		Lock:        sel.Lock,
+	}
+	leftQuery := generateQuery(leftSel)
+
+	if jb.join.Left, err = buildJoinSide(leftQuery, schema); err != nil {
+		return nil, err
+	}
+	if jb.join.Right, err = buildJoinSide(rightQuery, schema); err != nil {
+		return nil, err
+	}
+	return jb.join, nil
+}
+
+// splitJoin returns the two sides of a join. A RIGHT JOIN is
+// converted to a LEFT JOIN by swapping its sides.
+func splitJoin(tableExprs sqlparser.TableExprs) (left, right *sqlparser.AliasedTableExpr, isLeft bool, on sqlparser.BoolExpr, err error) {
+	var leftExpr, rightExpr sqlparser.TableExpr
+	switch {
+	case len(tableExprs) == 2:
+		leftExpr, rightExpr = tableExprs[0], tableExprs[1]
+	case len(tableExprs) == 1:
+		join, ok := tableExprs[0].(*sqlparser.JoinTableExpr)
+		if !ok {
+			return nil, nil, false, nil, errors.New("unsupported: complex join in cross-shard join")
+		}
+		leftExpr, rightExpr, on = join.LeftExpr, join.RightExpr, join.On
+		switch join.Join {
+		case sqlparser.JoinStr, sqlparser.StraightJoinStr:
+		case sqlparser.LeftJoinStr:
+			isLeft = true
+		case sqlparser.RightJoinStr:
+			isLeft = true
+			leftExpr, rightExpr = rightExpr, leftExpr
+		default:
+			return nil, nil, false, nil, fmt.Errorf("unsupported: %s in cross-shard join", join.Join)
+		}
+	}
+	left, lok := leftExpr.(*sqlparser.AliasedTableExpr)
+	right, rok := rightExpr.(*sqlparser.AliasedTableExpr)
+	if !lok || !rok {
+		return nil, nil, false, nil, errors.New("unsupported: complex join in cross-shard join")
+	}
+	return left, right, isLeft, on, nil
+}
+
+func findTableAlias(from []*tableAlias, node *sqlparser.AliasedTableExpr) *tableAlias {
+	name := string(node.As)
+	if name == "" {
+		name = sqlparser.GetTableName(node.Expr)
+	}
+	for _, ta := range from {
+		if ta.Name == name {
+			return ta
+		}
+	}
+	panic("unexpected")
+}
+
+// side returns the sides of the join referenced by node.
+// All column references must be qualified.
+func (jb *joinBuilder) side(node sqlparser.SQLNode) (int, error) {
+	side := sideNone
+	var err error
+	buf := sqlparser.NewTrackedBuffer(func(buf *sqlparser.TrackedBuffer, node sqlparser.SQLNode) {
+		if col, ok := node.(*sqlparser.ColName); ok && err == nil {
+			switch string(col.Qualifier) {
+			case "":
+				err = fmt.Errorf("unsupported: unqualified column %s in cross-shard join", sqlparser.String(col))
+			case jb.left.Name:
+				side |= sideLeft
+			case jb.right.Name:
+				side |= sideRight
+			default:
+				err = fmt.Errorf("unsupported: column %s does not reference a table of the join", sqlparser.String(col))
+			}
+		}
+		node.Format(buf)
+	})
+	buf.Myprintf("%v", node)
+	return side, err
+}
+
+func (jb *joinBuilder) splitSelectExprs(selectExprs sqlparser.SelectExprs) error {
+	for _, selectExpr := range selectExprs {
+		selectExpr, ok := selectExpr.(*sqlparser.NonStarExpr)
+		if !ok {
+			return errors.New("unsupported: '*' in cross-shard join")
+		}
+		side, err := jb.side(selectExpr.Expr)
+		if err != nil {
+			return err
+		}
+		switch side {
+		case sideNone, sideLeft:
+			jb.leftExprs = append(jb.leftExprs, selectExpr)
+			jb.join.Cols = append(jb.join.Cols, -len(jb.leftExprs))
+		case sideRight:
+			jb.rightExprs = append(jb.rightExprs, selectExpr)
+			jb.join.Cols = append(jb.join.Cols, len(jb.rightExprs))
+		default:
+			return fmt.Errorf("unsupported: select expression references both sides of cross-shard join: %s", sqlparser.String(selectExpr))
+		}
+	}
+	return nil
+}
+
+// splitConds assigns the conditions to the query of the side they
+// reference. Conditions that reference both sides are evaluated by
+// the right query, with the left columns replaced by bind variables.
+func (jb *joinBuilder) splitConds(conds []sqlparser.BoolExpr) error {
+	for _, cond := range conds {
+		side, err := jb.side(cond)
+		if err != nil {
+			return err
+		}
+		switch {
+		case side == sideNone || side == sideLeft:
+			jb.leftConds = append(jb.leftConds, cond)
+		case jb.join.IsLeft:
+			return errors.New("unsupported: where clause references the right table of a left join")
+		default:
+			jb.rightConds = append(jb.rightConds, jb.normalizeEquality(cond))
+		}
+	}
+	return nil
+}
+
+// normalizeEquality swaps the operands of an equality between a
+// left and a right column, such that the right column comes first.
+// This allows the right query to be routed by the join column.
+func (jb *joinBuilder) normalizeEquality(cond sqlparser.BoolExpr) sqlparser.BoolExpr {
+	comparison, ok := cond.(*sqlparser.ComparisonExpr)
+	if !ok || comparison.Operator != sqlparser.EqualStr {
+		return cond
+	}
+	left, _ := jb.side(comparison.Left)
+	right, _ := jb.side(comparison.Right)
+	if left == sideLeft && right == sideRight {
+		comparison.Left, comparison.Right = comparison.Right, comparison.Left
+	}
+	return cond
+}
+
+// leftOrderBy returns the ORDER BY clause of the left query.
+// The order of the left rows is preserved by the nested loop,
+// so only left columns can be used.
+func (jb *joinBuilder) leftOrderBy(orderBy sqlparser.OrderBy) (sqlparser.OrderBy, error) {
+	var leftOrderBy sqlparser.OrderBy
+	for _, order := range orderBy {
+		if num, ok := order.Expr.(sqlparser.NumVal); ok {
+			pos, err := strconv.ParseInt(string(num), 0, 64)
+			if err != nil || pos < 1 || pos > int64(len(jb.join.Cols)) {
+				return nil, fmt.Errorf("invalid order by column number: %s", num)
+			}
+			col := jb.join.Cols[pos-1]
+			if col > 0 {
+				return nil, errors.New("unsupported: order by on the right table of a cross-shard join")
+			}
+			leftOrderBy = append(leftOrderBy, &sqlparser.Order{
+				Expr:      sqlparser.NumVal(strconv.Itoa(-col)),
+				Direction: order.Direction,
+			})
+			continue
+		}
+		side, err := jb.side(order.Expr)
+		if err != nil {
+			return nil, err
+		}
+		if side != sideNone && side != sideLeft {
+			return nil, errors.New("unsupported: order by on the right table of a cross-shard join")
+		}
+		if col, ok := order.Expr.(*sqlparser.ColName); ok {
+			// A multi-shard left query needs the
+			// ORDER BY columns in its select list.
+			jb.leftColumn(col)
+		}
+		leftOrderBy = append(leftOrderBy, order)
+	}
+	return leftOrderBy, nil
+}
+
+// leftColumn returns the position of col in the left select
+// list. The column is added if it's not already there.
+func (jb *joinBuilder) leftColumn(col *sqlparser.ColName) int {
+	for i, selectExpr := range jb.leftExprs {
+		selectExpr := selectExpr.(*sqlparser.NonStarExpr)
+		if selectExpr.As != "" {
+			continue
+		}
+		if leftCol, ok := selectExpr.Expr.(*sqlparser.ColName); ok && leftCol.Name == col.Name {
+			return i
+		}
+	}
+	jb.leftExprs = append(jb.leftExprs, &sqlparser.NonStarExpr{Expr: col})
+	return len(jb.leftExprs) - 1
+}
+
+// formatRight formats the right query. The references to the
+// left columns are replaced by bind variables.
+func (jb *joinBuilder) formatRight(buf *sqlparser.TrackedBuffer, node sqlparser.SQLNode) {
+	col, ok := node.(*sqlparser.ColName)
+	if !ok || string(col.Qualifier) != jb.left.Name {
+		node.Format(buf)
+		return
+	}
+	name := "_" + jb.left.Name + "_" + string(col.Name)
+	if _, ok := jb.join.Vars[name]; !ok {
+		jb.join.Vars[name] = jb.leftColumn(col)
+	}
+	buf.Myprintf("%s", ":"+name)
+}
+
+// joinConds combines the conditions with AND.
+func joinConds(conds []sqlparser.BoolExpr) sqlparser.BoolExpr {
+	var expr sqlparser.BoolExpr
+	for _, cond := range conds {
+		if _, ok := cond.(*sqlparser.OrExpr); ok && len(conds) > 1 {
+			cond = &sqlparser.ParenBoolExpr{Expr: cond}
+		}
+		if expr == nil {
+			expr = cond
+			continue
+		}
+		expr = &sqlparser.AndExpr{Left: expr, Right: cond}
+	}
+	return expr
+}
+
+// buildJoinSide builds the plan for the query of one side of a join.
+func buildJoinSide(query string, schema *Schema) (*Plan, error) {
+	plan := BuildPlan(query, schema)
+	if plan.ID == NoPlan {
+		return nil, errors.New(plan.Reason)
+	}
+	return plan, nil
+}
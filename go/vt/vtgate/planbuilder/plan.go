@@ -7,6 +7,7 @@ package planbuilder
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/youtube/vitess/go/vt/sqlparser"
 )
@@ -28,6 +29,15 @@ const (
 	DeleteEqual
 	InsertUnsharded
 	InsertSharded
+	SelectJoin
+	SelectRange
+	SelectPrefix
+	UpdateIN
+	UpdateScatter
+	DeleteIN
+	DeleteScatter
+	UpdateVindexChange
+	UpdateLookupChange
 	NumPlans
 )
 
@@ -45,6 +55,15 @@ var planName = [NumPlans]string{
 	"DeleteEqual",
 	"InsertUnsharded",
 	"InsertSharded",
+	"SelectJoin",
+	"SelectRange",
+	"SelectPrefix",
+	"UpdateIN",
+	"UpdateScatter",
+	"DeleteIN",
+	"DeleteScatter",
+	"UpdateVindexChange",
+	"UpdateLookupChange",
 }
 
 // Plan represents the routing strategy for a given query.
@@ -58,14 +77,84 @@ type Plan struct {
 	Original string
 	// Rewritten is the rewritten query. This is empty for
 	// all Unsharded plans since the Original query is sufficient.
+	// For UpdateVindexChange, it's the delete that removes the
+	// rows from their current shard.
 	Rewritten string
-	// Subquery is used for DeleteUnsharded to fetch the column values
-	// for owned vindexes so they can be deleted.
-	Subquery  string
+	// Subquery is used for DeleteEqual, DeleteIN and DeleteScatter
+	// to fetch the column values for owned vindexes so they can be
+	// deleted. For DeleteIN and DeleteScatter, it also fetches the
+	// values of the primary vindex columns, which are used to compute
+	// the keyspace id of each row. For UpdateVindexChange, it
+	// fetches the rows to be moved. For UpdateLookupChange, it
+	// fetches the current values of the columns of the changed
+	// vindexes.
+	Subquery string
+	// Changes is used by UpdateVindexChange and UpdateLookupChange.
+	// It maps the columns of the update to their new values. A value
+	// is a string if it's a bind var name. For UpdateLookupChange,
+	// only the vindex columns are listed.
+	Changes   map[string]interface{}
 	ColVindex *ColVindex
 	// Values is a single or a list of values that are used
-	// for making routing decisions.
+	// for making routing decisions. For SelectRange, it's the
+	// low and high bounds of the range, nil if unbounded.
+	// For a multi-column vindex, a single value is a list with
+	// one value per column. For SelectPrefix, it's the list of
+	// values of the leading columns. For InsertSharded, it has
+	// one entry per row, which is the list of the values of
+	// the row for each ColVindex of the table.
 	Values interface{}
+	// Prefix, Mid and Suffix are used by InsertSharded to build
+	// the insert for each shard. Mid has one entry per row.
+	// The insert is Prefix, followed by the comma-separated Mid
+	// of each row that goes to the shard, followed by Suffix.
+	Prefix string
+	Mid    []string
+	Suffix string
+	// OrderBy specifies the columns used to merge-sort the results
+	// of a multi-shard SELECT.
+	OrderBy []OrderByParams
+	// Limit and Offset are applied by VTGate to the merged results
+	// of a multi-shard SELECT. They're nil if absent, an int64 if
+	// the value is a number, or a string if it's a bind var name.
+	Limit  interface{}
+	Offset interface{}
+	// Aggregate specifies how to combine the partial aggregates
+	// of a multi-shard SELECT. It's nil if there's nothing to combine.
+	Aggregate *Aggregate
+	// Join specifies the queries that VTGate joins
+	// for a SelectJoin plan.
+	Join *Join
+	// Pullouts are the subqueries that VTGate executes before
+	// the query, to supply the values of list bind vars.
+	Pullouts []*Pullout
+	// Generate is used by InsertSharded to generate the value
+	// of the auto-increment column from its sequence.
+	Generate *Generate
+}
+
+// OrderByParams specifies how to compare a column while
+// merge-sorting the results of a multi-shard SELECT.
+type OrderByParams struct {
+	// Col is the name of the column in the result.
+	Col string
+	// Index is the position of the column in the result.
+	// It's -1 if the position can only be resolved by Col
+	// once the result fields are known.
+	Index int
+	Desc  bool
+}
+
+// Generate specifies how to generate the value of an
+// auto-increment column.
+type Generate struct {
+	// Query fetches the next value from the sequence. The
+	// number of values to reserve is supplied as the :n bind var.
+	Query string
+	// Values are the values supplied by the insert, one per
+	// row. A value is nil if it must be generated, or a string
+	// if it's a bind var name.
+	Values []interface{}
 }
 
 // Size is defined so that Plan can be given to an LRUCache.
@@ -81,18 +170,29 @@ func (pln *Plan) MarshalJSON() ([]byte, error) {
 	}
 	if pln.ColVindex != nil {
 		vindexName = pln.ColVindex.Name
-		col = pln.ColVindex.Col
+		col = strings.Join(pln.ColVindex.Cols, ", ")
 	}
 	marshalPlan := struct {
-		ID        PlanID      `json:",omitempty"`
-		Reason    string      `json:",omitempty"`
-		Table     string      `json:",omitempty"`
-		Original  string      `json:",omitempty"`
-		Rewritten string      `json:",omitempty"`
-		Subquery  string      `json:",omitempty"`
-		Vindex    string      `json:",omitempty"`
-		Col       string      `json:",omitempty"`
-		Values    interface{} `json:",omitempty"`
+		ID        PlanID                 `json:",omitempty"`
+		Reason    string                 `json:",omitempty"`
+		Table     string                 `json:",omitempty"`
+		Original  string                 `json:",omitempty"`
+		Rewritten string                 `json:",omitempty"`
+		Subquery  string                 `json:",omitempty"`
+		Changes   map[string]interface{} `json:",omitempty"`
+		Vindex    string                 `json:",omitempty"`
+		Col       string                 `json:",omitempty"`
+		Values    interface{}            `json:",omitempty"`
+		Prefix    string                 `json:",omitempty"`
+		Mid       []string               `json:",omitempty"`
+		Suffix    string                 `json:",omitempty"`
+		OrderBy   []OrderByParams        `json:",omitempty"`
+		Limit     interface{}            `json:",omitempty"`
+		Offset    interface{}            `json:",omitempty"`
+		Aggregate *Aggregate             `json:",omitempty"`
+		Join      *Join                  `json:",omitempty"`
+		Pullouts  []*Pullout             `json:",omitempty"`
+		Generate  *Generate              `json:",omitempty"`
 	}{
 		ID:        pln.ID,
 		Reason:    pln.Reason,
@@ -100,9 +200,20 @@ func (pln *Plan) MarshalJSON() ([]byte, error) {
 		Original:  pln.Original,
 		Rewritten: pln.Rewritten,
 		Subquery:  pln.Subquery,
+		Changes:   pln.Changes,
 		Vindex:    vindexName,
 		Col:       col,
 		Values:    pln.Values,
+		Prefix:    pln.Prefix,
+		Mid:       pln.Mid,
+		Suffix:    pln.Suffix,
+		OrderBy:   pln.OrderBy,
+		Limit:     pln.Limit,
+		Offset:    pln.Offset,
+		Aggregate: pln.Aggregate,
+		Join:      pln.Join,
+		Pullouts:  pln.Pullouts,
+		Generate:  pln.Generate,
 	}
 	return json.Marshal(marshalPlan)
 }
@@ -110,7 +221,8 @@ func (pln *Plan) MarshalJSON() ([]byte, error) {
 // IsMulti returns true if the SELECT query can potentially
 // be sent to more than one shard.
 func (pln *Plan) IsMulti() bool {
-	if pln.ID == SelectIN || pln.ID == SelectScatter {
+	switch pln.ID {
+	case SelectIN, SelectScatter, SelectRange, SelectPrefix:
 		return true
 	}
 	if pln.ID == SelectEqual && !IsUnique(pln.ColVindex.Vindex) {
@@ -59,10 +59,41 @@ func (*multiIndex) Delete(VCursor, []interface{}, []byte) error    { return nil
 
 func newMultiIndex(map[string]interface{}) (Vindex, error) { return &multiIndex{}, nil }
 
+// rangeIndex satisfies Unique, Ranged.
+type rangeIndex struct{}
+
+func (*rangeIndex) Cost() int { return 1 }
+func (*rangeIndex) Verify(VCursor, interface{}, []byte) (bool, error) {
+	return false, nil
+}
+func (*rangeIndex) Map(VCursor, []interface{}) ([][]byte, error) { return nil, nil }
+func (*rangeIndex) MapRange(VCursor, interface{}, interface{}) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func newRangeIndex(map[string]interface{}) (Vindex, error) { return &rangeIndex{}, nil }
+
+// multiColIndex satisfies Unique, MultiColumn.
+type multiColIndex struct{}
+
+func (*multiColIndex) Cost() int { return 1 }
+func (*multiColIndex) Verify(VCursor, interface{}, []byte) (bool, error) {
+	return false, nil
+}
+func (*multiColIndex) Map(VCursor, []interface{}) ([][]byte, error) { return nil, nil }
+func (*multiColIndex) ColumnCount() int                             { return 2 }
+func (*multiColIndex) MapPrefix(VCursor, []interface{}) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func newMultiColIndex(map[string]interface{}) (Vindex, error) { return &multiColIndex{}, nil }
+
 func init() {
 	Register("hash", newHashIndex)
 	Register("lookup", newLookupIndex)
 	Register("multi", newMultiIndex)
+	Register("range", newRangeIndex)
+	Register("multicol", newMultiColIndex)
 }
 
 func TestPlanName(t *testing.T) {
@@ -56,6 +56,37 @@ func IsUnique(v Vindex) bool {
 	return ok
 }
 
+// A Ranged vindex maps ids to keyspace ids in a way that
+// preserves their order. This allows VTGate to send a query
+// with a range condition on the vindex column only to the
+// shards that cover the matching keyspace ids.
+type Ranged interface {
+	// MapRange returns the range of keyspace ids [start, end)
+	// that covers the ids from low to high, inclusive. A nil
+	// low or high means that the ids are unbounded on that side.
+	// Likewise, an empty start or end means that the keyspace
+	// ids are unbounded. It returns an error if low is greater
+	// than high.
+	MapRange(cursor VCursor, low, high interface{}) (start, end []byte, err error)
+}
+
+// A MultiColumn vindex computes keyspace ids from the values
+// of multiple columns. The ids it receives in Map and Verify are
+// []interface{} tuples with one value per column, in the order in
+// which the columns are listed in the vschema. The leading columns
+// determine the leading bytes of the keyspace id. This allows VTGate
+// to send a query that only specifies the values of the leading
+// columns to the shards that cover the matching keyspace ids.
+type MultiColumn interface {
+	// ColumnCount returns the number of columns of the vindex.
+	ColumnCount() int
+
+	// MapPrefix returns the range of keyspace ids [start, end)
+	// that covers the ids whose leading values are prefix. An
+	// empty end means that the range is unbounded.
+	MapPrefix(cursor VCursor, prefix []interface{}) (start, end []byte, err error)
+}
+
 // A Reversible vindex is one that can perform a
 // reverse lookup from a keyspace id to an id. This
 // is optional. If present, VTGate can use it to
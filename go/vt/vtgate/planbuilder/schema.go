@@ -24,6 +24,16 @@ type Table struct {
 	ColVindexes []*ColVindex
 	Ordered     []*ColVindex
 	Owned       []*ColVindex
+	IsSequence  bool
+	Autoinc     *Autoinc
+}
+
+// Autoinc contains the auto-increment info for a Table.
+// The values of Col are generated from the Sequence table
+// if they're not supplied by an insert.
+type Autoinc struct {
+	Col      string
+	Sequence *Table
 }
 
 // Keyspace contains the keyspcae info for each Table.
@@ -33,8 +43,12 @@ type Keyspace struct {
 }
 
 // ColVindex contains the index info for each index of a table.
+// Cols lists all the columns of a multi-column vindex. For a
+// single column vindex, it contains only Col. In both cases,
+// Col is the first column.
 type ColVindex struct {
 	Col    string
+	Cols   []string
 	Type   string
 	Name   string
 	Owned  bool
@@ -44,6 +58,7 @@ type ColVindex struct {
 // BuildSchema builds a Schema from a SchemaFormal.
 func BuildSchema(source *SchemaFormal) (schema *Schema, err error) {
 	schema = &Schema{Tables: make(map[string]*Table)}
+	autoincs := make(map[*Table]*AutoincFormal)
 	for ksname, ks := range source.Keyspaces {
 		keyspace := &Keyspace{
 			Name:    ksname,
@@ -72,6 +87,13 @@ func BuildSchema(source *SchemaFormal) (schema *Schema, err error) {
 				Keyspace: keyspace,
 			}
 			if !keyspace.Sharded {
+				if cname != "" {
+					class, ok := ks.Classes[cname]
+					if !ok {
+						return nil, fmt.Errorf("class %s not found for table %s", cname, tname)
+					}
+					t.IsSequence = class.Type == "Sequence"
+				}
 				schema.Tables[tname] = t
 				continue
 			}
@@ -79,18 +101,41 @@ func BuildSchema(source *SchemaFormal) (schema *Schema, err error) {
 			if !ok {
 				return nil, fmt.Errorf("class %s not found for table %s", cname, tname)
 			}
+			if class.Type == "Sequence" {
+				return nil, fmt.Errorf("sequence table %s must be in an unsharded keyspace", tname)
+			}
+			if class.Autoinc != nil {
+				autoincs[t] = class.Autoinc
+			}
 			for i, ind := range class.ColVindexes {
 				vindexInfo, ok := ks.Vindexes[ind.Name]
 				if !ok {
 					return nil, fmt.Errorf("vindex %s not found for class %s", ind.Name, cname)
 				}
+				cols := ind.Cols
+				if len(cols) == 0 {
+					cols = []string{ind.Col}
+				} else if ind.Col != "" {
+					return nil, fmt.Errorf("index %s specifies both Col and Cols for class %s", ind.Name, cname)
+				}
 				columnVindex := &ColVindex{
-					Col:    ind.Col,
+					Col:    cols[0],
+					Cols:   cols,
 					Type:   vindexInfo.Type,
 					Name:   ind.Name,
 					Owned:  vindexInfo.Owner == tname,
 					Vindex: vindexes[ind.Name],
 				}
+				if mc, ok := columnVindex.Vindex.(MultiColumn); ok {
+					if mc.ColumnCount() != len(cols) {
+						return nil, fmt.Errorf("index %s needs %d columns for class %s", ind.Name, mc.ColumnCount(), cname)
+					}
+					if columnVindex.Owned {
+						return nil, fmt.Errorf("multi-column index %s cannot be owned for class %s", ind.Name, cname)
+					}
+				} else if len(cols) != 1 {
+					return nil, fmt.Errorf("index %s is not MultiColumn for class %s", ind.Name, cname)
+				}
 				if i == 0 {
 					// Perform Primary vindex check.
 					if _, ok := columnVindex.Vindex.(Unique); !ok {
@@ -118,6 +163,21 @@ func BuildSchema(source *SchemaFormal) (schema *Schema, err error) {
 			schema.Tables[tname] = t
 		}
 	}
+	// Sequences can be in any keyspace. So, they're resolved
+	// only after all the tables have been built.
+	for t, autoinc := range autoincs {
+		seq, ok := schema.Tables[autoinc.Sequence]
+		if !ok {
+			return nil, fmt.Errorf("sequence %s not found for table %s", autoinc.Sequence, t.Name)
+		}
+		if !seq.IsSequence {
+			return nil, fmt.Errorf("table %s is not a sequence for table %s", autoinc.Sequence, t.Name)
+		}
+		t.Autoinc = &Autoinc{
+			Col:      autoinc.Col,
+			Sequence: seq,
+		}
+	}
 	return schema, nil
 }
 
@@ -174,15 +234,28 @@ type VindexFormal struct {
 }
 
 // ClassFormal is the info for each table class as loaded from
-// the source.
+// the source. Type is "Sequence" for the class of sequence tables,
+// which must be unsharded. Autoinc is optional, and specifies the
+// column whose values are generated from a sequence.
 type ClassFormal struct {
+	Type        string
 	ColVindexes []ColVindexFormal
+	Autoinc     *AutoincFormal
+}
+
+// AutoincFormal is the auto-increment info for a table class
+// as loaded from the source.
+type AutoincFormal struct {
+	Col      string
+	Sequence string
 }
 
 // ColVindexFormal is the info for each indexed column
-// of a table as loaded from the source.
+// of a table as loaded from the source. Cols is used
+// instead of Col for multi-column vindexes.
 type ColVindexFormal struct {
 	Col  string
+	Cols []string
 	Name string
 }
 
@@ -203,3 +276,13 @@ func NewSchema(data []byte) (schema *Schema, err error) {
 	}
 	return BuildSchema(&source)
 }
+
+// NewKeyspaceFormal parses the JSON vschema of a single
+// keyspace, as it's stored in the topo server.
+func NewKeyspaceFormal(data []byte) (*KeyspaceFormal, error) {
+	var ks KeyspaceFormal
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("Unmarshal failed: %s %v", data, err)
+	}
+	return &ks, nil
+}
@@ -67,11 +67,29 @@ func NewSTLU(params map[string]interface{}) (Vindex, error) {
 	return &stLU{Params: params}, nil
 }
 
+// stMC satisfies Unique, MultiColumn.
+type stMC struct {
+	Params map[string]interface{}
+}
+
+func (*stMC) Cost() int                                         { return 1 }
+func (*stMC) Verify(VCursor, interface{}, []byte) (bool, error) { return false, nil }
+func (*stMC) Map(VCursor, []interface{}) ([][]byte, error)      { return nil, nil }
+func (*stMC) ColumnCount() int                                  { return 2 }
+func (*stMC) MapPrefix(VCursor, []interface{}) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func NewSTMC(params map[string]interface{}) (Vindex, error) {
+	return &stMC{Params: params}, nil
+}
+
 func init() {
 	Register("stfu", NewSTFU)
 	Register("stf", NewSTF)
 	Register("stln", NewSTLN)
 	Register("stlu", NewSTLU)
+	Register("stmc", NewSTMC)
 }
 
 func TestUnshardedSchema(t *testing.T) {
@@ -156,6 +174,7 @@ func TestShardedSchemaOwned(t *testing.T) {
 				ColVindexes: []*ColVindex{
 					&ColVindex{
 						Col:   "c1",
+						Cols:  []string{"c1"},
 						Type:  "stfu",
 						Name:  "stfu1",
 						Owned: true,
@@ -167,6 +186,7 @@ func TestShardedSchemaOwned(t *testing.T) {
 					},
 					&ColVindex{
 						Col:    "c2",
+						Cols:   []string{"c2"},
 						Type:   "stln",
 						Name:   "stln1",
 						Owned:  true,
@@ -235,6 +255,7 @@ func TestShardedSchemaNotOwned(t *testing.T) {
 				ColVindexes: []*ColVindex{
 					&ColVindex{
 						Col:    "c1",
+						Cols:   []string{"c1"},
 						Type:   "stlu",
 						Name:   "stlu1",
 						Owned:  false,
@@ -242,6 +263,7 @@ func TestShardedSchemaNotOwned(t *testing.T) {
 					},
 					&ColVindex{
 						Col:    "c2",
+						Cols:   []string{"c2"},
 						Type:   "stfu",
 						Name:   "stfu1",
 						Owned:  false,
@@ -566,3 +588,194 @@ func TestBuildSchemaNonPrimaryLookupFail(t *testing.T) {
 		t.Errorf("BuildSchema: %v, want %v", err, want)
 	}
 }
+
+func TestShardedSchemaMultiColumn(t *testing.T) {
+	good := SchemaFormal{
+		Keyspaces: map[string]KeyspaceFormal{
+			"sharded": {
+				Sharded: true,
+				Vindexes: map[string]VindexFormal{
+					"stmc1": {
+						Type: "stmc",
+					},
+				},
+				Classes: map[string]ClassFormal{
+					"t1": {
+						ColVindexes: []ColVindexFormal{
+							{
+								Cols: []string{"c1", "c2"},
+								Name: "stmc1",
+							},
+						},
+					},
+				},
+				Tables: map[string]string{
+					"t1": "t1",
+				},
+			},
+		},
+	}
+	got, err := BuildSchema(&good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ColVindex{
+		Col:    "c1",
+		Cols:   []string{"c1", "c2"},
+		Type:   "stmc",
+		Name:   "stmc1",
+		Vindex: &stMC{},
+	}
+	if gotcv := got.Tables["t1"].ColVindexes[0]; !reflect.DeepEqual(gotcv, want) {
+		t.Errorf("BuildSchema: %+v, want %+v", gotcv, want)
+	}
+}
+
+func TestBuildSchemaMultiColumnFail(t *testing.T) {
+	testcases := []struct {
+		vindex VindexFormal
+		col    ColVindexFormal
+		err    string
+	}{{
+		vindex: VindexFormal{Type: "stmc"},
+		col:    ColVindexFormal{Col: "c1", Cols: []string{"c1", "c2"}, Name: "v1"},
+		err:    "index v1 specifies both Col and Cols for class t1",
+	}, {
+		vindex: VindexFormal{Type: "stmc"},
+		col:    ColVindexFormal{Col: "c1", Name: "v1"},
+		err:    "index v1 needs 2 columns for class t1",
+	}, {
+		vindex: VindexFormal{Type: "stmc", Owner: "t1"},
+		col:    ColVindexFormal{Cols: []string{"c1", "c2"}, Name: "v1"},
+		err:    "multi-column index v1 cannot be owned for class t1",
+	}, {
+		vindex: VindexFormal{Type: "stfu"},
+		col:    ColVindexFormal{Cols: []string{"c1", "c2"}, Name: "v1"},
+		err:    "index v1 is not MultiColumn for class t1",
+	}}
+	for _, tcase := range testcases {
+		bad := SchemaFormal{
+			Keyspaces: map[string]KeyspaceFormal{
+				"sharded": {
+					Sharded: true,
+					Vindexes: map[string]VindexFormal{
+						"v1": tcase.vindex,
+					},
+					Classes: map[string]ClassFormal{
+						"t1": {
+							ColVindexes: []ColVindexFormal{tcase.col},
+						},
+					},
+					Tables: map[string]string{
+						"t1": "t1",
+					},
+				},
+			},
+		}
+		_, err := BuildSchema(&bad)
+		if err == nil || err.Error() != tcase.err {
+			t.Errorf("BuildSchema: %v, want %v", err, tcase.err)
+		}
+	}
+}
+
+func TestShardedSchemaAutoinc(t *testing.T) {
+	good := SchemaFormal{
+		Keyspaces: map[string]KeyspaceFormal{
+			"unsharded": {
+				Classes: map[string]ClassFormal{
+					"seq": {
+						Type: "Sequence",
+					},
+				},
+				Tables: map[string]string{
+					"seq": "seq",
+					"t2":  "",
+				},
+			},
+			"sharded": {
+				Sharded: true,
+				Vindexes: map[string]VindexFormal{
+					"stfu1": {
+						Type: "stfu",
+					},
+				},
+				Classes: map[string]ClassFormal{
+					"t1": {
+						ColVindexes: []ColVindexFormal{
+							{
+								Col:  "c1",
+								Name: "stfu1",
+							},
+						},
+						Autoinc: &AutoincFormal{
+							Col:      "c2",
+							Sequence: "seq",
+						},
+					},
+				},
+				Tables: map[string]string{
+					"t1": "t1",
+				},
+			},
+		},
+	}
+	got, err := BuildSchema(&good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seq := got.Tables["seq"]
+	if !seq.IsSequence {
+		t.Errorf("IsSequence: false, want true")
+	}
+	if got.Tables["t2"].IsSequence {
+		t.Errorf("IsSequence: true, want false")
+	}
+	want := &Autoinc{
+		Col:      "c2",
+		Sequence: seq,
+	}
+	if !reflect.DeepEqual(got.Tables["t1"].Autoinc, want) {
+		t.Errorf("Autoinc: %+v, want %+v", got.Tables["t1"].Autoinc, want)
+	}
+}
+
+func TestBuildSchemaAutoincFail(t *testing.T) {
+	testcases := []struct {
+		class ClassFormal
+		err   string
+	}{{
+		class: ClassFormal{Type: "Sequence"},
+		err:   "sequence table t1 must be in an unsharded keyspace",
+	}, {
+		class: ClassFormal{Autoinc: &AutoincFormal{Col: "c1", Sequence: "noexist"}},
+		err:   "sequence noexist not found for table t1",
+	}, {
+		class: ClassFormal{Autoinc: &AutoincFormal{Col: "c1", Sequence: "t2"}},
+		err:   "table t2 is not a sequence for table t1",
+	}}
+	for _, tcase := range testcases {
+		bad := SchemaFormal{
+			Keyspaces: map[string]KeyspaceFormal{
+				"unsharded": {
+					Tables: map[string]string{
+						"t2": "",
+					},
+				},
+				"sharded": {
+					Sharded: true,
+					Classes: map[string]ClassFormal{
+						"t1": tcase.class,
+					},
+					Tables: map[string]string{
+						"t1": "t1",
+					},
+				},
+			},
+		}
+		_, err := BuildSchema(&bad)
+		if err == nil || err.Error() != tcase.err {
+			t.Errorf("BuildSchema: %v, want %v", err, tcase.err)
+		}
+	}
+}
@@ -6,47 +6,77 @@ package planbuilder
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/youtube/vitess/go/vt/sqlparser"
 )
 
 func buildSelectPlan(sel *sqlparser.Select, schema *Schema) *Plan {
 	plan := &Plan{ID: NoPlan}
-	tablename, _ := analyzeFrom(sel.From)
-	plan.Table, plan.Reason = schema.FindTable(tablename)
-	if plan.Reason != "" {
+	from, on, reason := analyzeFrom(sel.From, schema)
+	if reason != "" {
+		plan.Reason = reason
+		return plan
+	}
+	plan.Table = from[0].Table
+	plan.Reason = checkJoin(from, on, sel.Where)
+	if plan.Reason != "" && len(from) != 2 {
 		return plan
 	}
-	if !plan.Table.Keyspace.Sharded {
+	if plan.Reason == "" && !plan.Table.Keyspace.Sharded {
 		plan.ID = SelectUnsharded
 		return plan
 	}
+	pullouts, err := pulloutSubqueries(sel, schema)
+	if err != nil {
+		plan.ID = NoPlan
+		plan.Reason = err.Error()
+		return plan
+	}
+	if plan.Reason != "" {
+		// The join can still be performed by VTGate.
+		plan = buildJoinPlan(sel, schema, from)
+	} else {
+		buildRoutePlan(sel, plan, from)
+	}
+	if plan.ID != NoPlan {
+		plan.Pullouts = pullouts
+	}
+	return plan
+}
 
-	getWhereRouting(sel.Where, plan, false)
+// buildRoutePlan fills the fields of a plan that sends
+// the SELECT to the shards of a sharded keyspace.
+func buildRoutePlan(sel *sqlparser.Select, plan *Plan, from []*tableAlias) {
+	getWhereRouting(sel.Where, plan, from, false)
 	if plan.IsMulti() {
 		if hasPostProcessing(sel) {
+			if err := buildAggregate(sel, plan); err != nil {
+				plan.ID = NoPlan
+				plan.Reason = err.Error()
+				return
+			}
+		}
+		if err := buildOrderByLimit(sel, plan); err != nil {
 			plan.ID = NoPlan
-			plan.Reason = "multi-shard query has post-processing constructs"
-			return plan
+			plan.Reason = err.Error()
+			plan.Aggregate = nil
+			return
+		}
+		if plan.Aggregate != nil {
+			// ORDER BY and LIMIT can only be applied after
+			// the partial aggregates have been combined.
+			sel.OrderBy = nil
+			sel.Limit = nil
 		}
 	}
 	// The where clause might have changed.
 	plan.Rewritten = generateQuery(sel)
-	return plan
-}
-
-// TODO(sougou): Copied from tabletserver. Reuse.
-func analyzeFrom(tableExprs sqlparser.TableExprs) (tablename string, hasHints bool) {
-	if len(tableExprs) > 1 {
-		return "", false
-	}
-	node, ok := tableExprs[0].(*sqlparser.AliasedTableExpr)
-	if !ok {
-		return "", false
-	}
-	return sqlparser.GetTableName(node.Expr), node.Hints != nil
 }
 
+// hasAggregates returns true if one of the select
+// expressions uses an aggregate function.
 func hasAggregates(node sqlparser.SelectExprs) bool {
 	for _, node := range node {
 		switch node := node.(type) {
@@ -59,6 +89,7 @@ func hasAggregates(node sqlparser.SelectExprs) bool {
 	return false
 }
 
+// exprHasAggregates returns true if node uses an aggregate function.
 func exprHasAggregates(node sqlparser.Expr) bool {
 	switch node := node.(type) {
 	case *sqlparser.AndExpr:
@@ -117,6 +148,119 @@ func exprHasAggregates(node sqlparser.Expr) bool {
 	}
 }
 
+// hasPostProcessing returns true if the results of a multi-shard
+// SELECT have to be combined by buildAggregate's instructions.
+// ORDER BY and LIMIT are handled by buildOrderByLimit.
 func hasPostProcessing(sel *sqlparser.Select) bool {
-	return hasAggregates(sel.SelectExprs) || sel.Distinct != "" || sel.GroupBy != nil || sel.Having != nil || sel.OrderBy != nil || sel.Limit != nil
+	return hasAggregates(sel.SelectExprs) || sel.Distinct != "" || sel.GroupBy != nil || sel.Having != nil
+}
+
+// buildOrderByLimit fills the OrderBy, Limit and Offset fields
+// of a multi-shard plan. The ORDER BY clause is sent to every shard
+// as is. The LIMIT clause is rewritten such that each shard returns
+// offset+rowcount rows, because the OFFSET can only be applied
+// after the results are merged. If the plan has an Aggregate, the
+// caller removes both clauses from the query instead.
+func buildOrderByLimit(sel *sqlparser.Select, plan *Plan) error {
+	selectExprs := sel.SelectExprs
+	if plan.Aggregate != nil {
+		// The columns added by buildAggregate can't be referenced.
+		selectExprs = selectExprs[:plan.Aggregate.Columns]
+	}
+	var orderBy []OrderByParams
+	for _, order := range sel.OrderBy {
+		params, err := findOrderByColumn(selectExprs, order.Expr)
+		if err != nil {
+			return err
+		}
+		params.Desc = order.Direction == sqlparser.DescScr
+		orderBy = append(orderBy, params)
+	}
+	if sel.Limit == nil {
+		plan.OrderBy = orderBy
+		return nil
+	}
+	offset, rowcount, err := sel.Limit.Limits()
+	if err != nil {
+		return fmt.Errorf("invalid limit: %v", err)
+	}
+	plan.OrderBy = orderBy
+	plan.Offset = offset
+	plan.Limit = rowcount
+	if offset == nil || plan.Aggregate != nil {
+		return nil
+	}
+	o, ok := offset.(int64)
+	if r, rok := rowcount.(int64); ok && rok {
+		sel.Limit = &sqlparser.Limit{Rowcount: sqlparser.NumVal(strconv.AppendInt(nil, o+r, 10))}
+		return nil
+	}
+	// At least one of the values is a bind var. VTGate
+	// will compute the value of LimitVarName at execution time.
+	sel.Limit = &sqlparser.Limit{Rowcount: sqlparser.ValArg(":" + LimitVarName)}
+	return nil
+}
+
+// findOrderByColumn locates the ORDER BY expression in the
+// select list. Expressions that are neither a column nor a column
+// number are not supported because VTGate cannot evaluate them.
+func findOrderByColumn(selectExprs sqlparser.SelectExprs, expr sqlparser.ValExpr) (OrderByParams, error) {
+	switch expr := expr.(type) {
+	case sqlparser.NumVal:
+		pos, err := strconv.ParseInt(string(expr), 0, 64)
+		if err != nil {
+			return OrderByParams{}, fmt.Errorf("invalid order by column number: %s", sqlparser.String(expr))
+		}
+		if pos < 1 || (!hasStar(selectExprs) && pos > int64(len(selectExprs))) {
+			return OrderByParams{}, fmt.Errorf("order by column number out of range: %d", pos)
+		}
+		return OrderByParams{Index: int(pos - 1)}, nil
+	case *sqlparser.ColName:
+		starFound := false
+		for i, selectExpr := range selectExprs {
+			switch selectExpr := selectExpr.(type) {
+			case *sqlparser.StarExpr:
+				starFound = true
+			case *sqlparser.NonStarExpr:
+				if !selectExprMatches(selectExpr, expr) {
+					continue
+				}
+				params := OrderByParams{Col: string(expr.Name), Index: i}
+				// The position of the column is unknown if a
+				// '*' precedes it in the select list.
+				if starFound {
+					params.Index = -1
+				}
+				return params, nil
+			}
+		}
+		if starFound {
+			return OrderByParams{Col: string(expr.Name), Index: -1}, nil
+		}
+		return OrderByParams{}, fmt.Errorf("order by column %s is not in the select list", sqlparser.String(expr))
+	}
+	return OrderByParams{}, fmt.Errorf("complex order by expression: %s", sqlparser.String(expr))
+}
+
+// selectExprMatches returns true if the select expression is
+// referenced by col, either by its alias or by its column name.
+// Like in MySQL, the names are compared regardless of their case.
+func selectExprMatches(selectExpr *sqlparser.NonStarExpr, col *sqlparser.ColName) bool {
+	if selectExpr.As != "" {
+		return col.Qualifier == "" && strings.EqualFold(string(selectExpr.As), string(col.Name))
+	}
+	selCol, ok := selectExpr.Expr.(*sqlparser.ColName)
+	if !ok || !strings.EqualFold(string(selCol.Name), string(col.Name)) {
+		return false
+	}
+	return col.Qualifier == "" || selCol.Qualifier == "" || selCol.Qualifier == col.Qualifier
+}
+
+func hasStar(selectExprs sqlparser.SelectExprs) bool {
+	for _, selectExpr := range selectExprs {
+		if _, ok := selectExpr.(*sqlparser.StarExpr); ok {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,44 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planbuilder
+
+import (
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/sqlparser"
+)
+
+func TestSelectExprMatches(t *testing.T) {
+	testcases := []struct {
+		expr *sqlparser.NonStarExpr
+		col  *sqlparser.ColName
+		want bool
+	}{{
+		expr: &sqlparser.NonStarExpr{Expr: &sqlparser.ColName{Name: "id"}, As: "Foo"},
+		col:  &sqlparser.ColName{Name: "fOO"},
+		want: true,
+	}, {
+		expr: &sqlparser.NonStarExpr{Expr: &sqlparser.ColName{Name: "id"}, As: "foo"},
+		col:  &sqlparser.ColName{Name: "foo", Qualifier: "user"},
+		want: false,
+	}, {
+		expr: &sqlparser.NonStarExpr{Expr: &sqlparser.ColName{Name: "Id", Qualifier: "user"}},
+		col:  &sqlparser.ColName{Name: "ID"},
+		want: true,
+	}, {
+		expr: &sqlparser.NonStarExpr{Expr: &sqlparser.ColName{Name: "id", Qualifier: "user"}},
+		col:  &sqlparser.ColName{Name: "id", Qualifier: "music"},
+		want: false,
+	}, {
+		expr: &sqlparser.NonStarExpr{Expr: &sqlparser.ColName{Name: "id"}},
+		col:  &sqlparser.ColName{Name: "name"},
+		want: false,
+	}}
+	for _, tcase := range testcases {
+		if got := selectExprMatches(tcase.expr, tcase.col); got != tcase.want {
+			t.Errorf("selectExprMatches(%s, %s): %v, want %v", sqlparser.String(tcase.expr), sqlparser.String(tcase.col), got, tcase.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/youtube/vitess/go/vt/sqlparser"
+)
+
+// SubqueryVarName is the prefix of the list bind var names
+// used to pass the results of pulled-out subqueries.
+const SubqueryVarName = "__sq"
+
+// Pullout is an uncorrelated subquery that VTGate executes
+// before the query that contains it. The values of the first
+// column of its results are supplied to that query as the list
+// bind variable Var.
+type Pullout struct {
+	Var  string
+	Plan *Plan
+}
+
+// pulloutSubqueries pulls out the uncorrelated subqueries of the
+// 'col IN (subquery)' conditions of the WHERE clause, and replaces
+// them with list bind vars. Other subqueries are left untouched.
+func pulloutSubqueries(sel *sqlparser.Select, schema *Schema) ([]*Pullout, error) {
+	if sel.Where == nil {
+		return nil, nil
+	}
+	var pullouts []*Pullout
+	for _, cond := range splitAndExpression(nil, sel.Where.Expr) {
+		comparison, ok := cond.(*sqlparser.ComparisonExpr)
+		if !ok || comparison.Operator != sqlparser.InStr {
+			continue
+		}
+		subquery, ok := comparison.Right.(*sqlparser.Subquery)
+		if !ok {
+			continue
+		}
+		plan, err := buildPulloutPlan(subquery, schema)
+		if err != nil {
+			return nil, err
+		}
+		name := SubqueryVarName + strconv.Itoa(len(pullouts)+1)
+		pullouts = append(pullouts, &Pullout{Var: name, Plan: plan})
+		comparison.Right = sqlparser.ListArg("::" + name)
+	}
+	return pullouts, nil
+}
+
+func buildPulloutPlan(subquery *sqlparser.Subquery, schema *Schema) (*Plan, error) {
+	sel, ok := subquery.Select.(*sqlparser.Select)
+	if !ok {
+		return nil, errors.New("unsupported: union in subquery")
+	}
+	if len(sel.SelectExprs) != 1 {
+		return nil, errors.New("unsupported: subquery must return a single column")
+	}
+	if _, ok := sel.SelectExprs[0].(*sqlparser.NonStarExpr); !ok {
+		return nil, errors.New("unsupported: subquery must return a single column")
+	}
+	if isCorrelated(sel) {
+		return nil, errors.New("unsupported: correlated subquery")
+	}
+	plan := BuildPlan(sqlparser.String(sel), schema)
+	if plan.ID == NoPlan {
+		return nil, fmt.Errorf("subquery: %s", plan.Reason)
+	}
+	return plan, nil
+}
+
+// isCorrelated returns true if sel references columns of tables
+// that it doesn't select from. Unqualified column names are
+// assumed to reference its own tables.
+func isCorrelated(sel *sqlparser.Select) bool {
+	tables := make(map[string]bool)
+	var qualifiers []string
+	buf := sqlparser.NewTrackedBuffer(func(buf *sqlparser.TrackedBuffer, node sqlparser.SQLNode) {
+		switch node := node.(type) {
+		case *sqlparser.AliasedTableExpr:
+			if node.As != "" {
+				tables[string(node.As)] = true
+			} else if name := sqlparser.GetTableName(node.Expr); name != "" {
+				tables[name] = true
+			}
+		case *sqlparser.ColName:
+			if node.Qualifier != "" {
+				qualifiers = append(qualifiers, string(node.Qualifier))
+			}
+		}
+		node.Format(buf)
+	})
+	buf.Myprintf("%v", sel)
+	for _, qualifier := range qualifiers {
+		if !tables[qualifier] {
+			return true
+		}
+	}
+	return false
+}
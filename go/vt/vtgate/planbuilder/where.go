@@ -6,6 +6,7 @@ package planbuilder
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/youtube/vitess/go/vt/sqlparser"
@@ -16,11 +17,18 @@ import (
 // like for IN clauses.
 const ListVarName = "_vals"
 
+// LimitVarName is the bind var name used for multi-shard
+// plans that require VTGate to compute the LIMIT sent to
+// each shard, like when the OFFSET is a bind var.
+const LimitVarName = "_limit"
+
 // getWhereRouting fills the plan fields for the where clause of a SELECT
 // statement. It gets reused for DML planning also, where the select plan is
 // replaced with the appropriate DML plan after the fact.
+// The vindexes of all the tables in from are considered. They must
+// be in the same keyspace as plan.Table.
 // onlyUnique matches only Unique indexes.
-func getWhereRouting(where *sqlparser.Where, plan *Plan, onlyUnique bool) {
+func getWhereRouting(where *sqlparser.Where, plan *Plan, from []*tableAlias, onlyUnique bool) {
 	if where == nil {
 		plan.ID = SelectScatter
 		return
@@ -41,20 +49,79 @@ func getWhereRouting(where *sqlparser.Where, plan *Plan, onlyUnique bool) {
 		plan.Values = values
 		return
 	}
-	for _, index := range plan.Table.Ordered {
-		if onlyUnique && !IsUnique(index.Vindex) {
+	for _, rv := range routingVindexes(from) {
+		if onlyUnique && !IsUnique(rv.index.Vindex) {
+			continue
+		}
+		if len(rv.index.Cols) > 1 {
+			if values := getPrefixMatch(where.Expr, rv.table, rv.index.Cols); len(values) == len(rv.index.Cols) {
+				plan.ID = SelectEqual
+				plan.ColVindex = rv.index
+				plan.Values = values
+				return
+			}
 			continue
 		}
-		if planID, values := getMatch(where.Expr, index.Col); planID != SelectScatter {
+		if planID, values := getMatch(where.Expr, rv.table, rv.index.Col); planID != SelectScatter {
 			plan.ID = planID
-			plan.ColVindex = index
+			plan.ColVindex = rv.index
 			plan.Values = values
 			return
 		}
 	}
+	if !onlyUnique {
+		for _, rv := range routingVindexes(from) {
+			if len(rv.index.Cols) > 1 {
+				if values := getPrefixMatch(where.Expr, rv.table, rv.index.Cols); values != nil {
+					plan.ID = SelectPrefix
+					plan.ColVindex = rv.index
+					plan.Values = values
+					return
+				}
+				continue
+			}
+			if _, ok := rv.index.Vindex.(Ranged); !ok {
+				continue
+			}
+			if values := getRangeMatch(where.Expr, rv.table, rv.index.Col); values != nil {
+				plan.ID = SelectRange
+				plan.ColVindex = rv.index
+				plan.Values = values
+				return
+			}
+		}
+	}
 	plan.ID = SelectScatter
 }
 
+// routingVindex is a vindex of a table in the FROM clause.
+type routingVindex struct {
+	table *tableAlias
+	index *ColVindex
+}
+
+type byRoutingCost []routingVindex
+
+func (rc byRoutingCost) Len() int      { return len(rc) }
+func (rc byRoutingCost) Swap(i, j int) { rc[i], rc[j] = rc[j], rc[i] }
+func (rc byRoutingCost) Less(i, j int) bool {
+	return rc[i].index.Vindex.Cost() < rc[j].index.Vindex.Cost()
+}
+
+// routingVindexes returns the vindexes of all the tables in from,
+// sorted by cost. Vindexes of the same cost are sorted in the order
+// in which their tables appear.
+func routingVindexes(from []*tableAlias) []routingVindex {
+	var rvs []routingVindex
+	for _, ta := range from {
+		for _, index := range ta.Table.Ordered {
+			rvs = append(rvs, routingVindex{table: ta, index: index})
+		}
+	}
+	sort.Stable(byRoutingCost(rvs))
+	return rvs
+}
+
 func hasSubquery(node sqlparser.Expr) bool {
 	switch node := node.(type) {
 	case *sqlparser.AndExpr:
@@ -168,21 +235,21 @@ func getKeyrangeFromBool(node sqlparser.BoolExpr) (newnode sqlparser.BoolExpr, v
 	return node, nil, nil
 }
 
-func getMatch(node sqlparser.BoolExpr, col string) (planID PlanID, values interface{}) {
+func getMatch(node sqlparser.BoolExpr, table *tableAlias, col string) (planID PlanID, values interface{}) {
 	switch node := node.(type) {
 	case *sqlparser.AndExpr:
-		if planID, values = getMatch(node.Left, col); planID != SelectScatter {
+		if planID, values = getMatch(node.Left, table, col); planID != SelectScatter {
 			return planID, values
 		}
-		if planID, values = getMatch(node.Right, col); planID != SelectScatter {
+		if planID, values = getMatch(node.Right, table, col); planID != SelectScatter {
 			return planID, values
 		}
 	case *sqlparser.ParenBoolExpr:
-		return getMatch(node.Expr, col)
+		return getMatch(node.Expr, table, col)
 	case *sqlparser.ComparisonExpr:
 		switch node.Operator {
 		case "=":
-			if !nameMatch(node.Left, col) {
+			if !nameMatch(node.Left, table, col) {
 				return SelectScatter, nil
 			}
 			if !sqlparser.IsValue(node.Right) {
@@ -194,7 +261,7 @@ func getMatch(node sqlparser.BoolExpr, col string) (planID PlanID, values interf
 			}
 			return SelectEqual, val
 		case "in":
-			if !nameMatch(node.Left, col) {
+			if !nameMatch(node.Left, table, col) {
 				return SelectScatter, nil
 			}
 			if !sqlparser.IsSimpleTuple(node.Right) {
@@ -211,7 +278,123 @@ func getMatch(node sqlparser.BoolExpr, col string) (planID PlanID, values interf
 	return SelectScatter, nil
 }
 
-func nameMatch(node sqlparser.ValExpr, col string) bool {
+// getPrefixMatch returns the values that the AND-ed equality
+// conditions of node set on the leading columns of cols, in order.
+// It stops at the first column that has no such condition, and
+// returns nil if the first column has none.
+func getPrefixMatch(node sqlparser.BoolExpr, table *tableAlias, cols []string) (values []interface{}) {
+	conds := splitAndExpression(nil, node)
+	for _, col := range cols {
+		val, ok := getEqualValue(conds, table, col)
+		if !ok {
+			break
+		}
+		values = append(values, val)
+	}
+	return values
+}
+
+// getEqualValue returns the value of the first condition
+// of conds that's an equality between col and a value.
+func getEqualValue(conds []sqlparser.BoolExpr, table *tableAlias, col string) (interface{}, bool) {
+	for _, cond := range conds {
+		cond, ok := cond.(*sqlparser.ComparisonExpr)
+		if !ok || cond.Operator != sqlparser.EqualStr {
+			continue
+		}
+		if !nameMatch(cond.Left, table, col) || !sqlparser.IsValue(cond.Right) {
+			continue
+		}
+		val, err := asInterface(cond.Right)
+		if err != nil {
+			continue
+		}
+		return val, true
+	}
+	return nil, false
+}
+
+// getRangeMatch returns the low and high bounds that the AND-ed
+// conditions of node set on col, or nil if there's no bound.
+// BETWEEN, <, <=, > and >= conditions are considered. Only the
+// first bound found on each side is used, because bind vars
+// can't be compared at this stage.
+func getRangeMatch(node sqlparser.BoolExpr, table *tableAlias, col string) (values []interface{}) {
+	var low, high interface{}
+	found := false
+	for _, cond := range splitAndExpression(nil, node) {
+		switch cond := cond.(type) {
+		case *sqlparser.RangeCond:
+			if cond.Operator != sqlparser.BetweenStr || !nameMatch(cond.Left, table, col) {
+				continue
+			}
+			from, err := rangeValue(cond.From)
+			if err != nil {
+				continue
+			}
+			to, err := rangeValue(cond.To)
+			if err != nil {
+				continue
+			}
+			if low == nil {
+				low = from
+			}
+			if high == nil {
+				high = to
+			}
+			found = true
+		case *sqlparser.ComparisonExpr:
+			operator, val := cond.Operator, cond.Right
+			if !nameMatch(cond.Left, table, col) {
+				if !nameMatch(cond.Right, table, col) {
+					continue
+				}
+				operator, val = reverseOperators[operator], cond.Left
+			}
+			v, err := rangeValue(val)
+			if err != nil {
+				continue
+			}
+			switch operator {
+			case sqlparser.LessThanStr, sqlparser.LessEqualStr:
+				if high == nil {
+					high = v
+				}
+			case sqlparser.GreaterThanStr, sqlparser.GreaterEqualStr:
+				if low == nil {
+					low = v
+				}
+			default:
+				continue
+			}
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return []interface{}{low, high}
+}
+
+// reverseOperators maps the comparison operators to the
+// ones that give the same result if the operands are swapped.
+var reverseOperators = map[string]string{
+	sqlparser.LessThanStr:     sqlparser.GreaterThanStr,
+	sqlparser.LessEqualStr:    sqlparser.GreaterEqualStr,
+	sqlparser.GreaterThanStr:  sqlparser.LessThanStr,
+	sqlparser.GreaterEqualStr: sqlparser.LessEqualStr,
+}
+
+// rangeValue returns the value of a range bound. NULL is
+// not accepted because it would be confused with no bound.
+func rangeValue(node sqlparser.ValExpr) (interface{}, error) {
+	if !sqlparser.IsValue(node) {
+		return nil, fmt.Errorf("%v is not a value", sqlparser.String(node))
+	}
+	return asInterface(node)
+}
+
+func nameMatch(node sqlparser.ValExpr, table *tableAlias, col string) bool {
 	colname, ok := node.(*sqlparser.ColName)
 	if !ok {
 		return false
@@ -219,7 +402,10 @@ func nameMatch(node sqlparser.ValExpr, col string) bool {
 	if string(colname.Name) != col {
 		return false
 	}
-	return true
+	if colname.Qualifier == "" {
+		return table.Implicit
+	}
+	return string(colname.Qualifier) == table.Name
 }
 
 // asInterface is similar to sqlparser.AsInterface, but it converts
@@ -9,22 +9,62 @@ package vtgate
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
 
 	"github.com/youtube/vitess/go/acl"
 	"github.com/youtube/vitess/go/cache"
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/vt/topo"
 	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
 )
 
+var vschemaKeyspacesRefreshInterval = flag.Duration("vschema_keyspaces_refresh_interval", 1*time.Minute, "how often vtgate looks for the keyspaces created or deleted in topo, to watch their VSchema")
+
 var noPlan = &planbuilder.Plan{
 	ID:     planbuilder.NoPlan,
 	Reason: "planbuiler not initialized",
 }
 
+// Planner builds and caches the plans of the V3 queries.
+// Its schema can be swapped while it's serving queries.
 type Planner struct {
-	schema *planbuilder.Schema
-	plans  *cache.LRUCache
+	// mu protects schema and the fields below it. Plans are
+	// built under the read lock so that a plan built with
+	// an old schema never makes it into the cache.
+	mu         sync.RWMutex
+	schema     *planbuilder.Schema
+	schemaJSON string
+	version    int64
+	loadTime   time.Time
+	lastError  string
+
+	plans *cache.LRUCache
+}
+
+// cachedPlan is a plan in the cache of the Planner, with the number
+// of times it was used since it was built.
+type cachedPlan struct {
+	plan *planbuilder.Plan
+	hits sync2.AtomicInt64
+}
+
+// Size is defined so that cachedPlan can be given to an LRUCache.
+func (*cachedPlan) Size() int {
+	return 1
+}
+
+// VSchemaStatus describes the schema currently used by the Planner.
+type VSchemaStatus struct {
+	Version   int64
+	LoadTime  time.Time
+	LastError string
 }
 
 func NewPlanner(schema *planbuilder.Schema, cacheSize int) *Planner {
@@ -32,24 +72,274 @@ func NewPlanner(schema *planbuilder.Schema, cacheSize int) *Planner {
 		schema: schema,
 		plans:  cache.NewLRUCache(int64(cacheSize)),
 	}
-	// TODO(sougou): Uncomment after making Planner testable.
-	//http.Handle("/debug/query_plans", plr)
-	//http.Handle("/debug/schema", plr)
+	if schema != nil {
+		plr.version = 1
+		plr.loadTime = time.Now()
+	}
 	return plr
 }
 
+// GetPlan returns the plan of sql, and counts a hit if it was
+// already cached.
 func (plr *Planner) GetPlan(sql string) *planbuilder.Plan {
+	plr.mu.RLock()
+	defer plr.mu.RUnlock()
 	if plr.schema == nil {
 		return noPlan
 	}
 	if result, ok := plr.plans.Get(sql); ok {
-		return result.(*planbuilder.Plan)
+		cp := result.(*cachedPlan)
+		cp.hits.Add(1)
+		return cp.plan
 	}
 	plan := planbuilder.BuildPlan(sql, plr.schema)
-	plr.plans.Set(sql, plan)
+	plr.plans.Set(sql, &cachedPlan{plan: plan})
 	return plan
 }
 
+// peekPlan returns the cached plan of sql, or builds one without
+// caching it. It's used by Explain and by the caller quotas, so that
+// they change neither the plan cache nor the stats of
+// /debug/query_plans.
+func (plr *Planner) peekPlan(sql string) *planbuilder.Plan {
+	plr.mu.RLock()
+	defer plr.mu.RUnlock()
+	if plr.schema == nil {
+		return noPlan
+	}
+	if result, ok := plr.plans.Peek(sql); ok {
+		return result.(*cachedPlan).plan
+	}
+	return planbuilder.BuildPlan(sql, plr.schema)
+}
+
+// SetSchema replaces the schema of the Planner and flushes
+// the plans built with the previous one.
+func (plr *Planner) SetSchema(schema *planbuilder.Schema) {
+	plr.mu.Lock()
+	defer plr.mu.Unlock()
+	plr.setSchemaLocked(schema, "")
+}
+
+func (plr *Planner) setSchemaLocked(schema *planbuilder.Schema, schemaJSON string) {
+	plr.schema = schema
+	plr.schemaJSON = schemaJSON
+	plr.version++
+	plr.loadTime = time.Now()
+	plr.lastError = ""
+	plr.plans.Clear()
+}
+
+// LoadSchema parses schemaJSON and makes it the schema of the
+// Planner. Loading the same JSON again is a no-op. If schemaJSON
+// can't be parsed, the current schema is kept.
+func (plr *Planner) LoadSchema(schemaJSON string) error {
+	schema, err := planbuilder.NewSchema([]byte(schemaJSON))
+	plr.mu.Lock()
+	defer plr.mu.Unlock()
+	if err != nil {
+		plr.lastError = err.Error()
+		return err
+	}
+	if plr.schemaJSON == schemaJSON && plr.schema != nil {
+		return nil
+	}
+	plr.setSchemaLocked(schema, schemaJSON)
+	return nil
+}
+
+// loadKeyspaces combines the JSON vschemas of the keyspaces,
+// and loads the result like LoadSchema.
+func (plr *Planner) loadKeyspaces(vschemas map[string]string) error {
+	source := planbuilder.SchemaFormal{Keyspaces: make(map[string]planbuilder.KeyspaceFormal)}
+	for keyspace, vschema := range vschemas {
+		ks, err := planbuilder.NewKeyspaceFormal([]byte(vschema))
+		if err != nil {
+			err = fmt.Errorf("keyspace %s: %v", keyspace, err)
+			plr.mu.Lock()
+			plr.lastError = err.Error()
+			plr.mu.Unlock()
+			return err
+		}
+		source.Keyspaces[keyspace] = *ks
+	}
+	// json.Marshal sorts the keyspaces, so the same
+	// vschemas always produce the same JSON.
+	data, err := json.Marshal(&source)
+	if err != nil {
+		return err
+	}
+	return plr.LoadSchema(string(data))
+}
+
+// vschemaWatch is the watch of the VSchema of a keyspace.
+type vschemaWatch struct {
+	keyspace     string
+	stopWatching chan<- struct{}
+}
+
+// vschemaUpdate is a notification of a vschemaWatch. closed is
+// set if the watch was closed.
+type vschemaUpdate struct {
+	watch   *vschemaWatch
+	vschema string
+	closed  bool
+}
+
+// WatchVSchema loads the VSchema of all the keyspaces from topo,
+// and reloads it every time one of them changes, until ctx is done.
+// The first version is loaded before it returns, so the Planner is
+// ready to serve if there is no error. The list of keyspaces is
+// refreshed every -vschema_keyspaces_refresh_interval: the keyspaces
+// that are created later are then watched too, and the ones that are
+// deleted are dropped.
+func (plr *Planner) WatchVSchema(ctx context.Context, ts topo.Server) error {
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	// cancel stops the goroutines of the watches if
+	// the first VSchema can't be loaded.
+	ctx, cancel := context.WithCancel(ctx)
+	updates := make(chan vschemaUpdate)
+	watches := make(map[string]*vschemaWatch)
+	vschemas := make(map[string]string, len(keyspaces))
+	startWatch := func(keyspace string) error {
+		notifications, stopWatching, err := ts.WatchVSchema(ctx, keyspace)
+		if err != nil {
+			return err
+		}
+		w := &vschemaWatch{keyspace: keyspace, stopWatching: stopWatching}
+		watches[keyspace] = w
+		go func() {
+			for vschema := range notifications {
+				select {
+				case updates <- vschemaUpdate{watch: w, vschema: vschema}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case updates <- vschemaUpdate{watch: w, closed: true}:
+			case <-ctx.Done():
+			}
+		}()
+		return nil
+	}
+	stopWatch := func(keyspace string) {
+		close(watches[keyspace].stopWatching)
+		delete(watches, keyspace)
+	}
+	stopAll := func() {
+		for keyspace := range watches {
+			stopWatch(keyspace)
+		}
+		cancel()
+	}
+
+	// Wait for the first VSchema of every keyspace.
+	for _, keyspace := range keyspaces {
+		if err := startWatch(keyspace); err != nil {
+			stopAll()
+			return err
+		}
+	}
+	for len(vschemas) < len(keyspaces) {
+		select {
+		case u := <-updates:
+			if u.closed {
+				stopAll()
+				return fmt.Errorf("VSchema watch for keyspace %s was closed before the first notification", u.watch.keyspace)
+			}
+			vschemas[u.watch.keyspace] = u.vschema
+		case <-ctx.Done():
+			stopAll()
+			return ctx.Err()
+		}
+	}
+	if err := plr.loadKeyspaces(vschemas); err != nil {
+		stopAll()
+		return err
+	}
+
+	go func() {
+		defer cancel()
+		ticker := time.NewTicker(*vschemaKeyspacesRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case u := <-updates:
+				if watches[u.watch.keyspace] != u.watch {
+					// The keyspace was deleted.
+					continue
+				}
+				if u.closed {
+					// The watch is started again by the next refresh.
+					log.Warningf("VSchema watch for keyspace %s was closed, retrying in %v", u.watch.keyspace, *vschemaKeyspacesRefreshInterval)
+					delete(watches, u.watch.keyspace)
+					continue
+				}
+				vschemas[u.watch.keyspace] = u.vschema
+			case <-ticker.C:
+				keyspaces, err := ts.GetKeyspaces(ctx)
+				if err != nil {
+					log.Warningf("Cannot refresh the keyspaces of the VSchema, retrying in %v: %v", *vschemaKeyspacesRefreshInterval, err)
+					continue
+				}
+				current := make(map[string]bool, len(keyspaces))
+				for _, keyspace := range keyspaces {
+					current[keyspace] = true
+					if watches[keyspace] != nil {
+						continue
+					}
+					// The VSchema is loaded with the first notification.
+					if err := startWatch(keyspace); err != nil {
+						log.Warningf("Cannot watch the VSchema of keyspace %s, retrying in %v: %v", keyspace, *vschemaKeyspacesRefreshInterval, err)
+					}
+				}
+				// A keyspace can be watched without a VSchema yet,
+				// if it's deleted before its first notification.
+				for keyspace := range watches {
+					if !current[keyspace] {
+						stopWatch(keyspace)
+					}
+				}
+				deleted := false
+				for keyspace := range vschemas {
+					if !current[keyspace] {
+						delete(vschemas, keyspace)
+						deleted = true
+					}
+				}
+				if !deleted {
+					continue
+				}
+			case <-ctx.Done():
+				stopAll()
+				return
+			}
+			if err := plr.loadKeyspaces(vschemas); err != nil {
+				log.Errorf("Keeping the current VSchema: %v", err)
+				continue
+			}
+			log.Infof("Loaded VSchema from topo, version %d", plr.Status().Version)
+		}
+	}()
+	return nil
+}
+
+// Status returns the status of the schema used by the Planner.
+func (plr *Planner) Status() *VSchemaStatus {
+	plr.mu.RLock()
+	defer plr.mu.RUnlock()
+	return &VSchemaStatus{
+		Version:   plr.version,
+		LoadTime:  plr.loadTime,
+		LastError: plr.lastError,
+	}
+}
+
 func (plr *Planner) ServeHTTP(response http.ResponseWriter, request *http.Request) {
 	if err := acl.CheckAccessHTTP(request, acl.DEBUGGING); err != nil {
 		acl.SendError(response, err)
@@ -61,8 +351,10 @@ func (plr *Planner) ServeHTTP(response http.ResponseWriter, request *http.Reques
 		response.Write([]byte(fmt.Sprintf("Length: %d\n", len(keys))))
 		for _, v := range keys {
 			response.Write([]byte(fmt.Sprintf("%#v\n", v)))
-			if plan, ok := plr.plans.Get(v); ok {
-				if b, err := json.MarshalIndent(plan, "", "  "); err != nil {
+			if result, ok := plr.plans.Peek(v); ok {
+				cp := result.(*cachedPlan)
+				response.Write([]byte(fmt.Sprintf("Hits: %d\n", cp.hits.Get())))
+				if b, err := json.MarshalIndent(cp.plan, "", "  "); err != nil {
 					response.Write([]byte(err.Error()))
 				} else {
 					response.Write(b)
@@ -72,7 +364,9 @@ func (plr *Planner) ServeHTTP(response http.ResponseWriter, request *http.Reques
 		}
 	} else if request.URL.Path == "/debug/schema" {
 		response.Header().Set("Content-Type", "application/json; charset=utf-8")
+		plr.mu.RLock()
 		b, err := json.MarshalIndent(plr.schema, "", " ")
+		plr.mu.RUnlock()
 		if err != nil {
 			response.Write([]byte(err.Error()))
 			return
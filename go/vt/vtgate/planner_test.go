@@ -0,0 +1,252 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/topo"
+	"github.com/youtube/vitess/go/vt/topo/test/faketopo"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+const (
+	plannerSchema1 = `{"Keyspaces": {"ks": {"Tables": {"t1": ""}}}}`
+	plannerSchema2 = `{"Keyspaces": {"ks": {"Tables": {"t2": ""}}}}`
+)
+
+// fakeVSchemaTopo is a topo.Server whose VSchema watches are
+// fed by the test, one per keyspace.
+type fakeVSchemaTopo struct {
+	faketopo.FakeTopo
+
+	// mu protects the maps.
+	mu            sync.Mutex
+	notifications map[string]chan string
+	stopWatching  map[string]chan struct{}
+	watched       map[string]bool
+}
+
+func newFakeVSchemaTopo(keyspaces ...string) *fakeVSchemaTopo {
+	ft := &fakeVSchemaTopo{
+		notifications: make(map[string]chan string),
+		stopWatching:  make(map[string]chan struct{}),
+		watched:       make(map[string]bool),
+	}
+	for _, keyspace := range keyspaces {
+		ft.addKeyspace(keyspace)
+	}
+	return ft
+}
+
+// addKeyspace creates a keyspace, and returns the channels of its watch.
+func (ft *fakeVSchemaTopo) addKeyspace(keyspace string) (chan string, chan struct{}) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.notifications[keyspace] = make(chan string, 10)
+	ft.stopWatching[keyspace] = make(chan struct{})
+	return ft.notifications[keyspace], ft.stopWatching[keyspace]
+}
+
+func (ft *fakeVSchemaTopo) deleteKeyspace(keyspace string) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	delete(ft.notifications, keyspace)
+	delete(ft.stopWatching, keyspace)
+}
+
+// isWatched returns true if the VSchema of keyspace was watched.
+func (ft *fakeVSchemaTopo) isWatched(keyspace string) bool {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return ft.watched[keyspace]
+}
+
+func (ft *fakeVSchemaTopo) GetKeyspaces(ctx context.Context) ([]string, error) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	var keyspaces []string
+	for keyspace := range ft.notifications {
+		keyspaces = append(keyspaces, keyspace)
+	}
+	return keyspaces, nil
+}
+
+func (ft *fakeVSchemaTopo) WatchVSchema(ctx context.Context, keyspace string) (<-chan string, chan<- struct{}, error) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.watched[keyspace] = true
+	return ft.notifications[keyspace], ft.stopWatching[keyspace], nil
+}
+
+func TestPlannerLoadSchema(t *testing.T) {
+	plr := NewPlanner(nil, 10)
+	if got := plr.GetPlan("select * from t1"); got != noPlan {
+		t.Errorf("GetPlan: %v, want noPlan", got)
+	}
+	if got := plr.Status().Version; got != 0 {
+		t.Errorf("Version: %d, want 0", got)
+	}
+
+	if err := plr.LoadSchema(plannerSchema1); err != nil {
+		t.Fatal(err)
+	}
+	if got := plr.GetPlan("select * from t1"); got.ID != planbuilder.SelectUnsharded {
+		t.Errorf("GetPlan(t1): %v, want SelectUnsharded", got)
+	}
+	if got := plr.plans.Length(); got != 1 {
+		t.Errorf("plans: %d, want 1", got)
+	}
+	if got := plr.Status().Version; got != 1 {
+		t.Errorf("Version: %d, want 1", got)
+	}
+
+	// Same schema again: the plans are kept.
+	if err := plr.LoadSchema(plannerSchema1); err != nil {
+		t.Fatal(err)
+	}
+	if got := plr.plans.Length(); got != 1 {
+		t.Errorf("plans: %d, want 1", got)
+	}
+	if got := plr.Status().Version; got != 1 {
+		t.Errorf("Version: %d, want 1", got)
+	}
+
+	// New schema: the plans are flushed.
+	if err := plr.LoadSchema(plannerSchema2); err != nil {
+		t.Fatal(err)
+	}
+	if got := plr.plans.Length(); got != 0 {
+		t.Errorf("plans: %d, want 0", got)
+	}
+	if got := plr.GetPlan("select * from t1"); got.Reason != "table t1 not found" {
+		t.Errorf("GetPlan(t1).Reason: %q, want table t1 not found", got.Reason)
+	}
+	if got := plr.Status().Version; got != 2 {
+		t.Errorf("Version: %d, want 2", got)
+	}
+
+	// Invalid schema: the current one is kept.
+	if err := plr.LoadSchema("{"); err == nil {
+		t.Errorf("LoadSchema: nil, want error")
+	}
+	status := plr.Status()
+	if status.Version != 2 || status.LastError == "" {
+		t.Errorf("Status: %+v, want version 2 and an error", status)
+	}
+	if got := plr.GetPlan("select * from t2"); got.ID != planbuilder.SelectUnsharded {
+		t.Errorf("GetPlan(t2): %v, want SelectUnsharded", got)
+	}
+}
+
+func TestPlannerWatchVSchema(t *testing.T) {
+	ft := newFakeVSchemaTopo()
+	notifications1, stopWatching1 := ft.addKeyspace("ks1")
+	notifications2, stopWatching2 := ft.addKeyspace("ks2")
+	notifications1 <- `{"Tables": {"t1": ""}}`
+	notifications2 <- "{}"
+	ctx, cancel := context.WithCancel(context.Background())
+	plr := NewPlanner(nil, 10)
+	if err := plr.WatchVSchema(ctx, topo.Server{Impl: ft}); err != nil {
+		t.Fatal(err)
+	}
+	// The first version is loaded when WatchVSchema returns.
+	if got := plr.GetPlan("select * from t1"); got.ID != planbuilder.SelectUnsharded || got.Table.Keyspace.Name != "ks1" {
+		t.Errorf("GetPlan(t1): %v, want SelectUnsharded in ks1", got)
+	}
+	if got := plr.Status().Version; got != 1 {
+		t.Errorf("Version: %d, want 1", got)
+	}
+
+	// A change in one keyspace keeps the tables of the other one.
+	notifications2 <- `{"Tables": {"t2": ""}}`
+	for plr.Status().Version != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	if got := plr.GetPlan("select * from t2"); got.ID != planbuilder.SelectUnsharded || got.Table.Keyspace.Name != "ks2" {
+		t.Errorf("GetPlan(t2): %v, want SelectUnsharded in ks2", got)
+	}
+	if got := plr.GetPlan("select * from t1"); got.ID != planbuilder.SelectUnsharded {
+		t.Errorf("GetPlan(t1): %v, want SelectUnsharded", got)
+	}
+
+	// A table defined in two keyspaces is rejected.
+	notifications2 <- `{"Tables": {"t1": ""}}`
+	for plr.Status().LastError == "" {
+		time.Sleep(time.Millisecond)
+	}
+	if got := plr.Status().Version; got != 2 {
+		t.Errorf("Version: %d, want 2", got)
+	}
+
+	cancel()
+	for keyspace, stopWatching := range map[string]chan struct{}{"ks1": stopWatching1, "ks2": stopWatching2} {
+		select {
+		case <-stopWatching:
+		case <-time.After(5 * time.Second):
+			t.Errorf("watch was not stopped for %s", keyspace)
+		}
+	}
+}
+
+func TestPlannerWatchVSchemaKeyspaces(t *testing.T) {
+	saved := *vschemaKeyspacesRefreshInterval
+	*vschemaKeyspacesRefreshInterval = 10 * time.Millisecond
+	defer func() { *vschemaKeyspacesRefreshInterval = saved }()
+
+	ft := newFakeVSchemaTopo()
+	notifications1, stopWatching1 := ft.addKeyspace("ks1")
+	notifications1 <- `{"Tables": {"t1": ""}}`
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	plr := NewPlanner(nil, 10)
+	if err := plr.WatchVSchema(ctx, topo.Server{Impl: ft}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A keyspace created after the start is watched.
+	notifications2, _ := ft.addKeyspace("ks2")
+	notifications2 <- `{"Tables": {"t2": ""}}`
+	for plr.Status().Version != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	if got := plr.GetPlan("select * from t2"); got.ID != planbuilder.SelectUnsharded || got.Table.Keyspace.Name != "ks2" {
+		t.Errorf("GetPlan(t2): %v, want SelectUnsharded in ks2", got)
+	}
+
+	// A deleted keyspace is dropped, and its watch stopped.
+	ft.deleteKeyspace("ks1")
+	select {
+	case <-stopWatching1:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("watch was not stopped for ks1")
+	}
+	for plr.Status().Version != 3 {
+		time.Sleep(time.Millisecond)
+	}
+	if got := plr.GetPlan("select * from t1"); got.Reason != "table t1 not found" {
+		t.Errorf("GetPlan(t1).Reason: %q, want table t1 not found", got.Reason)
+	}
+	if got := plr.GetPlan("select * from t2"); got.ID != planbuilder.SelectUnsharded {
+		t.Errorf("GetPlan(t2): %v, want SelectUnsharded", got)
+	}
+
+	// A keyspace deleted before its first notification
+	// has its watch stopped too.
+	_, stopWatching3 := ft.addKeyspace("ks3")
+	for !ft.isWatched("ks3") {
+		time.Sleep(time.Millisecond)
+	}
+	ft.deleteKeyspace("ks3")
+	select {
+	case <-stopWatching3:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("watch was not stopped for ks3")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	log "github.com/golang/glog"
+
+	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/vt/logz"
+)
+
+var (
+	querylogzHeader = []byte(`
+		<thead>
+			<tr>
+				<th>Method</th>
+				<th>Context</th>
+				<th>Effective Caller</th>
+				<th>Immediate Caller</th>
+				<th>Start</th>
+				<th>End</th>
+				<th>Duration</th>
+				<th>Plan time</th>
+				<th>Execute time</th>
+				<th>Commit time</th>
+				<th>Keyspace</th>
+				<th>Tablet Type</th>
+				<th>Plan</th>
+				<th>SQL</th>
+				<th>Shard Queries</th>
+				<th>Rows Returned</th>
+				<th>Rows Affected</th>
+				<th>Error</th>
+			</tr>
+		</thead>
+	`)
+	querylogzFuncMap = template.FuncMap{
+		"stampMicro":   func(t time.Time) string { return t.Format(time.StampMicro) },
+		"cssWrappable": logz.Wrappable,
+		"unquote":      func(s string) string { return strings.Trim(s, "\"") },
+	}
+	querylogzTmpl = template.Must(template.New("example").Funcs(querylogzFuncMap).Parse(`
+		<tr class="{{.ColorLevel}}">
+			<td>{{.Method}}</td>
+			<td>{{.ContextHTML}}</td>
+			<td>{{.EffectiveCaller}}</td>
+			<td>{{.ImmediateCaller}}</td>
+			<td>{{.StartTime | stampMicro}}</td>
+			<td>{{.EndTime | stampMicro}}</td>
+			<td>{{.TotalTime.Seconds}}</td>
+			<td>{{.PlanTime.Seconds}}</td>
+			<td>{{.ExecuteTime.Seconds}}</td>
+			<td>{{.CommitTime.Seconds}}</td>
+			<td>{{.Keyspace}}</td>
+			<td>{{.TabletType}}</td>
+			<td>{{.PlanID}}</td>
+			<td>{{.SQL | unquote | cssWrappable}}</td>
+			<td>{{.ShardQueries}}</td>
+			<td>{{.RowsReturned}}</td>
+			<td>{{.RowsAffected}}</td>
+			<td>{{.ErrorStr}}</td>
+		</tr>
+	`))
+)
+
+// querylogzHandler serves a human readable snapshot of the
+// current vtgate query log.
+func querylogzHandler(ch chan interface{}, w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	timeout, limit := logz.ParseTimeoutLimitParams(r)
+	logz.StartHTMLTable(w)
+	defer logz.EndHTMLTable(w)
+	w.Write(querylogzHeader)
+
+	tmr := time.NewTimer(timeout)
+	defer tmr.Stop()
+	for i := 0; i < limit; i++ {
+		select {
+		case out := <-ch:
+			select {
+			case <-tmr.C:
+				return
+			default:
+			}
+			stats, ok := out.(*LogStats)
+			if !ok {
+				err := fmt.Errorf("Unexpected value in %s: %#v (expecting value of type %T)", QueryLogger.Name(), out, &LogStats{})
+				io.WriteString(w, `<tr class="error">`)
+				io.WriteString(w, err.Error())
+				io.WriteString(w, "</tr>")
+				log.Error(err)
+				continue
+			}
+			var level string
+			if stats.TotalTime().Seconds() < 0.01 {
+				level = "low"
+			} else if stats.TotalTime().Seconds() < 0.1 {
+				level = "medium"
+			} else {
+				level = "high"
+			}
+			tmplData := struct {
+				*LogStats
+				ColorLevel string
+			}{stats, level}
+			if err := querylogzTmpl.Execute(w, tmplData); err != nil {
+				log.Errorf("querylogz: couldn't execute template: %v", err)
+			}
+		case <-tmr.C:
+			return
+		}
+	}
+}
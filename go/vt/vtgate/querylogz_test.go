@@ -0,0 +1,81 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+func TestQuerylogzHandlerInvalidLogStats(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/querylogz?timeout=10&limit=1", nil)
+	response := httptest.NewRecorder()
+	ch := make(chan interface{}, 1)
+	ch <- "test msg"
+	querylogzHandler(ch, response, req)
+	close(ch)
+	if !strings.Contains(response.Body.String(), "error") {
+		t.Fatalf("should show an error page for an non LogStats")
+	}
+}
+
+func TestQuerylogzHandler(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/querylogz?timeout=10&limit=1", nil)
+	ctx := callerid.NewContext(
+		context.Background(),
+		callerid.NewEffectiveCallerID("effective-caller", "component", "subcomponent"),
+		callerid.NewImmediateCallerID("immediate-caller"),
+	)
+	logStats := NewLogStats(ctx, "Execute", "select name from test_table limit 1000", nil)
+	logStats.Keyspace = "Any"
+	logStats.TabletType = "replica"
+	logStats.StartTime, _ = time.Parse("Jan 2 15:04:05", "Nov 29 13:33:09")
+	logStats.EndTime = logStats.StartTime.Add(20 * time.Millisecond)
+	logStats.recordPlan(planbuilder.SelectScatter, time.Millisecond)
+	logStats.addShardQueries(4, 15*time.Millisecond)
+	logStats.RowsReturned = 1000
+
+	pattern := []string{
+		`<tr class="medium">`,
+		`<td>Execute</td>`,
+		`<td></td>`,
+		`<td>effective-caller</td>`,
+		`<td>immediate-caller</td>`,
+		`<td>Nov 29 13:33:09.000000</td>`,
+		`<td>Nov 29 13:33:09.020000</td>`,
+		`<td>0.02</td>`,
+		`<td>0.001</td>`,
+		`<td>0.015</td>`,
+		`<td>0</td>`,
+		`<td>Any</td>`,
+		`<td>replica</td>`,
+		`<td>SelectScatter</td>`,
+		`<td>select name from test_table limit 1000</td>`,
+		`<td>4</td>`,
+		`<td>1000</td>`,
+		`<td>0</td>`,
+		`<td></td>`,
+	}
+	response := httptest.NewRecorder()
+	ch := make(chan interface{}, 1)
+	ch <- logStats
+	querylogzHandler(ch, response, req)
+	close(ch)
+	body, _ := ioutil.ReadAll(response.Body)
+	matcher := regexp.MustCompile(strings.Join(pattern, `\s*`))
+	if !matcher.Match(body) {
+		t.Fatalf("querylogz page does not contain stats: %v, pattern: %v, page: %s", logStats, pattern, string(body))
+	}
+}
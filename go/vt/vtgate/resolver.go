@@ -373,6 +373,11 @@ func (res *Resolver) Rollback(ctx context.Context, inSession *vtgatepb.Session)
 	return res.scatterConn.Rollback(ctx, NewSafeSession(inSession))
 }
 
+// ResolveTransaction resolves the specified distributed transaction.
+func (res *Resolver) ResolveTransaction(ctx context.Context, dtid string) error {
+	return res.scatterConn.ResolveTransaction(ctx, dtid)
+}
+
 // StrsEquals compares contents of two string slices.
 func StrsEquals(a, b []string) bool {
 	if len(a) != len(b) {
@@ -7,8 +7,13 @@ package vtgate
 // This is a V3 file. Do not intermix with V2.
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/sqlannotation"
@@ -65,19 +70,54 @@ func (rtr *Router) Execute(ctx context.Context, sql string, bindVariables map[st
 	if bindVariables == nil {
 		bindVariables = make(map[string]interface{})
 	}
+	if qr, ok, err := rtr.execSessionStatement(ctx, sql, tabletType, session); ok {
+		return qr, err
+	}
+	tabletType = sessionTabletType(tabletType, session)
+	if session != nil {
+		if session.NoAutocommit && !session.InTransaction && !notInTransaction && tabletType == topodatapb.TabletType_MASTER {
+			session.InTransaction = true
+		}
+	}
 	vcursor := newRequestContext(ctx, sql, bindVariables, tabletType, session, notInTransaction, rtr)
+	if session != nil && session.Shard != "" {
+		return rtr.execShard(vcursor)
+	}
+	planStart := time.Now()
 	plan := rtr.planner.GetPlan(sql)
+	logStatsFromContext(ctx).recordPlan(plan.ID, time.Now().Sub(planStart))
+	if plan.ID == planbuilder.NoPlan && session != nil && session.Keyspace != "" {
+		return rtr.execDefaultKeyspace(vcursor, plan)
+	}
+	if plan.Pullouts != nil {
+		if err := rtr.execPullouts(vcursor, plan); err != nil {
+			return nil, err
+		}
+	}
 
 	switch plan.ID {
 	case planbuilder.UpdateEqual:
 		return rtr.execUpdateEqual(vcursor, plan)
+	case planbuilder.UpdateIN, planbuilder.UpdateScatter:
+		return rtr.execUpdateMultiShard(vcursor, plan)
+	case planbuilder.UpdateVindexChange:
+		return rtr.execInTransaction(vcursor, plan, rtr.execUpdateVindexChange)
+	case planbuilder.UpdateLookupChange:
+		return rtr.execInTransaction(vcursor, plan, rtr.execUpdateLookupChange)
 	case planbuilder.DeleteEqual:
-		return rtr.execDeleteEqual(vcursor, plan)
+		return rtr.execOwnedDML(vcursor, plan, rtr.execDeleteEqual)
+	case planbuilder.DeleteIN, planbuilder.DeleteScatter:
+		return rtr.execOwnedDML(vcursor, plan, rtr.execDeleteMultiShard)
 	case planbuilder.InsertSharded:
-		return rtr.execInsertSharded(vcursor, plan)
+		return rtr.execOwnedDML(vcursor, plan, rtr.execInsertSharded)
+	case planbuilder.SelectJoin:
+		return rtr.execJoin(vcursor, plan)
 	}
 
-	var err error
+	offset, rowcount, err := getLimits(plan, vcursor.bindVariables)
+	if err != nil {
+		return nil, err
+	}
 	var params *scatterParams
 	switch plan.ID {
 	case planbuilder.SelectUnsharded, planbuilder.UpdateUnsharded,
@@ -89,6 +129,10 @@ func (rtr *Router) Execute(ctx context.Context, sql string, bindVariables map[st
 		params, err = rtr.paramsSelectIN(vcursor, plan)
 	case planbuilder.SelectKeyrange:
 		params, err = rtr.paramsSelectKeyrange(vcursor, plan)
+	case planbuilder.SelectRange:
+		params, err = rtr.paramsSelectRange(vcursor, plan)
+	case planbuilder.SelectPrefix:
+		params, err = rtr.paramsSelectPrefix(vcursor, plan)
 	case planbuilder.SelectScatter:
 		params, err = rtr.paramsSelectScatter(vcursor, plan)
 	default:
@@ -97,7 +141,7 @@ func (rtr *Router) Execute(ctx context.Context, sql string, bindVariables map[st
 	if err != nil {
 		return nil, err
 	}
-	return rtr.scatterConn.ExecuteMulti(
+	qr, err := rtr.scatterConn.ExecuteMulti(
 		ctx,
 		params.query,
 		params.ks,
@@ -106,6 +150,47 @@ func (rtr *Router) Execute(ctx context.Context, sql string, bindVariables map[st
 		NewSafeSession(session),
 		notInTransaction,
 	)
+	if err != nil || !hasPostProcessing(plan) {
+		return qr, err
+	}
+	if err := postProcess(qr, plan, offset, rowcount); err != nil {
+		return nil, err
+	}
+	return qr, nil
+}
+
+// keyspaces returns the keyspaces sql goes to, as far as they're
+// known before it's executed: the keyspace of the session if the
+// query is sent there, or else the keyspaces of the tables of its
+// plan. session can be nil.
+func (rtr *Router) keyspaces(sql string, session *vtgatepb.Session) []string {
+	if session != nil && session.Shard != "" {
+		return []string{session.Keyspace}
+	}
+	plan := rtr.planner.peekPlan(sql)
+	if plan.ID == planbuilder.NoPlan && session != nil && session.Keyspace != "" {
+		return []string{session.Keyspace}
+	}
+	return planKeyspaces(nil, plan)
+}
+
+// planKeyspaces appends the keyspaces of the tables of plan, and
+// of the plans of its joins and subqueries, to keyspaces.
+func planKeyspaces(keyspaces []string, plan *planbuilder.Plan) []string {
+	if plan == nil {
+		return keyspaces
+	}
+	if plan.Table != nil && plan.Table.Keyspace != nil {
+		keyspaces = append(keyspaces, plan.Table.Keyspace.Name)
+	}
+	if plan.Join != nil {
+		keyspaces = planKeyspaces(keyspaces, plan.Join.Left)
+		keyspaces = planKeyspaces(keyspaces, plan.Join.Right)
+	}
+	for _, pullout := range plan.Pullouts {
+		keyspaces = planKeyspaces(keyspaces, pullout.Plan)
+	}
+	return keyspaces
 }
 
 // StreamExecute executes a streaming query.
@@ -114,9 +199,22 @@ func (rtr *Router) StreamExecute(ctx context.Context, sql string, bindVariables
 		bindVariables = make(map[string]interface{})
 	}
 	vcursor := newRequestContext(ctx, sql, bindVariables, tabletType, nil, false, rtr)
+	planStart := time.Now()
 	plan := rtr.planner.GetPlan(sql)
+	logStatsFromContext(ctx).recordPlan(plan.ID, time.Now().Sub(planStart))
+	if plan.Pullouts != nil {
+		if err := rtr.execPullouts(vcursor, plan); err != nil {
+			return err
+		}
+	}
+	if plan.ID == planbuilder.SelectJoin {
+		return rtr.streamJoin(vcursor, plan, sendReply)
+	}
 
-	var err error
+	offset, rowcount, err := getLimits(plan, vcursor.bindVariables)
+	if err != nil {
+		return err
+	}
 	var params *scatterParams
 	switch plan.ID {
 	case planbuilder.SelectUnsharded:
@@ -127,6 +225,10 @@ func (rtr *Router) StreamExecute(ctx context.Context, sql string, bindVariables
 		params, err = rtr.paramsSelectIN(vcursor, plan)
 	case planbuilder.SelectKeyrange:
 		params, err = rtr.paramsSelectKeyrange(vcursor, plan)
+	case planbuilder.SelectRange:
+		params, err = rtr.paramsSelectRange(vcursor, plan)
+	case planbuilder.SelectPrefix:
+		params, err = rtr.paramsSelectPrefix(vcursor, plan)
 	case planbuilder.SelectScatter:
 		params, err = rtr.paramsSelectScatter(vcursor, plan)
 	default:
@@ -135,16 +237,115 @@ func (rtr *Router) StreamExecute(ctx context.Context, sql string, bindVariables
 	if err != nil {
 		return err
 	}
-	return rtr.scatterConn.StreamExecuteMulti(
+	if plan.Aggregate != nil {
+		// Aggregates can only be combined once all the
+		// rows are received.
+		qr := &sqltypes.Result{}
+		err := rtr.scatterConn.StreamExecuteMulti(
+			ctx,
+			params.query,
+			params.ks,
+			params.shardVars,
+			tabletType,
+			func(reply *sqltypes.Result) error {
+				if qr.Fields == nil {
+					qr.Fields = reply.Fields
+				}
+				qr.Rows = append(qr.Rows, reply.Rows...)
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if err := postProcess(qr, plan, offset, rowcount); err != nil {
+			return err
+		}
+		return sendReply(qr)
+	}
+	if !hasPostProcessing(plan) {
+		return rtr.scatterConn.StreamExecuteMulti(
+			ctx,
+			params.query,
+			params.ks,
+			params.shardVars,
+			tabletType,
+			sendReply,
+		)
+	}
+	return rtr.scatterConn.StreamExecuteMultiMerge(
 		ctx,
 		params.query,
 		params.ks,
 		params.shardVars,
 		tabletType,
+		newOrderByColumns(plan.OrderBy),
+		offset,
+		rowcount,
 		sendReply,
 	)
 }
 
+// hasPostProcessing returns true if the results of plan
+// need to be processed by VTGate after they're merged.
+func hasPostProcessing(plan *planbuilder.Plan) bool {
+	return plan.Aggregate != nil || plan.OrderBy != nil || plan.Limit != nil
+}
+
+// postProcess applies the aggregation, ORDER BY and LIMIT of
+// a multi-shard plan to the merged result.
+func postProcess(qr *sqltypes.Result, plan *planbuilder.Plan, offset, rowcount int64) error {
+	orderBy := newOrderByColumns(plan.OrderBy)
+	if plan.Aggregate != nil {
+		if err := aggregateRows(qr, plan.Aggregate); err != nil {
+			return err
+		}
+		if orderBy == nil {
+			// Like MySQL, return the groups sorted by their keys.
+			for _, k := range plan.Aggregate.Keys {
+				orderBy = append(orderBy, orderByColumn{Index: k})
+			}
+		}
+	}
+	if err := sortRows(qr, orderBy); err != nil {
+		return err
+	}
+	if plan.Limit != nil {
+		limitRows(qr, offset, rowcount)
+	}
+	if plan.Aggregate != nil {
+		truncateColumns(qr, plan.Aggregate.Columns)
+	}
+	return nil
+}
+
+// execPullouts executes the pulled-out subqueries of plan, and
+// adds their results to the bind vars of vcursor as lists.
+func (rtr *Router) execPullouts(vcursor *requestContext, plan *planbuilder.Plan) error {
+	bindVars := make(map[string]interface{}, len(vcursor.bindVariables)+len(plan.Pullouts))
+	for k, v := range vcursor.bindVariables {
+		bindVars[k] = v
+	}
+	for _, pullout := range plan.Pullouts {
+		qr, err := rtr.Execute(vcursor.ctx, pullout.Plan.Original, vcursor.bindVariables, vcursor.tabletType, vcursor.session, vcursor.notInTransaction)
+		if err != nil {
+			return fmt.Errorf("execPullouts: %v", err)
+		}
+		values := make([]interface{}, 0, len(qr.Rows))
+		for _, row := range qr.Rows {
+			values = append(values, row[0].ToNative())
+		}
+		if len(values) == 0 {
+			// An empty list is not valid SQL. IN (NULL)
+			// is equivalent to IN () because it never matches.
+			values = append(values, nil)
+		}
+		bindVars[pullout.Var] = values
+	}
+	vcursor.bindVariables = bindVars
+	return nil
+}
+
 func (rtr *Router) paramsUnsharded(vcursor *requestContext, plan *planbuilder.Plan) (*scatterParams, error) {
 	ks, _, allShards, err := getKeyspaceShards(vcursor.ctx, rtr.serv, rtr.cell, plan.Table.Keyspace.Name, vcursor.tabletType)
 	if err != nil {
@@ -161,6 +362,15 @@ func (rtr *Router) paramsSelectEqual(vcursor *requestContext, plan *planbuilder.
 	if err != nil {
 		return nil, fmt.Errorf("paramsSelectEqual: %v", err)
 	}
+	if keys[0] == nil {
+		// NULL can't match any row, but the query is still
+		// sent to a shard to obtain the fields of the result.
+		ks, _, allShards, err := getKeyspaceShards(vcursor.ctx, rtr.serv, rtr.cell, plan.Table.Keyspace.Name, vcursor.tabletType)
+		if err != nil {
+			return nil, fmt.Errorf("paramsSelectEqual: %v", err)
+		}
+		return newScatterParams(plan.Rewritten, ks, vcursor.bindVariables, []string{allShards[0].Name}), nil
+	}
 	ks, routing, err := rtr.resolveShards(vcursor, keys, plan)
 	if err != nil {
 		return nil, fmt.Errorf("paramsSelectEqual: %v", err)
@@ -169,10 +379,23 @@ func (rtr *Router) paramsSelectEqual(vcursor *requestContext, plan *planbuilder.
 }
 
 func (rtr *Router) paramsSelectIN(vcursor *requestContext, plan *planbuilder.Plan) (*scatterParams, error) {
-	keys, err := rtr.resolveKeys(plan.Values.([]interface{}), vcursor.bindVariables)
+	keys, err := rtr.resolveList(plan.Values, vcursor.bindVariables)
 	if err != nil {
 		return nil, fmt.Errorf("paramsSelectIN: %v", err)
 	}
+	if len(keys) == 0 {
+		// No value can match a row, and an empty list is not
+		// valid SQL: the query is not sent to any shard.
+		ks, _, _, err := getKeyspaceShards(vcursor.ctx, rtr.serv, rtr.cell, plan.Table.Keyspace.Name, vcursor.tabletType)
+		if err != nil {
+			return nil, fmt.Errorf("paramsSelectIN: %v", err)
+		}
+		return &scatterParams{
+			query:     plan.Rewritten,
+			ks:        ks,
+			shardVars: map[string]map[string]interface{}{},
+		}, nil
+	}
 	ks, routing, err := rtr.resolveShards(vcursor, keys, plan)
 	if err != nil {
 		return nil, fmt.Errorf("paramsSelectEqual: %v", err)
@@ -203,6 +426,40 @@ func (rtr *Router) paramsSelectKeyrange(vcursor *requestContext, plan *planbuild
 	return newScatterParams(plan.Rewritten, ks, vcursor.bindVariables, shards), nil
 }
 
+func (rtr *Router) paramsSelectRange(vcursor *requestContext, plan *planbuilder.Plan) (*scatterParams, error) {
+	keys, err := rtr.resolveKeys(plan.Values.([]interface{}), vcursor.bindVariables)
+	if err != nil {
+		return nil, fmt.Errorf("paramsSelectRange: %v", err)
+	}
+	start, end, err := plan.ColVindex.Vindex.(planbuilder.Ranged).MapRange(vcursor, keys[0], keys[1])
+	if err != nil {
+		return nil, fmt.Errorf("paramsSelectRange: %v", err)
+	}
+	kr := &topodatapb.KeyRange{Start: start, End: end}
+	ks, shards, err := mapKeyRangesToShards(vcursor.ctx, rtr.serv, rtr.cell, plan.Table.Keyspace.Name, vcursor.tabletType, []*topodatapb.KeyRange{kr})
+	if err != nil {
+		return nil, fmt.Errorf("paramsSelectRange: %v", err)
+	}
+	return newScatterParams(plan.Rewritten, ks, vcursor.bindVariables, shards), nil
+}
+
+func (rtr *Router) paramsSelectPrefix(vcursor *requestContext, plan *planbuilder.Plan) (*scatterParams, error) {
+	prefix, err := rtr.resolveKeys(plan.Values.([]interface{}), vcursor.bindVariables)
+	if err != nil {
+		return nil, fmt.Errorf("paramsSelectPrefix: %v", err)
+	}
+	start, end, err := plan.ColVindex.Vindex.(planbuilder.MultiColumn).MapPrefix(vcursor, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("paramsSelectPrefix: %v", err)
+	}
+	kr := &topodatapb.KeyRange{Start: start, End: end}
+	ks, shards, err := mapKeyRangesToShards(vcursor.ctx, rtr.serv, rtr.cell, plan.Table.Keyspace.Name, vcursor.tabletType, []*topodatapb.KeyRange{kr})
+	if err != nil {
+		return nil, fmt.Errorf("paramsSelectPrefix: %v", err)
+	}
+	return newScatterParams(plan.Rewritten, ks, vcursor.bindVariables, shards), nil
+}
+
 func getKeyRange(keys []interface{}) (*topodatapb.KeyRange, error) {
 	var ksids [][]byte
 	for _, k := range keys {
@@ -231,6 +488,38 @@ func (rtr *Router) paramsSelectScatter(vcursor *requestContext, plan *planbuilde
 	return newScatterParams(plan.Rewritten, ks, vcursor.bindVariables, shards), nil
 }
 
+// execOwnedDML executes a DML on a table that owns vindexes. Such
+// a DML also creates or deletes the vindex entries, and all these
+// writes must succeed or fail together. So, it's executed by
+// execInTransaction.
+func (rtr *Router) execOwnedDML(vcursor *requestContext, plan *planbuilder.Plan, exec func(*requestContext, *planbuilder.Plan) (*sqltypes.Result, error)) (*sqltypes.Result, error) {
+	if len(plan.Table.Owned) == 0 {
+		return exec(vcursor, plan)
+	}
+	return rtr.execInTransaction(vcursor, plan, exec)
+}
+
+// execInTransaction executes exec within a transaction. If the
+// session is not already in a transaction, execInTransaction opens
+// one for the statement, and commits it only if exec succeeds.
+func (rtr *Router) execInTransaction(vcursor *requestContext, plan *planbuilder.Plan, exec func(*requestContext, *planbuilder.Plan) (*sqltypes.Result, error)) (*sqltypes.Result, error) {
+	if vcursor.session != nil && vcursor.session.InTransaction {
+		return exec(vcursor, plan)
+	}
+	session := NewSafeSession(&vtgatepb.Session{InTransaction: true})
+	vcursor.session = session.Session
+	vcursor.notInTransaction = false
+	qr, err := exec(vcursor, plan)
+	if err != nil {
+		rtr.scatterConn.Rollback(vcursor.ctx, session)
+		return nil, err
+	}
+	if err := rtr.scatterConn.Commit(vcursor.ctx, session); err != nil {
+		return nil, err
+	}
+	return qr, nil
+}
+
 func (rtr *Router) execUpdateEqual(vcursor *requestContext, plan *planbuilder.Plan) (*sqltypes.Result, error) {
 	keys, err := rtr.resolveKeys([]interface{}{plan.Values}, vcursor.bindVariables)
 	if err != nil {
@@ -287,55 +576,482 @@ func (rtr *Router) execDeleteEqual(vcursor *requestContext, plan *planbuilder.Pl
 		vcursor.notInTransaction)
 }
 
-func (rtr *Router) execInsertSharded(vcursor *requestContext, plan *planbuilder.Plan) (*sqltypes.Result, error) {
-	input := plan.Values.([]interface{})
-	keys, err := rtr.resolveKeys(input, vcursor.bindVariables)
+// execUpdateVindexChange executes an update that changes a vindex
+// column by moving the rows: they're deleted along with their owned
+// vindex entries, and inserted back with the new values. The insert
+// creates the new vindex entries, and sends the rows to the shards
+// where they now belong.
+func (rtr *Router) execUpdateVindexChange(vcursor *requestContext, plan *planbuilder.Plan) (*sqltypes.Result, error) {
+	keys, err := rtr.resolveKeys([]interface{}{plan.Values}, vcursor.bindVariables)
 	if err != nil {
-		return nil, fmt.Errorf("execInsertSharded: %v", err)
+		return nil, fmt.Errorf("execUpdateVindexChange: %v", err)
 	}
-	ksid, generated, err := rtr.handlePrimary(vcursor, keys[0], plan.Table.ColVindexes[0], vcursor.bindVariables)
+	ks, shard, ksid, err := rtr.resolveSingleShard(vcursor, keys[0], plan)
 	if err != nil {
-		return nil, fmt.Errorf("execInsertSharded: %v", err)
+		return nil, fmt.Errorf("execUpdateVindexChange: %v", err)
+	}
+	if len(ksid) == 0 {
+		return &sqltypes.Result{}, nil
+	}
+	rows, err := rtr.scatterConn.Execute(
+		vcursor.ctx,
+		plan.Subquery,
+		vcursor.bindVariables,
+		ks,
+		[]string{shard},
+		vcursor.tabletType,
+		NewSafeSession(vcursor.session),
+		vcursor.notInTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("execUpdateVindexChange: %v", err)
+	}
+	if len(rows.Rows) == 0 {
+		return &sqltypes.Result{}, nil
+	}
+	insert, insertVars, err := rtr.buildMoveInsert(vcursor, plan, rows)
+	if err != nil {
+		return nil, fmt.Errorf("execUpdateVindexChange: %v", err)
+	}
+	ownedRows, err := ownedColumns(plan, rows)
+	if err != nil {
+		return nil, fmt.Errorf("execUpdateVindexChange: %v", err)
+	}
+	if err := deleteVindexRows(vcursor, plan, ownedRows, ksid); err != nil {
+		return nil, fmt.Errorf("execUpdateVindexChange: %v", err)
+	}
+	vcursor.bindVariables[ksidName] = string(ksid)
+	result, err := rtr.scatterConn.Execute(
+		vcursor.ctx,
+		sqlannotation.AddKeyspaceID(plan.Rewritten, ksid),
+		vcursor.bindVariables,
+		ks,
+		[]string{shard},
+		vcursor.tabletType,
+		NewSafeSession(vcursor.session),
+		vcursor.notInTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("execUpdateVindexChange: %v", err)
+	}
+	if _, err := rtr.Execute(vcursor.ctx, insert, insertVars, vcursor.tabletType, vcursor.session, false); err != nil {
+		return nil, fmt.Errorf("execUpdateVindexChange: %v", err)
+	}
+	return result, nil
+}
+
+// execUpdateLookupChange executes an update that changes the columns
+// of lookup vindexes only, so the rows stay in their shard. Before the
+// update is sent, the entries of the changed vindexes that the table
+// owns are replaced with the new values, and the new values of the
+// other changed vindexes are verified.
+func (rtr *Router) execUpdateLookupChange(vcursor *requestContext, plan *planbuilder.Plan) (*sqltypes.Result, error) {
+	keys, err := rtr.resolveKeys([]interface{}{plan.Values}, vcursor.bindVariables)
+	if err != nil {
+		return nil, fmt.Errorf("execUpdateLookupChange: %v", err)
 	}
-	ks, shard, err := rtr.getRouting(vcursor.ctx, plan.Table.Keyspace.Name, vcursor.tabletType, ksid)
+	ks, shard, ksid, err := rtr.resolveSingleShard(vcursor, keys[0], plan)
+	if err != nil {
+		return nil, fmt.Errorf("execUpdateLookupChange: %v", err)
+	}
+	if len(ksid) == 0 {
+		return &sqltypes.Result{}, nil
+	}
+	rows, err := rtr.scatterConn.Execute(
+		vcursor.ctx,
+		plan.Subquery,
+		vcursor.bindVariables,
+		ks,
+		[]string{shard},
+		vcursor.tabletType,
+		NewSafeSession(vcursor.session),
+		vcursor.notInTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("execUpdateLookupChange: %v", err)
+	}
+	if len(rows.Rows) == 0 {
+		return &sqltypes.Result{}, nil
+	}
+	if err := rtr.changeLookupEntries(vcursor, plan, rows, ksid); err != nil {
+		return nil, fmt.Errorf("execUpdateLookupChange: %v", err)
+	}
+	vcursor.bindVariables[ksidName] = string(ksid)
+	rewritten := sqlannotation.AddKeyspaceID(plan.Rewritten, ksid)
+	return rtr.scatterConn.Execute(
+		vcursor.ctx,
+		rewritten,
+		vcursor.bindVariables,
+		ks,
+		[]string{shard},
+		vcursor.tabletType,
+		NewSafeSession(vcursor.session),
+		vcursor.notInTransaction)
+}
+
+// changeLookupEntries updates the changed lookup vindexes of the
+// rows of an UpdateLookupChange plan, which all map to ksid. rows
+// has the current values of the columns of these vindexes. NULL
+// values have no vindex entry.
+func (rtr *Router) changeLookupEntries(vcursor *requestContext, plan *planbuilder.Plan, rows *sqltypes.Result, ksid []byte) error {
+	colNum := make(map[string]int, len(rows.Fields))
+	for i, field := range rows.Fields {
+		colNum[field.Name] = i
+	}
+	changes := make(map[string]interface{}, len(plan.Changes))
+	for col, val := range plan.Changes {
+		vals, err := rtr.resolveKeys([]interface{}{val}, vcursor.bindVariables)
+		if err != nil {
+			return err
+		}
+		changes[col] = vals[0]
+	}
+	for _, colVindex := range plan.Table.ColVindexes[1:] {
+		changed := false
+		for _, col := range colVindex.Cols {
+			if _, ok := changes[col]; ok {
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		var oldKeys, newKeys []interface{}
+		for _, row := range rows.Rows {
+			oldKey, newKey := make([]interface{}, 0, len(colVindex.Cols)), make([]interface{}, 0, len(colVindex.Cols))
+			for _, col := range colVindex.Cols {
+				i, ok := colNum[col]
+				if !ok {
+					return fmt.Errorf("column %s not found in table %s", col, plan.Table.Name)
+				}
+				val := row[i].ToNative()
+				if b, ok := val.([]byte); ok {
+					val = string(b)
+				}
+				oldKey = append(oldKey, val)
+				if newVal, ok := changes[col]; ok {
+					val = newVal
+				}
+				newKey = append(newKey, val)
+			}
+			oldKeys = appendVindexKey(oldKeys, oldKey)
+			newKeys = appendVindexKey(newKeys, newKey)
+		}
+		if !colVindex.Owned {
+			for _, key := range newKeys {
+				ok, err := colVindex.Vindex.Verify(vcursor, key, ksid)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("value %v for column %s does not map to keyspace id %v", key, colVindex.Col, hex.EncodeToString(ksid))
+				}
+			}
+			continue
+		}
+		// Owned vindexes can't be multi-column.
+		lookup := colVindex.Vindex.(planbuilder.Lookup)
+		if len(oldKeys) != 0 {
+			if err := lookup.Delete(vcursor, oldKeys, ksid); err != nil {
+				return err
+			}
+		}
+		for _, key := range newKeys {
+			if err := lookup.Create(vcursor, key, ksid); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendVindexKey appends the vindex key made of vals to keys, unless
+// it's already there or has a NULL value. The key of a multi-column
+// vindex is the list of the values of its columns.
+func appendVindexKey(keys []interface{}, vals []interface{}) []interface{} {
+	for _, val := range vals {
+		if val == nil {
+			return keys
+		}
+	}
+	var key interface{} = vals
+	if len(vals) == 1 {
+		key = vals[0]
+	}
+	for _, k := range keys {
+		if reflect.DeepEqual(k, key) {
+			return keys
+		}
+	}
+	return append(keys, key)
+}
+
+// buildMoveInsert builds the insert that puts back the rows moved
+// by an UpdateVindexChange plan, with the new values of the update.
+// The values are supplied as bind vars.
+func (rtr *Router) buildMoveInsert(vcursor *requestContext, plan *planbuilder.Plan, rows *sqltypes.Result) (string, map[string]interface{}, error) {
+	cols := make([]string, 0, len(rows.Fields))
+	colNum := make(map[string]int, len(rows.Fields))
+	for i, field := range rows.Fields {
+		cols = append(cols, field.Name)
+		colNum[field.Name] = i
+	}
+	changes := make(map[int]interface{}, len(plan.Changes))
+	for col, val := range plan.Changes {
+		i, ok := colNum[col]
+		if !ok {
+			return "", nil, fmt.Errorf("column %s not found in table %s", col, plan.Table.Name)
+		}
+		vals, err := rtr.resolveKeys([]interface{}{val}, vcursor.bindVariables)
+		if err != nil {
+			return "", nil, err
+		}
+		changes[i] = vals[0]
+	}
+	bindVars := make(map[string]interface{}, len(rows.Rows)*len(cols))
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "insert into %s(%s) values ", plan.Table.Name, strings.Join(cols, ", "))
+	for rowNum, row := range rows.Rows {
+		if rowNum != 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("(")
+		for i, val := range row {
+			name := fmt.Sprintf("v%d_%d", rowNum, i)
+			if i != 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, ":%s", name)
+			if newVal, ok := changes[i]; ok {
+				bindVars[name] = newVal
+				continue
+			}
+			bindVars[name] = val.ToNative()
+		}
+		buf.WriteString(")")
+	}
+	return buf.String(), bindVars, nil
+}
+
+// ownedColumns returns the values of the owned vindex columns
+// of rows, in the order of the owned vindexes of the table.
+func ownedColumns(plan *planbuilder.Plan, rows *sqltypes.Result) ([][]sqltypes.Value, error) {
+	positions := make([]int, 0, len(plan.Table.Owned))
+	for _, colVindex := range plan.Table.Owned {
+		pos := -1
+		for i, field := range rows.Fields {
+			if field.Name == colVindex.Col {
+				pos = i
+				break
+			}
+		}
+		if pos == -1 {
+			return nil, fmt.Errorf("column %s not found in table %s", colVindex.Col, plan.Table.Name)
+		}
+		positions = append(positions, pos)
+	}
+	owned := make([][]sqltypes.Value, 0, len(rows.Rows))
+	for _, row := range rows.Rows {
+		vals := make([]sqltypes.Value, 0, len(positions))
+		for _, pos := range positions {
+			vals = append(vals, row[pos])
+		}
+		owned = append(owned, vals)
+	}
+	return owned, nil
+}
+
+func (rtr *Router) execUpdateMultiShard(vcursor *requestContext, plan *planbuilder.Plan) (*sqltypes.Result, error) {
+	params, err := rtr.paramsMultiShardDML(vcursor, plan)
+	if err != nil {
+		return nil, fmt.Errorf("execUpdateMultiShard: %v", err)
+	}
+	return rtr.scatterConn.ExecuteMulti(
+		vcursor.ctx,
+		sqlannotation.AddFilteredReplicationUnfriendly(params.query),
+		params.ks,
+		params.shardVars,
+		vcursor.tabletType,
+		NewSafeSession(vcursor.session),
+		vcursor.notInTransaction)
+}
+
+func (rtr *Router) execDeleteMultiShard(vcursor *requestContext, plan *planbuilder.Plan) (*sqltypes.Result, error) {
+	params, err := rtr.paramsMultiShardDML(vcursor, plan)
+	if err != nil {
+		return nil, fmt.Errorf("execDeleteMultiShard: %v", err)
+	}
+	if plan.Subquery != "" {
+		err = rtr.deleteVindexEntriesMultiShard(vcursor, plan, params.ks, params.shardVars)
+		if err != nil {
+			return nil, fmt.Errorf("execDeleteMultiShard: %v", err)
+		}
+	}
+	return rtr.scatterConn.ExecuteMulti(
+		vcursor.ctx,
+		sqlannotation.AddFilteredReplicationUnfriendly(params.query),
+		params.ks,
+		params.shardVars,
+		vcursor.tabletType,
+		NewSafeSession(vcursor.session),
+		vcursor.notInTransaction)
+}
+
+// paramsMultiShardDML returns the shards targeted by an UpdateIN,
+// UpdateScatter, DeleteIN or DeleteScatter plan. They're computed
+// the same way as for the equivalent select.
+func (rtr *Router) paramsMultiShardDML(vcursor *requestContext, plan *planbuilder.Plan) (*scatterParams, error) {
+	switch plan.ID {
+	case planbuilder.UpdateIN, planbuilder.DeleteIN:
+		return rtr.paramsSelectIN(vcursor, plan)
+	}
+	return rtr.paramsSelectScatter(vcursor, plan)
+}
+
+func (rtr *Router) execInsertSharded(vcursor *requestContext, plan *planbuilder.Plan) (*sqltypes.Result, error) {
+	seqgen, err := rtr.handleGenerate(vcursor, plan.Generate)
 	if err != nil {
 		return nil, fmt.Errorf("execInsertSharded: %v", err)
 	}
-	for i := 1; i < len(keys); i++ {
-		newgen, err := rtr.handleNonPrimary(vcursor, keys[i], plan.Table.ColVindexes[i], vcursor.bindVariables, ksid)
+	// Like MySQL, the insert id of a multi-row insert
+	// is the first generated value.
+	insertid := seqgen
+	var ks string
+	var shards []string
+	shardRows := make(map[string][]int)
+	shardKsids := make(map[string][][]byte)
+	for rowNum, input := range plan.Values.([]interface{}) {
+		keys, err := rtr.resolveKeys(input.([]interface{}), vcursor.bindVariables)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("execInsertSharded: %v", err)
+		}
+		ksid, generated, err := rtr.handlePrimary(vcursor, keys[0], plan.Table.ColVindexes[0], vcursor.bindVariables, rowNum)
+		if err != nil {
+			return nil, fmt.Errorf("execInsertSharded: %v", err)
 		}
-		if newgen != 0 {
-			if generated != 0 {
+		for i := 1; i < len(keys); i++ {
+			newgen, err := rtr.handleNonPrimary(vcursor, keys[i], plan.Table.ColVindexes[i], vcursor.bindVariables, ksid, rowNum)
+			if err != nil {
+				return nil, err
+			}
+			if newgen != 0 {
+				if generated != 0 {
+					return nil, fmt.Errorf("insert generated more than one value")
+				}
+				generated = newgen
+			}
+		}
+		if generated != 0 {
+			if seqgen != 0 {
 				return nil, fmt.Errorf("insert generated more than one value")
 			}
-			generated = newgen
+			if insertid == 0 {
+				insertid = generated
+			}
+		}
+		var shard string
+		ks, shard, err = rtr.getRouting(vcursor.ctx, plan.Table.Keyspace.Name, vcursor.tabletType, ksid)
+		if err != nil {
+			return nil, fmt.Errorf("execInsertSharded: %v", err)
+		}
+		if _, ok := shardRows[shard]; !ok {
+			shards = append(shards, shard)
 		}
+		shardRows[shard] = append(shardRows[shard], rowNum)
+		shardKsids[shard] = appendKsid(shardKsids[shard], ksid)
 	}
-	vcursor.bindVariables[ksidName] = string(ksid)
-	rewritten := sqlannotation.AddKeyspaceID(plan.Rewritten, ksid)
-	result, err := rtr.scatterConn.Execute(
+
+	// Each shard gets an insert with only the rows that
+	// map to it.
+	sqls := make(map[string]string, len(shards))
+	bindVars := make(map[string]map[string]interface{}, len(shards))
+	for _, shard := range shards {
+		mids := make([]string, 0, len(shardRows[shard]))
+		for _, rowNum := range shardRows[shard] {
+			mids = append(mids, plan.Mid[rowNum])
+		}
+		ksids := shardKsids[shard]
+		sqls[shard] = sqlannotation.AddIfDML(plan.Prefix+strings.Join(mids, ", ")+plan.Suffix, ksids)
+		bv := make(map[string]interface{}, len(vcursor.bindVariables)+1)
+		for k, v := range vcursor.bindVariables {
+			bv[k] = v
+		}
+		if len(ksids) == 1 {
+			bv[ksidName] = string(ksids[0])
+		}
+		bindVars[shard] = bv
+	}
+	result, err := rtr.scatterConn.ExecuteEntityIds(
 		vcursor.ctx,
-		rewritten,
-		vcursor.bindVariables,
+		shards,
+		sqls,
+		bindVars,
 		ks,
-		[]string{shard},
 		vcursor.tabletType,
 		NewSafeSession(vcursor.session),
 		vcursor.notInTransaction)
 	if err != nil {
 		return nil, fmt.Errorf("execInsertSharded: %v", err)
 	}
-	if generated != 0 {
+	if insertid != 0 {
 		if result.InsertID != 0 {
 			return nil, fmt.Errorf("vindex and db generated a value each for insert")
 		}
-		result.InsertID = uint64(generated)
+		result.InsertID = uint64(insertid)
 	}
 	return result, nil
 }
 
+// appendKsid appends ksid to ksids if it's not already in the list.
+func appendKsid(ksids [][]byte, ksid []byte) [][]byte {
+	for _, k := range ksids {
+		if bytes.Equal(k, ksid) {
+			return ksids
+		}
+	}
+	return append(ksids, ksid)
+}
+
+// handleGenerate sets the bind vars of the auto-increment column
+// to the values supplied by the insert. The missing values are
+// fetched from the sequence in a single request, and the first
+// of them is returned as generated. The values of a sequence are
+// reserved outside of the current transaction.
+func (rtr *Router) handleGenerate(vcursor *requestContext, gen *planbuilder.Generate) (generated int64, err error) {
+	if gen == nil {
+		return 0, nil
+	}
+	vals, err := rtr.resolveKeys(gen.Values, vcursor.bindVariables)
+	if err != nil {
+		return 0, err
+	}
+	count := int64(0)
+	for _, val := range vals {
+		if val == nil {
+			count++
+		}
+	}
+	if count != 0 {
+		qr, err := rtr.Execute(vcursor.ctx, gen.Query, map[string]interface{}{"n": count}, vcursor.tabletType, nil, true)
+		if err != nil {
+			return 0, err
+		}
+		if len(qr.Rows) != 1 || len(qr.Rows[0]) != 1 {
+			return 0, fmt.Errorf("unexpected result from sequence: %v", qr.Rows)
+		}
+		generated, err = qr.Rows[0][0].ParseInt64()
+		if err != nil {
+			return 0, err
+		}
+	}
+	next := generated
+	for i, val := range vals {
+		if val == nil {
+			val = next
+			next++
+		}
+		vcursor.bindVariables[planbuilder.SeqVarName+strconv.Itoa(i)] = val
+	}
+	return generated, nil
+}
+
 func (rtr *Router) resolveKeys(vals []interface{}, bindVars map[string]interface{}) (keys []interface{}, err error) {
 	keys = make([]interface{}, 0, len(vals))
 	for _, val := range vals {
@@ -346,6 +1062,44 @@ func (rtr *Router) resolveKeys(vals []interface{}, bindVars map[string]interface
 				return nil, fmt.Errorf("could not find bind var %s", val)
 			}
 			keys = append(keys, v)
+		case []byte:
+			keys = append(keys, string(val))
+		case []interface{}:
+			// The values of a multi-column vindex.
+			tuple, err := rtr.resolveKeys(val, bindVars)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, tuple)
+		default:
+			keys = append(keys, val)
+		}
+	}
+	return keys, nil
+}
+
+// resolveList resolves the values of an IN clause, which
+// are either a list of values or the name of a list bind var.
+// NULL values are dropped because they can't match any row.
+func (rtr *Router) resolveList(vals interface{}, bindVars map[string]interface{}) (keys []interface{}, err error) {
+	var list []interface{}
+	if name, ok := vals.(string); ok {
+		v, ok := bindVars[name[2:]]
+		if !ok {
+			return nil, fmt.Errorf("could not find bind var %s", name)
+		}
+		if list, ok = v.([]interface{}); !ok {
+			return nil, fmt.Errorf("expecting list for bind var %s: %v", name, v)
+		}
+	} else {
+		if list, err = rtr.resolveKeys(vals.([]interface{}), bindVars); err != nil {
+			return nil, err
+		}
+	}
+	keys = make([]interface{}, 0, len(list))
+	for _, val := range list {
+		switch val := val.(type) {
+		case nil:
 		case []byte:
 			keys = append(keys, string(val))
 		default:
@@ -431,12 +1185,76 @@ func (rtr *Router) deleteVindexEntries(vcursor *requestContext, plan *planbuilde
 	if err != nil {
 		return err
 	}
+	return deleteVindexRows(vcursor, plan, result.Rows, ksid)
+}
+
+// deleteVindexEntriesMultiShard deletes the owned vindex entries of
+// the rows that a multi-shard delete will delete. The keyspace id of
+// each row is computed from the values of its primary vindex columns,
+// which follow the owned vindex columns in the result of the Subquery.
+func (rtr *Router) deleteVindexEntriesMultiShard(vcursor *requestContext, plan *planbuilder.Plan, ks string, shardVars map[string]map[string]interface{}) error {
+	result, err := rtr.scatterConn.ExecuteMulti(
+		vcursor.ctx,
+		plan.Subquery,
+		ks,
+		shardVars,
+		vcursor.tabletType,
+		NewSafeSession(vcursor.session),
+		vcursor.notInTransaction)
+	if err != nil {
+		return err
+	}
 	if len(result.Rows) == 0 {
 		return nil
 	}
+	primary := plan.Table.ColVindexes[0]
+	pos := len(plan.Table.Owned)
+	vindexKeys := make([]interface{}, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		if len(primary.Cols) <= 1 {
+			vindexKeys = append(vindexKeys, row[pos].ToNative())
+			continue
+		}
+		vals := make([]interface{}, 0, len(primary.Cols))
+		for i := range primary.Cols {
+			vals = append(vals, row[pos+i].ToNative())
+		}
+		vindexKeys = append(vindexKeys, vals)
+	}
+	ksids, err := primary.Vindex.(planbuilder.Unique).Map(vcursor, vindexKeys)
+	if err != nil {
+		return err
+	}
+	var order []string
+	rowsByKsid := make(map[string][][]sqltypes.Value)
+	for i, ksid := range ksids {
+		if len(ksid) == 0 {
+			return fmt.Errorf("could not map %v to a keyspace id", vindexKeys[i])
+		}
+		k := string(ksid)
+		if _, ok := rowsByKsid[k]; !ok {
+			order = append(order, k)
+		}
+		rowsByKsid[k] = append(rowsByKsid[k], result.Rows[i])
+	}
+	for _, k := range order {
+		if err := deleteVindexRows(vcursor, plan, rowsByKsid[k], []byte(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteVindexRows deletes the owned vindex entries that map the
+// values in rows to ksid. The values of the owned vindex columns
+// are the leading columns of rows.
+func deleteVindexRows(vcursor *requestContext, plan *planbuilder.Plan, rows [][]sqltypes.Value, ksid []byte) (err error) {
+	if len(rows) == 0 {
+		return nil
+	}
 	for i, colVindex := range plan.Table.Owned {
 		keys := make(map[interface{}]bool)
-		for _, row := range result.Rows {
+		for _, row := range rows {
 			switch k := row[i].ToNative().(type) {
 			case []byte:
 				keys[string(k)] = true
@@ -464,7 +1282,7 @@ func (rtr *Router) deleteVindexEntries(vcursor *requestContext, plan *planbuilde
 	return nil
 }
 
-func (rtr *Router) handlePrimary(vcursor *requestContext, vindexKey interface{}, colVindex *planbuilder.ColVindex, bv map[string]interface{}) (ksid []byte, generated int64, err error) {
+func (rtr *Router) handlePrimary(vcursor *requestContext, vindexKey interface{}, colVindex *planbuilder.ColVindex, bv map[string]interface{}, rowNum int) (ksid []byte, generated int64, err error) {
 	if colVindex.Owned {
 		if vindexKey == nil {
 			generator, ok := colVindex.Vindex.(planbuilder.FunctionalGenerator)
@@ -486,8 +1304,8 @@ func (rtr *Router) handlePrimary(vcursor *requestContext, vindexKey interface{},
 			}
 		}
 	}
-	if vindexKey == nil {
-		return nil, 0, fmt.Errorf("value must be supplied for column %s", colVindex.Col)
+	if col, ok := missingColumn(colVindex, vindexKey); ok {
+		return nil, 0, fmt.Errorf("value must be supplied for column %s", col)
 	}
 	mapper := colVindex.Vindex.(planbuilder.Unique)
 	ksids, err := mapper.Map(vcursor, []interface{}{vindexKey})
@@ -498,11 +1316,11 @@ func (rtr *Router) handlePrimary(vcursor *requestContext, vindexKey interface{},
 	if len(ksid) == 0 {
 		return nil, 0, fmt.Errorf("could not map %v to a keyspace id", vindexKey)
 	}
-	bv["_"+colVindex.Col] = vindexKey
+	setVindexBindVars(colVindex, vindexKey, bv, rowNum)
 	return ksid, generated, nil
 }
 
-func (rtr *Router) handleNonPrimary(vcursor *requestContext, vindexKey interface{}, colVindex *planbuilder.ColVindex, bv map[string]interface{}, ksid []byte) (generated int64, err error) {
+func (rtr *Router) handleNonPrimary(vcursor *requestContext, vindexKey interface{}, colVindex *planbuilder.ColVindex, bv map[string]interface{}, ksid []byte, rowNum int) (generated int64, err error) {
 	if colVindex.Owned {
 		if vindexKey == nil {
 			generator, ok := colVindex.Vindex.(planbuilder.LookupGenerator)
@@ -534,6 +1352,9 @@ func (rtr *Router) handleNonPrimary(vcursor *requestContext, vindexKey interface
 				return 0, fmt.Errorf("could not compute value for column %v", colVindex.Col)
 			}
 		} else {
+			if col, missing := missingColumn(colVindex, vindexKey); missing {
+				return 0, fmt.Errorf("value must be supplied for column %s", col)
+			}
 			ok, err := colVindex.Vindex.Verify(vcursor, vindexKey, ksid)
 			if err != nil {
 				return 0, err
@@ -543,10 +1364,39 @@ func (rtr *Router) handleNonPrimary(vcursor *requestContext, vindexKey interface
 			}
 		}
 	}
-	bv["_"+colVindex.Col] = vindexKey
+	setVindexBindVars(colVindex, vindexKey, bv, rowNum)
 	return generated, nil
 }
 
+// missingColumn returns the first column of colVindex whose
+// value is not supplied by vindexKey. The vindexKey of a
+// multi-column vindex is the list of values of its columns.
+func missingColumn(colVindex *planbuilder.ColVindex, vindexKey interface{}) (col string, missing bool) {
+	if len(colVindex.Cols) <= 1 {
+		return colVindex.Col, vindexKey == nil
+	}
+	vals := vindexKey.([]interface{})
+	for i, val := range vals {
+		if val == nil {
+			return colVindex.Cols[i], true
+		}
+	}
+	return "", false
+}
+
+// setVindexBindVars sets the bind vars that the rewritten
+// insert uses for the columns of colVindex in the row rowNum.
+func setVindexBindVars(colVindex *planbuilder.ColVindex, vindexKey interface{}, bv map[string]interface{}, rowNum int) {
+	suffix := strconv.Itoa(rowNum)
+	if len(colVindex.Cols) <= 1 {
+		bv["_"+colVindex.Col+suffix] = vindexKey
+		return
+	}
+	for i, val := range vindexKey.([]interface{}) {
+		bv["_"+colVindex.Cols[i]+suffix] = val
+	}
+}
+
 func (rtr *Router) getRouting(ctx context.Context, keyspace string, tabletType topodatapb.TabletType, ksid []byte) (newKeyspace, shard string, err error) {
 	newKeyspace, _, allShards, err := getKeyspaceShards(ctx, rtr.serv, rtr.cell, keyspace, tabletType)
 	if err != nil {
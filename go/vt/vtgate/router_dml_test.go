@@ -8,12 +8,17 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+	"github.com/youtube/vitess/go/vt/topo"
 	_ "github.com/youtube/vitess/go/vt/vtgate/vindexes"
+	"golang.org/x/net/context"
 
 	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
 )
 
 func TestUpdateEqual(t *testing.T) {
@@ -246,6 +251,532 @@ func TestDeleteEqualFail(t *testing.T) {
 	s.ShardSpec = DefaultShardSpec
 }
 
+func TestUpdateVindexChange(t *testing.T) {
+	router, sbc1, sbc2, sbclookup := createRouterEnv()
+
+	userResult := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{"id", sqltypes.Int64},
+			{"name", sqltypes.VarChar},
+			{"val", sqltypes.Int64},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("myname")),
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("5")),
+		}},
+	}
+	sbc1.setResults([]*sqltypes.Result{userResult})
+	_, err := routerExec(router, "update user set id = 1, name = 'newname' where id = 1", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select * from user where id = 1 for update",
+		BindVariables: map[string]interface{}{},
+	}, {
+		Sql: "delete from user where id = 1 /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		BindVariables: map[string]interface{}{
+			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
+		},
+	}, {
+		Sql: "insert into user(id, name, val) values (:_id0, :_name0, :v0_2) /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		BindVariables: map[string]interface{}{
+			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
+			"v0_0":        int64(1),
+			"v0_1":        "newname",
+			"v0_2":        int64(5),
+			"_id0":        int64(1),
+			"_name0":      "newname",
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	if sbc2.Queries != nil {
+		t.Errorf("sbc2.Queries: %+v, want nil\n", sbc2.Queries)
+	}
+	// The old vindex entries are deleted, and the new ones created.
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "delete from user_idx where id in ::id",
+		BindVariables: map[string]interface{}{
+			"id": []interface{}{int64(1)},
+		},
+	}, {
+		Sql: "delete from name_user_map where name in ::name and user_id = :user_id",
+		BindVariables: map[string]interface{}{
+			"user_id": int64(1),
+			"name":    []interface{}{"myname"},
+		},
+	}, {
+		Sql: "insert into user_idx(id) values(:id)",
+		BindVariables: map[string]interface{}{
+			"id": int64(1),
+		},
+	}, {
+		Sql: "insert into name_user_map(name, user_id) values(:name, :user_id)",
+		BindVariables: map[string]interface{}{
+			"name":    "newname",
+			"user_id": int64(1),
+		},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries: %+v, want %+v\n", sbclookup.Queries, wantQueries)
+	}
+	if commitCount := sbc1.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbc1.CommitCount: %d, want 1", commitCount)
+	}
+	if commitCount := sbclookup.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbclookup.CommitCount: %d, want 1", commitCount)
+	}
+
+	// A change of the primary vindex column moves the row to another shard.
+	sbc1.Queries = nil
+	sbclookup.Queries = nil
+	sbc1.setResults([]*sqltypes.Result{userResult})
+	_, err = routerExec(router, "update user set id = :newid where id = 1", map[string]interface{}{"newid": int64(3)})
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "select * from user where id = 1 for update",
+		BindVariables: map[string]interface{}{
+			"newid": int64(3),
+		},
+	}, {
+		Sql: "delete from user where id = 1 /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		BindVariables: map[string]interface{}{
+			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
+			"newid":       int64(3),
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "insert into user(id, name, val) values (:_id0, :_name0, :v0_2) /* vtgate:: keyspace_id:4eb190c9a2fa169c */",
+		BindVariables: map[string]interface{}{
+			"keyspace_id": "N\xb1\x90ɢ\xfa\x16\x9c",
+			"v0_0":        int64(3),
+			"v0_1":        []byte("myname"),
+			"v0_2":        int64(5),
+			"_id0":        int64(3),
+			"_name0":      []byte("myname"),
+		},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+	if commitCount := sbc2.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbc2.CommitCount: %d, want 1", commitCount)
+	}
+
+	// Nothing is moved if no row matches.
+	sbc1.Queries = nil
+	sbc2.Queries = nil
+	sbc1.setResults([]*sqltypes.Result{&sqltypes.Result{}})
+	_, err = routerExec(router, "update user set id = 3 where id = 1", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(sbc1.Queries) != 1 {
+		t.Errorf("sbc1.Queries: %+v, want only the select\n", sbc1.Queries)
+	}
+	if sbc2.Queries != nil {
+		t.Errorf("sbc2.Queries: %+v, want nil\n", sbc2.Queries)
+	}
+}
+
+func TestUpdateVindexChangeFail(t *testing.T) {
+	router, sbc1, sbc2, sbclookup := createRouterEnv()
+
+	userResult := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{"id", sqltypes.Int64},
+			{"name", sqltypes.VarChar},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("myname")),
+		}},
+	}
+	sbc1.setResults([]*sqltypes.Result{userResult})
+	_, err := routerExec(router, "update user set id = 1, nocol = 2 where id = 1", nil)
+	want := "execUpdateVindexChange: column nocol not found in table user"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+	if rollbackCount := sbc1.RollbackCount.Get(); rollbackCount != 1 {
+		t.Errorf("sbc1.RollbackCount: %d, want 1", rollbackCount)
+	}
+
+	sbc1.setResults([]*sqltypes.Result{userResult})
+	_, err = routerExec(router, "update user set id = :aa where id = 1", nil)
+	want = "execUpdateVindexChange: could not find bind var :aa"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+
+	// If the insert fails, the whole move is rolled back.
+	sbc1.setResults([]*sqltypes.Result{userResult})
+	sbc1.RollbackCount.Set(0)
+	sbc2.mustFailServer = 1
+	_, err = routerExec(router, "update user set id = 3 where id = 1", nil)
+	want = "execUpdateVindexChange: execInsertSharded: shard, host: TestRouter.40-60.master"
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("routerExec: %v, want prefix %v", err, want)
+	}
+	if rollbackCount := sbc1.RollbackCount.Get(); rollbackCount != 1 {
+		t.Errorf("sbc1.RollbackCount: %d, want 1", rollbackCount)
+	}
+	if rollbackCount := sbclookup.RollbackCount.Get(); rollbackCount != 1 {
+		t.Errorf("sbclookup.RollbackCount: %d, want 1", rollbackCount)
+	}
+}
+
+func TestUpdateLookupChange(t *testing.T) {
+	router, sbc1, sbc2, sbclookup := createRouterEnv()
+
+	sbc1.setResults([]*sqltypes.Result{{
+		Fields: []*querypb.Field{
+			{"name", sqltypes.VarChar},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("myname")),
+		}},
+	}})
+	_, err := routerExec(router, "update user set name = 'newname', val = val + 1 where id = 1 order by val", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	// The row isn't moved: the update is sent as is.
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select name from user where id = 1 for update",
+		BindVariables: map[string]interface{}{},
+	}, {
+		Sql: "update user set name = 'newname', val = val + 1 where id = 1 order by val asc /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		BindVariables: map[string]interface{}{
+			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	if sbc2.Queries != nil {
+		t.Errorf("sbc2.Queries: %+v, want nil\n", sbc2.Queries)
+	}
+	// Only the entries of the lookup vindex are replaced.
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "delete from name_user_map where name in ::name and user_id = :user_id",
+		BindVariables: map[string]interface{}{
+			"user_id": int64(1),
+			"name":    []interface{}{"myname"},
+		},
+	}, {
+		Sql: "insert into name_user_map(name, user_id) values(:name, :user_id)",
+		BindVariables: map[string]interface{}{
+			"name":    "newname",
+			"user_id": int64(1),
+		},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries: %+v, want %+v\n", sbclookup.Queries, wantQueries)
+	}
+	if commitCount := sbc1.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbc1.CommitCount: %d, want 1", commitCount)
+	}
+	if commitCount := sbclookup.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbclookup.CommitCount: %d, want 1", commitCount)
+	}
+
+	// The new value of a vindex the table doesn't own is verified.
+	sbc1.Queries = nil
+	sbclookup.Queries = nil
+	sbc1.setResults([]*sqltypes.Result{{
+		Fields: []*querypb.Field{
+			{"music_id", sqltypes.Int64},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("2")),
+		}},
+	}})
+	_, err = routerExec(router, "update music_extra set music_id = 3 where user_id = 1", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "select music_id from music_user_map where music_id = :music_id and user_id = :user_id",
+		BindVariables: map[string]interface{}{
+			"music_id": int64(3),
+			"user_id":  int64(1),
+		},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries: %+v, want %+v\n", sbclookup.Queries, wantQueries)
+	}
+	if len(sbc1.Queries) != 2 {
+		t.Errorf("sbc1.Queries: %+v, want the select and the update\n", sbc1.Queries)
+	}
+
+	// Nothing is updated if no row matches.
+	sbc1.Queries = nil
+	sbclookup.Queries = nil
+	sbc1.setResults([]*sqltypes.Result{&sqltypes.Result{}})
+	_, err = routerExec(router, "update user set name = 'newname' where id = 1", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(sbc1.Queries) != 1 {
+		t.Errorf("sbc1.Queries: %+v, want only the select\n", sbc1.Queries)
+	}
+	if sbclookup.Queries != nil {
+		t.Errorf("sbclookup.Queries: %+v, want nil\n", sbclookup.Queries)
+	}
+}
+
+func TestUpdateLookupChangeFail(t *testing.T) {
+	router, sbc1, _, sbclookup := createRouterEnv()
+
+	_, err := routerExec(router, "update user set name = :aa where id = 1", nil)
+	want := "execUpdateLookupChange: could not find bind var :aa"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+
+	// If the new entry can't be created, the update isn't sent.
+	sbc1.setResults([]*sqltypes.Result{{
+		Fields: []*querypb.Field{
+			{"name", sqltypes.VarChar},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("myname")),
+		}},
+	}})
+	sbc1.Queries = nil
+	sbc1.RollbackCount.Set(0)
+	sbclookup.mustFailServer = 2
+	_, err = routerExec(router, "update user set name = 'newname' where id = 1", nil)
+	want = "execUpdateLookupChange: "
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("routerExec: %v, want prefix %v", err, want)
+	}
+	if len(sbc1.Queries) != 1 {
+		t.Errorf("sbc1.Queries: %+v, want only the select\n", sbc1.Queries)
+	}
+	if rollbackCount := sbc1.RollbackCount.Get(); rollbackCount != 1 {
+		t.Errorf("sbc1.RollbackCount: %d, want 1", rollbackCount)
+	}
+}
+
+func TestUpdateMultiShard(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+
+	_, err := routerExec(router, "update user set a = 2 where id in (1, 3)", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "update user set a = 2 where id in ::_vals/* vtgate:: filtered_replication_unfriendly */",
+		BindVariables: map[string]interface{}{
+			"_vals": []interface{}{int64(1)},
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "update user set a = 2 where id in ::_vals/* vtgate:: filtered_replication_unfriendly */",
+		BindVariables: map[string]interface{}{
+			"_vals": []interface{}{int64(3)},
+		},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+
+}
+
+func TestUpdateScatter(t *testing.T) {
+	// Special setup: Don't use createRouterEnv.
+	s := createSandbox("TestRouter")
+	shards := []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"}
+	var conns []*sandboxConn
+	for _, shard := range shards {
+		sbc := &sandboxConn{}
+		conns = append(conns, sbc)
+		s.MapTestConn(shard, sbc)
+	}
+	serv := new(sandboxTopo)
+	scatterConn := NewScatterConn(nil, topo.Server{}, serv, "", "aa", 1*time.Second, 10, 2*time.Millisecond, 1*time.Millisecond, 24*time.Hour, nil, "")
+	router := NewRouter(serv, "aa", routerSchema, "", scatterConn)
+
+	result, err := routerExec(router, "update user set a = 2 where v = 1", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "update user set a = 2 where v = 1/* vtgate:: filtered_replication_unfriendly */",
+		BindVariables: map[string]interface{}{},
+	}}
+	for _, conn := range conns {
+		if !reflect.DeepEqual(conn.Queries, wantQueries) {
+			t.Errorf("conn.Queries = %#v, want %#v", conn.Queries, wantQueries)
+		}
+	}
+	if result.RowsAffected != 8 {
+		t.Errorf("result.RowsAffected: %d, want 8", result.RowsAffected)
+	}
+}
+
+func TestDeleteScatter(t *testing.T) {
+	// Special setup: Don't use createRouterEnv.
+	s := createSandbox("TestRouter")
+	shards := []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"}
+	var conns []*sandboxConn
+	for _, shard := range shards {
+		sbc := &sandboxConn{}
+		conns = append(conns, sbc)
+		s.MapTestConn(shard, sbc)
+	}
+	serv := new(sandboxTopo)
+	scatterConn := NewScatterConn(nil, topo.Server{}, serv, "", "aa", 1*time.Second, 10, 2*time.Millisecond, 1*time.Millisecond, 24*time.Hour, nil, "")
+	router := NewRouter(serv, "aa", routerSchema, "", scatterConn)
+
+	// A table without owned vindexes needs no subquery.
+	_, err := routerExec(router, "delete from music_extra where v = 1", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "delete from music_extra where v = 1/* vtgate:: filtered_replication_unfriendly */",
+		BindVariables: map[string]interface{}{},
+	}}
+	for _, conn := range conns {
+		if !reflect.DeepEqual(conn.Queries, wantQueries) {
+			t.Errorf("conn.Queries = %#v, want %#v", conn.Queries, wantQueries)
+		}
+	}
+}
+
+func TestDeleteMultiShard(t *testing.T) {
+	router, sbc1, sbc2, sbclookup := createRouterEnv()
+
+	sbc1.setResults([]*sqltypes.Result{&sqltypes.Result{
+		Fields: []*querypb.Field{
+			{"id", sqltypes.Int32},
+			{"name", sqltypes.VarChar},
+			{"id", sqltypes.Int32},
+		},
+		RowsAffected: 2,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("a")),
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+		}, {
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("b")),
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
+		}},
+	}})
+	_, err := routerExec(router, "delete from user where id in (1, 2)", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "select id, name, id from user where id in ::_vals for update",
+		BindVariables: map[string]interface{}{
+			"_vals": []interface{}{int64(1), int64(2)},
+		},
+	}, {
+		Sql: "delete from user where id in ::_vals/* vtgate:: filtered_replication_unfriendly */",
+		BindVariables: map[string]interface{}{
+			"_vals": []interface{}{int64(1), int64(2)},
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	if sbc2.Queries != nil {
+		t.Errorf("sbc2.Queries: %+v, want nil\n", sbc2.Queries)
+	}
+	// The vindex entries are deleted for each keyspace id.
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "delete from user_idx where id in ::id",
+		BindVariables: map[string]interface{}{
+			"id": []interface{}{int64(1)},
+		},
+	}, {
+		Sql: "delete from name_user_map where name in ::name and user_id = :user_id",
+		BindVariables: map[string]interface{}{
+			"user_id": int64(1),
+			"name":    []interface{}{"a"},
+		},
+	}, {
+		Sql: "delete from user_idx where id in ::id",
+		BindVariables: map[string]interface{}{
+			"id": []interface{}{int64(2)},
+		},
+	}, {
+		Sql: "delete from name_user_map where name in ::name and user_id = :user_id",
+		BindVariables: map[string]interface{}{
+			"user_id": int64(2),
+			"name":    []interface{}{"b"},
+		},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries: %+v, want %+v\n", sbclookup.Queries, wantQueries)
+	}
+	// The deletes are committed together.
+	if commitCount := sbc1.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbc1.CommitCount: %d, want 1", commitCount)
+	}
+	if commitCount := sbclookup.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbclookup.CommitCount: %d, want 1", commitCount)
+	}
+
+}
+
+func TestDeleteMultiShardFail(t *testing.T) {
+	router, sbc1, _, _ := createRouterEnv()
+
+	_, err := routerExec(router, "delete from user where id in ::aa", nil)
+	want := "execDeleteMultiShard: paramsSelectIN: could not find bind var ::aa"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+
+	sbc1.mustFailServer = 1
+	_, err = routerExec(router, "delete from user where id in (1, 2)", nil)
+	want = "execDeleteMultiShard: shard, host: TestRouter.-20.master"
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("routerExec: %v, want prefix %v", err, want)
+	}
+
+	sbc1.setResults([]*sqltypes.Result{&sqltypes.Result{
+		Fields: []*querypb.Field{
+			{"id", sqltypes.Int32},
+			{"name", sqltypes.VarChar},
+			{"id", sqltypes.VarChar},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("a")),
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("a")),
+		}},
+	}})
+	_, err = routerExec(router, "delete from user where id in (1, 2)", nil)
+	want = "execDeleteMultiShard: hash.Map"
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("routerExec: %v, want prefix %v", err, want)
+	}
+}
+
 func TestInsertSharded(t *testing.T) {
 	router, sbc1, sbc2, sbclookup := createRouterEnv()
 
@@ -254,11 +785,11 @@ func TestInsertSharded(t *testing.T) {
 		t.Error(err)
 	}
 	wantQueries := []querytypes.BoundQuery{{
-		Sql: "insert into user(id, v, name) values (:_id, 2, :_name) /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		Sql: "insert into user(id, v, name) values (:_id0, 2, :_name0) /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
 		BindVariables: map[string]interface{}{
 			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
-			"_id":         int64(1),
-			"_name":       "myname",
+			"_id0":        int64(1),
+			"_name0":      "myname",
 		},
 	}}
 	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
@@ -290,11 +821,11 @@ func TestInsertSharded(t *testing.T) {
 		t.Error(err)
 	}
 	wantQueries = []querytypes.BoundQuery{{
-		Sql: "insert into user(id, v, name) values (:_id, 2, :_name) /* vtgate:: keyspace_id:4eb190c9a2fa169c */",
+		Sql: "insert into user(id, v, name) values (:_id0, 2, :_name0) /* vtgate:: keyspace_id:4eb190c9a2fa169c */",
 		BindVariables: map[string]interface{}{
 			"keyspace_id": "N\xb1\x90ɢ\xfa\x16\x9c",
-			"_id":         int64(3),
-			"_name":       "myname2",
+			"_id0":        int64(3),
+			"_name0":      "myname2",
 		},
 	}}
 	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
@@ -320,6 +851,56 @@ func TestInsertSharded(t *testing.T) {
 	}
 }
 
+func TestInsertShardedMultiRow(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+
+	result, err := routerExec(router, "insert into user(id, v, name) values (1, 2, 'a'), (3, 4, 'b'), (2, 6, 'c')", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantBindVars := map[string]interface{}{
+		"_id0":   int64(1),
+		"_name0": "a",
+		"_id1":   int64(3),
+		"_name1": "b",
+		"_id2":   int64(2),
+		"_name2": "c",
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "insert into user(id, v, name) values (:_id0, 2, :_name0), (:_id2, 6, :_name2)/* vtgate:: filtered_replication_unfriendly */",
+		BindVariables: wantBindVars,
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	wantBindVars2 := map[string]interface{}{
+		"keyspace_id": "N\xb1\x90ɢ\xfa\x16\x9c",
+	}
+	for k, v := range wantBindVars {
+		wantBindVars2[k] = v
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql:           "insert into user(id, v, name) values (:_id1, 4, :_name1) /* vtgate:: keyspace_id:4eb190c9a2fa169c */",
+		BindVariables: wantBindVars2,
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+	if result.RowsAffected != 2 {
+		t.Errorf("result.RowsAffected: %d, want 2", result.RowsAffected)
+	}
+
+	// Within a transaction, all shards join the session.
+	session := &vtgatepb.Session{InTransaction: true}
+	_, err = router.Execute(context.Background(), "insert into user(id, v, name) values (1, 2, 'a'), (3, 4, 'b')", nil, topodatapb.TabletType_MASTER, session, false)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(session.ShardSessions) != 3 {
+		t.Errorf("len(session.ShardSessions): %d, want 3", len(session.ShardSessions))
+	}
+}
+
 func TestInsertGenerator(t *testing.T) {
 	router, sbc, _, sbclookup := createRouterEnv()
 
@@ -329,11 +910,11 @@ func TestInsertGenerator(t *testing.T) {
 		t.Error(err)
 	}
 	wantQueries := []querytypes.BoundQuery{{
-		Sql: "insert into user(v, name, id) values (2, :_name, :_id) /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		Sql: "insert into user(v, name, id) values (2, :_name0, :_id0) /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
 		BindVariables: map[string]interface{}{
 			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
-			"_id":         int64(1),
-			"_name":       "myname",
+			"_id0":        int64(1),
+			"_name0":      "myname",
 		},
 	}}
 	if !reflect.DeepEqual(sbc.Queries, wantQueries) {
@@ -369,11 +950,11 @@ func TestInsertLookupOwned(t *testing.T) {
 		t.Error(err)
 	}
 	wantQueries := []querytypes.BoundQuery{{
-		Sql: "insert into music(user_id, id) values (:_user_id, :_id) /* vtgate:: keyspace_id:06e7ea22ce92708f */",
+		Sql: "insert into music(user_id, id) values (:_user_id0, :_id0) /* vtgate:: keyspace_id:06e7ea22ce92708f */",
 		BindVariables: map[string]interface{}{
 			"keyspace_id": "\x06\xe7\xea\"Βp\x8f",
-			"_user_id":    int64(2),
-			"_id":         int64(3),
+			"_user_id0":   int64(2),
+			"_id0":        int64(3),
 		},
 	}}
 	if !reflect.DeepEqual(sbc.Queries, wantQueries) {
@@ -400,11 +981,11 @@ func TestInsertLookupOwnedGenerator(t *testing.T) {
 		t.Error(err)
 	}
 	wantQueries := []querytypes.BoundQuery{{
-		Sql: "insert into music(user_id, id) values (:_user_id, :_id) /* vtgate:: keyspace_id:06e7ea22ce92708f */",
+		Sql: "insert into music(user_id, id) values (:_user_id0, :_id0) /* vtgate:: keyspace_id:06e7ea22ce92708f */",
 		BindVariables: map[string]interface{}{
 			"keyspace_id": "\x06\xe7\xea\"Βp\x8f",
-			"_user_id":    int64(2),
-			"_id":         int64(1),
+			"_user_id0":   int64(2),
+			"_id0":        int64(1),
 		},
 	}}
 	if !reflect.DeepEqual(sbc.Queries, wantQueries) {
@@ -435,11 +1016,11 @@ func TestInsertLookupUnowned(t *testing.T) {
 		t.Error(err)
 	}
 	wantQueries := []querytypes.BoundQuery{{
-		Sql: "insert into music_extra(user_id, music_id) values (:_user_id, :_music_id) /* vtgate:: keyspace_id:06e7ea22ce92708f */",
+		Sql: "insert into music_extra(user_id, music_id) values (:_user_id0, :_music_id0) /* vtgate:: keyspace_id:06e7ea22ce92708f */",
 		BindVariables: map[string]interface{}{
 			"keyspace_id": "\x06\xe7\xea\"Βp\x8f",
-			"_user_id":    int64(2),
-			"_music_id":   int64(3),
+			"_user_id0":   int64(2),
+			"_music_id0":  int64(3),
 		},
 	}}
 	if !reflect.DeepEqual(sbc.Queries, wantQueries) {
@@ -465,11 +1046,11 @@ func TestInsertLookupUnownedUnsupplied(t *testing.T) {
 		t.Error(err)
 	}
 	wantQueries := []querytypes.BoundQuery{{
-		Sql: "insert into music_extra_reversed(music_id, user_id) values (:_music_id, :_user_id) /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		Sql: "insert into music_extra_reversed(music_id, user_id) values (:_music_id0, :_user_id0) /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
 		BindVariables: map[string]interface{}{
 			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
-			"_user_id":    int64(1),
-			"_music_id":   int64(3),
+			"_user_id0":   int64(1),
+			"_music_id0":  int64(3),
 		},
 	}}
 	if !reflect.DeepEqual(sbc.Queries, wantQueries) {
@@ -486,6 +1067,203 @@ func TestInsertLookupUnownedUnsupplied(t *testing.T) {
 	}
 }
 
+func TestInsertOwnedTransaction(t *testing.T) {
+	router, sbc1, _, sbclookup := createRouterEnv()
+
+	// Without a transaction, the insert and the vindex entries
+	// are committed together.
+	_, err := routerExec(router, "insert into user(id, v, name) values (1, 2, 'myname')", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if commitCount := sbc1.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbc1.CommitCount: %d, want 1", commitCount)
+	}
+	if commitCount := sbclookup.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbclookup.CommitCount: %d, want 1", commitCount)
+	}
+
+	// If the insert fails, the vindex entries are rolled back.
+	sbc1.mustFailServer = 1
+	_, err = routerExec(router, "insert into user(id, v, name) values (1, 2, 'myname')", nil)
+	if err == nil {
+		t.Errorf("routerExec: nil, want error")
+	}
+	if commitCount := sbclookup.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbclookup.CommitCount: %d, want 1", commitCount)
+	}
+	if rollbackCount := sbclookup.RollbackCount.Get(); rollbackCount != 1 {
+		t.Errorf("sbclookup.RollbackCount: %d, want 1", rollbackCount)
+	}
+
+	// Within a transaction, the writes are left to the caller's commit.
+	session := &vtgatepb.Session{InTransaction: true}
+	_, err = router.Execute(context.Background(), "insert into user(id, v, name) values (1, 2, 'myname')", nil, topodatapb.TabletType_MASTER, session, false)
+	if err != nil {
+		t.Error(err)
+	}
+	if commitCount := sbclookup.CommitCount.Get(); commitCount != 1 {
+		t.Errorf("sbclookup.CommitCount: %d, want 1", commitCount)
+	}
+	if len(session.ShardSessions) != 2 {
+		t.Errorf("len(session.ShardSessions): %d, want 2", len(session.ShardSessions))
+	}
+}
+
+func TestInsertMultiColumn(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+
+	_, err := routerExec(router, "insert into tenant_object(tenant_id, object_id, name) values (1, 2, 'a')", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "insert into tenant_object(tenant_id, object_id, name) values (:_tenant_id0, :_object_id0, 'a') /* vtgate:: keyspace_id:1606e7ea22ce9270 */",
+		BindVariables: map[string]interface{}{
+			"keyspace_id": "\x16\x06\xe7\xea\x22\xce\x92\x70",
+			"_tenant_id0": int64(1),
+			"_object_id0": int64(2),
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	if sbc2.Queries != nil {
+		t.Errorf("sbc2.Queries: %+v, want nil\n", sbc2.Queries)
+	}
+
+	_, err = routerExec(router, "insert into tenant_object(tenant_id, name) values (1, 'a')", nil)
+	want := "execInsertSharded: value must be supplied for column object_id"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+}
+
+func TestInsertSequence(t *testing.T) {
+	router, sbc, _, sbclookup := createRouterEnv()
+
+	sbclookup.setResults([]*sqltypes.Result{&sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "nextval", Type: sqltypes.Int64},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		}},
+	}})
+	result, err := routerExec(router, "insert into autoinc_table(v) values (2)", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "select nextval(:n) from user_seq",
+		BindVariables: map[string]interface{}{
+			"n": int64(1),
+		},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries: %+v, want %+v\n", sbclookup.Queries, wantQueries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "insert into autoinc_table(v, id) values (2, :_id0) /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		BindVariables: map[string]interface{}{
+			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
+			"__seq0":      int64(1),
+			"_id0":        int64(1),
+		},
+	}}
+	if !reflect.DeepEqual(sbc.Queries, wantQueries) {
+		t.Errorf("sbc.Queries: %+v, want %+v\n", sbc.Queries, wantQueries)
+	}
+	wantResult := *singleRowResult
+	wantResult.InsertID = 1
+	if !reflect.DeepEqual(result, &wantResult) {
+		t.Errorf("result: %+v, want %+v", result, &wantResult)
+	}
+
+	// A supplied value is used as is.
+	sbc.Queries = nil
+	sbclookup.Queries = nil
+	result, err = routerExec(router, "insert into autoinc_table(id, v) values (:id, 2)", map[string]interface{}{"id": int64(1)})
+	if err != nil {
+		t.Error(err)
+	}
+	if sbclookup.Queries != nil {
+		t.Errorf("sbclookup.Queries: %+v, want nil\n", sbclookup.Queries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "insert into autoinc_table(id, v) values (:_id0, 2) /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		BindVariables: map[string]interface{}{
+			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
+			"id":          int64(1),
+			"__seq0":      int64(1),
+			"_id0":        int64(1),
+		},
+	}}
+	if !reflect.DeepEqual(sbc.Queries, wantQueries) {
+		t.Errorf("sbc.Queries: %+v, want %+v\n", sbc.Queries, wantQueries)
+	}
+	if !reflect.DeepEqual(result, singleRowResult) {
+		t.Errorf("result: %+v, want %+v", result, singleRowResult)
+	}
+
+	sbclookup.mustFailServer = 1
+	_, err = routerExec(router, "insert into autoinc_table(v) values (2)", nil)
+	want := "execInsertSharded: shard, host: TestUnsharded.0.master, host:\"0\" port_map:<key:\"vt\" value:1 > , error: err"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+}
+
+func TestInsertSequenceMultiRow(t *testing.T) {
+	router, sbc1, sbc2, sbclookup := createRouterEnv()
+
+	sbclookup.setResults([]*sqltypes.Result{&sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "nextval", Type: sqltypes.Int64},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		}},
+	}})
+	result, err := routerExec(router, "insert into autoinc_table(id, v) values (null, 2), (3, 4), (null, 6)", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	// Only the missing values are fetched from the sequence.
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "select nextval(:n) from user_seq",
+		BindVariables: map[string]interface{}{
+			"n": int64(2),
+		},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries: %+v, want %+v\n", sbclookup.Queries, wantQueries)
+	}
+	wantBindVars := map[string]interface{}{
+		"__seq0": int64(1),
+		"__seq1": int64(3),
+		"__seq2": int64(2),
+		"_id0":   int64(1),
+		"_id1":   int64(3),
+		"_id2":   int64(2),
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql:           "insert into autoinc_table(id, v) values (:_id0, 2), (:_id2, 6)/* vtgate:: filtered_replication_unfriendly */",
+		BindVariables: wantBindVars,
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	if len(sbc2.Queries) != 1 {
+		t.Errorf("len(sbc2.Queries): %d, want 1", len(sbc2.Queries))
+	}
+	if result.InsertID != 1 {
+		t.Errorf("result.InsertID: %d, want 1", result.InsertID)
+	}
+}
+
 func TestInsertFail(t *testing.T) {
 	router, sbc, _, sbclookup := createRouterEnv()
 
@@ -0,0 +1,185 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+// This is a V3 file. Do not intermix with V2.
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+	"golang.org/x/net/context"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+// Explain returns the plan of sql, and the shards it is sent to for
+// bindVariables. The query is not executed, and no sequence value is
+// reserved. But the lookup vindexes may be queried to resolve the
+// shards.
+func (rtr *Router) Explain(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType) *vtgatepb.QueryPlan {
+	// The bind vars are copied because resolving the shards
+	// of an insert adds to them.
+	bv := make(map[string]interface{}, len(bindVariables))
+	for k, v := range bindVariables {
+		bv[k] = v
+	}
+	return rtr.explainPlan(ctx, rtr.planner.peekPlan(sql), bv, tabletType)
+}
+
+func (rtr *Router) explainPlan(ctx context.Context, plan *planbuilder.Plan, bindVariables map[string]interface{}, tabletType topodatapb.TabletType) *vtgatepb.QueryPlan {
+	qp := &vtgatepb.QueryPlan{
+		PlanId: plan.ID.String(),
+		Reason: plan.Reason,
+	}
+	if plan.Table != nil {
+		qp.Table = plan.Table.Name
+		qp.Keyspace = plan.Table.Keyspace.Name
+	}
+	if plan.ColVindex != nil {
+		qp.Vindex = plan.ColVindex.Name
+	}
+	for _, pullout := range plan.Pullouts {
+		qp.Pullouts = append(qp.Pullouts, rtr.explainPlan(ctx, pullout.Plan, bindVariables, tabletType))
+	}
+	switch plan.ID {
+	case planbuilder.NoPlan:
+		return qp
+	case planbuilder.SelectJoin:
+		qp.JoinLeft = rtr.explainPlan(ctx, plan.Join.Left, bindVariables, tabletType)
+		qp.JoinRight = rtr.explainPlan(ctx, plan.Join.Right, bindVariables, tabletType)
+		return qp
+	}
+
+	// The unsharded plans don't rewrite the query.
+	qp.RewrittenSql = plan.Rewritten
+	if qp.RewrittenSql == "" {
+		qp.RewrittenSql = plan.Original
+	}
+	vcursor := newRequestContext(ctx, plan.Original, bindVariables, tabletType, nil, true, rtr)
+	ks, shards, err := rtr.explainShards(vcursor, plan)
+	if err != nil {
+		qp.ShardError = err.Error()
+		return qp
+	}
+	if ks != "" {
+		qp.Keyspace = ks
+	}
+	sort.Strings(shards)
+	qp.Shards = shards
+	return qp
+}
+
+// explainShards returns the shards a plan sends its query to. They're
+// resolved like in Execute, which has an exec or params function
+// for each plan.
+func (rtr *Router) explainShards(vcursor *requestContext, plan *planbuilder.Plan) (string, []string, error) {
+	var params *scatterParams
+	var err error
+	switch plan.ID {
+	case planbuilder.SelectUnsharded, planbuilder.UpdateUnsharded,
+		planbuilder.DeleteUnsharded, planbuilder.InsertUnsharded:
+		params, err = rtr.paramsUnsharded(vcursor, plan)
+	case planbuilder.SelectEqual:
+		params, err = rtr.paramsSelectEqual(vcursor, plan)
+	case planbuilder.SelectIN:
+		params, err = rtr.paramsSelectIN(vcursor, plan)
+	case planbuilder.SelectKeyrange:
+		params, err = rtr.paramsSelectKeyrange(vcursor, plan)
+	case planbuilder.SelectRange:
+		params, err = rtr.paramsSelectRange(vcursor, plan)
+	case planbuilder.SelectPrefix:
+		params, err = rtr.paramsSelectPrefix(vcursor, plan)
+	case planbuilder.SelectScatter:
+		params, err = rtr.paramsSelectScatter(vcursor, plan)
+	case planbuilder.UpdateIN, planbuilder.UpdateScatter,
+		planbuilder.DeleteIN, planbuilder.DeleteScatter:
+		params, err = rtr.paramsMultiShardDML(vcursor, plan)
+	case planbuilder.UpdateEqual, planbuilder.DeleteEqual,
+		planbuilder.UpdateVindexChange, planbuilder.UpdateLookupChange:
+		return rtr.explainSingleShard(vcursor, plan)
+	case planbuilder.InsertSharded:
+		return rtr.explainInsertSharded(vcursor, plan)
+	default:
+		return "", nil, fmt.Errorf("unexpected plan %v", plan.ID)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	shards := make([]string, 0, len(params.shardVars))
+	for shard := range params.shardVars {
+		shards = append(shards, shard)
+	}
+	return params.ks, shards, nil
+}
+
+// explainSingleShard returns the shard of an UpdateEqual, DeleteEqual,
+// UpdateVindexChange or UpdateLookupChange plan. There is none if no
+// row can match.
+func (rtr *Router) explainSingleShard(vcursor *requestContext, plan *planbuilder.Plan) (string, []string, error) {
+	keys, err := rtr.resolveKeys([]interface{}{plan.Values}, vcursor.bindVariables)
+	if err != nil {
+		return "", nil, err
+	}
+	ks, shard, ksid, err := rtr.resolveSingleShard(vcursor, keys[0], plan)
+	if err != nil || len(ksid) == 0 {
+		return "", nil, err
+	}
+	return ks, []string{shard}, nil
+}
+
+// explainInsertSharded returns the shards of the rows of an
+// InsertSharded plan. The values generated by a sequence or by
+// the primary vindex are not known, so the rows that need them
+// can't be routed.
+func (rtr *Router) explainInsertSharded(vcursor *requestContext, plan *planbuilder.Plan) (string, []string, error) {
+	if gen := plan.Generate; gen != nil {
+		vals, err := rtr.resolveKeys(gen.Values, vcursor.bindVariables)
+		if err != nil {
+			return "", nil, err
+		}
+		for i, val := range vals {
+			if val != nil {
+				vcursor.bindVariables[planbuilder.SeqVarName+strconv.Itoa(i)] = val
+			}
+		}
+	}
+	colVindex := plan.Table.ColVindexes[0]
+	var ks string
+	var shards []string
+	seen := make(map[string]bool)
+	for rowNum, input := range plan.Values.([]interface{}) {
+		keys, err := rtr.resolveKeys(input.([]interface{})[:1], vcursor.bindVariables)
+		if err != nil {
+			return "", nil, fmt.Errorf("row %d: %v", rowNum, err)
+		}
+		if keys[0] == nil {
+			return "", nil, fmt.Errorf("row %d: the value of %s is generated", rowNum, colVindex.Col)
+		}
+		if col, ok := missingColumn(colVindex, keys[0]); ok {
+			return "", nil, fmt.Errorf("row %d: value must be supplied for column %s", rowNum, col)
+		}
+		ksids, err := colVindex.Vindex.(planbuilder.Unique).Map(vcursor, keys)
+		if err != nil {
+			return "", nil, fmt.Errorf("row %d: %v", rowNum, err)
+		}
+		if len(ksids[0]) == 0 {
+			return "", nil, fmt.Errorf("row %d: could not map %v to a keyspace id", rowNum, keys[0])
+		}
+		var shard string
+		ks, shard, err = rtr.getRouting(vcursor.ctx, plan.Table.Keyspace.Name, vcursor.tabletType, ksids[0])
+		if err != nil {
+			return "", nil, fmt.Errorf("row %d: %v", rowNum, err)
+		}
+		if !seen[shard] {
+			seen[shard] = true
+			shards = append(shards, shard)
+		}
+	}
+	return ks, shards, nil
+}
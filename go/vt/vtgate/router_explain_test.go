@@ -0,0 +1,170 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	_ "github.com/youtube/vitess/go/vt/vtgate/vindexes"
+	"golang.org/x/net/context"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+func TestExplain(t *testing.T) {
+	router, sbc1, sbc2, sbclookup := createRouterEnv()
+
+	testcases := []struct {
+		sql  string
+		bv   map[string]interface{}
+		want *vtgatepb.QueryPlan
+	}{{
+		sql: "select * from user where id = 1",
+		want: &vtgatepb.QueryPlan{
+			PlanId:       "SelectEqual",
+			Table:        "user",
+			Keyspace:     "TestRouter",
+			Vindex:       "user_index",
+			RewrittenSql: "select * from user where id = 1",
+			Shards:       []string{"-20"},
+		},
+	}, {
+		sql: "select * from user where id = :id",
+		bv:  map[string]interface{}{"id": 3},
+		want: &vtgatepb.QueryPlan{
+			PlanId:       "SelectEqual",
+			Table:        "user",
+			Keyspace:     "TestRouter",
+			Vindex:       "user_index",
+			RewrittenSql: "select * from user where id = :id",
+			Shards:       []string{"40-60"},
+		},
+	}, {
+		sql: "select * from user where id in (1, 3)",
+		want: &vtgatepb.QueryPlan{
+			PlanId:       "SelectIN",
+			Table:        "user",
+			Keyspace:     "TestRouter",
+			Vindex:       "user_index",
+			RewrittenSql: "select * from user where id in ::_vals",
+			Shards:       []string{"-20", "40-60"},
+		},
+	}, {
+		sql: "select * from user where id = :id",
+		want: &vtgatepb.QueryPlan{
+			PlanId:       "SelectEqual",
+			Table:        "user",
+			Keyspace:     "TestRouter",
+			Vindex:       "user_index",
+			RewrittenSql: "select * from user where id = :id",
+			ShardError:   "paramsSelectEqual: could not find bind var :id",
+		},
+	}, {
+		sql: "select user.id, user_extra.id from user join user_extra on user.col = user_extra.user_id where user.id = 1",
+		want: &vtgatepb.QueryPlan{
+			PlanId:   "SelectJoin",
+			Table:    "user",
+			Keyspace: "TestRouter",
+			JoinLeft: &vtgatepb.QueryPlan{
+				PlanId:       "SelectEqual",
+				Table:        "user",
+				Keyspace:     "TestRouter",
+				Vindex:       "user_index",
+				RewrittenSql: "select user.id, user.col from user where user.id = 1",
+				Shards:       []string{"-20"},
+			},
+			JoinRight: &vtgatepb.QueryPlan{
+				PlanId:       "SelectEqual",
+				Table:        "user_extra",
+				Keyspace:     "TestRouter",
+				Vindex:       "user_index",
+				RewrittenSql: "select user_extra.id from user_extra where user_extra.user_id = :_user_col",
+				ShardError:   "paramsSelectEqual: could not find bind var :_user_col",
+			},
+		},
+	}, {
+		sql: "select * from nonexistent",
+		want: &vtgatepb.QueryPlan{
+			PlanId: "NoPlan",
+			Reason: "table nonexistent not found",
+		},
+	}, {
+		sql: "insert into user(id, v, name) values (1, 2, 'a'), (3, 4, 'b')",
+		want: &vtgatepb.QueryPlan{
+			PlanId:       "InsertSharded",
+			Table:        "user",
+			Keyspace:     "TestRouter",
+			RewrittenSql: "insert into user(id, v, name) values (:_id0, 2, :_name0), (:_id1, 4, :_name1)",
+			Shards:       []string{"-20", "40-60"},
+		},
+	}, {
+		sql: "insert into user(v, name) values (2, 'myname')",
+		want: &vtgatepb.QueryPlan{
+			PlanId:       "InsertSharded",
+			Table:        "user",
+			Keyspace:     "TestRouter",
+			RewrittenSql: "insert into user(v, name, id) values (2, :_name0, :_id0)",
+			ShardError:   "row 0: the value of id is generated",
+		},
+	}, {
+		sql: "insert into user_seq(id) values (1)",
+		want: &vtgatepb.QueryPlan{
+			PlanId:       "InsertUnsharded",
+			Table:        "user_seq",
+			Keyspace:     "TestUnsharded",
+			RewrittenSql: "insert into user_seq(id) values (1)",
+			Shards:       []string{"0"},
+		},
+	}}
+	for _, tcase := range testcases {
+		got := router.Explain(context.Background(), tcase.sql, tcase.bv, topodatapb.TabletType_MASTER)
+		if !proto.Equal(got, tcase.want) {
+			t.Errorf("Explain(%s):\n%v, want\n%v", tcase.sql, proto.MarshalTextString(got), proto.MarshalTextString(tcase.want))
+		}
+	}
+	for _, sbc := range []*sandboxConn{sbc1, sbc2, sbclookup} {
+		if count := sbc.ExecCount.Get(); count != 0 {
+			t.Errorf("ExecCount: %v, want 0", count)
+		}
+	}
+
+	// Explain doesn't cache the plans it builds.
+	req, _ := http.NewRequest("GET", "/debug/query_plans", nil)
+	w := httptest.NewRecorder()
+	router.planner.ServeHTTP(w, req)
+	if strings.Contains(w.Body.String(), "select * from user where id = 1") {
+		t.Errorf("/debug/query_plans: %s, want no plan", w.Body.String())
+	}
+
+	// Explain doesn't count as a use of a cached plan.
+	for i := 0; i < 2; i++ {
+		if _, err := routerExec(router, "select * from user where id = 1", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	router.Explain(context.Background(), "select * from user where id = 1", nil, topodatapb.TabletType_MASTER)
+	w = httptest.NewRecorder()
+	router.planner.ServeHTTP(w, req)
+	want := "\"select * from user where id = 1\"\nHits: 1\n"
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("/debug/query_plans: %s, want it to contain %q", w.Body.String(), want)
+	}
+}
+
+func TestExplainBindVars(t *testing.T) {
+	router, _, _, _ := createRouterEnv()
+	bv := map[string]interface{}{"id": 1}
+	router.Explain(context.Background(), "insert into user(id, v, name) values (:id, 2, 'a')", bv, topodatapb.TabletType_MASTER)
+	want := map[string]interface{}{"id": 1}
+	if !reflect.DeepEqual(bv, want) {
+		t.Errorf("bind vars: %v, want %v", bv, want)
+	}
+}
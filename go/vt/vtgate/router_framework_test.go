@@ -73,6 +73,18 @@ var routerSchema = createTestSchema(`
         },
         "keyspace_id": {
           "Type": "numeric"
+        },
+        "region_index": {
+          "Type": "range",
+          "Params": {
+            "Ranges": "0:00,100:50,200:90"
+          }
+        },
+        "tenant_index": {
+          "Type": "multicol_hash",
+          "Params": {
+            "ColumnBytes": "1,7"
+          }
         }
       },
       "Classes": {
@@ -159,6 +171,34 @@ var routerSchema = createTestSchema(`
               "Name": "keyspace_id"
             }
           ]
+        },
+        "sales": {
+          "ColVindexes": [
+            {
+              "Col": "region",
+              "Name": "region_index"
+            }
+          ]
+        },
+        "tenant_object": {
+          "ColVindexes": [
+            {
+              "Cols": ["tenant_id", "object_id"],
+              "Name": "tenant_index"
+            }
+          ]
+        },
+        "autoinc_table": {
+          "ColVindexes": [
+            {
+              "Col": "id",
+              "Name": "idx_noauto"
+            }
+          ],
+          "Autoinc": {
+            "Col": "id",
+            "Sequence": "user_seq"
+          }
         }
       },
       "Tables": {
@@ -169,7 +209,10 @@ var routerSchema = createTestSchema(`
         "music_extra_reversed": "music_extra_reversed",
         "multi_autoinc_table": "multi_autoinc_table",
         "noauto_table": "noauto_table",
-        "ksid_table": "ksid_table"
+        "ksid_table": "ksid_table",
+        "sales": "sales",
+        "tenant_object": "tenant_object",
+        "autoinc_table": "autoinc_table"
       }
     },
     "TestBadSharding": {
@@ -180,12 +223,18 @@ var routerSchema = createTestSchema(`
     },
     "TestUnsharded": {
       "Sharded": false,
+      "Classes": {
+        "seq": {
+          "Type": "Sequence"
+        }
+      },
       "Tables": {
         "user_idx": "",
         "music_user_map": "",
         "name_user_map": "",
         "idx1": "",
-        "idx2": ""
+        "idx2": "",
+        "user_seq": "seq"
       }
     }
   }
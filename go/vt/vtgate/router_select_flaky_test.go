@@ -6,6 +6,7 @@ package vtgate
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -14,6 +15,8 @@ import (
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/topo"
 	_ "github.com/youtube/vitess/go/vt/vtgate/vindexes"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
 )
 
 func TestUnsharded(t *testing.T) {
@@ -411,6 +414,298 @@ func TestSelectINFail(t *testing.T) {
 	}
 }
 
+func TestSelectINListBindVar(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+
+	_, err := routerExec(router, "select * from user where id in ::list", map[string]interface{}{
+		"list": []interface{}{int64(1), int64(3)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "select * from user where id in ::_vals",
+		BindVariables: map[string]interface{}{
+			"list":  []interface{}{int64(1), int64(3)},
+			"_vals": []interface{}{int64(1)},
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "select * from user where id in ::_vals",
+		BindVariables: map[string]interface{}{
+			"list":  []interface{}{int64(1), int64(3)},
+			"_vals": []interface{}{int64(3)},
+		},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+
+	_, err = routerExec(router, "select * from user where id in ::list", map[string]interface{}{
+		"list": int64(1),
+	})
+	want := "paramsSelectIN: expecting list for bind var ::list: 1"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+}
+
+func TestSelectINNull(t *testing.T) {
+	router, sbc1, sbc2, sbclookup := createRouterEnv()
+
+	// NULL can't match any row: the query isn't sent.
+	result, err := routerExec(router, "select * from user where id in ::list", map[string]interface{}{
+		"list": []interface{}{nil},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sbc1.Queries != nil || sbc2.Queries != nil {
+		t.Errorf("sbc1.Queries: %+v, sbc2.Queries: %+v, want nil\n", sbc1.Queries, sbc2.Queries)
+	}
+	if result != nil && len(result.Rows) != 0 {
+		t.Errorf("result: %+v, want no rows", result)
+	}
+
+	_, err = routerExec(router, "select * from user where name in ::list", map[string]interface{}{
+		"list": []interface{}{nil},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sbclookup.Queries != nil || sbc1.Queries != nil || sbc2.Queries != nil {
+		t.Errorf("sbclookup.Queries: %+v, sbc1.Queries: %+v, sbc2.Queries: %+v, want nil\n", sbclookup.Queries, sbc1.Queries, sbc2.Queries)
+	}
+
+	result, err = routerExec(router, "select * from user where id in (:a)", map[string]interface{}{
+		"a": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sbc1.Queries != nil || sbc2.Queries != nil {
+		t.Errorf("sbc1.Queries: %+v, sbc2.Queries: %+v, want nil\n", sbc1.Queries, sbc2.Queries)
+	}
+	if result != nil && len(result.Rows) != 0 {
+		t.Errorf("result: %+v, want no rows", result)
+	}
+
+	// The other values are still routed.
+	_, err = routerExec(router, "select * from user where id in ::list", map[string]interface{}{
+		"list": []interface{}{nil, int64(3)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sbc1.Queries != nil {
+		t.Errorf("sbc1.Queries: %+v, want nil\n", sbc1.Queries)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "select * from user where id in ::_vals",
+		BindVariables: map[string]interface{}{
+			"list":  []interface{}{nil, int64(3)},
+			"_vals": []interface{}{int64(3)},
+		},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+}
+
+func TestSelectINSubquery(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+	sbc1.setResults([]*sqltypes.Result{{
+		Fields: []*querypb.Field{
+			{Name: "col", Type: sqltypes.Int32},
+		},
+		RowsAffected: 2,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeTrusted(sqltypes.Int32, []byte("1"))},
+			{sqltypes.MakeTrusted(sqltypes.Int32, []byte("3"))},
+		},
+	}})
+
+	_, err := routerExec(router, "select * from user where id in (select col from user_extra where user_id = 1)", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select col from user_extra where user_id = 1",
+		BindVariables: map[string]interface{}{},
+	}, {
+		Sql: "select * from user where id in ::_vals",
+		BindVariables: map[string]interface{}{
+			"__sq1": []interface{}{int64(1), int64(3)},
+			"_vals": []interface{}{int64(1)},
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "select * from user where id in ::_vals",
+		BindVariables: map[string]interface{}{
+			"__sq1": []interface{}{int64(1), int64(3)},
+			"_vals": []interface{}{int64(3)},
+		},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+
+	// An empty subquery result is passed as a list with a NULL.
+	sbc1.Queries = nil
+	sbc2.Queries = nil
+	sbc1.setResults([]*sqltypes.Result{{
+		Fields: []*querypb.Field{
+			{Name: "col", Type: sqltypes.Int32},
+		},
+	}})
+	_, err = routerExec(router, "select * from user where col in (select col from user_extra where user_id = 1) and id = 3", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "select * from user where col in ::__sq1 and id = 3",
+		BindVariables: map[string]interface{}{
+			"__sq1": []interface{}{nil},
+		},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+
+	// The outer query isn't sent if the subquery
+	// returns no value for the vindex column.
+	sbc1.Queries = nil
+	sbc2.Queries = nil
+	sbc1.setResults([]*sqltypes.Result{{
+		Fields: []*querypb.Field{
+			{Name: "col", Type: sqltypes.Int32},
+		},
+	}})
+	result, err := routerStream(router, "select * from user where id in (select col from user_extra where user_id = 1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sbc1.Queries) != 1 || sbc2.Queries != nil {
+		t.Errorf("sbc1.Queries: %+v, sbc2.Queries: %+v, want only the subquery\n", sbc1.Queries, sbc2.Queries)
+	}
+	if result != nil && len(result.Rows) != 0 {
+		t.Errorf("result: %+v, want no rows", result)
+	}
+}
+
+func TestSelectRange(t *testing.T) {
+	router, conns := createScatterRouterEnv(func(int) *sqltypes.Result { return singleRowResult })
+
+	_, err := routerExec(router, "select * from sales where region between 100 and 150", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The range maps to the keyspace ids from 0x50 to 0x90.
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select * from sales where region between 100 and 150",
+		BindVariables: map[string]interface{}{},
+	}}
+	for i, conn := range conns {
+		if i >= 2 && i <= 4 {
+			if !reflect.DeepEqual(conn.Queries, wantQueries) {
+				t.Errorf("conns[%d].Queries: %+v, want %+v\n", i, conn.Queries, wantQueries)
+			}
+			continue
+		}
+		if conn.Queries != nil {
+			t.Errorf("conns[%d].Queries: %+v, want nil\n", i, conn.Queries)
+		}
+	}
+
+	for _, conn := range conns {
+		conn.Queries = nil
+	}
+	bv := map[string]interface{}{"low": int64(200)}
+	_, err = routerExec(router, "select * from sales where region >= :low", bv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, conn := range conns {
+		if i >= 4 {
+			if len(conn.Queries) != 1 {
+				t.Errorf("conns[%d].Queries: %+v, want 1 query\n", i, conn.Queries)
+			}
+			continue
+		}
+		if conn.Queries != nil {
+			t.Errorf("conns[%d].Queries: %+v, want nil\n", i, conn.Queries)
+		}
+	}
+
+	_, err = routerExec(router, "select * from sales where region >= 'a'", nil)
+	want := "paramsSelectRange: Range.MapRange: unexpected type for a: string"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+}
+
+func TestSelectMultiColumn(t *testing.T) {
+	router, conns := createScatterRouterEnv(func(int) *sqltypes.Result { return singleRowResult })
+
+	_, err := routerExec(router, "select * from tenant_object where tenant_id = 1 and object_id = 2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The keyspace id is 1606e7ea22ce9270.
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select * from tenant_object where tenant_id = 1 and object_id = 2",
+		BindVariables: map[string]interface{}{},
+	}}
+	for i, conn := range conns {
+		if i == 0 {
+			if !reflect.DeepEqual(conn.Queries, wantQueries) {
+				t.Errorf("conns[%d].Queries: %+v, want %+v\n", i, conn.Queries, wantQueries)
+			}
+			continue
+		}
+		if conn.Queries != nil {
+			t.Errorf("conns[%d].Queries: %+v, want nil\n", i, conn.Queries)
+		}
+	}
+
+	for _, conn := range conns {
+		conn.Queries = nil
+	}
+	bv := map[string]interface{}{"tid": int64(3)}
+	_, err = routerExec(router, "select * from tenant_object where tenant_id = :tid", bv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The prefix maps to the keyspace ids from 0x4e to 0x4f.
+	wantQueries = []querytypes.BoundQuery{{
+		Sql:           "select * from tenant_object where tenant_id = :tid",
+		BindVariables: bv,
+	}}
+	for i, conn := range conns {
+		if i == 2 {
+			if !reflect.DeepEqual(conn.Queries, wantQueries) {
+				t.Errorf("conns[%d].Queries: %+v, want %+v\n", i, conn.Queries, wantQueries)
+			}
+			continue
+		}
+		if conn.Queries != nil {
+			t.Errorf("conns[%d].Queries: %+v, want nil\n", i, conn.Queries)
+		}
+	}
+
+	_, err = routerExec(router, "select * from tenant_object where tenant_id = 'a'", nil)
+	want := "paramsSelectPrefix: MultiColHash.MapPrefix: unexpected type for a: string"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+}
+
 func TestSelectKeyrange(t *testing.T) {
 	router, sbc1, sbc2, _ := createRouterEnv()
 
@@ -592,3 +887,412 @@ func TestSelectScatterFail(t *testing.T) {
 		t.Errorf("routerExec: %v, want %v", err, want)
 	}
 }
+
+// createOrderByRouterEnv creates a router for a keyspace of eight shards.
+// The shards return a single row each, with col set to
+// 3, 2, 1, 0, 3, 2, 1, 0 respectively.
+func createOrderByRouterEnv() (*Router, []*sandboxConn) {
+	return createScatterRouterEnv(func(i int) *sqltypes.Result {
+		return &sqltypes.Result{
+			Fields:       orderByFields,
+			RowsAffected: 1,
+			Rows: [][]sqltypes.Value{
+				orderByRow(strconv.Itoa(i), strconv.Itoa(3-i%4)),
+			},
+		}
+	})
+}
+
+// createScatterRouterEnv creates a router for a keyspace with
+// eight shards. The i'th shard returns shardResult(i).
+func createScatterRouterEnv(shardResult func(i int) *sqltypes.Result) (*Router, []*sandboxConn) {
+	s := createSandbox("TestRouter")
+	shards := []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"}
+	var conns []*sandboxConn
+	for i, shard := range shards {
+		sbc := &sandboxConn{}
+		sbc.setResults([]*sqltypes.Result{shardResult(i)})
+		conns = append(conns, sbc)
+		s.MapTestConn(shard, sbc)
+	}
+	serv := new(sandboxTopo)
+	scatterConn := NewScatterConn(nil, topo.Server{}, serv, "", "aa", 1*time.Second, 10, 2*time.Millisecond, 1*time.Millisecond, 24*time.Hour, nil, "")
+	return NewRouter(serv, "aa", routerSchema, "", scatterConn), conns
+}
+
+func orderByRow(id, col string) []sqltypes.Value {
+	return []sqltypes.Value{
+		sqltypes.MakeTrusted(sqltypes.Int32, []byte(id)),
+		sqltypes.MakeTrusted(sqltypes.Int32, []byte(col)),
+	}
+}
+
+var orderByFields = []*querypb.Field{
+	{Name: "id", Type: sqltypes.Int32},
+	{Name: "col", Type: sqltypes.Int32},
+}
+
+func TestSelectScatterOrderBy(t *testing.T) {
+	router, conns := createOrderByRouterEnv()
+
+	result, err := routerExec(router, "select id, col from user order by col, id desc", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select id, col from user order by col asc, id desc",
+		BindVariables: map[string]interface{}{},
+	}}
+	for _, conn := range conns {
+		if !reflect.DeepEqual(conn.Queries, wantQueries) {
+			t.Errorf("conn.Queries = %#v, want %#v", conn.Queries, wantQueries)
+		}
+	}
+	wantResult := &sqltypes.Result{
+		Fields:       orderByFields,
+		RowsAffected: 8,
+		Rows: [][]sqltypes.Value{
+			orderByRow("7", "0"),
+			orderByRow("3", "0"),
+			orderByRow("6", "1"),
+			orderByRow("2", "1"),
+			orderByRow("5", "2"),
+			orderByRow("1", "2"),
+			orderByRow("4", "3"),
+			orderByRow("0", "3"),
+		},
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+}
+
+func TestSelectScatterOrderByLimit(t *testing.T) {
+	router, conns := createOrderByRouterEnv()
+
+	result, err := routerExec(router, "select id, col from user order by col desc, id limit :off, 3", map[string]interface{}{
+		"off": 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "select id, col from user order by col desc, id asc limit :_limit",
+		BindVariables: map[string]interface{}{
+			"off":    1,
+			"_limit": int64(4),
+		},
+	}}
+	for _, conn := range conns {
+		if !reflect.DeepEqual(conn.Queries, wantQueries) {
+			t.Errorf("conn.Queries = %#v, want %#v", conn.Queries, wantQueries)
+		}
+	}
+	wantResult := &sqltypes.Result{
+		Fields:       orderByFields,
+		RowsAffected: 3,
+		Rows: [][]sqltypes.Value{
+			orderByRow("4", "3"),
+			orderByRow("1", "2"),
+			orderByRow("5", "2"),
+		},
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+
+	_, err = routerExec(router, "select id, col from user order by col desc, id limit :off, 3", nil)
+	want := "invalid limit: could not find bind var :off"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+}
+
+func TestStreamSelectScatterOrderBy(t *testing.T) {
+	router, _ := createOrderByRouterEnv()
+
+	result, err := routerStream(router, "select id, col from user order by col desc, id limit 1, 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantResult := &sqltypes.Result{
+		Fields: orderByFields,
+		Rows: [][]sqltypes.Value{
+			orderByRow("4", "3"),
+			orderByRow("1", "2"),
+			orderByRow("5", "2"),
+		},
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+
+	router, _ = createOrderByRouterEnv()
+	_, err = routerStream(router, "select * from user order by foo")
+	want := "order by column foo not found in result"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerStream: %v, want %v", err, want)
+	}
+}
+
+func TestSelectScatterAggregate(t *testing.T) {
+	fields := []*querypb.Field{
+		{Name: "col", Type: sqltypes.Int32},
+		{Name: "count(*)", Type: sqltypes.Int64},
+	}
+	router, conns := createScatterRouterEnv(func(i int) *sqltypes.Result {
+		return &sqltypes.Result{
+			Fields:       fields,
+			RowsAffected: 1,
+			Rows: [][]sqltypes.Value{{
+				sqltypes.MakeTrusted(sqltypes.Int32, []byte(strconv.Itoa(i%2))),
+				sqltypes.MakeTrusted(sqltypes.Int64, []byte(strconv.Itoa(i+1))),
+			}},
+		}
+	})
+
+	result, err := routerExec(router, "select col, count(*) from user group by col", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select col, count(*) from user group by col",
+		BindVariables: map[string]interface{}{},
+	}}
+	for _, conn := range conns {
+		if !reflect.DeepEqual(conn.Queries, wantQueries) {
+			t.Errorf("conn.Queries = %#v, want %#v", conn.Queries, wantQueries)
+		}
+	}
+	wantResult := &sqltypes.Result{
+		Fields:       fields,
+		RowsAffected: 2,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("0")),
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("16")),
+		}, {
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("20")),
+		}},
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+}
+
+func TestSelectINAggregateNoShards(t *testing.T) {
+	router, conns := createScatterRouterEnv(func(i int) *sqltypes.Result {
+		return &sqltypes.Result{}
+	})
+
+	// The query is not sent to any shard, but still returns a row.
+	result, err := routerExec(router, "select count(*) from user where id in ::vals", map[string]interface{}{
+		"vals": []interface{}{nil},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, conn := range conns {
+		if conn.Queries != nil {
+			t.Errorf("conn.Queries = %#v, want nil", conn.Queries)
+		}
+	}
+	wantResult := &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte("0")),
+		}},
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+}
+
+func TestStreamSelectScatterAggregate(t *testing.T) {
+	router, conns := createScatterRouterEnv(func(i int) *sqltypes.Result {
+		return &sqltypes.Result{
+			Fields: []*querypb.Field{
+				{Name: "sum(col)", Type: sqltypes.Decimal},
+				{Name: "count(col)", Type: sqltypes.Int64},
+			},
+			Rows: [][]sqltypes.Value{{
+				sqltypes.MakeTrusted(sqltypes.Decimal, []byte(strconv.Itoa(i))),
+				sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+			}},
+		}
+	})
+
+	result, err := routerStream(router, "select avg(col) from user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select sum(col), count(col) from user",
+		BindVariables: map[string]interface{}{},
+	}}
+	if !reflect.DeepEqual(conns[0].Queries, wantQueries) {
+		t.Errorf("conns[0].Queries = %#v, want %#v", conns[0].Queries, wantQueries)
+	}
+	wantResult := &sqltypes.Result{
+		Fields:       []*querypb.Field{{Name: "avg(col)", Type: sqltypes.Decimal}},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Decimal, []byte("3.5000")),
+		}},
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+}
+
+func TestSelectEqualJoin(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+
+	_, err := routerExec(router, "select * from user join user_extra on user.id = user_extra.user_id where user_extra.user_id = 3", nil)
+	if err != nil {
+		t.Error(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select * from user join user_extra on user.id = user_extra.user_id where user_extra.user_id = 3",
+		BindVariables: map[string]interface{}{},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+	if sbc1.Queries != nil {
+		t.Errorf("sbc1.Queries: %+v, want nil\n", sbc1.Queries)
+	}
+
+	_, err = routerExec(router, "select * from user join user_extra on user.col = user_extra.col", nil)
+	want := "cannot route query: select * from user join user_extra on user.col = user_extra.col: unsupported: '*' in cross-shard join"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+}
+
+var joinLeftResult = &sqltypes.Result{
+	Fields: []*querypb.Field{
+		{Name: "id", Type: sqltypes.Int32},
+		{Name: "col", Type: sqltypes.Int32},
+	},
+	RowsAffected: 1,
+	Rows: [][]sqltypes.Value{{
+		sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+		sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
+	}},
+}
+
+func TestSelectJoin(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+	sbc1.setResults([]*sqltypes.Result{joinLeftResult})
+
+	result, err := routerExec(router, "select user.id, user_extra.id from user join user_extra on user.col = user_extra.user_id where user.id = 1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select user.id, user.col from user where user.id = 1",
+		BindVariables: map[string]interface{}{},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "select user_extra.id from user_extra where user_extra.user_id = :_user_col",
+		BindVariables: map[string]interface{}{
+			"_user_col": int64(3),
+		},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+	wantResult := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "id", Type: sqltypes.Int32},
+			{Name: "id", Type: sqltypes.Int32},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+		}},
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+
+	sbc1.Queries = nil
+	sbc2.Queries = nil
+	sbc1.setResults([]*sqltypes.Result{joinLeftResult})
+	result, err = routerStream(router, "select user.id, user_extra.id from user join user_extra on user.col = user_extra.user_id where user.id = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+}
+
+func TestSelectLeftJoin(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+	sbc1.setResults([]*sqltypes.Result{joinLeftResult})
+	sbc2.setResults([]*sqltypes.Result{{
+		Fields: []*querypb.Field{
+			{Name: "id", Type: sqltypes.Int32},
+		},
+	}})
+
+	result, err := routerExec(router, "select user.id, user_extra.id from user left join user_extra on user.col = user_extra.user_id where user.id = 1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantResult := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "id", Type: sqltypes.Int32},
+			{Name: "id", Type: sqltypes.Int32},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+			sqltypes.NULL,
+		}},
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+}
+
+func TestSelectJoinNoLeftRows(t *testing.T) {
+	router, sbc1, _, _ := createRouterEnv()
+	sbc1.setResults([]*sqltypes.Result{{Fields: joinLeftResult.Fields}})
+
+	result, err := routerExec(router, "select user.id, user_extra.id from user join user_extra on user.col = user_extra.user_id where user.id = 1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The right query is sent with a NULL value to obtain its fields.
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select user.id, user.col from user where user.id = 1",
+		BindVariables: map[string]interface{}{},
+	}, {
+		Sql: "select user_extra.id from user_extra where user_extra.user_id = :_user_col",
+		BindVariables: map[string]interface{}{
+			"_user_col": nil,
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	wantResult := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "id", Type: sqltypes.Int32},
+			{Name: "id", Type: sqltypes.Int32},
+		},
+	}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("result: %+v, want %+v", result, wantResult)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+// This is a V3 file. Do not intermix with V2.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+	"github.com/youtube/vitess/go/vt/topo/topoproto"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+	"golang.org/x/net/context"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+// The USE and SET statements change the routing state stored in the
+// session, instead of being sent to the tablets:
+//   USE keyspace[:shard][@tablet_type]
+//   SET autocommit = 0|1
+//   SET workload = 'oltp'
+//   SET target_tablet_type = 'tablet_type'
+
+// workloads maps the values of SET workload to whether vtgate
+// supports them. The olap and dba workloads need the tablets to
+// serve the queries differently, which they don't do yet, so they
+// are rejected instead of being silently served as oltp.
+var workloads = map[string]bool{
+	"oltp": true,
+	"olap": false,
+	"dba":  false,
+}
+
+// execSessionStatement executes sql if it's a USE or a SET statement.
+// It returns false if it's neither.
+func (rtr *Router) execSessionStatement(ctx context.Context, sql string, tabletType topodatapb.TabletType, session *vtgatepb.Session) (*sqltypes.Result, bool, error) {
+	trimmed := strings.TrimSpace(sql)
+	var verb string
+	if fields := strings.Fields(trimmed); len(fields) != 0 {
+		verb = strings.ToLower(fields[0])
+	}
+	if verb != "use" && verb != "set" {
+		return nil, false, nil
+	}
+	if session == nil {
+		return nil, true, fmt.Errorf("%s statements need a session: %s", strings.ToUpper(verb), sql)
+	}
+
+	var err error
+	switch verb {
+	case "use":
+		err = rtr.execUse(ctx, strings.TrimSpace(trimmed[len(verb):]), tabletType, session)
+	case "set":
+		err = rtr.execSet(ctx, sql, session)
+	}
+	if err != nil {
+		return nil, true, err
+	}
+	return &sqltypes.Result{}, true, nil
+}
+
+// execUse sets the target of the session, which is
+// keyspace[:shard][@tablet_type]. An empty target clears it.
+func (rtr *Router) execUse(ctx context.Context, target string, tabletType topodatapb.TabletType, session *vtgatepb.Session) error {
+	target = strings.TrimRight(target, "; \t\r\n")
+	target = strings.Trim(target, "`")
+
+	var keyspace, shard string
+	targetTabletType := topodatapb.TabletType_UNKNOWN
+	if last := strings.LastIndex(target, "@"); last != -1 {
+		var err error
+		targetTabletType, err = topoproto.ParseTabletType(target[last+1:])
+		if err != nil {
+			return fmt.Errorf("invalid target %v: %v", target, err)
+		}
+		target = target[:last]
+	}
+	keyspace = target
+	if i := strings.Index(target, ":"); i != -1 {
+		keyspace, shard = target[:i], target[i+1:]
+		if keyspace == "" || shard == "" {
+			return fmt.Errorf("invalid target %v: expected keyspace:shard", target)
+		}
+	}
+
+	if keyspace != "" {
+		if targetTabletType != topodatapb.TabletType_UNKNOWN {
+			tabletType = targetTabletType
+		}
+		_, _, allShards, err := getKeyspaceShards(ctx, rtr.serv, rtr.cell, keyspace, tabletType)
+		if err != nil {
+			return err
+		}
+		if shard != "" && !hasShard(allShards, shard) {
+			return fmt.Errorf("shard %v not found in keyspace %v", shard, keyspace)
+		}
+	}
+
+	session.Keyspace = keyspace
+	session.Shard = shard
+	session.TabletType = targetTabletType
+	return nil
+}
+
+func hasShard(shards []*topodatapb.ShardReference, shard string) bool {
+	for _, s := range shards {
+		if s.Name == shard {
+			return true
+		}
+	}
+	return false
+}
+
+// execSet sets the session variables of a SET statement. Either all
+// of them are set, or none.
+func (rtr *Router) execSet(ctx context.Context, sql string, session *vtgatepb.Session) error {
+	statement, err := sqlparser.Parse(sql)
+	if err != nil {
+		return err
+	}
+	set, ok := statement.(*sqlparser.Set)
+	if !ok {
+		return fmt.Errorf("unsupported SET statement: %s", sql)
+	}
+
+	newSession := *session
+	for _, expr := range set.Exprs {
+		name := strings.ToLower(string(expr.Name.Name))
+		value, err := setValue(expr.Expr)
+		if err != nil {
+			return fmt.Errorf("invalid value for %v: %v", name, err)
+		}
+		switch name {
+		case "autocommit":
+			switch strings.ToLower(value) {
+			case "1", "true":
+				newSession.NoAutocommit = false
+			case "0", "false":
+				newSession.NoAutocommit = true
+			default:
+				return fmt.Errorf("invalid value for autocommit: %v", value)
+			}
+		case "workload":
+			value = strings.ToLower(value)
+			supported, ok := workloads[value]
+			if !ok {
+				return fmt.Errorf("invalid value for workload: %v", value)
+			}
+			if !supported {
+				return fmt.Errorf("unsupported workload: %v", value)
+			}
+			newSession.Workload = value
+		case "target_tablet_type":
+			newSession.TabletType = topodatapb.TabletType_UNKNOWN
+			if value != "" {
+				if newSession.TabletType, err = topoproto.ParseTabletType(value); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported SET variable: %v", name)
+		}
+	}
+
+	// Like in MySQL, turning autocommit back on commits the
+	// current transaction.
+	if session.NoAutocommit && !newSession.NoAutocommit && session.InTransaction {
+		if err := rtr.scatterConn.Commit(ctx, NewSafeSession(session)); err != nil {
+			return err
+		}
+	}
+	session.NoAutocommit = newSession.NoAutocommit
+	session.Workload = newSession.Workload
+	session.TabletType = newSession.TabletType
+	return nil
+}
+
+// setValue returns the value of a SET variable. Unquoted words, like
+// in SET workload = oltp, are parsed as column names.
+func setValue(expr sqlparser.ValExpr) (string, error) {
+	switch expr := expr.(type) {
+	case sqlparser.StrVal:
+		return string(expr), nil
+	case sqlparser.NumVal:
+		return string(expr), nil
+	case *sqlparser.ColName:
+		if expr.Qualifier == "" {
+			return string(expr.Name), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported value %v", sqlparser.String(expr))
+}
+
+// execShard sends sql to the shard of the session, set by
+// USE keyspace:shard, without building a plan.
+func (rtr *Router) execShard(vcursor *requestContext) (*sqltypes.Result, error) {
+	session := vcursor.session
+	ks, _, _, err := getKeyspaceShards(vcursor.ctx, rtr.serv, rtr.cell, session.Keyspace, vcursor.tabletType)
+	if err != nil {
+		return nil, fmt.Errorf("execShard: %v", err)
+	}
+	return rtr.scatterConn.Execute(
+		vcursor.ctx,
+		vcursor.sql,
+		vcursor.bindVariables,
+		ks,
+		[]string{session.Shard},
+		vcursor.tabletType,
+		NewSafeSession(session),
+		vcursor.notInTransaction,
+	)
+}
+
+// execDefaultKeyspace sends sql to the default keyspace of the
+// session, set by USE keyspace, if it's unsharded. It's used for the
+// queries the VSchema can't route.
+func (rtr *Router) execDefaultKeyspace(vcursor *requestContext, plan *planbuilder.Plan) (*sqltypes.Result, error) {
+	ks, _, allShards, err := getKeyspaceShards(vcursor.ctx, rtr.serv, rtr.cell, vcursor.session.Keyspace, vcursor.tabletType)
+	if err != nil {
+		return nil, fmt.Errorf("execDefaultKeyspace: %v", err)
+	}
+	if len(allShards) != 1 {
+		return nil, fmt.Errorf("cannot route query: %s: %s, and keyspace %s is sharded", vcursor.sql, plan.Reason, ks)
+	}
+	return rtr.scatterConn.Execute(
+		vcursor.ctx,
+		vcursor.sql,
+		vcursor.bindVariables,
+		ks,
+		[]string{allShards[0].Name},
+		vcursor.tabletType,
+		NewSafeSession(vcursor.session),
+		vcursor.notInTransaction,
+	)
+}
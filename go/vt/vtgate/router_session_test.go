@@ -0,0 +1,288 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+	_ "github.com/youtube/vitess/go/vt/vtgate/vindexes"
+	"golang.org/x/net/context"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+func routerExecSession(router *Router, sql string, session *vtgatepb.Session) (*sqltypes.Result, error) {
+	return router.Execute(context.Background(),
+		sql,
+		nil,
+		topodatapb.TabletType_MASTER,
+		session,
+		false)
+}
+
+func TestUse(t *testing.T) {
+	router, sbc1, sbc2, sbclookup := createRouterEnv()
+
+	_, err := routerExec(router, "use TestUnsharded", nil)
+	want := "USE statements need a session"
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("use without session: %v, want %s", err, want)
+	}
+
+	testcases := []struct {
+		target string
+		want   vtgatepb.Session
+	}{{
+		target: "TestUnsharded",
+		want:   vtgatepb.Session{Keyspace: "TestUnsharded"},
+	}, {
+		target: "`TestRouter:-20`;",
+		want:   vtgatepb.Session{Keyspace: "TestRouter", Shard: "-20"},
+	}, {
+		target: "TestRouter@replica",
+		want:   vtgatepb.Session{Keyspace: "TestRouter", TabletType: topodatapb.TabletType_REPLICA},
+	}, {
+		target: "TestRouter:40-60@rdonly",
+		want:   vtgatepb.Session{Keyspace: "TestRouter", Shard: "40-60", TabletType: topodatapb.TabletType_RDONLY},
+	}, {
+		target: "@replica",
+		want:   vtgatepb.Session{TabletType: topodatapb.TabletType_REPLICA},
+	}, {
+		target: "``",
+		want:   vtgatepb.Session{},
+	}}
+	for _, tc := range testcases {
+		session := &vtgatepb.Session{Keyspace: "previous", Shard: "previous", Workload: "oltp"}
+		tc.want.Workload = "oltp"
+		if _, err := routerExecSession(router, "use "+tc.target, session); err != nil {
+			t.Errorf("use %v: %v", tc.target, err)
+			continue
+		}
+		if !reflect.DeepEqual(*session, tc.want) {
+			t.Errorf("use %v: %+v, want %+v", tc.target, *session, tc.want)
+		}
+	}
+
+	errcases := []struct {
+		target string
+		want   string
+	}{{
+		target: "TestBadSharding",
+		want:   "keyspace TestBadSharding fetch error",
+	}, {
+		target: "TestRouter:-99",
+		want:   "shard -99 not found in keyspace TestRouter",
+	}, {
+		target: "TestRouter:",
+		want:   "invalid target TestRouter:: expected keyspace:shard",
+	}, {
+		target: "TestRouter@badtype",
+		want:   "invalid target TestRouter@badtype: unknown TabletType badtype",
+	}}
+	getSandbox("TestBadSharding").SrvKeyspaceMustFail = 1
+	for _, tc := range errcases {
+		session := &vtgatepb.Session{Keyspace: "TestUnsharded"}
+		_, err := routerExecSession(router, "use "+tc.target, session)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("use %v: %v, want %s", tc.target, err, tc.want)
+		}
+		if session.Keyspace != "TestUnsharded" {
+			t.Errorf("use %v changed the session: %+v", tc.target, session)
+		}
+	}
+
+	// The default keyspace gets the queries the VSchema can't route,
+	// if it's unsharded.
+	session := &vtgatepb.Session{}
+	if _, err := routerExecSession(router, "use TestUnsharded", session); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := routerExecSession(router, "select * from unknown_table", session); err != nil {
+		t.Error(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select * from unknown_table",
+		BindVariables: map[string]interface{}{},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries: %+v, want %+v", sbclookup.Queries, wantQueries)
+	}
+	if _, err := routerExecSession(router, "use TestRouter", session); err != nil {
+		t.Fatal(err)
+	}
+	_, err = routerExecSession(router, "select * from unknown_table", session)
+	want = "table unknown_table not found, and keyspace TestRouter is sharded"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("sharded default keyspace: %v, want %s", err, want)
+	}
+
+	// The tables of the VSchema are still routed by it.
+	sbc1.Queries = nil
+	if _, err := routerExecSession(router, "select * from user where id = 1", session); err != nil {
+		t.Error(err)
+	}
+	if len(sbc1.Queries) != 1 {
+		t.Errorf("sbc1.Queries: %+v, want one query", sbc1.Queries)
+	}
+
+	// A shard target bypasses the VSchema.
+	sbc1.Queries = nil
+	if _, err := routerExecSession(router, "use TestRouter:40-60", session); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := routerExecSession(router, "select * from user where id = 1", session); err != nil {
+		t.Error(err)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql:           "select * from user where id = 1",
+		BindVariables: map[string]interface{}{},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v", sbc2.Queries, wantQueries)
+	}
+	if sbc1.Queries != nil {
+		t.Errorf("sbc1.Queries: %+v, want nil", sbc1.Queries)
+	}
+}
+
+func TestSet(t *testing.T) {
+	router, _, _, _ := createRouterEnv()
+
+	_, err := routerExec(router, "set autocommit = 0", nil)
+	want := "SET statements need a session"
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("set without session: %v, want %s", err, want)
+	}
+
+	testcases := []struct {
+		sql  string
+		want vtgatepb.Session
+	}{{
+		sql:  "set autocommit = 0",
+		want: vtgatepb.Session{NoAutocommit: true},
+	}, {
+		sql:  "SET AUTOCOMMIT = 1",
+		want: vtgatepb.Session{},
+	}, {
+		sql:  "set workload = 'OLTP'",
+		want: vtgatepb.Session{Workload: "oltp"},
+	}, {
+		sql:  "set workload = oltp",
+		want: vtgatepb.Session{Workload: "oltp"},
+	}, {
+		sql:  "set target_tablet_type = 'replica'",
+		want: vtgatepb.Session{TabletType: topodatapb.TabletType_REPLICA},
+	}, {
+		sql:  "set target_tablet_type = 'rdonly', autocommit = 0, workload = 'oltp'",
+		want: vtgatepb.Session{TabletType: topodatapb.TabletType_RDONLY, NoAutocommit: true, Workload: "oltp"},
+	}}
+	for _, tc := range testcases {
+		session := &vtgatepb.Session{TabletType: topodatapb.TabletType_MASTER}
+		if tc.want.TabletType == topodatapb.TabletType_UNKNOWN {
+			tc.want.TabletType = topodatapb.TabletType_MASTER
+		}
+		if _, err := routerExecSession(router, tc.sql, session); err != nil {
+			t.Errorf("%v: %v", tc.sql, err)
+			continue
+		}
+		if !reflect.DeepEqual(*session, tc.want) {
+			t.Errorf("%v: %+v, want %+v", tc.sql, *session, tc.want)
+		}
+	}
+
+	// target_tablet_type can be cleared.
+	session := &vtgatepb.Session{TabletType: topodatapb.TabletType_REPLICA}
+	if _, err := routerExecSession(router, "set target_tablet_type = ''", session); err != nil {
+		t.Error(err)
+	}
+	if session.TabletType != topodatapb.TabletType_UNKNOWN {
+		t.Errorf("TabletType: %v, want UNKNOWN", session.TabletType)
+	}
+
+	errcases := []struct {
+		sql  string
+		want string
+	}{{
+		sql:  "set autocommit = 2",
+		want: "invalid value for autocommit: 2",
+	}, {
+		sql:  "set workload = 'batch'",
+		want: "invalid value for workload: batch",
+	}, {
+		sql:  "set workload = 'olap'",
+		want: "unsupported workload: olap",
+	}, {
+		sql:  "set workload = dba",
+		want: "unsupported workload: dba",
+	}, {
+		sql:  "set target_tablet_type = 'badtype'",
+		want: "unknown TabletType badtype",
+	}, {
+		sql:  "set autocommit = 0, unknown = 1",
+		want: "unsupported SET variable: unknown",
+	}, {
+		sql:  "set autocommit = :a",
+		want: "invalid value for autocommit: unsupported value :a",
+	}}
+	for _, tc := range errcases {
+		session := &vtgatepb.Session{}
+		_, err := routerExecSession(router, tc.sql, session)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%v: %v, want %s", tc.sql, err, tc.want)
+		}
+		if !reflect.DeepEqual(*session, vtgatepb.Session{}) {
+			t.Errorf("%v changed the session: %+v", tc.sql, session)
+		}
+	}
+}
+
+func TestNoAutocommit(t *testing.T) {
+	router, sbc1, _, _ := createRouterEnv()
+
+	session := &vtgatepb.Session{}
+	if _, err := routerExecSession(router, "set autocommit = 0", session); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := routerExecSession(router, "update user set a = 2 where id = 1", session); err != nil {
+		t.Fatal(err)
+	}
+	if !session.InTransaction || len(session.ShardSessions) != 1 {
+		t.Errorf("session: %+v, want a transaction on one shard", session)
+	}
+	if got := sbc1.BeginCount.Get(); got != 1 {
+		t.Errorf("sbc1.BeginCount: %v, want 1", got)
+	}
+
+	// Turning autocommit back on commits the transaction.
+	if _, err := routerExecSession(router, "set autocommit = 1", session); err != nil {
+		t.Fatal(err)
+	}
+	if session.InTransaction || session.ShardSessions != nil || session.NoAutocommit {
+		t.Errorf("session: %+v, want no transaction", session)
+	}
+	if got := sbc1.CommitCount.Get(); got != 1 {
+		t.Errorf("sbc1.CommitCount: %v, want 1", got)
+	}
+
+	// No transaction is started on replicas.
+	session = &vtgatepb.Session{NoAutocommit: true}
+	if _, err := routerExecSession(router, "use TestRouter@replica", session); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := routerExecSession(router, "select * from user where id = 1", session); err != nil {
+		t.Fatal(err)
+	}
+	if session.InTransaction {
+		t.Errorf("session: %+v, want no transaction", session)
+	}
+	if got := sbc1.BeginCount.Get(); got != 1 {
+		t.Errorf("sbc1.BeginCount: %v, want 1", got)
+	}
+}
@@ -329,14 +329,15 @@ func sandboxDialer(ctx context.Context, endPoint *topodatapb.EndPoint, keyspace,
 
 // sandboxConn satisfies the TabletConn interface
 type sandboxConn struct {
-	endPoint       *topodatapb.EndPoint
-	mustFailRetry  int
-	mustFailFatal  int
-	mustFailServer int
-	mustFailConn   int
-	mustFailTxPool int
-	mustFailNotTx  int
-	mustDelay      time.Duration
+	endPoint           *topodatapb.EndPoint
+	mustFailRetry      int
+	mustFailNotServing int
+	mustFailFatal      int
+	mustFailServer     int
+	mustFailConn       int
+	mustFailTxPool     int
+	mustFailNotTx      int
+	mustDelay          time.Duration
 
 	// A callback to tweak the behavior on each conn call
 	onConnUse func(*sandboxConn)
@@ -350,6 +351,20 @@ type sandboxConn struct {
 	CloseCount         sync2.AtomicInt64
 	AsTransactionCount sync2.AtomicInt64
 
+	// These Count vars report how often the corresponding
+	// two-phase commit functions were called.
+	PrepareCount             sync2.AtomicInt64
+	CommitPreparedCount      sync2.AtomicInt64
+	RollbackPreparedCount    sync2.AtomicInt64
+	CreateTransactionCount   sync2.AtomicInt64
+	StartCommitCount         sync2.AtomicInt64
+	SetRollbackCount         sync2.AtomicInt64
+	ConcludeTransactionCount sync2.AtomicInt64
+	ReadTransactionCount     sync2.AtomicInt64
+
+	// ReadTransactionResult is returned by ReadTransaction.
+	ReadTransactionResult *querypb.TransactionMetadata
+
 	// Queries stores the non-batch requests received.
 	Queries []querytypes.BoundQuery
 
@@ -378,6 +393,14 @@ func (sbc *sandboxConn) getError() error {
 			ServerCode: vtrpcpb.ErrorCode_QUERY_NOT_SERVED,
 		}
 	}
+	if sbc.mustFailNotServing > 0 {
+		sbc.mustFailNotServing--
+		return &tabletconn.ServerError{
+			Code:       tabletconn.ERR_RETRY,
+			Err:        "retry: operation not allowed in state NOT_SERVING",
+			ServerCode: vtrpcpb.ErrorCode_QUERY_NOT_SERVED,
+		}
+	}
 	if sbc.mustFailFatal > 0 {
 		sbc.mustFailFatal--
 		return &tabletconn.ServerError{
@@ -534,6 +557,60 @@ func (sbc *sandboxConn) Rollback2(ctx context.Context, transactionID int64) erro
 	return sbc.Rollback(ctx, transactionID)
 }
 
+func (sbc *sandboxConn) Prepare(ctx context.Context, transactionID int64, dtid string) error {
+	sbc.ExecCount.Add(1)
+	sbc.PrepareCount.Add(1)
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) CommitPrepared(ctx context.Context, dtid string) error {
+	sbc.ExecCount.Add(1)
+	sbc.CommitPreparedCount.Add(1)
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) RollbackPrepared(ctx context.Context, dtid string, originalID int64) error {
+	sbc.ExecCount.Add(1)
+	sbc.RollbackPreparedCount.Add(1)
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) CreateTransaction(ctx context.Context, dtid string, participants []*querypb.Target) error {
+	sbc.ExecCount.Add(1)
+	sbc.CreateTransactionCount.Add(1)
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) StartCommit(ctx context.Context, transactionID int64, dtid string) error {
+	sbc.ExecCount.Add(1)
+	sbc.StartCommitCount.Add(1)
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) SetRollback(ctx context.Context, dtid string, transactionID int64) error {
+	sbc.ExecCount.Add(1)
+	sbc.SetRollbackCount.Add(1)
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) ConcludeTransaction(ctx context.Context, dtid string) error {
+	sbc.ExecCount.Add(1)
+	sbc.ConcludeTransactionCount.Add(1)
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) ReadTransaction(ctx context.Context, dtid string) (*querypb.TransactionMetadata, error) {
+	sbc.ExecCount.Add(1)
+	sbc.ReadTransactionCount.Add(1)
+	if err := sbc.getError(); err != nil {
+		return nil, err
+	}
+	if sbc.ReadTransactionResult == nil {
+		return &querypb.TransactionMetadata{}, nil
+	}
+	return sbc.ReadTransactionResult, nil
+}
+
 var sandboxSQRowCount = int64(10)
 
 // Fake SplitQuery creates splits from the original query by appending the
@@ -227,11 +227,19 @@ func (stc *ScatterConn) ExecuteBatch(
 	tabletType topodatapb.TabletType,
 	asTransaction bool,
 	session *SafeSession) (qrs []sqltypes.Result, err error) {
+	var shards []string
+	for _, req := range batchRequest.Requests {
+		shards = append(shards, req.Keyspace+"/"+req.Shard)
+	}
+	if err := checkFanOut(ctx, shards); err != nil {
+		return nil, err
+	}
 	allErrors := new(concurrency.AllErrorRecorder)
 
 	results := make([]sqltypes.Result, batchRequest.Length)
 	var resMutex sync.Mutex
 
+	batchStart := time.Now()
 	var wg sync.WaitGroup
 	for _, req := range batchRequest.Requests {
 		wg.Add(1)
@@ -270,6 +278,7 @@ func (stc *ScatterConn) ExecuteBatch(
 		}(req)
 	}
 	wg.Wait()
+	logStatsFromContext(ctx).addShardQueries(len(batchRequest.Requests), time.Now().Sub(batchStart))
 	// If we want to rollback, we have to do it before closing results
 	// so that the session is updated to be not InTransaction.
 	if allErrors.HasErrors() {
@@ -389,6 +398,59 @@ func (stc *ScatterConn) StreamExecuteMulti(
 	return allErrors.AggrError(stc.aggregateErrors)
 }
 
+// StreamExecuteMultiMerge is like StreamExecuteMulti, but it keeps
+// the rows streamed by each shard separate and merges them in the
+// order specified by orderBy. It then skips offset rows and stops
+// after rowcount rows have been sent, unless rowcount is -1.
+func (stc *ScatterConn) StreamExecuteMultiMerge(
+	ctx context.Context,
+	query string,
+	keyspace string,
+	shardVars map[string]map[string]interface{},
+	tabletType topodatapb.TabletType,
+	orderBy []orderByColumn,
+	offset, rowcount int64,
+	sendReply func(reply *sqltypes.Result) error,
+) error {
+	// The shard streams are canceled if the merge ends early.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	shards := getShards(shardVars)
+	results, allErrors := stc.multiGo(
+		ctx,
+		"StreamExecute",
+		keyspace,
+		shards,
+		tabletType,
+		NewSafeSession(nil),
+		false,
+		func(shard string, transactionID int64, sResults chan<- interface{}) error {
+			sr, errFunc := stc.gateway.StreamExecute(ctx, keyspace, shard, tabletType, query, shardVars[shard], transactionID)
+			if sr != nil {
+				for qr := range sr {
+					sResults <- &shardResult{shard: shard, qr: qr}
+				}
+			}
+			err := errFunc()
+			sResults <- &shardResult{shard: shard, err: err}
+			return err
+		})
+	stopped, err := newStreamMerger(shards, orderBy, offset, rowcount, sendReply).run(results)
+	cancel()
+	// We still need to finish pumping
+	for range results {
+	}
+	if err != nil {
+		return err
+	}
+	if stopped {
+		// All requested rows were sent. Errors caused
+		// by canceling the other streams are expected.
+		return nil
+	}
+	return allErrors.AggrError(stc.aggregateErrors)
+}
+
 // Commit commits the current transaction. There are no retries on this operation.
 func (stc *ScatterConn) Commit(ctx context.Context, session *SafeSession) (err error) {
 	if session == nil {
@@ -403,6 +465,14 @@ func (stc *ScatterConn) Commit(ctx context.Context, session *SafeSession) (err e
 			fmt.Errorf("cannot commit: not in transaction"),
 		)
 	}
+	defer func(start time.Time) {
+		logStatsFromContext(ctx).addCommitTime(time.Now().Sub(start))
+	}(time.Now())
+	if *enableTwoPC && len(session.ShardSessions) > 1 {
+		err = stc.twoPCCommit(ctx, session)
+		session.Reset()
+		return err
+	}
 	committing := true
 	for _, shardSession := range session.ShardSessions {
 		if !committing {
@@ -606,8 +676,19 @@ func (stc *ScatterConn) multiGo(
 ) (rResults <-chan interface{}, allErrors *concurrency.AllErrorRecorder) {
 	allErrors = new(concurrency.AllErrorRecorder)
 	results := make(chan interface{}, len(shards))
+	shardSet := unique(shards)
+	fanOut := make([]string, 0, len(shardSet))
+	for shard := range shardSet {
+		fanOut = append(fanOut, keyspace+"/"+shard)
+	}
+	if err := checkFanOut(ctx, fanOut); err != nil {
+		allErrors.RecordError(err)
+		close(results)
+		return results, allErrors
+	}
+	multiGoStart := time.Now()
 	var wg sync.WaitGroup
-	for shard := range unique(shards) {
+	for shard := range shardSet {
 		wg.Add(1)
 		go func(shard string) {
 			statsKey := []string{name, keyspace, shard, strings.ToLower(tabletType.String())}
@@ -649,6 +730,7 @@ func (stc *ScatterConn) multiGo(
 				}
 			}
 		}
+		logStatsFromContext(ctx).addShardQueries(len(shardSet), time.Now().Sub(multiGoStart))
 		close(results)
 	}()
 	return results, allErrors
@@ -21,6 +21,7 @@ import (
 	"github.com/youtube/vitess/go/vt/vterrors"
 	"golang.org/x/net/context"
 
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 )
@@ -46,6 +47,10 @@ type ShardConn struct {
 
 	connectTimings *stats.MultiTimings
 
+	// buffer holds the master requests while the shard fails over.
+	// It's nil if buffering is disabled, or for other tablet types.
+	buffer *masterBuffer
+
 	// conn needs a mutex because it can change during the lifetime of ShardConn.
 	mu   sync.Mutex
 	conn tabletconn.TabletConn
@@ -188,6 +193,65 @@ func (sdc *ShardConn) Rollback(ctx context.Context, transactionID int64) (err er
 	}, transactionID, false)
 }
 
+// Prepare prepares the specified transaction for the distributed transaction dtid. The retry rules are the same as Execute.
+func (sdc *ShardConn) Prepare(ctx context.Context, transactionID int64, dtid string) (err error) {
+	return sdc.withRetry(ctx, func(conn tabletconn.TabletConn) error {
+		return conn.Prepare(ctx, transactionID, dtid)
+	}, transactionID, false)
+}
+
+// CommitPrepared commits the prepared transaction of dtid. The retry rules are the same as Execute.
+func (sdc *ShardConn) CommitPrepared(ctx context.Context, dtid string) (err error) {
+	return sdc.withRetry(ctx, func(conn tabletconn.TabletConn) error {
+		return conn.CommitPrepared(ctx, dtid)
+	}, 0, false)
+}
+
+// RollbackPrepared rolls back the prepared transaction of dtid, or originalID if it was not prepared. The retry rules are the same as Execute.
+func (sdc *ShardConn) RollbackPrepared(ctx context.Context, dtid string, originalID int64) (err error) {
+	return sdc.withRetry(ctx, func(conn tabletconn.TabletConn) error {
+		return conn.RollbackPrepared(ctx, dtid, originalID)
+	}, 0, false)
+}
+
+// CreateTransaction records the distributed transaction dtid on its coordinator. The retry rules are the same as Execute.
+func (sdc *ShardConn) CreateTransaction(ctx context.Context, dtid string, participants []*querypb.Target) (err error) {
+	return sdc.withRetry(ctx, func(conn tabletconn.TabletConn) error {
+		return conn.CreateTransaction(ctx, dtid, participants)
+	}, 0, false)
+}
+
+// StartCommit commits the transaction of the coordinator, and marks the distributed transaction dtid for commit. The retry rules are the same as Execute.
+func (sdc *ShardConn) StartCommit(ctx context.Context, transactionID int64, dtid string) (err error) {
+	return sdc.withRetry(ctx, func(conn tabletconn.TabletConn) error {
+		return conn.StartCommit(ctx, transactionID, dtid)
+	}, transactionID, false)
+}
+
+// SetRollback marks the distributed transaction dtid for rollback. The retry rules are the same as Execute.
+func (sdc *ShardConn) SetRollback(ctx context.Context, dtid string, transactionID int64) (err error) {
+	return sdc.withRetry(ctx, func(conn tabletconn.TabletConn) error {
+		return conn.SetRollback(ctx, dtid, transactionID)
+	}, 0, false)
+}
+
+// ConcludeTransaction deletes the record of the distributed transaction dtid. The retry rules are the same as Execute.
+func (sdc *ShardConn) ConcludeTransaction(ctx context.Context, dtid string) (err error) {
+	return sdc.withRetry(ctx, func(conn tabletconn.TabletConn) error {
+		return conn.ConcludeTransaction(ctx, dtid)
+	}, 0, false)
+}
+
+// ReadTransaction returns the record of the distributed transaction dtid. The retry rules are the same as Execute.
+func (sdc *ShardConn) ReadTransaction(ctx context.Context, dtid string) (metadata *querypb.TransactionMetadata, err error) {
+	err = sdc.withRetry(ctx, func(conn tabletconn.TabletConn) error {
+		var innerErr error
+		metadata, innerErr = conn.ReadTransaction(ctx, dtid)
+		return innerErr
+	}, 0, false)
+	return metadata, err
+}
+
 // SplitQuery splits a query into sub queries. The retry rules are the same as Execute.
 func (sdc *ShardConn) SplitQuery(ctx context.Context, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64) (queries []querytypes.QuerySplit, err error) {
 	err = sdc.withRetry(ctx, func(conn tabletconn.TabletConn) error {
@@ -229,11 +293,44 @@ func (sdc *ShardConn) closeCurrent() {
 // a resharding event, and set the re-resolve bit and let the upper layers
 // re-resolve and retry.
 func (sdc *ShardConn) withRetry(ctx context.Context, action func(conn tabletconn.TabletConn) error, transactionID int64, isStreaming bool) error {
+	inTransaction := (transactionID != 0)
+	if sdc.buffer == nil || inTransaction {
+		endPoint, err := sdc.retry(ctx, action, transactionID, isStreaming)
+		return sdc.WrapError(err, endPoint, inTransaction)
+	}
+
+	// Master requests outside of a transaction are held while
+	// the shard fails over, and replayed once it's done. Once the
+	// old master is marked down, the retries fail with other errors,
+	// so any attempt that failed because of the failover counts.
+	if err := sdc.buffer.wait(ctx, sdc.keyspace, sdc.shard); err != nil {
+		return sdc.WrapError(err, nil, false)
+	}
+	failingOver := false
+	endPoint, err := sdc.retry(ctx, func(conn tabletconn.TabletConn) error {
+		err := action(conn)
+		if isFailoverError(err, isStreaming) {
+			failingOver = true
+		}
+		return err
+	}, transactionID, isStreaming)
+	if err != nil && failingOver {
+		sdc.buffer.startFailover(sdc.keyspace, sdc.shard)
+		if err := sdc.buffer.wait(ctx, sdc.keyspace, sdc.shard); err != nil {
+			return sdc.WrapError(err, endPoint, false)
+		}
+		endPoint, err = sdc.retry(ctx, action, transactionID, isStreaming)
+	}
+	return sdc.WrapError(err, endPoint, false)
+}
+
+// retry executes the action, and retries it on retryable errors.
+// It returns the last error, and the endpoint it came from.
+func (sdc *ShardConn) retry(ctx context.Context, action func(conn tabletconn.TabletConn) error, transactionID int64, isStreaming bool) (*topodatapb.EndPoint, error) {
 	var conn tabletconn.TabletConn
 	var endPoint *topodatapb.EndPoint
 	var err error
 	var isTimeout bool
-	inTransaction := (transactionID != 0)
 	// execute the action at least once even without retrying
 	for i := 0; i < sdc.retryCount+1; i++ {
 		conn, endPoint, isTimeout, err = sdc.getConn(ctx)
@@ -250,7 +347,7 @@ func (sdc *ShardConn) withRetry(ctx context.Context, action func(conn tabletconn
 		}
 		break
 	}
-	return sdc.WrapError(err, endPoint, inTransaction)
+	return endPoint, err
 }
 
 type connectResult struct {
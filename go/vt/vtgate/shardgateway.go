@@ -20,6 +20,7 @@ import (
 	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
 	"github.com/youtube/vitess/go/vt/topo"
 
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 )
 
@@ -32,7 +33,7 @@ func init() {
 }
 
 func createShardGateway(hc discovery.HealthCheck, topoServer topo.Server, serv topo.SrvTopoServer, cell string, retryDelay time.Duration, retryCount int, connTimeoutTotal, connTimeoutPerConn, connLife time.Duration, connTimings *stats.MultiTimings, _ []topodatapb.TabletType) Gateway {
-	return &shardGateway{
+	sg := &shardGateway{
 		toposerv:           serv,
 		cell:               cell,
 		retryDelay:         retryDelay,
@@ -43,6 +44,22 @@ func createShardGateway(hc discovery.HealthCheck, topoServer topo.Server, serv t
 		connTimings:        connTimings,
 		shardConns:         make(map[string]*ShardConn),
 	}
+	if *enableMasterBuffer {
+		sg.buffer = newMasterBuffer(*masterBufferWindow, *masterBufferMaxSize)
+		if hc != nil {
+			// The health check tells the buffer when
+			// the masters stop and start serving.
+			hc.SetListener(sg.buffer)
+			for _, c := range strings.Split(*cellsToWatch, ",") {
+				if c == "" {
+					continue
+				}
+				ctw := discovery.NewCellTabletsWatcher(topoServer, hc, c, *refreshInterval, *topoReadConcurrency)
+				sg.tabletsWatchers = append(sg.tabletsWatchers, ctw)
+			}
+		}
+	}
+	return sg
 }
 
 // A Gateway is the query processing module for each shard.
@@ -55,6 +72,8 @@ type shardGateway struct {
 	connTimeoutPerConn time.Duration
 	connLife           time.Duration
 	connTimings        *stats.MultiTimings
+	buffer             *masterBuffer
+	tabletsWatchers    []*discovery.TopologyWatcher
 
 	mu         sync.Mutex
 	shardConns map[string]*ShardConn
@@ -136,6 +155,46 @@ func (sg *shardGateway) Rollback(ctx context.Context, keyspace string, shard str
 	return sg.getConnection(ctx, keyspace, shard, tabletType).Rollback(ctx, transactionID)
 }
 
+// Prepare prepares the specified transaction for the distributed transaction dtid.
+func (sg *shardGateway) Prepare(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType, transactionID int64, dtid string) error {
+	return sg.getConnection(ctx, keyspace, shard, tabletType).Prepare(ctx, transactionID, dtid)
+}
+
+// CommitPrepared commits the prepared transaction of dtid.
+func (sg *shardGateway) CommitPrepared(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType, dtid string) error {
+	return sg.getConnection(ctx, keyspace, shard, tabletType).CommitPrepared(ctx, dtid)
+}
+
+// RollbackPrepared rolls back the prepared transaction of dtid, or originalID if it was not prepared.
+func (sg *shardGateway) RollbackPrepared(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType, dtid string, originalID int64) error {
+	return sg.getConnection(ctx, keyspace, shard, tabletType).RollbackPrepared(ctx, dtid, originalID)
+}
+
+// CreateTransaction records the distributed transaction dtid on its coordinator.
+func (sg *shardGateway) CreateTransaction(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType, dtid string, participants []*querypb.Target) error {
+	return sg.getConnection(ctx, keyspace, shard, tabletType).CreateTransaction(ctx, dtid, participants)
+}
+
+// StartCommit commits the transaction of the coordinator, and marks the distributed transaction dtid for commit.
+func (sg *shardGateway) StartCommit(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType, transactionID int64, dtid string) error {
+	return sg.getConnection(ctx, keyspace, shard, tabletType).StartCommit(ctx, transactionID, dtid)
+}
+
+// SetRollback marks the distributed transaction dtid for rollback.
+func (sg *shardGateway) SetRollback(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType, dtid string, transactionID int64) error {
+	return sg.getConnection(ctx, keyspace, shard, tabletType).SetRollback(ctx, dtid, transactionID)
+}
+
+// ConcludeTransaction deletes the record of the distributed transaction dtid.
+func (sg *shardGateway) ConcludeTransaction(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType, dtid string) error {
+	return sg.getConnection(ctx, keyspace, shard, tabletType).ConcludeTransaction(ctx, dtid)
+}
+
+// ReadTransaction returns the record of the distributed transaction dtid.
+func (sg *shardGateway) ReadTransaction(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType, dtid string) (*querypb.TransactionMetadata, error) {
+	return sg.getConnection(ctx, keyspace, shard, tabletType).ReadTransaction(ctx, dtid)
+}
+
 // SplitQuery splits a query into sub-queries for the specified keyspace, shard, and tablet type.
 func (sg *shardGateway) SplitQuery(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType, sql string, bindVars map[string]interface{}, splitColumn string, splitCount int64) ([]querytypes.QuerySplit, error) {
 	return sg.getConnection(ctx, keyspace, shard, tabletType).SplitQuery(ctx, sql, bindVars, splitColumn, splitCount)
@@ -143,6 +202,9 @@ func (sg *shardGateway) SplitQuery(ctx context.Context, keyspace string, shard s
 
 // Close shuts down the underlying connections.
 func (sg *shardGateway) Close(ctx context.Context) error {
+	for _, ctw := range sg.tabletsWatchers {
+		ctw.Stop()
+	}
 	sg.mu.Lock()
 	defer sg.mu.Unlock()
 	for _, v := range sg.shardConns {
@@ -160,6 +222,9 @@ func (sg *shardGateway) getConnection(ctx context.Context, keyspace, shard strin
 	sdc, ok := sg.shardConns[key]
 	if !ok {
 		sdc = NewShardConn(ctx, sg.toposerv, sg.cell, keyspace, shard, tabletType, sg.retryDelay, sg.retryCount, sg.connTimeoutTotal, sg.connTimeoutPerConn, sg.connLife, sg.connTimings)
+		if tabletType == topodatapb.TabletType_MASTER {
+			sdc.buffer = sg.buffer
+		}
 		sg.shardConns[key] = sdc
 	}
 	return sdc
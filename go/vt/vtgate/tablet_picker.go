@@ -0,0 +1,188 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"math/rand"
+	"time"
+
+	log "github.com/golang/glog"
+
+	"github.com/youtube/vitess/go/ewma"
+	"github.com/youtube/vitess/go/vt/discovery"
+	"github.com/youtube/vitess/go/vt/estimator"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var (
+	// TabletPickerImplementation controls the implementation of TabletPicker.
+	TabletPickerImplementation = flag.String("tablet_picker", "random", "the strategy discoverygateway uses to pick a tablet among the usable ones: random, weighted or power_of_two")
+)
+
+const (
+	tabletPickerRandom     = "random"
+	tabletPickerWeighted   = "weighted"
+	tabletPickerPowerOfTwo = "power_of_two"
+)
+
+func init() {
+	RegisterTabletPickerCreator(tabletPickerRandom, func() TabletPicker {
+		return &randomPicker{newTabletWeigher()}
+	})
+	RegisterTabletPickerCreator(tabletPickerWeighted, func() TabletPicker {
+		return &weightedPicker{newTabletWeigher()}
+	})
+	RegisterTabletPickerCreator(tabletPickerPowerOfTwo, func() TabletPicker {
+		return &powerOfTwoPicker{newTabletWeigher()}
+	})
+}
+
+// A TabletPicker picks the tablet that serves a request, among
+// the usable endpoints of a target. It's used by discoveryGateway.
+type TabletPicker interface {
+	// Pick returns the endpoint of epsList that serves the request.
+	// epsList is never empty.
+	Pick(epsList []*discovery.EndPointStats) *discovery.EndPointStats
+
+	// RecordLatency records the latency of a request served by endPoint.
+	RecordLatency(endPoint *topodatapb.EndPoint, latency time.Duration)
+
+	// Weight returns the current weight of an endpoint. The higher
+	// the weight, the more the endpoint is likely to be picked.
+	Weight(eps *discovery.EndPointStats) float64
+
+	// Latency returns the latency EWMA of an endpoint.
+	Latency(endPoint *topodatapb.EndPoint) time.Duration
+}
+
+// TabletPickerCreator is the func which can create the actual TabletPicker object.
+type TabletPickerCreator func() TabletPicker
+
+var tabletPickerCreators = make(map[string]TabletPickerCreator)
+
+// RegisterTabletPickerCreator registers a TabletPickerCreator with given name.
+func RegisterTabletPickerCreator(name string, tpc TabletPickerCreator) {
+	if _, ok := tabletPickerCreators[name]; ok {
+		log.Fatalf("TabletPicker %s already exists", name)
+	}
+	tabletPickerCreators[name] = tpc
+}
+
+// GetTabletPickerCreator returns the TabletPickerCreator specified by TabletPickerImplementation flag.
+func GetTabletPickerCreator() TabletPickerCreator {
+	tpc, ok := tabletPickerCreators[*TabletPickerImplementation]
+	if !ok {
+		log.Fatalf("No tablet picker registered as %s", *TabletPickerImplementation)
+	}
+	return tpc
+}
+
+// tabletWeigher weighs the tablets by the RealtimeStats they stream
+// and by the latency vtgate observes. The cost of a tablet is the
+// product of (1 + its latency EWMA in milliseconds), (1 + its CPU
+// usage) and (1 + its replication lag in seconds), and its weight
+// is the inverse of its cost. A tablet without latency history
+// is considered fast, so that it gets requests.
+type tabletWeigher struct {
+	latency *estimator.Estimator
+}
+
+func newTabletWeigher() tabletWeigher {
+	return tabletWeigher{
+		latency: estimator.NewEstimator(estimator.DefaultCapacity, ewma.DefaultWeightingFactor),
+	}
+}
+
+// RecordLatency is part of the TabletPicker interface.
+func (tw tabletWeigher) RecordLatency(endPoint *topodatapb.EndPoint, latency time.Duration) {
+	tw.latency.AddHistory(discovery.EndPointToMapKey(endPoint), float64(latency))
+}
+
+// Latency is part of the TabletPicker interface.
+func (tw tabletWeigher) Latency(endPoint *topodatapb.EndPoint) time.Duration {
+	return time.Duration(tw.latency.Estimate(discovery.EndPointToMapKey(endPoint)))
+}
+
+// Weight is part of the TabletPicker interface.
+func (tw tabletWeigher) Weight(eps *discovery.EndPointStats) float64 {
+	cost := 1 + tw.Latency(eps.EndPoint).Seconds()*1000
+	if eps.Stats != nil {
+		cost *= 1 + eps.Stats.CpuUsage
+		cost *= 1 + float64(eps.Stats.SecondsBehindMaster)
+	}
+	return 1 / cost
+}
+
+// randomPicker picks a tablet at random, regardless of its weight.
+type randomPicker struct {
+	tabletWeigher
+}
+
+// Pick is part of the TabletPicker interface.
+func (rp *randomPicker) Pick(epsList []*discovery.EndPointStats) *discovery.EndPointStats {
+	return epsList[rand.Intn(len(epsList))]
+}
+
+// weightedPicker picks a tablet at random, with a probability
+// proportional to its weight.
+type weightedPicker struct {
+	tabletWeigher
+}
+
+// Pick is part of the TabletPicker interface.
+func (wp *weightedPicker) Pick(epsList []*discovery.EndPointStats) *discovery.EndPointStats {
+	weights := make([]float64, len(epsList))
+	var total float64
+	for i, eps := range epsList {
+		weights[i] = wp.Weight(eps)
+		total += weights[i]
+	}
+	r := rand.Float64() * total
+	for i, w := range weights {
+		if r < w {
+			return epsList[i]
+		}
+		r -= w
+	}
+	return epsList[len(epsList)-1]
+}
+
+// powerOfTwoPicker picks two tablets at random, and uses the one
+// with the highest weight. It avoids the slow tablets without
+// sending all the requests to the fastest one.
+type powerOfTwoPicker struct {
+	tabletWeigher
+}
+
+// Pick is part of the TabletPicker interface.
+func (pp *powerOfTwoPicker) Pick(epsList []*discovery.EndPointStats) *discovery.EndPointStats {
+	if len(epsList) == 1 {
+		return epsList[0]
+	}
+	i := rand.Intn(len(epsList))
+	j := rand.Intn(len(epsList) - 1)
+	if j >= i {
+		j++
+	}
+	if pp.Weight(epsList[j]) > pp.Weight(epsList[i]) {
+		return epsList[j]
+	}
+	return epsList[i]
+}
+
+// TabletWeight is the current weight of a tablet, for the status page.
+type TabletWeight struct {
+	Cell                string
+	Name                string
+	Target              *querypb.Target
+	EndPoint            *topodatapb.EndPoint
+	Latency             time.Duration
+	CPUUsage            float64
+	SecondsBehindMaster uint32
+	Weight              float64
+}
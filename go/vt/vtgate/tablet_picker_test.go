@@ -0,0 +1,130 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/discovery"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func pickerTestStats(host string, cpuUsage float64, lag uint32) *discovery.EndPointStats {
+	ep := topo.NewEndPoint(0, host)
+	ep.PortMap["vt"] = 1
+	return &discovery.EndPointStats{
+		EndPoint: ep,
+		Serving:  true,
+		Stats: &querypb.RealtimeStats{
+			CpuUsage:            cpuUsage,
+			SecondsBehindMaster: lag,
+		},
+	}
+}
+
+func TestTabletWeigher(t *testing.T) {
+	tw := newTabletWeigher()
+	idle := pickerTestStats("idle", 0, 0)
+	busy := pickerTestStats("busy", 0.5, 0)
+	lagging := pickerTestStats("lagging", 0, 10)
+	if got := tw.Weight(idle); got != 1 {
+		t.Errorf("Weight(idle): %v, want 1", got)
+	}
+	if got := tw.Weight(busy); got >= tw.Weight(idle) {
+		t.Errorf("Weight(busy): %v, want less than Weight(idle)", got)
+	}
+	if got := tw.Weight(lagging); got >= tw.Weight(busy) {
+		t.Errorf("Weight(lagging): %v, want less than Weight(busy)", got)
+	}
+
+	tw.RecordLatency(idle.EndPoint, 10*time.Millisecond)
+	if got := tw.Latency(idle.EndPoint); got != 10*time.Millisecond {
+		t.Errorf("Latency(idle): %v, want 10ms", got)
+	}
+	if got, want := tw.Weight(idle), 1.0/11; got != want {
+		t.Errorf("Weight(idle): %v, want %v", got, want)
+	}
+}
+
+func TestPowerOfTwoPicker(t *testing.T) {
+	pp := &powerOfTwoPicker{newTabletWeigher()}
+	fast := pickerTestStats("fast", 0, 0)
+	slow := pickerTestStats("slow", 0, 0)
+	pp.RecordLatency(slow.EndPoint, 100*time.Millisecond)
+	epsList := []*discovery.EndPointStats{fast, slow}
+	for i := 0; i < 10; i++ {
+		if got := pp.Pick(epsList); got != fast {
+			t.Fatalf("Pick: %v, want fast", got.EndPoint.Host)
+		}
+	}
+	if got := pp.Pick(epsList[1:]); got != slow {
+		t.Errorf("Pick: %v, want slow", got.EndPoint.Host)
+	}
+}
+
+func TestWeightedPicker(t *testing.T) {
+	wp := &weightedPicker{newTabletWeigher()}
+	fast := pickerTestStats("fast", 0, 0)
+	slow := pickerTestStats("slow", 0, 0)
+	wp.RecordLatency(slow.EndPoint, 1000*time.Millisecond)
+	epsList := []*discovery.EndPointStats{fast, slow}
+	picks := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		picks[wp.Pick(epsList).EndPoint.Host]++
+	}
+	// slow has a 1/1002 chance to be picked.
+	if picks["fast"] < 990 {
+		t.Errorf("picks: %v, want fast to be picked most of the time", picks)
+	}
+}
+
+func TestDiscoveryGatewayRecordLatency(t *testing.T) {
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "cell", time.Millisecond, 2, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+	ep := hc.addTestEndPoint("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil, &sandboxConn{})
+	if _, err := dg.Execute(context.Background(), "ks", "0", topodatapb.TabletType_REPLICA, "query", nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if dg.picker.Latency(ep) == 0 {
+		t.Errorf("Latency: 0, want the latency of Execute")
+	}
+}
+
+func TestDiscoveryGatewayRecordErrorLatency(t *testing.T) {
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "cell", time.Millisecond, 2, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+	sbc := &sandboxConn{}
+	hc.addTestEndPoint("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil, sbc)
+	eps := dg.getEndPoints("ks", "0", topodatapb.TabletType_REPLICA)[0]
+	if _, err := dg.Execute(context.Background(), "ks", "0", topodatapb.TabletType_REPLICA, "query", nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	weight := dg.picker.Weight(eps)
+
+	// The tablet starts failing fast: its weight drops.
+	sbc.mustFailConn = 1
+	if _, err := dg.Execute(context.Background(), "ks", "0", topodatapb.TabletType_REPLICA, "query", nil, 0); err == nil {
+		t.Fatalf("Execute: nil, want error")
+	}
+	if got := dg.picker.Weight(eps); got >= weight {
+		t.Errorf("Weight after a tablet error: %v, want less than %v", got, weight)
+	}
+
+	// The errors of the query itself don't.
+	latency := dg.picker.Latency(eps.EndPoint)
+	sbc.mustFailServer = 1
+	if _, err := dg.Execute(context.Background(), "ks", "0", topodatapb.TabletType_REPLICA, "query", nil, 0); err == nil {
+		t.Fatalf("Execute: nil, want error")
+	}
+	if got := dg.picker.Latency(eps.EndPoint); got >= latency {
+		t.Errorf("Latency after a query error: %v, want less than %v", got, latency)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/concurrency"
+	"github.com/youtube/vitess/go/vt/vterrors"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+var enableTwoPC = flag.Bool("enable_twopc", false, "use two-phase commit for transactions that span multiple shards")
+
+// twoPCCommit commits the transaction of session atomically.
+// The first shard of the session is the coordinator (mm). The
+// transaction is recorded in mm, the other shards are prepared,
+// and the transaction of mm is committed along with the decision
+// to commit. After that, the prepared transactions are committed.
+// If vtgate goes away before it's done, the watchdog of mm
+// resolves the transaction through ResolveTransaction.
+func (stc *ScatterConn) twoPCCommit(ctx context.Context, session *SafeSession) error {
+	mm := session.ShardSessions[0]
+	participants := make([]*querypb.Target, 0, len(session.ShardSessions)-1)
+	for _, s := range session.ShardSessions[1:] {
+		participants = append(participants, s.Target)
+	}
+	dtid := generateDTID(mm)
+	if err := stc.gateway.CreateTransaction(ctx, mm.Target.Keyspace, mm.Target.Shard, mm.Target.TabletType, dtid, participants); err != nil {
+		stc.rollbackShardSessions(ctx, session.ShardSessions)
+		return err
+	}
+
+	var mu sync.Mutex
+	var unprepared []*vtgatepb.Session_ShardSession
+	err := stc.runTwoPC(ctx, session.ShardSessions[1:], func(s *vtgatepb.Session_ShardSession) error {
+		if err := stc.gateway.Prepare(ctx, s.Target.Keyspace, s.Target.Shard, s.Target.TabletType, s.TransactionId, dtid); err != nil {
+			mu.Lock()
+			unprepared = append(unprepared, s)
+			mu.Unlock()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		if rerr := stc.rollbackTwoPC(ctx, session.ShardSessions, dtid); rerr != nil {
+			// The decision to roll back was not recorded, so the
+			// watchdog of mm will roll back the prepared transactions.
+			// The others are not known to it, and are rolled back
+			// now instead of holding their locks until they time out.
+			stc.rollbackShardSessions(ctx, append([]*vtgatepb.Session_ShardSession{mm}, unprepared...))
+			return rerr
+		}
+		return err
+	}
+
+	// Once StartCommit succeeds, the transaction can only be
+	// committed. If it fails, it's not known if the decision
+	// was recorded, so the watchdog is left to resolve it.
+	if err := stc.gateway.StartCommit(ctx, mm.Target.Keyspace, mm.Target.Shard, mm.Target.TabletType, mm.TransactionId, dtid); err != nil {
+		return err
+	}
+	return stc.commitTwoPC(ctx, mm.Target, session.ShardSessions[1:], dtid)
+}
+
+// ResolveTransaction resolves the distributed transaction dtid,
+// which was abandoned by the vtgate that started it. If the
+// decision to commit was recorded, the transaction is committed.
+// Otherwise, it's rolled back.
+func (stc *ScatterConn) ResolveTransaction(ctx context.Context, dtid string) error {
+	mm, err := dtidTarget(dtid)
+	if err != nil {
+		return err
+	}
+	metadata, err := stc.gateway.ReadTransaction(ctx, mm.Keyspace, mm.Shard, mm.TabletType, dtid)
+	if err != nil {
+		return err
+	}
+	if metadata == nil || metadata.Dtid == "" {
+		// The transaction was already resolved.
+		return nil
+	}
+	participants := make([]*vtgatepb.Session_ShardSession, 0, len(metadata.Participants))
+	for _, target := range metadata.Participants {
+		participants = append(participants, &vtgatepb.Session_ShardSession{Target: target})
+	}
+	switch metadata.State {
+	case querypb.TransactionState_PREPARE:
+		// The decision to commit was never made.
+		if err := stc.gateway.SetRollback(ctx, mm.Keyspace, mm.Shard, mm.TabletType, dtid, 0); err != nil {
+			return err
+		}
+		return stc.concludeRollback(ctx, mm, participants, dtid)
+	case querypb.TransactionState_ROLLBACK:
+		return stc.concludeRollback(ctx, mm, participants, dtid)
+	case querypb.TransactionState_COMMIT:
+		return stc.commitTwoPC(ctx, mm, participants, dtid)
+	}
+	return vterrors.FromError(
+		vtrpcpb.ErrorCode_INTERNAL_ERROR,
+		fmt.Errorf("invalid state for distributed transaction %s: %v", dtid, metadata.State),
+	)
+}
+
+// rollbackTwoPC records the decision to roll back on the coordinator,
+// which is the first of shardSessions, and rolls back all the shards.
+func (stc *ScatterConn) rollbackTwoPC(ctx context.Context, shardSessions []*vtgatepb.Session_ShardSession, dtid string) error {
+	mm := shardSessions[0]
+	if err := stc.gateway.SetRollback(ctx, mm.Target.Keyspace, mm.Target.Shard, mm.Target.TabletType, dtid, mm.TransactionId); err != nil {
+		return err
+	}
+	return stc.concludeRollback(ctx, mm.Target, shardSessions[1:], dtid)
+}
+
+// concludeRollback rolls back the participants of dtid, and deletes
+// its record from the coordinator mm.
+func (stc *ScatterConn) concludeRollback(ctx context.Context, mm *querypb.Target, participants []*vtgatepb.Session_ShardSession, dtid string) error {
+	err := stc.runTwoPC(ctx, participants, func(s *vtgatepb.Session_ShardSession) error {
+		return stc.gateway.RollbackPrepared(ctx, s.Target.Keyspace, s.Target.Shard, s.Target.TabletType, dtid, s.TransactionId)
+	})
+	if err != nil {
+		return err
+	}
+	return stc.gateway.ConcludeTransaction(ctx, mm.Keyspace, mm.Shard, mm.TabletType, dtid)
+}
+
+// commitTwoPC commits the prepared transactions of the participants
+// of dtid, and deletes its record from the coordinator mm.
+func (stc *ScatterConn) commitTwoPC(ctx context.Context, mm *querypb.Target, participants []*vtgatepb.Session_ShardSession, dtid string) error {
+	err := stc.runTwoPC(ctx, participants, func(s *vtgatepb.Session_ShardSession) error {
+		return stc.gateway.CommitPrepared(ctx, s.Target.Keyspace, s.Target.Shard, s.Target.TabletType, dtid)
+	})
+	if err != nil {
+		return err
+	}
+	return stc.gateway.ConcludeTransaction(ctx, mm.Keyspace, mm.Shard, mm.TabletType, dtid)
+}
+
+// runTwoPC runs action on all the shard sessions in parallel,
+// and returns the aggregated errors.
+func (stc *ScatterConn) runTwoPC(ctx context.Context, shardSessions []*vtgatepb.Session_ShardSession, action func(*vtgatepb.Session_ShardSession) error) error {
+	var wg sync.WaitGroup
+	allErrors := new(concurrency.AllErrorRecorder)
+	for _, s := range shardSessions {
+		wg.Add(1)
+		go func(s *vtgatepb.Session_ShardSession) {
+			defer wg.Done()
+			if err := action(s); err != nil {
+				allErrors.RecordError(err)
+			}
+		}(s)
+	}
+	wg.Wait()
+	return stc.aggregateErrors(allErrors.Errors)
+}
+
+// rollbackShardSessions rolls back the transactions of all
+// the shard sessions, ignoring errors.
+func (stc *ScatterConn) rollbackShardSessions(ctx context.Context, shardSessions []*vtgatepb.Session_ShardSession) {
+	for _, s := range shardSessions {
+		stc.gateway.Rollback(ctx, s.Target.Keyspace, s.Target.Shard, s.Target.TabletType, s.TransactionId)
+	}
+}
+
+// generateDTID returns a dtid that identifies the distributed
+// transaction by the transaction of its coordinator mm.
+func generateDTID(mm *vtgatepb.Session_ShardSession) string {
+	return fmt.Sprintf("%s:%s:%d", mm.Target.Keyspace, mm.Target.Shard, mm.TransactionId)
+}
+
+// dtidTarget returns the target of the coordinator of dtid.
+func dtidTarget(dtid string) (*querypb.Target, error) {
+	splits := strings.Split(dtid, ":")
+	if len(splits) != 3 {
+		return nil, vterrors.FromError(
+			vtrpcpb.ErrorCode_BAD_INPUT,
+			fmt.Errorf("invalid dtid: %s", dtid),
+		)
+	}
+	if _, err := strconv.ParseInt(splits[2], 10, 64); err != nil {
+		return nil, vterrors.FromError(
+			vtrpcpb.ErrorCode_BAD_INPUT,
+			fmt.Errorf("invalid dtid: %s", dtid),
+		)
+	}
+	return &querypb.Target{
+		Keyspace:   splits[0],
+		Shard:      splits[1],
+		TabletType: topodatapb.TabletType_MASTER,
+	}, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/topo"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+func twoPCSetup(t *testing.T, name string) (*ScatterConn, *sandboxConn, *sandboxConn, *SafeSession) {
+	s := createSandbox(name)
+	sbc0 := &sandboxConn{}
+	s.MapTestConn("0", sbc0)
+	sbc1 := &sandboxConn{}
+	s.MapTestConn("1", sbc1)
+	stc := NewScatterConn(nil, topo.Server{}, new(sandboxTopo), "", "aa", retryDelay, retryCount, connTimeoutTotal, connTimeoutPerConn, connLife, nil, "")
+	// Sequence the executes to make shard 0 the coordinator.
+	session := NewSafeSession(&vtgatepb.Session{InTransaction: true})
+	for _, shard := range []string{"0", "1"} {
+		if _, err := stc.Execute(context.Background(), "query1", nil, name, []string{shard}, topodatapb.TabletType_MASTER, session, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return stc, sbc0, sbc1, session
+}
+
+func checkCount(t *testing.T, name string, got int64, want int64) {
+	if got != want {
+		t.Errorf("%s: %d, want %d", name, got, want)
+	}
+}
+
+func TestTwoPCCommit(t *testing.T) {
+	*enableTwoPC = true
+	defer func() { *enableTwoPC = false }()
+	stc, sbc0, sbc1, session := twoPCSetup(t, "TestTwoPCCommit")
+
+	if err := stc.Commit(context.Background(), session); err != nil {
+		t.Fatal(err)
+	}
+	if session.InTransaction() {
+		t.Errorf("session.InTransaction: true, want false")
+	}
+	checkCount(t, "sbc0.CreateTransactionCount", sbc0.CreateTransactionCount.Get(), 1)
+	checkCount(t, "sbc1.PrepareCount", sbc1.PrepareCount.Get(), 1)
+	checkCount(t, "sbc0.StartCommitCount", sbc0.StartCommitCount.Get(), 1)
+	checkCount(t, "sbc1.CommitPreparedCount", sbc1.CommitPreparedCount.Get(), 1)
+	checkCount(t, "sbc0.ConcludeTransactionCount", sbc0.ConcludeTransactionCount.Get(), 1)
+	checkCount(t, "sbc0.CommitCount", sbc0.CommitCount.Get(), 0)
+	checkCount(t, "sbc1.CommitCount", sbc1.CommitCount.Get(), 0)
+}
+
+func TestTwoPCCommitPrepareFail(t *testing.T) {
+	*enableTwoPC = true
+	defer func() { *enableTwoPC = false }()
+	stc, sbc0, sbc1, session := twoPCSetup(t, "TestTwoPCCommitPrepareFail")
+
+	sbc1.mustFailServer = 1
+	if err := stc.Commit(context.Background(), session); err == nil {
+		t.Errorf("Commit: nil, want error")
+	}
+	checkCount(t, "sbc1.PrepareCount", sbc1.PrepareCount.Get(), 1)
+	checkCount(t, "sbc0.SetRollbackCount", sbc0.SetRollbackCount.Get(), 1)
+	checkCount(t, "sbc1.RollbackPreparedCount", sbc1.RollbackPreparedCount.Get(), 1)
+	checkCount(t, "sbc0.ConcludeTransactionCount", sbc0.ConcludeTransactionCount.Get(), 1)
+	checkCount(t, "sbc0.StartCommitCount", sbc0.StartCommitCount.Get(), 0)
+}
+
+func TestTwoPCCommitSetRollbackFail(t *testing.T) {
+	*enableTwoPC = true
+	defer func() { *enableTwoPC = false }()
+	stc, sbc0, sbc1, session := twoPCSetup(t, "TestTwoPCCommitSetRollbackFail")
+
+	sbc1.mustFailServer = 1
+	sbc0.onConnUse = func(sbc *sandboxConn) {
+		if sbc.SetRollbackCount.Get() == 1 {
+			sbc.mustFailServer = 1
+			sbc.onConnUse = nil
+		}
+	}
+	if err := stc.Commit(context.Background(), session); err == nil {
+		t.Errorf("Commit: nil, want error")
+	}
+	// The transactions that were not prepared are rolled back.
+	checkCount(t, "sbc1.PrepareCount", sbc1.PrepareCount.Get(), 1)
+	checkCount(t, "sbc0.SetRollbackCount", sbc0.SetRollbackCount.Get(), 1)
+	checkCount(t, "sbc0.RollbackCount", sbc0.RollbackCount.Get(), 1)
+	checkCount(t, "sbc1.RollbackCount", sbc1.RollbackCount.Get(), 1)
+	checkCount(t, "sbc1.RollbackPreparedCount", sbc1.RollbackPreparedCount.Get(), 0)
+	checkCount(t, "sbc0.ConcludeTransactionCount", sbc0.ConcludeTransactionCount.Get(), 0)
+}
+
+func TestTwoPCCommitStartCommitFail(t *testing.T) {
+	*enableTwoPC = true
+	defer func() { *enableTwoPC = false }()
+	stc, sbc0, sbc1, session := twoPCSetup(t, "TestTwoPCCommitStartCommitFail")
+
+	sbc0.onConnUse = func(sbc *sandboxConn) {
+		if sbc.StartCommitCount.Get() == 1 {
+			sbc.mustFailServer = 1
+			sbc.onConnUse = nil
+		}
+	}
+	if err := stc.Commit(context.Background(), session); err == nil {
+		t.Errorf("Commit: nil, want error")
+	}
+	// The prepared transactions are left for the watchdog.
+	checkCount(t, "sbc1.CommitPreparedCount", sbc1.CommitPreparedCount.Get(), 0)
+	checkCount(t, "sbc1.RollbackPreparedCount", sbc1.RollbackPreparedCount.Get(), 0)
+	checkCount(t, "sbc0.ConcludeTransactionCount", sbc0.ConcludeTransactionCount.Get(), 0)
+}
+
+func TestTwoPCSingleShard(t *testing.T) {
+	*enableTwoPC = true
+	defer func() { *enableTwoPC = false }()
+	s := createSandbox("TestTwoPCSingleShard")
+	sbc0 := &sandboxConn{}
+	s.MapTestConn("0", sbc0)
+	stc := NewScatterConn(nil, topo.Server{}, new(sandboxTopo), "", "aa", retryDelay, retryCount, connTimeoutTotal, connTimeoutPerConn, connLife, nil, "")
+	session := NewSafeSession(&vtgatepb.Session{InTransaction: true})
+	stc.Execute(context.Background(), "query1", nil, "TestTwoPCSingleShard", []string{"0"}, topodatapb.TabletType_MASTER, session, false)
+
+	if err := stc.Commit(context.Background(), session); err != nil {
+		t.Fatal(err)
+	}
+	checkCount(t, "sbc0.CommitCount", sbc0.CommitCount.Get(), 1)
+	checkCount(t, "sbc0.CreateTransactionCount", sbc0.CreateTransactionCount.Get(), 0)
+}
+
+func TestResolveTransaction(t *testing.T) {
+	stc, sbc0, sbc1, _ := twoPCSetup(t, "TestResolveTransaction")
+	dtid := "TestResolveTransaction:0:1"
+	participants := []*querypb.Target{{
+		Keyspace:   "TestResolveTransaction",
+		Shard:      "1",
+		TabletType: topodatapb.TabletType_MASTER,
+	}}
+
+	// Nothing to resolve.
+	if err := stc.ResolveTransaction(context.Background(), dtid); err != nil {
+		t.Fatal(err)
+	}
+	checkCount(t, "sbc0.ReadTransactionCount", sbc0.ReadTransactionCount.Get(), 1)
+	checkCount(t, "sbc0.ConcludeTransactionCount", sbc0.ConcludeTransactionCount.Get(), 0)
+
+	sbc0.ReadTransactionResult = &querypb.TransactionMetadata{
+		Dtid:         dtid,
+		State:        querypb.TransactionState_PREPARE,
+		Participants: participants,
+	}
+	if err := stc.ResolveTransaction(context.Background(), dtid); err != nil {
+		t.Fatal(err)
+	}
+	checkCount(t, "sbc0.SetRollbackCount", sbc0.SetRollbackCount.Get(), 1)
+	checkCount(t, "sbc1.RollbackPreparedCount", sbc1.RollbackPreparedCount.Get(), 1)
+	checkCount(t, "sbc0.ConcludeTransactionCount", sbc0.ConcludeTransactionCount.Get(), 1)
+
+	sbc0.ReadTransactionResult.State = querypb.TransactionState_ROLLBACK
+	if err := stc.ResolveTransaction(context.Background(), dtid); err != nil {
+		t.Fatal(err)
+	}
+	checkCount(t, "sbc0.SetRollbackCount", sbc0.SetRollbackCount.Get(), 1)
+	checkCount(t, "sbc1.RollbackPreparedCount", sbc1.RollbackPreparedCount.Get(), 2)
+	checkCount(t, "sbc0.ConcludeTransactionCount", sbc0.ConcludeTransactionCount.Get(), 2)
+
+	sbc0.ReadTransactionResult.State = querypb.TransactionState_COMMIT
+	if err := stc.ResolveTransaction(context.Background(), dtid); err != nil {
+		t.Fatal(err)
+	}
+	checkCount(t, "sbc1.CommitPreparedCount", sbc1.CommitPreparedCount.Get(), 1)
+	checkCount(t, "sbc0.ConcludeTransactionCount", sbc0.ConcludeTransactionCount.Get(), 3)
+
+	want := "invalid dtid: aa"
+	if err := stc.ResolveTransaction(context.Background(), "aa"); err == nil || err.Error() != want {
+		t.Errorf("ResolveTransaction(aa): %v, want %s", err, want)
+	}
+}
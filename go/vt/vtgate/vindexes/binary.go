@@ -0,0 +1,72 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+// Binary defines a vindex that uses the binary value of the id
+// as the keyspace id. It's meant for ids that are already evenly
+// distributed, like random tokens. It's Unique and Reversible.
+type Binary struct{}
+
+// NewBinary creates a Binary vindex.
+func NewBinary(_ map[string]interface{}) (planbuilder.Vindex, error) {
+	return Binary{}, nil
+}
+
+// Cost returns the cost of this vindex as 0.
+func (Binary) Cost() int {
+	return 0
+}
+
+// Verify returns true if id and ksid match.
+func (Binary) Verify(_ planbuilder.VCursor, id interface{}, ksid []byte) (bool, error) {
+	data, err := getBytes(id)
+	if err != nil {
+		return false, fmt.Errorf("Binary.Verify: %v", err)
+	}
+	return bytes.Compare(data, ksid) == 0, nil
+}
+
+// Map returns the corresponding keyspace ids for the given ids.
+func (Binary) Map(_ planbuilder.VCursor, ids []interface{}) ([][]byte, error) {
+	out := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		data, err := getBytes(id)
+		if err != nil {
+			return nil, fmt.Errorf("Binary.Map: %v", err)
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// ReverseMap returns the id for the ksid.
+func (Binary) ReverseMap(_ planbuilder.VCursor, ksid []byte) (interface{}, error) {
+	if ksid == nil {
+		return nil, fmt.Errorf("Binary.ReverseMap: keyspace id is nil")
+	}
+	return []byte(ksid), nil
+}
+
+// getBytes returns the binary value of a string or []byte id.
+func getBytes(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	}
+	return nil, fmt.Errorf("unexpected type for %v: %T", v, v)
+}
+
+func init() {
+	planbuilder.Register("binary", NewBinary)
+}
@@ -0,0 +1,60 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+// BinaryMD5 defines a vindex that hashes the binary value of
+// the id with MD5 to compute the keyspace id. It's meant for
+// string ids like UUIDs or email addresses that must be compared
+// byte by byte. It's Unique, but not Reversible.
+type BinaryMD5 struct{}
+
+// NewBinaryMD5 creates a BinaryMD5 vindex.
+func NewBinaryMD5(_ map[string]interface{}) (planbuilder.Vindex, error) {
+	return BinaryMD5{}, nil
+}
+
+// Cost returns the cost of this vindex as 1.
+func (BinaryMD5) Cost() int {
+	return 1
+}
+
+// Verify returns true if id maps to ksid.
+func (BinaryMD5) Verify(_ planbuilder.VCursor, id interface{}, ksid []byte) (bool, error) {
+	data, err := getBytes(id)
+	if err != nil {
+		return false, fmt.Errorf("BinaryMD5.Verify: %v", err)
+	}
+	return bytes.Compare(binHash(data), ksid) == 0, nil
+}
+
+// Map returns the corresponding keyspace ids for the given ids.
+func (BinaryMD5) Map(_ planbuilder.VCursor, ids []interface{}) ([][]byte, error) {
+	out := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		data, err := getBytes(id)
+		if err != nil {
+			return nil, fmt.Errorf("BinaryMD5.Map: %v", err)
+		}
+		out = append(out, binHash(data))
+	}
+	return out, nil
+}
+
+func binHash(source []byte) []byte {
+	sum := md5.Sum(source)
+	return sum[:]
+}
+
+func init() {
+	planbuilder.Register("binary_md5", NewBinaryMD5)
+}
@@ -0,0 +1,56 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+var binVindex planbuilder.Vindex
+
+func init() {
+	binVindex, _ = planbuilder.CreateVindex("binary_md5", nil)
+}
+
+func TestBinaryMD5Cost(t *testing.T) {
+	if binVindex.Cost() != 1 {
+		t.Errorf("Cost(): %d, want 1", binVindex.Cost())
+	}
+}
+
+func TestBinaryMD5Map(t *testing.T) {
+	got, err := binVindex.(planbuilder.Unique).Map(nil, []interface{}{[]byte("test"), "Test"})
+	if err != nil {
+		t.Error(err)
+	}
+	want := [][]byte{
+		[]byte("\x09\x8f\x6b\xcd\x46\x21\xd3\x73\xca\xde\x4e\x83\x26\x27\xb4\xf6"),
+		[]byte("\x0c\xbc\x66\x11\xf5\x54\x0b\xd0\x80\x9a\x38\x8d\xc9\x5a\x61\x5b"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map(): %#v, want %#v", got, want)
+	}
+}
+
+func TestBinaryMD5MapBadData(t *testing.T) {
+	_, err := binVindex.(planbuilder.Unique).Map(nil, []interface{}{1})
+	want := `BinaryMD5.Map: unexpected type for 1: int`
+	if err == nil || err.Error() != want {
+		t.Errorf("Map: %v, want %v", err, want)
+	}
+}
+
+func TestBinaryMD5Verify(t *testing.T) {
+	success, err := binVindex.Verify(nil, "test", []byte("\x09\x8f\x6b\xcd\x46\x21\xd3\x73\xca\xde\x4e\x83\x26\x27\xb4\xf6"))
+	if err != nil {
+		t.Error(err)
+	}
+	if !success {
+		t.Errorf("Verify(): %+v, want true", success)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+var binOnlyVindex planbuilder.Vindex
+
+func init() {
+	binOnlyVindex, _ = planbuilder.CreateVindex("binary", nil)
+}
+
+func TestBinaryCost(t *testing.T) {
+	if binOnlyVindex.Cost() != 0 {
+		t.Errorf("Cost(): %d, want 0", binOnlyVindex.Cost())
+	}
+}
+
+func TestBinaryMap(t *testing.T) {
+	got, err := binOnlyVindex.(planbuilder.Unique).Map(nil, []interface{}{[]byte("test1"), "test2"})
+	if err != nil {
+		t.Error(err)
+	}
+	want := [][]byte{
+		[]byte("test1"),
+		[]byte("test2"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map(): %#v, want %#v", got, want)
+	}
+}
+
+func TestBinaryMapBadData(t *testing.T) {
+	_, err := binOnlyVindex.(planbuilder.Unique).Map(nil, []interface{}{1})
+	want := `Binary.Map: unexpected type for 1: int`
+	if err == nil || err.Error() != want {
+		t.Errorf("Map: %v, want %v", err, want)
+	}
+}
+
+func TestBinaryVerify(t *testing.T) {
+	success, err := binOnlyVindex.Verify(nil, []byte("test1"), []byte("test1"))
+	if err != nil {
+		t.Error(err)
+	}
+	if !success {
+		t.Errorf("Verify(): %+v, want true", success)
+	}
+	success, err = binOnlyVindex.Verify(nil, "test1", []byte("test2"))
+	if err != nil {
+		t.Error(err)
+	}
+	if success {
+		t.Errorf("Verify(): %+v, want false", success)
+	}
+}
+
+func TestBinaryReverseMap(t *testing.T) {
+	got, err := binOnlyVindex.(planbuilder.Reversible).ReverseMap(nil, []byte("test1"))
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(got, []byte("test1")) {
+		t.Errorf("ReverseMap(): %+v, want test1", got)
+	}
+
+	_, err = binOnlyVindex.(planbuilder.Reversible).ReverseMap(nil, nil)
+	want := "Binary.ReverseMap: keyspace id is nil"
+	if err == nil || err.Error() != want {
+		t.Errorf("ReverseMap: %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+func init() {
+	planbuilder.Register("lookup", NewLookupNonUnique)
+	planbuilder.Register("lookup_unique", NewLookupUnique)
+}
+
+//====================================================================
+
+// LookupNonUnique defines a vindex that uses a lookup table.
+// Unlike LookupHash, the To column of the table stores the
+// keyspace id itself, which lets an id map to any keyspace id.
+// It's NonUnique and a Lookup.
+type LookupNonUnique struct {
+	lkp lookup
+}
+
+// NewLookupNonUnique creates a LookupNonUnique vindex.
+func NewLookupNonUnique(m map[string]interface{}) (planbuilder.Vindex, error) {
+	ln := &LookupNonUnique{}
+	ln.lkp.Init(m, false)
+	return ln, nil
+}
+
+// Cost returns the cost of this vindex as 20.
+func (vind *LookupNonUnique) Cost() int {
+	return 20
+}
+
+// Map returns the corresponding KeyspaceId values for the given ids.
+func (vind *LookupNonUnique) Map(vcursor planbuilder.VCursor, ids []interface{}) ([][][]byte, error) {
+	return vind.lkp.Map2(vcursor, ids)
+}
+
+// Verify returns true if id maps to ksid.
+func (vind *LookupNonUnique) Verify(vcursor planbuilder.VCursor, id interface{}, ksid []byte) (bool, error) {
+	return vind.lkp.Verify(vcursor, id, ksid)
+}
+
+// Create reserves the id by inserting it into the vindex table.
+func (vind *LookupNonUnique) Create(vcursor planbuilder.VCursor, id interface{}, ksid []byte) error {
+	return vind.lkp.Create(vcursor, id, ksid)
+}
+
+// Delete deletes the entry from the vindex table.
+func (vind *LookupNonUnique) Delete(vcursor planbuilder.VCursor, ids []interface{}, ksid []byte) error {
+	return vind.lkp.Delete(vcursor, ids, ksid)
+}
+
+//====================================================================
+
+// LookupUnique defines a vindex that uses a lookup table.
+// The table is expected to define the id column as unique.
+// Unlike LookupHashUnique, the To column of the table stores
+// the keyspace id itself. It's Unique and a Lookup.
+type LookupUnique struct {
+	lkp lookup
+}
+
+// NewLookupUnique creates a LookupUnique vindex.
+func NewLookupUnique(m map[string]interface{}) (planbuilder.Vindex, error) {
+	lu := &LookupUnique{}
+	lu.lkp.Init(m, false)
+	return lu, nil
+}
+
+// Cost returns the cost of this vindex as 10.
+func (vind *LookupUnique) Cost() int {
+	return 10
+}
+
+// Map returns the corresponding KeyspaceId values for the given ids.
+func (vind *LookupUnique) Map(vcursor planbuilder.VCursor, ids []interface{}) ([][]byte, error) {
+	return vind.lkp.Map1(vcursor, ids)
+}
+
+// Verify returns true if id maps to ksid.
+func (vind *LookupUnique) Verify(vcursor planbuilder.VCursor, id interface{}, ksid []byte) (bool, error) {
+	return vind.lkp.Verify(vcursor, id, ksid)
+}
+
+// Create reserves the id by inserting it into the vindex table.
+func (vind *LookupUnique) Create(vcursor planbuilder.VCursor, id interface{}, ksid []byte) error {
+	return vind.lkp.Create(vcursor, id, ksid)
+}
+
+// Delete deletes the entry from the vindex table.
+func (vind *LookupUnique) Delete(vcursor planbuilder.VCursor, ids []interface{}, ksid []byte) error {
+	return vind.lkp.Delete(vcursor, ids, ksid)
+}
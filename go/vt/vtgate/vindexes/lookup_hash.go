@@ -7,6 +7,7 @@ package vindexes
 import (
 	"fmt"
 
+	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
 )
@@ -30,7 +31,7 @@ type LookupHash struct {
 // NewLookupHash creates a LookupHash vindex.
 func NewLookupHash(m map[string]interface{}) (planbuilder.Vindex, error) {
 	lhu := &LookupHash{}
-	lhu.lkp.Init(m)
+	lhu.lkp.Init(m, true)
 	return lhu, nil
 }
 
@@ -72,7 +73,7 @@ type LookupHashAuto struct {
 // NewLookupHashAuto creates a new LookupHashAuto.
 func NewLookupHashAuto(m map[string]interface{}) (planbuilder.Vindex, error) {
 	h := &LookupHashAuto{}
-	h.lkp.Init(m)
+	h.lkp.Init(m, true)
 	return h, nil
 }
 
@@ -118,7 +119,7 @@ type LookupHashUnique struct {
 // NewLookupHashUnique creates a LookupHashUnique vindex.
 func NewLookupHashUnique(m map[string]interface{}) (planbuilder.Vindex, error) {
 	lhu := &LookupHashUnique{}
-	lhu.lkp.Init(m)
+	lhu.lkp.Init(m, true)
 	return lhu, nil
 }
 
@@ -160,7 +161,7 @@ type LookupHashUniqueAuto struct {
 // NewLookupHashUniqueAuto creates a new LookupHashUniqueAuto.
 func NewLookupHashUniqueAuto(m map[string]interface{}) (planbuilder.Vindex, error) {
 	h := &LookupHashUniqueAuto{}
-	h.lkp.Init(m)
+	h.lkp.Init(m, true)
 	return h, nil
 }
 
@@ -197,12 +198,16 @@ func (vind *LookupHashUniqueAuto) Delete(vcursor planbuilder.VCursor, ids []inte
 //====================================================================
 
 // lookup implements the functions for the Lookup vindexes.
+// If isHashed is set, the To column stores the number that
+// hashes to the keyspace id. Otherwise, it stores the keyspace
+// id as is.
 type lookup struct {
 	Table, From, To    string
 	sel, ver, ins, del string
+	isHashed           bool
 }
 
-func (lkp *lookup) Init(m map[string]interface{}) {
+func (lkp *lookup) Init(m map[string]interface{}, isHashed bool) {
 	get := func(name string) string {
 		v, _ := m[name].(string)
 		return v
@@ -214,6 +219,7 @@ func (lkp *lookup) Init(m map[string]interface{}) {
 	lkp.Table = t
 	lkp.From = from
 	lkp.To = to
+	lkp.isHashed = isHashed
 	lkp.sel = fmt.Sprintf("select %s from %s where %s = :%s", to, t, from, from)
 	lkp.ver = fmt.Sprintf("select %s from %s where %s = :%s and %s = :%s", from, t, from, from, to, to)
 	lkp.ins = fmt.Sprintf("insert into %s(%s, %s) values(:%s, :%s)", t, from, to, from, to)
@@ -241,11 +247,11 @@ func (lkp *lookup) Map1(vcursor planbuilder.VCursor, ids []interface{}) ([][]byt
 		if len(result.Rows) != 1 {
 			return nil, fmt.Errorf("lookup.Map: unexpected multiple results from vindex %s: %v", lkp.Table, id)
 		}
-		num, err := getNumber(result.Rows[0][0].ToNative())
+		ksid, err := lkp.toKsid(result.Rows[0][0])
 		if err != nil {
 			return nil, fmt.Errorf("lookup.Map: %v", err)
 		}
-		out = append(out, vhash(num))
+		out = append(out, ksid)
 	}
 	return out, nil
 }
@@ -266,11 +272,11 @@ func (lkp *lookup) Map2(vcursor planbuilder.VCursor, ids []interface{}) ([][][]b
 		}
 		var ksids [][]byte
 		for _, row := range result.Rows {
-			num, err := getNumber(row[0].ToNative())
+			ksid, err := lkp.toKsid(row[0])
 			if err != nil {
 				return nil, fmt.Errorf("lookup.Map: %v", err)
 			}
-			ksids = append(ksids, vhash(num))
+			ksids = append(ksids, ksid)
 		}
 		out = append(out, ksids)
 	}
@@ -279,7 +285,7 @@ func (lkp *lookup) Map2(vcursor planbuilder.VCursor, ids []interface{}) ([][][]b
 
 // Verify returns true if id maps to ksid.
 func (lkp *lookup) Verify(vcursor planbuilder.VCursor, id interface{}, ksid []byte) (bool, error) {
-	val, err := vunhash(ksid)
+	val, err := lkp.fromKsid(ksid)
 	if err != nil {
 		return false, fmt.Errorf("lookup.Verify: %v", err)
 	}
@@ -302,7 +308,7 @@ func (lkp *lookup) Verify(vcursor planbuilder.VCursor, id interface{}, ksid []by
 
 // Create creates an association between id and ksid by inserting a row in the vindex table.
 func (lkp *lookup) Create(vcursor planbuilder.VCursor, id interface{}, ksid []byte) error {
-	val, err := vunhash(ksid)
+	val, err := lkp.fromKsid(ksid)
 	if err != nil {
 		return fmt.Errorf("lookup.Create: %v", err)
 	}
@@ -321,7 +327,7 @@ func (lkp *lookup) Create(vcursor planbuilder.VCursor, id interface{}, ksid []by
 
 // Generate generates an id and associates the ksid to the new id.
 func (lkp *lookup) Generate(vcursor planbuilder.VCursor, ksid []byte) (id int64, err error) {
-	val, err := vunhash(ksid)
+	val, err := lkp.fromKsid(ksid)
 	if err != nil {
 		return 0, fmt.Errorf("lookup.Generate: %v", err)
 	}
@@ -341,7 +347,7 @@ func (lkp *lookup) Generate(vcursor planbuilder.VCursor, ksid []byte) (id int64,
 
 // Delete deletes the association between ids and ksid.
 func (lkp *lookup) Delete(vcursor planbuilder.VCursor, ids []interface{}, ksid []byte) error {
-	val, err := vunhash(ksid)
+	val, err := lkp.fromKsid(ksid)
 	if err != nil {
 		return fmt.Errorf("lookup.Delete: %v", err)
 	}
@@ -357,3 +363,23 @@ func (lkp *lookup) Delete(vcursor planbuilder.VCursor, ids []interface{}, ksid [
 	}
 	return nil
 }
+
+// toKsid converts a value of the To column into a keyspace id.
+func (lkp *lookup) toKsid(v sqltypes.Value) ([]byte, error) {
+	if !lkp.isHashed {
+		return v.Raw(), nil
+	}
+	num, err := getNumber(v.ToNative())
+	if err != nil {
+		return nil, err
+	}
+	return vhash(num), nil
+}
+
+// fromKsid converts a keyspace id into a value for the To column.
+func (lkp *lookup) fromKsid(ksid []byte) (interface{}, error) {
+	if !lkp.isHashed {
+		return ksid, nil
+	}
+	return vunhash(ksid)
+}
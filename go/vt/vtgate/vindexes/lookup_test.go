@@ -0,0 +1,118 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+var lookupNonUnique planbuilder.Vindex
+
+func init() {
+	lkp, err := planbuilder.CreateVindex("lookup", map[string]interface{}{"Table": "t", "From": "fromc", "To": "toc"})
+	if err != nil {
+		panic(err)
+	}
+	lookupNonUnique = lkp
+}
+
+func TestLookupNonUniqueCost(t *testing.T) {
+	if lookupNonUnique.Cost() != 20 {
+		t.Errorf("Cost(): %d, want 20", lookupNonUnique.Cost())
+	}
+}
+
+func TestLookupNonUniqueMap(t *testing.T) {
+	vc := &vcursor{numRows: 2}
+	got, err := lookupNonUnique.(planbuilder.NonUnique).Map(vc, []interface{}{1, int32(2)})
+	if err != nil {
+		t.Error(err)
+	}
+	want := [][][]byte{{
+		[]byte("1"),
+		[]byte("2"),
+	}, {
+		[]byte("1"),
+		[]byte("2"),
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map(): %#v, want %+v", got, want)
+	}
+}
+
+func TestLookupNonUniqueVerify(t *testing.T) {
+	vc := &vcursor{numRows: 1}
+	success, err := lookupNonUnique.Verify(vc, 1, []byte("test"))
+	if err != nil {
+		t.Error(err)
+	}
+	if !success {
+		t.Errorf("Verify(): %+v, want true", success)
+	}
+	wantQuery := &querytypes.BoundQuery{
+		Sql: "select fromc from t where fromc = :fromc and toc = :toc",
+		BindVariables: map[string]interface{}{
+			"fromc": 1,
+			"toc":   []byte("test"),
+		},
+	}
+	if !reflect.DeepEqual(vc.query, wantQuery) {
+		t.Errorf("vc.query = %#v, want %#v", vc.query, wantQuery)
+	}
+}
+
+func TestLookupNonUniqueCreate(t *testing.T) {
+	vc := &vcursor{}
+	err := lookupNonUnique.(planbuilder.Lookup).Create(vc, 1, []byte("test"))
+	if err != nil {
+		t.Error(err)
+	}
+	wantQuery := &querytypes.BoundQuery{
+		Sql: "insert into t(fromc, toc) values(:fromc, :toc)",
+		BindVariables: map[string]interface{}{
+			"fromc": 1,
+			"toc":   []byte("test"),
+		},
+	}
+	if !reflect.DeepEqual(vc.query, wantQuery) {
+		t.Errorf("vc.query = %#v, want %#v", vc.query, wantQuery)
+	}
+}
+
+func TestLookupNonUniqueGenerate(t *testing.T) {
+	_, ok := lookupNonUnique.(planbuilder.LookupGenerator)
+	if ok {
+		t.Errorf("lookupNonUnique.(planbuilder.LookupGenerator): true, want false")
+	}
+}
+
+func TestLookupNonUniqueReverse(t *testing.T) {
+	_, ok := lookupNonUnique.(planbuilder.Reversible)
+	if ok {
+		t.Errorf("lookupNonUnique.(planbuilder.Reversible): true, want false")
+	}
+}
+
+func TestLookupNonUniqueDelete(t *testing.T) {
+	vc := &vcursor{}
+	err := lookupNonUnique.(planbuilder.Lookup).Delete(vc, []interface{}{1}, []byte("test"))
+	if err != nil {
+		t.Error(err)
+	}
+	wantQuery := &querytypes.BoundQuery{
+		Sql: "delete from t where fromc in ::fromc and toc = :toc",
+		BindVariables: map[string]interface{}{
+			"fromc": []interface{}{1},
+			"toc":   []byte("test"),
+		},
+	}
+	if !reflect.DeepEqual(vc.query, wantQuery) {
+		t.Errorf("vc.query = %#v, want %#v", vc.query, wantQuery)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+var lookupUnique planbuilder.Vindex
+
+func init() {
+	lkp, err := planbuilder.CreateVindex("lookup_unique", map[string]interface{}{"Table": "t", "From": "fromc", "To": "toc"})
+	if err != nil {
+		panic(err)
+	}
+	lookupUnique = lkp
+}
+
+func TestLookupUniqueCost(t *testing.T) {
+	if lookupUnique.Cost() != 10 {
+		t.Errorf("Cost(): %d, want 10", lookupUnique.Cost())
+	}
+}
+
+func TestLookupUniqueMap(t *testing.T) {
+	vc := &vcursor{numRows: 1}
+	got, err := lookupUnique.(planbuilder.Unique).Map(vc, []interface{}{1, int32(2)})
+	if err != nil {
+		t.Error(err)
+	}
+	want := [][]byte{
+		[]byte("1"),
+		[]byte("1"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map(): %#v, want %+v", got, want)
+	}
+
+	vc = &vcursor{numRows: 0}
+	got, err = lookupUnique.(planbuilder.Unique).Map(vc, []interface{}{1})
+	if err != nil {
+		t.Error(err)
+	}
+	want = [][]byte{{}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map(): %#v, want %+v", got, want)
+	}
+
+	vc = &vcursor{numRows: 2}
+	_, err = lookupUnique.(planbuilder.Unique).Map(vc, []interface{}{1})
+	wantErr := "lookup.Map: unexpected multiple results from vindex t: 1"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("Map(): %v, want %s", err, wantErr)
+	}
+}
+
+func TestLookupUniqueVerify(t *testing.T) {
+	vc := &vcursor{numRows: 1}
+	success, err := lookupUnique.Verify(vc, 1, []byte("test"))
+	if err != nil {
+		t.Error(err)
+	}
+	if !success {
+		t.Errorf("Verify(): %+v, want true", success)
+	}
+}
+
+func TestLookupUniqueCreate(t *testing.T) {
+	vc := &vcursor{}
+	err := lookupUnique.(planbuilder.Lookup).Create(vc, 1, []byte("test"))
+	if err != nil {
+		t.Error(err)
+	}
+	wantQuery := &querytypes.BoundQuery{
+		Sql: "insert into t(fromc, toc) values(:fromc, :toc)",
+		BindVariables: map[string]interface{}{
+			"fromc": 1,
+			"toc":   []byte("test"),
+		},
+	}
+	if !reflect.DeepEqual(vc.query, wantQuery) {
+		t.Errorf("vc.query = %#v, want %#v", vc.query, wantQuery)
+	}
+}
+
+func TestLookupUniqueGenerate(t *testing.T) {
+	_, ok := lookupUnique.(planbuilder.LookupGenerator)
+	if ok {
+		t.Errorf("lookupUnique.(planbuilder.LookupGenerator): true, want false")
+	}
+}
+
+func TestLookupUniqueReverse(t *testing.T) {
+	_, ok := lookupUnique.(planbuilder.Reversible)
+	if ok {
+		t.Errorf("lookupUnique.(planbuilder.Reversible): true, want false")
+	}
+}
+
+func TestLookupUniqueDelete(t *testing.T) {
+	vc := &vcursor{}
+	err := lookupUnique.(planbuilder.Lookup).Delete(vc, []interface{}{1}, []byte("test"))
+	if err != nil {
+		t.Error(err)
+	}
+	wantQuery := &querytypes.BoundQuery{
+		Sql: "delete from t where fromc in ::fromc and toc = :toc",
+		BindVariables: map[string]interface{}{
+			"fromc": []interface{}{1},
+			"toc":   []byte("test"),
+		},
+	}
+	if !reflect.DeepEqual(vc.query, wantQuery) {
+		t.Errorf("vc.query = %#v, want %#v", vc.query, wantQuery)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+// MultiColHash defines a multi-column vindex. Each column value
+// is hashed like in Hash, and the keyspace id is the concatenation
+// of the leading bytes of the hashes. The number of bytes taken from
+// each column is specified by the ColumnBytes param, a comma-separated
+// list with one entry per column, which must add up to 8 at most.
+// For example, with "1,7", the first column picks one of 256 ranges
+// of keyspace ids, and the second column spreads the rows within
+// that range. So, all the rows of a given first column value live
+// in the few shards that cover its range.
+// It's Unique and MultiColumn.
+type MultiColHash struct {
+	columnBytes []int
+}
+
+// NewMultiColHash creates a MultiColHash vindex.
+func NewMultiColHash(m map[string]interface{}) (planbuilder.Vindex, error) {
+	param, _ := m["ColumnBytes"].(string)
+	if param == "" {
+		return nil, fmt.Errorf("MultiColHash: ColumnBytes not specified")
+	}
+	mch := &MultiColHash{}
+	total := 0
+	for _, field := range strings.Split(param, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("MultiColHash: invalid ColumnBytes: %s", param)
+		}
+		total += n
+		mch.columnBytes = append(mch.columnBytes, n)
+	}
+	if len(mch.columnBytes) < 2 {
+		return nil, fmt.Errorf("MultiColHash: ColumnBytes must specify at least two columns: %s", param)
+	}
+	if total > 8 {
+		return nil, fmt.Errorf("MultiColHash: ColumnBytes add up to more than 8: %s", param)
+	}
+	return mch, nil
+}
+
+// Cost returns the cost of this vindex as 1.
+func (*MultiColHash) Cost() int {
+	return 1
+}
+
+// ColumnCount returns the number of columns of the vindex.
+func (mch *MultiColHash) ColumnCount() int {
+	return len(mch.columnBytes)
+}
+
+// Verify returns true if id maps to ksid.
+func (mch *MultiColHash) Verify(_ planbuilder.VCursor, id interface{}, ksid []byte) (bool, error) {
+	data, err := mch.hashPrefix(id, len(mch.columnBytes))
+	if err != nil {
+		return false, fmt.Errorf("MultiColHash.Verify: %v", err)
+	}
+	return bytes.Compare(data, ksid) == 0, nil
+}
+
+// Map returns the corresponding keyspace ids for the given ids.
+// Each id is a list with one value per column.
+func (mch *MultiColHash) Map(_ planbuilder.VCursor, ids []interface{}) ([][]byte, error) {
+	out := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		data, err := mch.hashPrefix(id, len(mch.columnBytes))
+		if err != nil {
+			return nil, fmt.Errorf("MultiColHash.Map: %v", err)
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// MapPrefix returns the range of keyspace ids that covers the
+// ids whose leading values are prefix. It satisfies the
+// planbuilder.MultiColumn interface.
+func (mch *MultiColHash) MapPrefix(_ planbuilder.VCursor, prefix []interface{}) (start, end []byte, err error) {
+	if len(prefix) == 0 || len(prefix) > len(mch.columnBytes) {
+		return nil, nil, fmt.Errorf("MultiColHash.MapPrefix: invalid number of values: %d", len(prefix))
+	}
+	start, err = mch.hashPrefix(prefix, len(prefix))
+	if err != nil {
+		return nil, nil, fmt.Errorf("MultiColHash.MapPrefix: %v", err)
+	}
+	return start, nextPrefix(start), nil
+}
+
+// hashPrefix returns the leading bytes of the keyspace id
+// computed from the first n values of id.
+func (mch *MultiColHash) hashPrefix(id interface{}, n int) ([]byte, error) {
+	vals, ok := id.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for %v: %T", id, id)
+	}
+	if len(vals) != n {
+		return nil, fmt.Errorf("expected %d values, got %d: %v", n, len(vals), vals)
+	}
+	var ksid []byte
+	for i, val := range vals {
+		num, err := getNumber(val)
+		if err != nil {
+			return nil, err
+		}
+		ksid = append(ksid, vhash(num)[:mch.columnBytes[i]]...)
+	}
+	return ksid, nil
+}
+
+// nextPrefix returns the smallest byte string greater than all
+// the ones that start with prefix, or nil if there's none.
+func nextPrefix(prefix []byte) []byte {
+	next := append([]byte(nil), prefix...)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next[:i+1]
+		}
+	}
+	return nil
+}
+
+func init() {
+	planbuilder.Register("multicol_hash", NewMultiColHash)
+}
@@ -0,0 +1,169 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+var multiColHash planbuilder.Vindex
+
+func init() {
+	mch, err := planbuilder.CreateVindex("multicol_hash", map[string]interface{}{"ColumnBytes": "1,7"})
+	if err != nil {
+		panic(err)
+	}
+	multiColHash = mch
+}
+
+func TestMultiColHashCost(t *testing.T) {
+	if multiColHash.Cost() != 1 {
+		t.Errorf("Cost(): %d, want 1", multiColHash.Cost())
+	}
+}
+
+func TestMultiColHashColumnCount(t *testing.T) {
+	if got := multiColHash.(planbuilder.MultiColumn).ColumnCount(); got != 2 {
+		t.Errorf("ColumnCount(): %d, want 2", got)
+	}
+}
+
+func TestMultiColHashMap(t *testing.T) {
+	got, err := multiColHash.(planbuilder.Unique).Map(nil, []interface{}{
+		[]interface{}{1, int64(2)},
+		[]interface{}{uint64(1), 3},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	want := [][]byte{
+		[]byte("\x16\x06\xe7\xea\x22\xce\x92\x70"),
+		[]byte("\x16\x4e\xb1\x90\xc9\xa2\xfa\x16"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map(): %#v, want %#v", got, want)
+	}
+}
+
+func TestMultiColHashMapBadData(t *testing.T) {
+	testcases := []struct {
+		id  interface{}
+		err string
+	}{{
+		id:  1,
+		err: "MultiColHash.Map: unexpected type for 1: int",
+	}, {
+		id:  []interface{}{1},
+		err: "MultiColHash.Map: expected 2 values, got 1: [1]",
+	}, {
+		id:  []interface{}{1, 1.1},
+		err: "MultiColHash.Map: unexpected type for 1.1: float64",
+	}}
+	for _, tcase := range testcases {
+		_, err := multiColHash.(planbuilder.Unique).Map(nil, []interface{}{tcase.id})
+		if err == nil || err.Error() != tcase.err {
+			t.Errorf("Map(%v): %v, want %s", tcase.id, err, tcase.err)
+		}
+	}
+}
+
+func TestMultiColHashMapPrefix(t *testing.T) {
+	testcases := []struct {
+		prefix     []interface{}
+		start, end []byte
+	}{{
+		prefix: []interface{}{1},
+		start:  []byte("\x16"),
+		end:    []byte("\x17"),
+	}, {
+		prefix: []interface{}{1, 2},
+		start:  []byte("\x16\x06\xe7\xea\x22\xce\x92\x70"),
+		end:    []byte("\x16\x06\xe7\xea\x22\xce\x92\x71"),
+	}}
+	for _, tcase := range testcases {
+		start, end, err := multiColHash.(planbuilder.MultiColumn).MapPrefix(nil, tcase.prefix)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(start, tcase.start) || !reflect.DeepEqual(end, tcase.end) {
+			t.Errorf("MapPrefix(%v): %x-%x, want %x-%x", tcase.prefix, start, end, tcase.start, tcase.end)
+		}
+	}
+
+	_, _, err := multiColHash.(planbuilder.MultiColumn).MapPrefix(nil, nil)
+	want := "MultiColHash.MapPrefix: invalid number of values: 0"
+	if err == nil || err.Error() != want {
+		t.Errorf("MapPrefix: %v, want %s", err, want)
+	}
+}
+
+func TestMultiColHashVerify(t *testing.T) {
+	success, err := multiColHash.Verify(nil, []interface{}{1, 2}, []byte("\x16\x06\xe7\xea\x22\xce\x92\x70"))
+	if err != nil {
+		t.Error(err)
+	}
+	if !success {
+		t.Errorf("Verify(): %+v, want true", success)
+	}
+	success, err = multiColHash.Verify(nil, []interface{}{1, 3}, []byte("\x16\x06\xe7\xea\x22\xce\x92\x70"))
+	if err != nil {
+		t.Error(err)
+	}
+	if success {
+		t.Errorf("Verify(): %+v, want false", success)
+	}
+}
+
+func TestNextPrefix(t *testing.T) {
+	testcases := []struct {
+		in, out []byte
+	}{{
+		in:  []byte("\x16"),
+		out: []byte("\x17"),
+	}, {
+		in:  []byte("\x16\xff"),
+		out: []byte("\x17"),
+	}, {
+		in:  []byte("\xff\xff"),
+		out: nil,
+	}}
+	for _, tcase := range testcases {
+		if got := nextPrefix(tcase.in); !reflect.DeepEqual(got, tcase.out) {
+			t.Errorf("nextPrefix(%x): %x, want %x", tcase.in, got, tcase.out)
+		}
+	}
+}
+
+func TestNewMultiColHashFail(t *testing.T) {
+	testcases := []struct {
+		params map[string]interface{}
+		err    string
+	}{{
+		params: map[string]interface{}{},
+		err:    "MultiColHash: ColumnBytes not specified",
+	}, {
+		params: map[string]interface{}{"ColumnBytes": "1,a"},
+		err:    "MultiColHash: invalid ColumnBytes: 1,a",
+	}, {
+		params: map[string]interface{}{"ColumnBytes": "1,0"},
+		err:    "MultiColHash: invalid ColumnBytes: 1,0",
+	}, {
+		params: map[string]interface{}{"ColumnBytes": "8"},
+		err:    "MultiColHash: ColumnBytes must specify at least two columns: 8",
+	}, {
+		params: map[string]interface{}{"ColumnBytes": "2,7"},
+		err:    "MultiColHash: ColumnBytes add up to more than 8: 2,7",
+	}}
+	for _, tcase := range testcases {
+		_, err := planbuilder.CreateVindex("multicol_hash", tcase.params)
+		if err == nil || err.Error() != tcase.err {
+			t.Errorf("CreateVindex(%v): %v, want %s", tcase.params, err, tcase.err)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+// Range defines a vindex that maps ranges of ids to keyspace
+// ids. It's meant for ordered sharding keys, like a region code,
+// where rows with close ids are expected to live in the same shard.
+// The ranges are configured with the Ranges param: a comma-separated
+// list of boundaries of the form id:keyspace_id, where keyspace_id
+// is in hex. An id is mapped to the keyspace id of the highest
+// boundary that's not greater than the id. Ids below the first
+// boundary can't be mapped. The Type param specifies if the ids
+// are integers ("int", the default) or strings ("string"). Like
+// in MySQL, string ids are compared with a case and accent
+// insensitive collation, and must be valid UTF-8.
+// It's Unique and Ranged.
+type Range struct {
+	isString bool
+	// bounds are the values of the ids of the boundaries, in
+	// increasing order. They're int64 for integer ids, or the
+	// collation key of string ids.
+	bounds []interface{}
+	ksids  [][]byte
+}
+
+// NewRange creates a Range vindex.
+func NewRange(m map[string]interface{}) (planbuilder.Vindex, error) {
+	rng := &Range{}
+	switch typ, _ := m["Type"].(string); typ {
+	case "", "int":
+	case "string":
+		rng.isString = true
+	default:
+		return nil, fmt.Errorf("Range: invalid type: %s", typ)
+	}
+	ranges, _ := m["Ranges"].(string)
+	if ranges == "" {
+		return nil, fmt.Errorf("Range: no ranges specified")
+	}
+	for _, boundary := range strings.Split(ranges, ",") {
+		parts := strings.Split(strings.TrimSpace(boundary), ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Range: invalid boundary: %s", boundary)
+		}
+		var id interface{} = parts[0]
+		if !rng.isString {
+			num, err := strconv.ParseInt(parts[0], 0, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Range: invalid boundary: %s", boundary)
+			}
+			id = num
+		}
+		bound, err := rng.value(id)
+		if err != nil {
+			return nil, fmt.Errorf("Range: invalid boundary: %s", boundary)
+		}
+		ksid, err := hex.DecodeString(parts[1])
+		if err != nil || len(ksid) == 0 {
+			return nil, fmt.Errorf("Range: invalid keyspace id in boundary: %s", boundary)
+		}
+		if n := len(rng.bounds); n != 0 {
+			if rng.compare(rng.bounds[n-1], bound) >= 0 {
+				return nil, fmt.Errorf("Range: boundaries are not in increasing order: %s", boundary)
+			}
+			if bytes.Compare(rng.ksids[n-1], ksid) >= 0 {
+				return nil, fmt.Errorf("Range: keyspace ids are not in increasing order: %s", boundary)
+			}
+		}
+		rng.bounds = append(rng.bounds, bound)
+		rng.ksids = append(rng.ksids, ksid)
+	}
+	return rng, nil
+}
+
+// Cost returns the cost of this vindex as 1.
+func (*Range) Cost() int {
+	return 1
+}
+
+// Verify returns true if id maps to ksid.
+func (rng *Range) Verify(_ planbuilder.VCursor, id interface{}, ksid []byte) (bool, error) {
+	i, err := rng.find(id)
+	if err != nil {
+		return false, fmt.Errorf("Range.Verify: %v", err)
+	}
+	if i < 0 {
+		return false, nil
+	}
+	return bytes.Compare(rng.ksids[i], ksid) == 0, nil
+}
+
+// Map returns the corresponding keyspace ids for the given ids.
+// The keyspace id of an id below the first boundary is empty.
+func (rng *Range) Map(_ planbuilder.VCursor, ids []interface{}) ([][]byte, error) {
+	out := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		i, err := rng.find(id)
+		if err != nil {
+			return nil, fmt.Errorf("Range.Map: %v", err)
+		}
+		if i < 0 {
+			out = append(out, nil)
+			continue
+		}
+		out = append(out, rng.ksids[i])
+	}
+	return out, nil
+}
+
+// MapRange returns the range of keyspace ids that covers the ids
+// from low to high. It satisfies the planbuilder.Ranged interface.
+// It returns an error if low is greater than high.
+func (rng *Range) MapRange(_ planbuilder.VCursor, low, high interface{}) (start, end []byte, err error) {
+	var lowVal, highVal interface{}
+	if low != nil {
+		if lowVal, err = rng.value(low); err != nil {
+			return nil, nil, fmt.Errorf("Range.MapRange: %v", err)
+		}
+	}
+	if high != nil {
+		if highVal, err = rng.value(high); err != nil {
+			return nil, nil, fmt.Errorf("Range.MapRange: %v", err)
+		}
+	}
+	if lowVal != nil && highVal != nil && rng.compare(lowVal, highVal) > 0 {
+		return nil, nil, fmt.Errorf("Range.MapRange: invalid range: %v is greater than %v", low, high)
+	}
+	if lowVal != nil {
+		i := rng.search(lowVal)
+		if i < 0 {
+			// No id can be mapped below the first boundary.
+			i = 0
+		}
+		start = rng.ksids[i]
+	}
+	if highVal != nil {
+		if i := rng.search(highVal); i+1 < len(rng.ksids) {
+			end = rng.ksids[i+1]
+		}
+	}
+	return start, end, nil
+}
+
+// find returns the position of the boundary of id,
+// or -1 if id is below the first boundary.
+func (rng *Range) find(id interface{}) (int, error) {
+	val, err := rng.value(id)
+	if err != nil {
+		return 0, err
+	}
+	return rng.search(val), nil
+}
+
+// value returns the value of id that's compared to the
+// boundaries: an int64, or the collation key of a string.
+func (rng *Range) value(id interface{}) (interface{}, error) {
+	if !rng.isString {
+		num, err := getNumber(id)
+		if err != nil {
+			return nil, err
+		}
+		return num, nil
+	}
+	source, err := getBytes(id)
+	if err != nil {
+		return nil, err
+	}
+	key, err := CollationKey(source)
+	if err != nil {
+		return nil, err
+	}
+	return string(key), nil
+}
+
+// search returns the position of the boundary of val,
+// or -1 if val is below the first boundary.
+func (rng *Range) search(val interface{}) int {
+	i := len(rng.bounds) - 1
+	for i >= 0 && rng.compare(rng.bounds[i], val) > 0 {
+		i--
+	}
+	return i
+}
+
+// compare compares two ids of the type of the vindex.
+func (rng *Range) compare(v1, v2 interface{}) int {
+	if rng.isString {
+		return strings.Compare(v1.(string), v2.(string))
+	}
+	n1, n2 := v1.(int64), v2.(int64)
+	switch {
+	case n1 < n2:
+		return -1
+	case n1 > n2:
+		return 1
+	}
+	return 0
+}
+
+func init() {
+	planbuilder.Register("range", NewRange)
+}
@@ -0,0 +1,209 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+var intRange, stringRange planbuilder.Vindex
+
+func init() {
+	var err error
+	intRange, err = planbuilder.CreateVindex("range", map[string]interface{}{
+		"Ranges": "0:10,100:40,200:80,300:c0",
+	})
+	if err != nil {
+		panic(err)
+	}
+	stringRange, err = planbuilder.CreateVindex("range", map[string]interface{}{
+		"Type":   "string",
+		"Ranges": "a:10,h:40,p:80",
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestRangeCost(t *testing.T) {
+	if intRange.Cost() != 1 {
+		t.Errorf("Cost(): %d, want 1", intRange.Cost())
+	}
+}
+
+func TestRangeMap(t *testing.T) {
+	got, err := intRange.(planbuilder.Unique).Map(nil, []interface{}{-1, 0, int64(99), uint64(100), 250, int32(1000)})
+	if err != nil {
+		t.Error(err)
+	}
+	want := [][]byte{
+		nil,
+		[]byte("\x10"),
+		[]byte("\x10"),
+		[]byte("\x40"),
+		[]byte("\x80"),
+		[]byte("\xc0"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map(): %#v, want %#v", got, want)
+	}
+
+	// String ids are compared regardless of their case.
+	got, err = stringRange.(planbuilder.Unique).Map(nil, []interface{}{"", "A", "a", []byte("hello"), "H", "Z", "z"})
+	if err != nil {
+		t.Error(err)
+	}
+	want = [][]byte{
+		nil,
+		[]byte("\x10"),
+		[]byte("\x10"),
+		[]byte("\x40"),
+		[]byte("\x40"),
+		[]byte("\x80"),
+		[]byte("\x80"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map(): %#v, want %#v", got, want)
+	}
+}
+
+func TestRangeMapBadData(t *testing.T) {
+	_, err := intRange.(planbuilder.Unique).Map(nil, []interface{}{"a"})
+	want := `Range.Map: unexpected type for a: string`
+	if err == nil || err.Error() != want {
+		t.Errorf("Map: %v, want %v", err, want)
+	}
+	_, err = stringRange.(planbuilder.Unique).Map(nil, []interface{}{1})
+	want = `Range.Map: unexpected type for 1: int`
+	if err == nil || err.Error() != want {
+		t.Errorf("Map: %v, want %v", err, want)
+	}
+}
+
+func TestRangeMapRange(t *testing.T) {
+	testcases := []struct {
+		low, high  interface{}
+		start, end []byte
+	}{{
+		low:   int64(150),
+		high:  int64(250),
+		start: []byte("\x40"),
+		end:   []byte("\xc0"),
+	}, {
+		low:   int64(-5),
+		high:  int64(50),
+		start: []byte("\x10"),
+		end:   []byte("\x40"),
+	}, {
+		low:   int64(250),
+		start: []byte("\x80"),
+	}, {
+		high: int64(350),
+	}, {
+		high: int64(100),
+		end:  []byte("\x80"),
+	}}
+	for _, tcase := range testcases {
+		start, end, err := intRange.(planbuilder.Ranged).MapRange(nil, tcase.low, tcase.high)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(start, tcase.start) || !reflect.DeepEqual(end, tcase.end) {
+			t.Errorf("MapRange(%v, %v): %x-%x, want %x-%x", tcase.low, tcase.high, start, end, tcase.start, tcase.end)
+		}
+	}
+
+	start, end, err := stringRange.(planbuilder.Ranged).MapRange(nil, "B", "i")
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(start, []byte("\x10")) || !reflect.DeepEqual(end, []byte("\x80")) {
+		t.Errorf("MapRange(B, i): %x-%x, want 10-80", start, end)
+	}
+
+	_, _, err = intRange.(planbuilder.Ranged).MapRange(nil, 1.5, nil)
+	want := `Range.MapRange: unexpected type for 1.5: float64`
+	if err == nil || err.Error() != want {
+		t.Errorf("MapRange: %v, want %v", err, want)
+	}
+}
+
+func TestRangeMapRangeInverted(t *testing.T) {
+	// The bounds are in the same boundary.
+	_, _, err := intRange.(planbuilder.Ranged).MapRange(nil, int64(250), int64(240))
+	want := `Range.MapRange: invalid range: 250 is greater than 240`
+	if err == nil || err.Error() != want {
+		t.Errorf("MapRange: %v, want %v", err, want)
+	}
+	_, _, err = intRange.(planbuilder.Ranged).MapRange(nil, int64(250), int64(50))
+	want = `Range.MapRange: invalid range: 250 is greater than 50`
+	if err == nil || err.Error() != want {
+		t.Errorf("MapRange: %v, want %v", err, want)
+	}
+	_, _, err = stringRange.(planbuilder.Ranged).MapRange(nil, "q", "B")
+	want = `Range.MapRange: invalid range: q is greater than B`
+	if err == nil || err.Error() != want {
+		t.Errorf("MapRange: %v, want %v", err, want)
+	}
+	// Equal bounds are a valid range.
+	if _, _, err := stringRange.(planbuilder.Ranged).MapRange(nil, "b", "B"); err != nil {
+		t.Errorf("MapRange(b, B): %v", err)
+	}
+}
+
+func TestRangeVerify(t *testing.T) {
+	success, err := intRange.Verify(nil, 150, []byte("\x40"))
+	if err != nil {
+		t.Error(err)
+	}
+	if !success {
+		t.Errorf("Verify(): %+v, want true", success)
+	}
+	success, err = intRange.Verify(nil, 150, []byte("\x10"))
+	if err != nil {
+		t.Error(err)
+	}
+	if success {
+		t.Errorf("Verify(): %+v, want false", success)
+	}
+}
+
+func TestNewRangeFail(t *testing.T) {
+	testcases := []struct {
+		params map[string]interface{}
+		err    string
+	}{{
+		params: map[string]interface{}{"Type": "float", "Ranges": "0:10"},
+		err:    "Range: invalid type: float",
+	}, {
+		params: map[string]interface{}{},
+		err:    "Range: no ranges specified",
+	}, {
+		params: map[string]interface{}{"Ranges": "0"},
+		err:    "Range: invalid boundary: 0",
+	}, {
+		params: map[string]interface{}{"Ranges": "a:10"},
+		err:    "Range: invalid boundary: a:10",
+	}, {
+		params: map[string]interface{}{"Ranges": "0:xx"},
+		err:    "Range: invalid keyspace id in boundary: 0:xx",
+	}, {
+		params: map[string]interface{}{"Ranges": "10:10,0:20"},
+		err:    "Range: boundaries are not in increasing order: 0:20",
+	}, {
+		params: map[string]interface{}{"Ranges": "0:20,10:10"},
+		err:    "Range: keyspace ids are not in increasing order: 10:10",
+	}}
+	for _, tcase := range testcases {
+		_, err := planbuilder.CreateVindex("range", tcase.params)
+		if err == nil || err.Error() != tcase.err {
+			t.Errorf("CreateVindex(%v): %v, want %s", tcase.params, err, tcase.err)
+		}
+	}
+}
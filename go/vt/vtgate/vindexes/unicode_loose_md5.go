@@ -0,0 +1,120 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// UnicodeLooseMD5 defines a vindex for unicode string ids that
+// are compared by MySQL with a case and accent insensitive
+// collation, like utf8_general_ci. The id is first normalized
+// to its collation key, which is then hashed with MD5 to compute
+// the keyspace id. This way, ids that MySQL considers equal are
+// mapped to the same keyspace id. It's Unique, but not Reversible.
+type UnicodeLooseMD5 struct{}
+
+// NewUnicodeLooseMD5 creates a UnicodeLooseMD5 vindex.
+func NewUnicodeLooseMD5(_ map[string]interface{}) (planbuilder.Vindex, error) {
+	return UnicodeLooseMD5{}, nil
+}
+
+// Cost returns the cost of this vindex as 1.
+func (UnicodeLooseMD5) Cost() int {
+	return 1
+}
+
+// Verify returns true if id maps to ksid.
+func (UnicodeLooseMD5) Verify(_ planbuilder.VCursor, id interface{}, ksid []byte) (bool, error) {
+	data, err := unicodeHash(id)
+	if err != nil {
+		return false, fmt.Errorf("UnicodeLooseMD5.Verify: %v", err)
+	}
+	return bytes.Compare(data, ksid) == 0, nil
+}
+
+// Map returns the corresponding keyspace ids for the given ids.
+func (UnicodeLooseMD5) Map(_ planbuilder.VCursor, ids []interface{}) ([][]byte, error) {
+	out := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		data, err := unicodeHash(id)
+		if err != nil {
+			return nil, fmt.Errorf("UnicodeLooseMD5.Map: %v", err)
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// pooledCollator is a collator along with the buffer it uses to
+// compute keys. A collator can't be used concurrently, so they're
+// kept in a pool.
+type pooledCollator struct {
+	col *collate.Collator
+	buf *collate.Buffer
+}
+
+var collatorPool = sync.Pool{
+	New: func() interface{} {
+		return &pooledCollator{
+			col: collate.New(language.English, collate.Loose),
+			buf: new(collate.Buffer),
+		}
+	},
+}
+
+func unicodeHash(id interface{}) ([]byte, error) {
+	source, err := getBytes(id)
+	if err != nil {
+		return nil, err
+	}
+	key, err := CollationKey(source)
+	if err != nil {
+		return nil, err
+	}
+	return binHash(key), nil
+}
+
+// CollationKey returns the key of source for the collation: two
+// strings that MySQL considers equal have the same key, and keys
+// sort like the strings they come from.
+func CollationKey(source []byte) ([]byte, error) {
+	// Invalid UTF-8 can't be passed to the collator.
+	if !utf8.Valid(source) {
+		return nil, fmt.Errorf("invalid UTF-8 in %q", source)
+	}
+	// Like MySQL, ignore the trailing spaces.
+	source = bytes.TrimRight(source, " ")
+	pc := collatorPool.Get().(*pooledCollator)
+	defer collatorPool.Put(pc)
+	pc.buf.Reset()
+	// The key is in the buffer of the collator, so it's copied.
+	return append([]byte(nil), pc.col.Key(pc.buf, source)...), nil
+}
+
+// CollationCompare compares a and b with the collation. It's
+// cheaper than comparing their keys.
+func CollationCompare(a, b []byte) (int, error) {
+	if !utf8.Valid(a) {
+		return 0, fmt.Errorf("invalid UTF-8 in %q", a)
+	}
+	if !utf8.Valid(b) {
+		return 0, fmt.Errorf("invalid UTF-8 in %q", b)
+	}
+	pc := collatorPool.Get().(*pooledCollator)
+	defer collatorPool.Put(pc)
+	return pc.col.Compare(bytes.TrimRight(a, " "), bytes.TrimRight(b, " ")), nil
+}
+
+func init() {
+	planbuilder.Register("unicode_loose_md5", NewUnicodeLooseMD5)
+}
@@ -0,0 +1,81 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+)
+
+var charVindex planbuilder.Vindex
+
+func init() {
+	charVindex, _ = planbuilder.CreateVindex("unicode_loose_md5", nil)
+}
+
+func TestUnicodeLooseMD5Cost(t *testing.T) {
+	if charVindex.Cost() != 1 {
+		t.Errorf("Cost(): %d, want 1", charVindex.Cost())
+	}
+}
+
+func TestUnicodeLooseMD5Map(t *testing.T) {
+	// All these ids are equal under a case and accent
+	// insensitive collation.
+	ids := []interface{}{"test", "Test", []byte("TEST"), "tést", "test  ", "ｔｅｓｔ"}
+	got, err := charVindex.(planbuilder.Unique).Map(nil, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range ids {
+		if !reflect.DeepEqual(got[i], got[0]) {
+			t.Errorf("Map(%q): %x, want %x", ids[i], got[i], got[0])
+		}
+	}
+
+	got, err = charVindex.(planbuilder.Unique).Map(nil, []interface{}{"test", "tester", " test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(got[0], got[1]) || reflect.DeepEqual(got[0], got[2]) {
+		t.Errorf("Map(): %x, want distinct values", got)
+	}
+}
+
+func TestUnicodeLooseMD5MapBadData(t *testing.T) {
+	_, err := charVindex.(planbuilder.Unique).Map(nil, []interface{}{1})
+	want := `UnicodeLooseMD5.Map: unexpected type for 1: int`
+	if err == nil || err.Error() != want {
+		t.Errorf("Map: %v, want %v", err, want)
+	}
+	_, err = charVindex.(planbuilder.Unique).Map(nil, []interface{}{"\xff"})
+	want = `UnicodeLooseMD5.Map: invalid UTF-8 in "\xff"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Map: %v, want %v", err, want)
+	}
+}
+
+func TestUnicodeLooseMD5Verify(t *testing.T) {
+	ksids, err := charVindex.(planbuilder.Unique).Map(nil, []interface{}{"test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	success, err := charVindex.Verify(nil, "TÉST", ksids[0])
+	if err != nil {
+		t.Error(err)
+	}
+	if !success {
+		t.Errorf("Verify(): %+v, want true", success)
+	}
+	success, err = charVindex.Verify(nil, "tester", ksids[0])
+	if err != nil {
+		t.Error(err)
+	}
+	if success {
+		t.Errorf("Verify(): %+v, want false", success)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"strings"
 	"time"
 
@@ -75,6 +76,9 @@ type VTGate struct {
 	maxInFlight int64
 	inFlight    sync2.AtomicInt64
 
+	// quotas are the per-caller quotas, nil if there are none.
+	quotas *callerQuotas
+
 	// the throttled loggers for all errors, one per API entry
 	logExecute                  *logutil.ThrottledLogger
 	logExecuteShards            *logutil.ThrottledLogger
@@ -107,6 +111,7 @@ func Init(hc discovery.HealthCheck, topoServer topo.Server, serv topo.SrvTopoSer
 
 		maxInFlight: int64(maxInFlight),
 		inFlight:    sync2.NewAtomicInt64(0),
+		quotas:      initCallerQuotas(),
 
 		logExecute:                  logutil.NewThrottledLogger("Execute", 5*time.Second),
 		logExecuteShards:            logutil.NewThrottledLogger("ExecuteShards", 5*time.Second),
@@ -122,6 +127,14 @@ func Init(hc discovery.HealthCheck, topoServer topo.Server, serv topo.SrvTopoSer
 	}
 	// Resuse resolver's scatterConn.
 	rpcVTGate.router = NewRouter(serv, cell, schema, "VTGateRouter", rpcVTGate.resolver.scatterConn)
+	http.Handle("/debug/query_plans", rpcVTGate.router.planner)
+	http.Handle("/debug/schema", rpcVTGate.router.planner)
+	QueryLogger.ServeLogs(*queryLogHandler, buildFmter(QueryLogger))
+	http.HandleFunc(*querylogzURL, func(w http.ResponseWriter, r *http.Request) {
+		ch := QueryLogger.Subscribe("querylogz")
+		defer QueryLogger.Unsubscribe(ch)
+		querylogzHandler(ch, w, r)
+	})
 	normalErrors = stats.NewMultiCounters("VtgateApiErrorCounts", []string{"Operation", "Keyspace", "DbType"})
 	infoErrors = stats.NewCounters("VtgateInfoErrorCounts")
 	internalErrors = stats.NewCounters("VtgateInternalErrorCounts")
@@ -139,6 +152,48 @@ func Init(hc discovery.HealthCheck, topoServer topo.Server, serv topo.SrvTopoSer
 	}
 }
 
+// WatchVSchema loads the V3 schema from topo and keeps
+// reloading it every time it changes, until ctx is done.
+func WatchVSchema(ctx context.Context, ts topo.Server) error {
+	return rpcVTGate.router.planner.WatchVSchema(ctx, ts)
+}
+
+// GetVSchemaStatus returns the status of the V3 schema,
+// for the status page.
+func GetVSchemaStatus() *VSchemaStatus {
+	return rpcVTGate.router.planner.Status()
+}
+
+// GetTabletWeights returns the current weights of the tablets,
+// for the status page. It returns nil if the gateway doesn't
+// use a tablet picker.
+func GetTabletWeights() []*TabletWeight {
+	if dg, ok := rpcVTGate.resolver.scatterConn.gateway.(*discoveryGateway); ok {
+		return dg.tabletWeights()
+	}
+	return nil
+}
+
+// newQueryLogStats returns the LogStats of a query of the API call
+// identified by statsKey, and a context that carries it down to
+// the router and ScatterConn.
+func newQueryLogStats(ctx context.Context, statsKey []string, tabletType topodatapb.TabletType, sql string, bindVariables map[string]interface{}) (*LogStats, context.Context) {
+	logStats := NewLogStats(ctx, statsKey[0], sql, bindVariables)
+	logStats.Keyspace = statsKey[1]
+	logStats.TabletType = strings.ToLower(tabletType.String())
+	return logStats, newContextWithLogStats(ctx, logStats)
+}
+
+// sessionTabletType returns the tablet type set in the session by
+// USE keyspace@tablet_type or SET target_tablet_type, which
+// overrides the one of the request, or tabletType if none is set.
+func sessionTabletType(tabletType topodatapb.TabletType, session *vtgatepb.Session) topodatapb.TabletType {
+	if session != nil && session.TabletType != topodatapb.TabletType_UNKNOWN {
+		return session.TabletType
+	}
+	return tabletType
+}
+
 // InitializeConnections pre-initializes VTGate by connecting to vttablets of all keyspace/shard/type.
 // It is not necessary to call this function before serving queries,
 // but it would reduce connection overhead when serving.
@@ -158,6 +213,7 @@ func (vtg *VTGate) InitializeConnections(ctx context.Context) (err error) {
 // Execute executes a non-streaming query by routing based on the values in the query.
 func (vtg *VTGate) Execute(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
 	startTime := time.Now()
+	tabletType = sessionTabletType(tabletType, session)
 	statsKey := []string{"Execute", "Any", strings.ToLower(tabletType.String())}
 	defer vtg.timings.Record(statsKey, startTime)
 
@@ -167,7 +223,23 @@ func (vtg *VTGate) Execute(ctx context.Context, sql string, bindVariables map[st
 		return nil, errTooManyInFlight
 	}
 
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, sql, bindVariables)
+	defer logStats.Send()
+
+	// The keyspaces of the query are only needed by the quotas.
+	var keyspaces []string
+	if vtg.quotas != nil {
+		keyspaces = vtg.router.keyspaces(sql, session)
+	}
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspaces...)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	qr, err := vtg.router.Execute(ctx, sql, bindVariables, tabletType, session, notInTransaction)
+	logStats.AddResult(qr)
+	logStats.Error = err
 	if err == nil {
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
 		return qr, nil
@@ -187,6 +259,7 @@ func (vtg *VTGate) Execute(ctx context.Context, sql string, bindVariables map[st
 // ExecuteShards executes a non-streaming query on the specified shards.
 func (vtg *VTGate) ExecuteShards(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, shards []string, tabletType topodatapb.TabletType, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
 	startTime := time.Now()
+	tabletType = sessionTabletType(tabletType, session)
 	statsKey := []string{"ExecuteShards", keyspace, strings.ToLower(tabletType.String())}
 	defer vtg.timings.Record(statsKey, startTime)
 
@@ -196,6 +269,15 @@ func (vtg *VTGate) ExecuteShards(ctx context.Context, sql string, bindVariables
 		return nil, errTooManyInFlight
 	}
 
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, sql, bindVariables)
+	defer logStats.Send()
+
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	sql = sqlannotation.AddFilteredReplicationUnfriendlyIfDML(sql)
 
 	qr, err := vtg.resolver.Execute(
@@ -210,6 +292,8 @@ func (vtg *VTGate) ExecuteShards(ctx context.Context, sql string, bindVariables
 		},
 		notInTransaction,
 	)
+	logStats.AddResult(qr)
+	logStats.Error = err
 	if err == nil {
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
 		return qr, nil
@@ -231,6 +315,7 @@ func (vtg *VTGate) ExecuteShards(ctx context.Context, sql string, bindVariables
 // ExecuteKeyspaceIds executes a non-streaming query based on the specified keyspace ids.
 func (vtg *VTGate) ExecuteKeyspaceIds(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, keyspaceIds [][]byte, tabletType topodatapb.TabletType, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
 	startTime := time.Now()
+	tabletType = sessionTabletType(tabletType, session)
 	statsKey := []string{"ExecuteKeyspaceIds", keyspace, strings.ToLower(tabletType.String())}
 	defer vtg.timings.Record(statsKey, startTime)
 
@@ -240,9 +325,20 @@ func (vtg *VTGate) ExecuteKeyspaceIds(ctx context.Context, sql string, bindVaria
 		return nil, errTooManyInFlight
 	}
 
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, sql, bindVariables)
+	defer logStats.Send()
+
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	sql = sqlannotation.AddIfDML(sql, keyspaceIds)
 
 	qr, err := vtg.resolver.ExecuteKeyspaceIds(ctx, sql, bindVariables, keyspace, keyspaceIds, tabletType, session, notInTransaction)
+	logStats.AddResult(qr)
+	logStats.Error = err
 	if err == nil {
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
 		return qr, nil
@@ -264,6 +360,7 @@ func (vtg *VTGate) ExecuteKeyspaceIds(ctx context.Context, sql string, bindVaria
 // ExecuteKeyRanges executes a non-streaming query based on the specified keyranges.
 func (vtg *VTGate) ExecuteKeyRanges(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, keyRanges []*topodatapb.KeyRange, tabletType topodatapb.TabletType, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
 	startTime := time.Now()
+	tabletType = sessionTabletType(tabletType, session)
 	statsKey := []string{"ExecuteKeyRanges", keyspace, strings.ToLower(tabletType.String())}
 	defer vtg.timings.Record(statsKey, startTime)
 
@@ -273,9 +370,20 @@ func (vtg *VTGate) ExecuteKeyRanges(ctx context.Context, sql string, bindVariabl
 		return nil, errTooManyInFlight
 	}
 
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, sql, bindVariables)
+	defer logStats.Send()
+
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	sql = sqlannotation.AddFilteredReplicationUnfriendlyIfDML(sql)
 
 	qr, err := vtg.resolver.ExecuteKeyRanges(ctx, sql, bindVariables, keyspace, keyRanges, tabletType, session, notInTransaction)
+	logStats.AddResult(qr)
+	logStats.Error = err
 	if err == nil {
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
 		return qr, nil
@@ -297,6 +405,7 @@ func (vtg *VTGate) ExecuteKeyRanges(ctx context.Context, sql string, bindVariabl
 // ExecuteEntityIds excutes a non-streaming query based on given KeyspaceId map.
 func (vtg *VTGate) ExecuteEntityIds(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, entityColumnName string, entityKeyspaceIDs []*vtgatepb.ExecuteEntityIdsRequest_EntityId, tabletType topodatapb.TabletType, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
 	startTime := time.Now()
+	tabletType = sessionTabletType(tabletType, session)
 	statsKey := []string{"ExecuteEntityIds", keyspace, strings.ToLower(tabletType.String())}
 	defer vtg.timings.Record(statsKey, startTime)
 
@@ -306,9 +415,20 @@ func (vtg *VTGate) ExecuteEntityIds(ctx context.Context, sql string, bindVariabl
 		return nil, errTooManyInFlight
 	}
 
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, sql, bindVariables)
+	defer logStats.Send()
+
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	sql = sqlannotation.AddFilteredReplicationUnfriendlyIfDML(sql)
 
 	qr, err := vtg.resolver.ExecuteEntityIds(ctx, sql, bindVariables, keyspace, entityColumnName, entityKeyspaceIDs, tabletType, session, notInTransaction)
+	logStats.AddResult(qr)
+	logStats.Error = err
 	if err == nil {
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
 		return qr, nil
@@ -331,6 +451,7 @@ func (vtg *VTGate) ExecuteEntityIds(ctx context.Context, sql string, bindVariabl
 // ExecuteBatchShards executes a group of queries on the specified shards.
 func (vtg *VTGate) ExecuteBatchShards(ctx context.Context, queries []*vtgatepb.BoundShardQuery, tabletType topodatapb.TabletType, asTransaction bool, session *vtgatepb.Session) ([]sqltypes.Result, error) {
 	startTime := time.Now()
+	tabletType = sessionTabletType(tabletType, session)
 	statsKey := []string{"ExecuteBatchShards", "", ""}
 	defer vtg.timings.Record(statsKey, startTime)
 
@@ -340,6 +461,21 @@ func (vtg *VTGate) ExecuteBatchShards(ctx context.Context, queries []*vtgatepb.B
 		return nil, errTooManyInFlight
 	}
 
+	sqls := make([]string, len(queries))
+	keyspaces := make([]string, len(queries))
+	for i, q := range queries {
+		sqls[i] = q.Query.Sql
+		keyspaces[i] = q.Keyspace
+	}
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, strings.Join(sqls, "; "), nil)
+	defer logStats.Send()
+
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspaces...)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	annotateBoundShardQueriesAsUnfriendly(queries)
 
 	qrs, err := vtg.resolver.ExecuteBatch(
@@ -350,6 +486,10 @@ func (vtg *VTGate) ExecuteBatchShards(ctx context.Context, queries []*vtgatepb.B
 		func() (*scatterBatchRequest, error) {
 			return boundShardQueriesToScatterBatchRequest(queries)
 		})
+	for i := range qrs {
+		logStats.AddResult(&qrs[i])
+	}
+	logStats.Error = err
 	if err == nil {
 		var rowCount int64
 		for _, qr := range qrs {
@@ -372,6 +512,7 @@ func (vtg *VTGate) ExecuteBatchShards(ctx context.Context, queries []*vtgatepb.B
 // ExecuteBatchKeyspaceIds executes a group of queries based on the specified keyspace ids.
 func (vtg *VTGate) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtgatepb.BoundKeyspaceIdQuery, tabletType topodatapb.TabletType, asTransaction bool, session *vtgatepb.Session) ([]sqltypes.Result, error) {
 	startTime := time.Now()
+	tabletType = sessionTabletType(tabletType, session)
 	statsKey := []string{"ExecuteBatchKeyspaceIds", "", ""}
 	defer vtg.timings.Record(statsKey, startTime)
 
@@ -381,6 +522,21 @@ func (vtg *VTGate) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtgat
 		return nil, errTooManyInFlight
 	}
 
+	sqls := make([]string, len(queries))
+	keyspaces := make([]string, len(queries))
+	for i, q := range queries {
+		sqls[i] = q.Query.Sql
+		keyspaces[i] = q.Keyspace
+	}
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, strings.Join(sqls, "; "), nil)
+	defer logStats.Send()
+
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspaces...)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	annotateBoundKeyspaceIDQueries(queries)
 
 	qrs, err := vtg.resolver.ExecuteBatchKeyspaceIds(
@@ -389,6 +545,10 @@ func (vtg *VTGate) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtgat
 		tabletType,
 		asTransaction,
 		session)
+	for i := range qrs {
+		logStats.AddResult(&qrs[i])
+	}
+	logStats.Error = err
 	if err == nil {
 		var rowCount int64
 		for _, qr := range qrs {
@@ -420,8 +580,22 @@ func (vtg *VTGate) StreamExecute(ctx context.Context, sql string, bindVariables
 		return errTooManyInFlight
 	}
 
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, sql, bindVariables)
+	defer logStats.Send()
+
+	// The keyspaces of the query are only needed by the quotas.
+	var keyspaces []string
+	if vtg.quotas != nil {
+		keyspaces = vtg.router.keyspaces(sql, nil)
+	}
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspaces...)
+	if err != nil {
+		return formatError(err)
+	}
+	defer release()
+
 	var rowCount int64
-	err := vtg.router.StreamExecute(
+	err = vtg.router.StreamExecute(
 		ctx,
 		sql,
 		bindVariables,
@@ -429,8 +603,10 @@ func (vtg *VTGate) StreamExecute(ctx context.Context, sql string, bindVariables
 		func(reply *sqltypes.Result) error {
 			rowCount += int64(len(reply.Rows))
 			vtg.rowsReturned.Add(statsKey, int64(len(reply.Rows)))
+			logStats.AddResult(reply)
 			return sendReply(reply)
 		})
+	logStats.Error = err
 
 	if err != nil {
 		normalErrors.Add(statsKey, 1)
@@ -461,8 +637,17 @@ func (vtg *VTGate) StreamExecuteKeyspaceIds(ctx context.Context, sql string, bin
 		return errTooManyInFlight
 	}
 
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, sql, bindVariables)
+	defer logStats.Send()
+
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspace)
+	if err != nil {
+		return formatError(err)
+	}
+	defer release()
+
 	var rowCount int64
-	err := vtg.resolver.StreamExecuteKeyspaceIds(
+	err = vtg.resolver.StreamExecuteKeyspaceIds(
 		ctx,
 		sql,
 		bindVariables,
@@ -472,8 +657,10 @@ func (vtg *VTGate) StreamExecuteKeyspaceIds(ctx context.Context, sql string, bin
 		func(reply *sqltypes.Result) error {
 			rowCount += int64(len(reply.Rows))
 			vtg.rowsReturned.Add(statsKey, int64(len(reply.Rows)))
+			logStats.AddResult(reply)
 			return sendReply(reply)
 		})
+	logStats.Error = err
 
 	if err != nil {
 		normalErrors.Add(statsKey, 1)
@@ -506,8 +693,17 @@ func (vtg *VTGate) StreamExecuteKeyRanges(ctx context.Context, sql string, bindV
 		return errTooManyInFlight
 	}
 
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, sql, bindVariables)
+	defer logStats.Send()
+
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspace)
+	if err != nil {
+		return formatError(err)
+	}
+	defer release()
+
 	var rowCount int64
-	err := vtg.resolver.StreamExecuteKeyRanges(
+	err = vtg.resolver.StreamExecuteKeyRanges(
 		ctx,
 		sql,
 		bindVariables,
@@ -517,8 +713,10 @@ func (vtg *VTGate) StreamExecuteKeyRanges(ctx context.Context, sql string, bindV
 		func(reply *sqltypes.Result) error {
 			rowCount += int64(len(reply.Rows))
 			vtg.rowsReturned.Add(statsKey, int64(len(reply.Rows)))
+			logStats.AddResult(reply)
 			return sendReply(reply)
 		})
+	logStats.Error = err
 
 	if err != nil {
 		normalErrors.Add(statsKey, 1)
@@ -546,8 +744,17 @@ func (vtg *VTGate) StreamExecuteShards(ctx context.Context, sql string, bindVari
 		return errTooManyInFlight
 	}
 
+	logStats, ctx := newQueryLogStats(ctx, statsKey, tabletType, sql, bindVariables)
+	defer logStats.Send()
+
+	ctx, release, err := vtg.acquireQuota(ctx, logStats, statsKey, keyspace)
+	if err != nil {
+		return formatError(err)
+	}
+	defer release()
+
 	var rowCount int64
-	err := vtg.resolver.StreamExecute(
+	err = vtg.resolver.StreamExecute(
 		ctx,
 		sql,
 		bindVariables,
@@ -559,8 +766,10 @@ func (vtg *VTGate) StreamExecuteShards(ctx context.Context, sql string, bindVari
 		func(reply *sqltypes.Result) error {
 			rowCount += int64(len(reply.Rows))
 			vtg.rowsReturned.Add(statsKey, int64(len(reply.Rows)))
+			logStats.AddResult(reply)
 			return sendReply(reply)
 		})
+	logStats.Error = err
 
 	if err != nil {
 		normalErrors.Add(statsKey, 1)
@@ -593,6 +802,11 @@ func (vtg *VTGate) Rollback(ctx context.Context, session *vtgatepb.Session) erro
 	return formatError(vtg.resolver.Rollback(ctx, session))
 }
 
+// ResolveTransaction resolves the specified distributed transaction.
+func (vtg *VTGate) ResolveTransaction(ctx context.Context, dtid string) error {
+	return formatError(vtg.resolver.ResolveTransaction(ctx, dtid))
+}
+
 // SplitQuery splits a query into sub queries by appending keyranges and
 // primary key range clauses. Rows corresponding to the sub queries
 // are guaranteed to be non-overlapping and will add up to the rows of
@@ -633,6 +847,11 @@ func (vtg *VTGate) GetSrvKeyspace(ctx context.Context, keyspace string) (*topoda
 	return vtg.resolver.toposerv.GetSrvKeyspace(ctx, vtg.resolver.cell, keyspace)
 }
 
+// Explain is part of the vtgate service API.
+func (vtg *VTGate) Explain(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	return vtg.router.Explain(ctx, sql, bindVariables, tabletType), nil
+}
+
 // GetSrvShard is part of the vtgate service API.
 func (vtg *VTGate) GetSrvShard(ctx context.Context, keyspace, shard string) (*topodatapb.SrvShard, error) {
 	return vtg.resolver.toposerv.GetSrvShard(ctx, vtg.resolver.cell, keyspace, shard)
@@ -683,6 +902,18 @@ func isErrorCausedByVTGate(err error) bool {
 	return false
 }
 
+// acquireQuota checks the quotas of the caller of a request to
+// keyspaces. A rejection is recorded in logStats and in the error
+// counters, like the other errors of the request.
+func (vtg *VTGate) acquireQuota(ctx context.Context, logStats *LogStats, statsKey []string, keyspaces ...string) (context.Context, func(), error) {
+	ctx, release, err := vtg.quotas.acquire(ctx, keyspaces...)
+	if err != nil {
+		logStats.Error = err
+		normalErrors.Add(statsKey, 1)
+	}
+	return ctx, release, err
+}
+
 func handleExecuteError(err error, statsKey []string, query map[string]interface{}, logger *logutil.ThrottledLogger) {
 	s := fmt.Sprintf(", vtgate: %v", servenv.ListeningURL.String())
 	newErr := vterrors.WithSuffix(err, s)
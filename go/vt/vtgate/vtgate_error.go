@@ -26,6 +26,7 @@ const (
 	PriorityUnknownError
 	PriorityInternalError
 	PriorityResourceExhausted
+	PriorityQuotaExceeded
 	PriorityUnauthenticated
 	PriorityPermissionDenied
 	PriorityBadInput
@@ -45,6 +46,7 @@ var errorPriorities = map[vtrpcpb.ErrorCode]int{
 	vtrpcpb.ErrorCode_INTERNAL_ERROR:     PriorityInternalError,
 	vtrpcpb.ErrorCode_TRANSIENT_ERROR:    PriorityTransientError,
 	vtrpcpb.ErrorCode_UNAUTHENTICATED:    PriorityUnauthenticated,
+	vtrpcpb.ErrorCode_QUOTA_EXCEEDED:     PriorityQuotaExceeded,
 }
 
 // aggregateVtGateErrorCodes aggregates a list of errors into a single error code.
@@ -169,6 +169,32 @@ func TestVTGateExecuteShards(t *testing.T) {
 	*/
 }
 
+func TestVTGateExecuteShardsSessionTabletType(t *testing.T) {
+	sandbox := createSandbox("TestVTGateExecuteShardsSessionTabletType")
+	sandbox.MapTestConn("0", &sandboxConn{})
+
+	// The tablet type of the session overrides the one of the request.
+	session, err := rpcVTGate.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	session.TabletType = topodatapb.TabletType_RDONLY
+	if _, err := rpcVTGate.ExecuteShards(context.Background(),
+		"query",
+		nil,
+		"TestVTGateExecuteShardsSessionTabletType",
+		[]string{"0"},
+		topodatapb.TabletType_REPLICA,
+		session,
+		false); err != nil {
+		t.Fatal(err)
+	}
+	if len(session.ShardSessions) != 1 || session.ShardSessions[0].Target.TabletType != topodatapb.TabletType_RDONLY {
+		t.Errorf("ShardSessions: %+v, want one rdonly session", session.ShardSessions)
+	}
+	rpcVTGate.Rollback(context.Background(), session)
+}
+
 func TestVTGateExecuteKeyspaceIds(t *testing.T) {
 	s := createSandbox("TestVTGateExecuteKeyspaceIds")
 	sbc1 := &sandboxConn{}
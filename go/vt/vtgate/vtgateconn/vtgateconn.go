@@ -40,6 +40,15 @@ func (conn *VTGateConn) Execute(ctx context.Context, query string, bindVars map[
 	return res, err
 }
 
+// Session returns a new VTGateSession, to send V3 queries that
+// depend on the state set by USE and SET statements.
+func (conn *VTGateConn) Session() *VTGateSession {
+	return &VTGateSession{
+		impl:    conn.impl,
+		session: &vtgatepb.Session{},
+	}
+}
+
 // ExecuteShards executes a non-streaming query for multiple shards on vtgate.
 func (conn *VTGateConn) ExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*sqltypes.Result, error) {
 	res, _, err := conn.impl.ExecuteShards(ctx, query, keyspace, shards, bindVars, tabletType, false, nil)
@@ -186,11 +195,41 @@ func (conn *VTGateConn) SplitQuery(ctx context.Context, keyspace string, query s
 	return conn.impl.SplitQuery(ctx, keyspace, query, bindVars, splitColumn, splitCount)
 }
 
+// ResolveTransaction resolves the distributed transaction dtid,
+// which was abandoned by the vtgate that started it.
+func (conn *VTGateConn) ResolveTransaction(ctx context.Context, dtid string) error {
+	return conn.impl.ResolveTransaction(ctx, dtid)
+}
+
+// Explain returns how vtgate routes a V3 query, and the shards it
+// is sent to for bindVars, without executing it.
+func (conn *VTGateConn) Explain(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	return conn.impl.Explain(ctx, query, bindVars, tabletType)
+}
+
 // GetSrvKeyspace returns a topo.SrvKeyspace object.
 func (conn *VTGateConn) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	return conn.impl.GetSrvKeyspace(ctx, keyspace)
 }
 
+// VTGateSession keeps the state set by the USE and SET statements,
+// like the target keyspace and tablet type, across the queries it
+// sends. It should not be concurrently used across goroutines.
+type VTGateSession struct {
+	impl    Impl
+	session interface{}
+}
+
+// Execute executes a non-streaming query on vtgate, in the session.
+// This is using v3 API.
+func (sn *VTGateSession) Execute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*sqltypes.Result, error) {
+	res, session, err := sn.impl.Execute(ctx, query, bindVars, tabletType, false, sn.session)
+	if session != nil {
+		sn.session = session
+	}
+	return res, err
+}
+
 // VTGateTx defines an ongoing transaction.
 // It should not be concurrently used across goroutines.
 type VTGateTx struct {
@@ -381,10 +420,16 @@ type Impl interface {
 	// Rollback rolls back the current transaction.
 	Rollback2(ctx context.Context, session interface{}) error
 
+	// ResolveTransaction resolves the specified distributed transaction.
+	ResolveTransaction(ctx context.Context, dtid string) error
+
 	// SplitQuery splits a query into equally sized smaller queries by
 	// appending primary key range clauses to the original query.
 	SplitQuery(ctx context.Context, keyspace string, query string, bindVars map[string]interface{}, splitColumn string, splitCount int64) ([]*vtgatepb.SplitQueryResponse_Part, error)
 
+	// Explain returns the plan of a V3 query, without executing it.
+	Explain(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error)
+
 	// GetSrvKeyspace returns a topo.SrvKeyspace.
 	GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error)
 
@@ -5,10 +5,16 @@
 package vtgateconn
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
 	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
 )
 
 func TestRegisterDialer(t *testing.T) {
@@ -33,3 +39,31 @@ func TestGetDialerWithProtocol(t *testing.T) {
 		t.Fatalf("dialerFunc has been registered, should not get nil: %v %v", err, c)
 	}
 }
+
+// sessionImpl is an Impl whose Execute records the sessions it gets,
+// and sets the keyspace of the session to the query.
+type sessionImpl struct {
+	Impl
+	sessions []vtgatepb.Session
+}
+
+func (si *sessionImpl) Execute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, notInTransaction bool, session interface{}) (*sqltypes.Result, interface{}, error) {
+	s := session.(*vtgatepb.Session)
+	si.sessions = append(si.sessions, *s)
+	s.Keyspace = query
+	return &sqltypes.Result{}, s, nil
+}
+
+func TestSession(t *testing.T) {
+	si := &sessionImpl{}
+	sn := (&VTGateConn{impl: si}).Session()
+	for _, query := range []string{"ks1", "ks2"} {
+		if _, err := sn.Execute(context.Background(), query, nil, topodatapb.TabletType_MASTER); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []vtgatepb.Session{{}, {Keyspace: "ks1"}}
+	if !reflect.DeepEqual(si.sessions, want) {
+		t.Errorf("sessions: %+v, want %+v", si.sessions, want)
+	}
+}
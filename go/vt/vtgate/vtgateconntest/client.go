@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/tb"
 	"github.com/youtube/vitess/go/vt/callerid"
@@ -598,6 +599,21 @@ func (f *fakeVTGateService) Rollback(ctx context.Context, inSession *vtgatepb.Se
 	return nil
 }
 
+// ResolveTransaction is part of the VTGateService interface
+func (f *fakeVTGateService) ResolveTransaction(ctx context.Context, dtid string) error {
+	if f.hasError {
+		return errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "ResolveTransaction")
+	if dtid != dtid2 {
+		return errors.New("ResolveTransaction: dtid mismatch")
+	}
+	return nil
+}
+
 // querySplitQuery contains all the fields we use to test SplitQuery
 type querySplitQuery struct {
 	Keyspace      string
@@ -629,6 +645,33 @@ func (f *fakeVTGateService) SplitQuery(ctx context.Context, keyspace string, sql
 	return splitQueryResult, nil
 }
 
+// queryExplain contains all the fields we use to test Explain
+type queryExplain struct {
+	SQL           string
+	BindVariables map[string]interface{}
+	TabletType    topodatapb.TabletType
+}
+
+// Explain is part of the VTGateService interface
+func (f *fakeVTGateService) Explain(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error) {
+	if f.hasError {
+		return nil, errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "Explain")
+	query := &queryExplain{
+		SQL:           sql,
+		BindVariables: bindVariables,
+		TabletType:    tabletType,
+	}
+	if !reflect.DeepEqual(query, explainRequest) {
+		f.t.Errorf("Explain has wrong input: got %#v wanted %#v", query, explainRequest)
+	}
+	return explainResult, nil
+}
+
 // GetSrvKeyspace is part of the VTGateService interface
 func (f *fakeVTGateService) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	if f.hasError {
@@ -703,7 +746,9 @@ func TestSuite(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGa
 	testTx2Pass(t, conn)
 	testTx2PassNotInTransaction(t, conn)
 	testTx2Fail(t, conn)
+	testResolveTransaction(t, conn)
 	testSplitQuery(t, conn)
+	testExplain(t, conn)
 	testGetSrvKeyspace(t, conn)
 
 	// force a panic at every call, then test that works
@@ -730,7 +775,9 @@ func TestSuite(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGa
 	testStreamExecuteShardsPanic(t, conn)
 	testStreamExecuteKeyRangesPanic(t, conn)
 	testStreamExecuteKeyspaceIdsPanic(t, conn)
+	testResolveTransactionPanic(t, conn)
 	testSplitQueryPanic(t, conn)
+	testExplainPanic(t, conn)
 	testGetSrvKeyspacePanic(t, conn)
 	fs.panics = false
 }
@@ -772,7 +819,9 @@ func TestErrorSuite(t *testing.T, fakeServer vtgateservice.VTGateService) {
 	testStreamExecuteKeyRanges2Error(t, conn, fs)
 	testStreamExecuteKeyspaceIdsError(t, conn, fs)
 	testStreamExecuteKeyspaceIds2Error(t, conn, fs)
+	testResolveTransactionError(t, conn)
 	testSplitQueryError(t, conn)
+	testExplainError(t, conn)
 	testGetSrvKeyspaceError(t, conn)
 	fs.hasError = false
 }
@@ -2077,6 +2126,48 @@ func testSplitQueryPanic(t *testing.T, conn *vtgateconn.VTGateConn) {
 	expectPanic(t, err)
 }
 
+func testResolveTransaction(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	if err := conn.ResolveTransaction(ctx, dtid2); err != nil {
+		t.Fatalf("ResolveTransaction failed: %v", err)
+	}
+}
+
+func testResolveTransactionError(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	err := conn.ResolveTransaction(ctx, dtid2)
+	verifyError(t, err, "ResolveTransaction")
+}
+
+func testResolveTransactionPanic(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	err := conn.ResolveTransaction(ctx, dtid2)
+	expectPanic(t, err)
+}
+
+func testExplain(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	plan, err := conn.Explain(ctx, explainRequest.SQL, explainRequest.BindVariables, explainRequest.TabletType)
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if !proto.Equal(plan, explainResult) {
+		t.Errorf("Explain returned wrong result: got %+v wanted %+v", plan, explainResult)
+	}
+}
+
+func testExplainError(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	_, err := conn.Explain(ctx, explainRequest.SQL, explainRequest.BindVariables, explainRequest.TabletType)
+	verifyError(t, err, "Explain")
+}
+
+func testExplainPanic(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	_, err := conn.Explain(ctx, explainRequest.SQL, explainRequest.BindVariables, explainRequest.TabletType)
+	expectPanic(t, err)
+}
+
 func testGetSrvKeyspace(t *testing.T, conn *vtgateconn.VTGateConn) {
 	ctx := newContext()
 	sk, err := conn.GetSrvKeyspace(ctx, getSrvKeyspaceKeyspace)
@@ -2640,6 +2731,32 @@ var splitQueryResult = []*vtgatepb.SplitQueryResponse_Part{
 	},
 }
 
+var dtid2 = "aa"
+
+var explainRequest = &queryExplain{
+	SQL: "select * from user where id = :id",
+	BindVariables: map[string]interface{}{
+		"id": int64(1),
+	},
+	TabletType: topodatapb.TabletType_REPLICA,
+}
+
+var explainResult = &vtgatepb.QueryPlan{
+	PlanId:       "SelectEqual",
+	Table:        "user",
+	Keyspace:     "user",
+	Vindex:       "user_index",
+	RewrittenSql: "select * from user where id = :id",
+	Shards:       []string{"-80"},
+	Pullouts: []*vtgatepb.QueryPlan{
+		&vtgatepb.QueryPlan{
+			PlanId:     "SelectUnsharded",
+			Keyspace:   "main",
+			ShardError: "missing bind var",
+		},
+	},
+}
+
 var getSrvKeyspaceKeyspace = "test_keyspace"
 
 var getSrvKeyspaceResult = &topodatapb.SrvKeyspace{
@@ -37,10 +37,16 @@ type VTGateService interface {
 	Begin(ctx context.Context) (*vtgatepb.Session, error)
 	Commit(ctx context.Context, session *vtgatepb.Session) error
 	Rollback(ctx context.Context, session *vtgatepb.Session) error
+	// ResolveTransaction resolves a distributed transaction that
+	// was abandoned by the vtgate that started it.
+	ResolveTransaction(ctx context.Context, dtid string) error
 
 	// Map Reduce support
 	SplitQuery(ctx context.Context, keyspace string, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64) ([]*vtgatepb.SplitQueryResponse_Part, error)
 
+	// Explain returns how a V3 query is routed, without executing it.
+	Explain(ctx context.Context, sql string, bindVariables map[string]interface{}, tabletType topodatapb.TabletType) (*vtgatepb.QueryPlan, error)
+
 	// Topology support
 	GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error)
 
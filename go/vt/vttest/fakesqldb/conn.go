@@ -8,6 +8,7 @@ package fakesqldb
 import (
 	"fmt"
 	"math/rand"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +35,7 @@ type DB struct {
 	Name         string
 	isConnFail   bool
 	data         map[string]*sqltypes.Result
+	patternData  []exprResult
 	rejectedData map[string]error
 	queryCalled  map[string]int
 	mu           sync.Mutex
@@ -50,14 +52,41 @@ func (db *DB) AddQuery(query string, expectedResult *sqltypes.Result) {
 	db.queryCalled[key] = 0
 }
 
+// exprResult is the expected result of the queries
+// that match a pattern.
+type exprResult struct {
+	expr   *regexp.Regexp
+	result *sqltypes.Result
+}
+
+// AddQueryPattern adds the expected result of the queries that
+// match queryPattern, a regular expression that must match the
+// whole query, regardless of case. The queries added by AddQuery take
+// precedence.
+func (db *DB) AddQueryPattern(queryPattern string, expectedResult *sqltypes.Result) {
+	expr := regexp.MustCompile("(?is)^" + queryPattern + "$")
+	result := &sqltypes.Result{}
+	*result = *expectedResult
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.patternData = append(db.patternData, exprResult{expr, result})
+}
+
 // GetQuery gets a query from the fake DB.
 func (db *DB) GetQuery(query string) (*sqltypes.Result, bool) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 	key := strings.ToLower(query)
-	result, ok := db.data[key]
 	db.queryCalled[key]++
-	return result, ok
+	if result, ok := db.data[key]; ok {
+		return result, true
+	}
+	for _, pat := range db.patternData {
+		if pat.expr.MatchString(key) {
+			return pat.result, true
+		}
+	}
+	return nil, false
 }
 
 // DeleteQuery deletes query from the fake DB.
@@ -0,0 +1,114 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlib
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/logutil"
+	"github.com/youtube/vitess/go/vt/mysqlctl/tmutils"
+	"github.com/youtube/vitess/go/vt/tabletmanager/tmclient"
+	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
+	"github.com/youtube/vitess/go/vt/wrangler"
+	"github.com/youtube/vitess/go/vt/zktopo"
+
+	tabletmanagerdatapb "github.com/youtube/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestApplyVSchema(t *testing.T) {
+	ctx := context.Background()
+	db := fakesqldb.Register()
+	ts := zktopo.NewTestServer(t, []string{"cell1"})
+	wr := wrangler.New(logutil.NewConsoleLogger(), ts, tmclient.NewTabletManagerClient())
+	vp := NewVtctlPipe(t, ts)
+	defer vp.Close()
+
+	master := NewFakeTablet(t, wr, "cell1", 0,
+		topodatapb.TabletType_MASTER, db, TabletKeyspaceShard(t, "ks", "-80"))
+	master.FakeMysqlDaemon.Schema = &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+			{
+				Name:    "user",
+				Columns: []string{"id", "name"},
+				Type:    tmutils.TableBaseTable,
+			},
+		},
+	}
+	master.StartActionLoop(t, wr)
+	defer master.StopActionLoop(t)
+
+	valid := `{
+  "Sharded": true,
+  "Vindexes": {
+    "user_index": {"Type": "hash", "Owner": "user"}
+  },
+  "Classes": {
+    "user": {"ColVindexes": [{"Col": "id", "Name": "user_index"}]}
+  },
+  "Tables": {"user": "user"}
+}`
+	if err := vp.Run([]string{"ApplyVSchema", "-keyspace", "ks", "-vschema", valid}); err != nil {
+		t.Fatalf("ApplyVSchema failed: %v", err)
+	}
+	got, err := ts.GetVSchema(ctx, "ks")
+	if err != nil {
+		t.Fatalf("GetVSchema failed: %v", err)
+	}
+	if got != valid {
+		t.Errorf("GetVSchema: %s, want %s", got, valid)
+	}
+
+	testcases := []struct {
+		desc    string
+		args    []string
+		wantErr string
+	}{{
+		desc:    "no keyspace",
+		args:    []string{"ApplyVSchema", "-vschema", valid},
+		wantErr: "keyspace flag must be specified",
+	}, {
+		desc:    "unknown keyspace",
+		args:    []string{"ApplyVSchema", "-keyspace", "nokeyspace", "-vschema", valid},
+		wantErr: "cannot get keyspace nokeyspace",
+	}, {
+		desc:    "unknown vindex type",
+		args:    []string{"ApplyVSchema", "-keyspace", "ks", "-vschema", strings.Replace(valid, `"hash"`, `"nohash"`, 1)},
+		wantErr: "vindex user_index: vindexType nohash not found",
+	}, {
+		desc:    "unknown owner",
+		args:    []string{"ApplyVSchema", "-keyspace", "ks", "-vschema", strings.Replace(valid, `"Owner": "user"`, `"Owner": "nouser"`, 1)},
+		wantErr: "vindex user_index: owner table nouser is not in keyspace ks",
+	}, {
+		desc:    "unknown class",
+		args:    []string{"ApplyVSchema", "-keyspace", "ks", "-vschema", strings.Replace(valid, `"Tables": {"user": "user"}`, `"Tables": {"user": "noclass"}`, 1)},
+		wantErr: "class noclass not found for table user",
+	}, {
+		desc:    "table not in the tablet schema",
+		args:    []string{"ApplyVSchema", "-keyspace", "ks", "-vschema", strings.Replace(valid, `"Tables": {"user": "user"}`, `"Tables": {"user": "user", "music": "user"}`, 1)},
+		wantErr: "table music is not in the schema of ks/-80",
+	}, {
+		desc:    "column not in the tablet schema",
+		args:    []string{"ApplyVSchema", "-keyspace", "ks", "-vschema", strings.Replace(valid, `"Col": "id"`, `"Col": "noid"`, 1)},
+		wantErr: "column noid of table user is not in the schema of ks/-80",
+	}}
+	for _, tc := range testcases {
+		err := vp.Run(tc.args)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%s: ApplyVSchema: %v, want %s", tc.desc, err, tc.wantErr)
+		}
+		// Nothing must be written when the validation fails.
+		got, err := ts.GetVSchema(ctx, "ks")
+		if err != nil {
+			t.Fatalf("GetVSchema failed: %v", err)
+		}
+		if got != valid {
+			t.Errorf("%s: GetVSchema: %s, want %s", tc.desc, got, valid)
+		}
+	}
+}
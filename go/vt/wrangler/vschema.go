@@ -0,0 +1,150 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wrangler
+
+import (
+	"fmt"
+	"sort"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/vt/topo/topoproto"
+	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
+	"golang.org/x/net/context"
+
+	// vindexes needs to be imported so that they register
+	// themselves against vtgate/planbuilder, to validate
+	// the vschemas before they're saved.
+	_ "github.com/youtube/vitess/go/vt/vtgate/vindexes"
+)
+
+// ApplyVSchema validates the JSON vschema of a keyspace and saves
+// it in the topo server. Nothing is saved if the vschema is invalid.
+func (wr *Wrangler) ApplyVSchema(ctx context.Context, keyspace, vschema string) error {
+	if err := wr.validateVSchema(ctx, keyspace, vschema); err != nil {
+		return err
+	}
+	return wr.ts.SaveVSchema(ctx, keyspace, vschema)
+}
+
+// validateVSchema checks that vschema can replace the current
+// vschema of keyspace:
+// - all its vindexes can be created,
+// - it builds along with the vschemas of the other keyspaces,
+// - the owner tables of its vindexes are in the keyspace,
+// - its tables, and the columns it uses, are in the schema of the
+// master of the first shard of the keyspace.
+func (wr *Wrangler) validateVSchema(ctx context.Context, keyspace, vschema string) error {
+	ks, err := planbuilder.NewKeyspaceFormal([]byte(vschema))
+	if err != nil {
+		return err
+	}
+	if _, err := wr.ts.GetKeyspace(ctx, keyspace); err != nil {
+		return fmt.Errorf("cannot get keyspace %v: %v", keyspace, err)
+	}
+
+	for vname, vindexInfo := range ks.Vindexes {
+		if _, err := planbuilder.CreateVindex(vindexInfo.Type, vindexInfo.Params); err != nil {
+			return fmt.Errorf("vindex %v: %v", vname, err)
+		}
+		if vindexInfo.Owner == "" {
+			continue
+		}
+		if _, ok := ks.Tables[vindexInfo.Owner]; !ok {
+			return fmt.Errorf("vindex %v: owner table %v is not in keyspace %v", vname, vindexInfo.Owner, keyspace)
+		}
+	}
+
+	// The vschemas of the other keyspaces are needed to resolve
+	// the sequences, and to check that no table is defined twice.
+	keyspaces, err := wr.ts.GetKeyspaces(ctx)
+	if err != nil {
+		return err
+	}
+	source := &planbuilder.SchemaFormal{Keyspaces: make(map[string]planbuilder.KeyspaceFormal)}
+	for _, other := range keyspaces {
+		if other == keyspace {
+			continue
+		}
+		otherVSchema, err := wr.ts.GetVSchema(ctx, other)
+		if err != nil {
+			return err
+		}
+		otherKs, err := planbuilder.NewKeyspaceFormal([]byte(otherVSchema))
+		if err != nil {
+			return fmt.Errorf("vschema of keyspace %v: %v", other, err)
+		}
+		source.Keyspaces[other] = *otherKs
+	}
+	source.Keyspaces[keyspace] = *ks
+	if _, err := planbuilder.BuildSchema(source); err != nil {
+		return err
+	}
+
+	if len(ks.Tables) == 0 {
+		return nil
+	}
+	return wr.validateVSchemaColumns(ctx, keyspace, ks)
+}
+
+// validateVSchemaColumns checks the tables and columns of ks against
+// the schema of the master of the first shard of the keyspace.
+func (wr *Wrangler) validateVSchemaColumns(ctx context.Context, keyspace string, ks *planbuilder.KeyspaceFormal) error {
+	shards, err := wr.ts.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	if len(shards) == 0 {
+		return fmt.Errorf("No shards in keyspace %v", keyspace)
+	}
+	sort.Strings(shards)
+	si, err := wr.ts.GetShard(ctx, keyspace, shards[0])
+	if err != nil {
+		return err
+	}
+	if !si.HasMaster() {
+		return fmt.Errorf("No master in shard %v/%v", keyspace, shards[0])
+	}
+	log.Infof("Gathering schema for master %v", topoproto.TabletAliasString(si.MasterAlias))
+	sd, err := wr.GetSchema(ctx, si.MasterAlias, nil, nil, true /* includeViews */)
+	if err != nil {
+		return err
+	}
+	columns := make(map[string]map[string]bool, len(sd.TableDefinitions))
+	for _, td := range sd.TableDefinitions {
+		columns[td.Name] = make(map[string]bool, len(td.Columns))
+		for _, col := range td.Columns {
+			columns[td.Name][col] = true
+		}
+	}
+
+	for tname, cname := range ks.Tables {
+		tableColumns, ok := columns[tname]
+		if !ok {
+			return fmt.Errorf("table %v is not in the schema of %v/%v", tname, keyspace, shards[0])
+		}
+		class, ok := ks.Classes[cname]
+		if !ok {
+			// Unsharded tables don't need a class.
+			continue
+		}
+		var cols []string
+		for _, ind := range class.ColVindexes {
+			if len(ind.Cols) != 0 {
+				cols = append(cols, ind.Cols...)
+			} else {
+				cols = append(cols, ind.Col)
+			}
+		}
+		if class.Autoinc != nil {
+			cols = append(cols, class.Autoinc.Col)
+		}
+		for _, col := range cols {
+			if !tableColumns[col] {
+				return fmt.Errorf("column %v of table %v is not in the schema of %v/%v", col, tname, keyspace, shards[0])
+			}
+		}
+	}
+	return nil
+}
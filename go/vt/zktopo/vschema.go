@@ -5,13 +5,14 @@
 package zktopo
 
 import (
+	"path"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/vt/topo"
 	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
-	"golang.org/x/net/context"
-	// vindexes needs to be imported so that they register
-	// themselves against vtgate/planbuilder. This will allow
-	// us to sanity check the schema being uploaded.
-	_ "github.com/youtube/vitess/go/vt/vtgate/vindexes"
 	"github.com/youtube/vitess/go/zk"
+	"golang.org/x/net/context"
 	"launchpad.net/gozk/zookeeper"
 )
 
@@ -19,23 +20,28 @@ import (
 This file contains the vschema management code for zktopo.Server
 */
 
-const (
-	globalVSchemaPath = "/zk/global/vt/vschema"
-)
+func vschemaPath(keyspace string) string {
+	return path.Join(globalKeyspacesPath, keyspace, "vschema")
+}
 
-// SaveVSchema saves the JSON vschema into the topo.
-func (zkts *Server) SaveVSchema(ctx context.Context, vschema string) error {
-	_, err := planbuilder.NewSchema([]byte(vschema))
+// SaveVSchema saves the JSON vschema of a keyspace into the topo.
+func (zkts *Server) SaveVSchema(ctx context.Context, keyspace, vschema string) error {
+	if _, err := planbuilder.NewKeyspaceFormal([]byte(vschema)); err != nil {
+		return err
+	}
+	_, err := zk.CreateOrUpdate(zkts.zconn, vschemaPath(keyspace), vschema, 0, zookeeper.WorldACL(zookeeper.PERM_ALL), false)
 	if err != nil {
+		if zookeeper.IsError(err, zookeeper.ZNONODE) {
+			return topo.ErrNoNode
+		}
 		return err
 	}
-	_, err = zk.CreateOrUpdate(zkts.zconn, globalVSchemaPath, vschema, 0, zookeeper.WorldACL(zookeeper.PERM_ALL), true)
-	return err
+	return nil
 }
 
-// GetVSchema fetches the JSON vschema from the topo.
-func (zkts *Server) GetVSchema(ctx context.Context) (string, error) {
-	data, _, err := zkts.zconn.Get(globalVSchemaPath)
+// GetVSchema fetches the JSON vschema of a keyspace from the topo.
+func (zkts *Server) GetVSchema(ctx context.Context, keyspace string) (string, error) {
+	data, _, err := zkts.zconn.Get(vschemaPath(keyspace))
 	if err != nil {
 		if zookeeper.IsError(err, zookeeper.ZNONODE) {
 			return "{}", nil
@@ -44,3 +50,96 @@ func (zkts *Server) GetVSchema(ctx context.Context) (string, error) {
 	}
 	return data, nil
 }
+
+// WatchVSchema is part of the topo.Server interface.
+func (zkts *Server) WatchVSchema(ctx context.Context, keyspace string) (<-chan string, chan<- struct{}, error) {
+	filePath := vschemaPath(keyspace)
+	notifications := make(chan string, 10)
+	stopWatching := make(chan struct{})
+
+	// send will return false if stopWatching is triggered
+	// while the notification is waiting to be received.
+	send := func(vschema string) bool {
+		select {
+		case notifications <- vschema:
+			return true
+		case <-stopWatching:
+			close(notifications)
+			return false
+		}
+	}
+
+	// waitOrInterrupted will return true if stopWatching is triggered
+	waitOrInterrupted := func() bool {
+		timer := time.After(WatchSleepDuration)
+		select {
+		case <-stopWatching:
+			close(notifications)
+			return true
+		case <-timer:
+		}
+		return false
+	}
+
+	go func() {
+		for {
+			// set the watch
+			data, _, watch, err := zkts.zconn.GetW(filePath)
+			if err != nil {
+				if !zookeeper.IsError(err, zookeeper.ZNONODE) {
+					log.Errorf("Cannot set watch on %v, waiting for %v to retry: %v", filePath, WatchSleepDuration, err)
+					if waitOrInterrupted() {
+						return
+					}
+					continue
+				}
+
+				// The vschema was never saved. Wait for it to be,
+				// unless it was created since GetW.
+				stat, existsWatch, err := zkts.zconn.ExistsW(filePath)
+				if err != nil {
+					log.Errorf("Cannot set watch on %v, waiting for %v to retry: %v", filePath, WatchSleepDuration, err)
+					if waitOrInterrupted() {
+						return
+					}
+					continue
+				}
+				if stat != nil {
+					continue
+				}
+				data = "{}"
+				watch = existsWatch
+			}
+
+			// send the current value
+			if !send(data) {
+				return
+			}
+
+			// now act on the watch
+			select {
+			case event, ok := <-watch:
+				if !ok {
+					log.Warningf("watch on %v was closed, waiting for %v to retry", filePath, WatchSleepDuration)
+					if waitOrInterrupted() {
+						return
+					}
+					continue
+				}
+
+				if !event.Ok() {
+					log.Warningf("received a non-OK event for %v, waiting for %v to retry", filePath, WatchSleepDuration)
+					if waitOrInterrupted() {
+						return
+					}
+				}
+			case <-stopWatching:
+				// user is not interested any more
+				close(notifications)
+				return
+			}
+		}
+	}()
+
+	return notifications, stopWatching, nil
+}
@@ -91,6 +91,13 @@ func TestVSchema(t *testing.T) {
 	test.CheckVSchema(ctx, t, ts)
 }
 
+func TestWatchVSchema(t *testing.T) {
+	WatchSleepDuration = 2 * time.Millisecond
+	ts := newTestServer(t, []string{"test"})
+	defer ts.Close()
+	test.CheckWatchVSchema(context.Background(), t, ts)
+}
+
 // TestPurgeActions is a ZK specific unit test
 func TestPurgeActions(t *testing.T) {
 	ctx := context.Background()
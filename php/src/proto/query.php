@@ -49,6 +49,15 @@ namespace query {
     const TUPLE = 28;
   }
 }
+namespace query {
+
+  class TransactionState extends \DrSlump\Protobuf\Enum {
+    const UNKNOWN = 0;
+    const PREPARE = 1;
+    const COMMIT = 2;
+    const ROLLBACK = 3;
+  }
+}
 namespace query {
 
   class Target extends \DrSlump\Protobuf\Message {
@@ -4749,3 +4758,2444 @@ namespace query {
   }
 }
 
+namespace query {
+
+  class PrepareRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $effective_caller_id = null;
+    
+    /**  @var \query\VTGateCallerID */
+    public $immediate_caller_id = null;
+    
+    /**  @var \query\Target */
+    public $target = null;
+    
+    /**  @var int */
+    public $transaction_id = null;
+    
+    /**  @var string */
+    public $dtid = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.PrepareRequest');
+
+      // OPTIONAL MESSAGE effective_caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "effective_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE immediate_caller_id = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "immediate_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\VTGateCallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE target = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "target";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      // OPTIONAL INT64 transaction_id = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "transaction_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_INT64;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING dtid = 5
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 5;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <effective_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasEffectiveCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <effective_caller_id> value
+     *
+     * @return \query\PrepareRequest
+     */
+    public function clearEffectiveCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <effective_caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getEffectiveCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <effective_caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \query\PrepareRequest
+     */
+    public function setEffectiveCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <immediate_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasImmediateCallerId(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <immediate_caller_id> value
+     *
+     * @return \query\PrepareRequest
+     */
+    public function clearImmediateCallerId(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <immediate_caller_id> value
+     *
+     * @return \query\VTGateCallerID
+     */
+    public function getImmediateCallerId(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <immediate_caller_id> value
+     *
+     * @param \query\VTGateCallerID $value
+     * @return \query\PrepareRequest
+     */
+    public function setImmediateCallerId(\query\VTGateCallerID $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <target> has a value
+     *
+     * @return boolean
+     */
+    public function hasTarget(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <target> value
+     *
+     * @return \query\PrepareRequest
+     */
+    public function clearTarget(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <target> value
+     *
+     * @return \query\Target
+     */
+    public function getTarget(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <target> value
+     *
+     * @param \query\Target $value
+     * @return \query\PrepareRequest
+     */
+    public function setTarget(\query\Target $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <transaction_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasTransactionId(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <transaction_id> value
+     *
+     * @return \query\PrepareRequest
+     */
+    public function clearTransactionId(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <transaction_id> value
+     *
+     * @return int
+     */
+    public function getTransactionId(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <transaction_id> value
+     *
+     * @param int $value
+     * @return \query\PrepareRequest
+     */
+    public function setTransactionId( $value){
+      return $this->_set(4, $value);
+    }
+    
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(5);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \query\PrepareRequest
+     */
+    public function clearDtid(){
+      return $this->_clear(5);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(5);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \query\PrepareRequest
+     */
+    public function setDtid( $value){
+      return $this->_set(5, $value);
+    }
+  }
+}
+
+namespace query {
+
+  class PrepareResponse extends \DrSlump\Protobuf\Message {
+
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.PrepareResponse');
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+  }
+}
+
+namespace query {
+
+  class CommitPreparedRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $effective_caller_id = null;
+    
+    /**  @var \query\VTGateCallerID */
+    public $immediate_caller_id = null;
+    
+    /**  @var \query\Target */
+    public $target = null;
+    
+    /**  @var string */
+    public $dtid = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.CommitPreparedRequest');
+
+      // OPTIONAL MESSAGE effective_caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "effective_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE immediate_caller_id = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "immediate_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\VTGateCallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE target = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "target";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING dtid = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <effective_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasEffectiveCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <effective_caller_id> value
+     *
+     * @return \query\CommitPreparedRequest
+     */
+    public function clearEffectiveCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <effective_caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getEffectiveCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <effective_caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \query\CommitPreparedRequest
+     */
+    public function setEffectiveCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <immediate_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasImmediateCallerId(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <immediate_caller_id> value
+     *
+     * @return \query\CommitPreparedRequest
+     */
+    public function clearImmediateCallerId(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <immediate_caller_id> value
+     *
+     * @return \query\VTGateCallerID
+     */
+    public function getImmediateCallerId(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <immediate_caller_id> value
+     *
+     * @param \query\VTGateCallerID $value
+     * @return \query\CommitPreparedRequest
+     */
+    public function setImmediateCallerId(\query\VTGateCallerID $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <target> has a value
+     *
+     * @return boolean
+     */
+    public function hasTarget(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <target> value
+     *
+     * @return \query\CommitPreparedRequest
+     */
+    public function clearTarget(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <target> value
+     *
+     * @return \query\Target
+     */
+    public function getTarget(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <target> value
+     *
+     * @param \query\Target $value
+     * @return \query\CommitPreparedRequest
+     */
+    public function setTarget(\query\Target $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \query\CommitPreparedRequest
+     */
+    public function clearDtid(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \query\CommitPreparedRequest
+     */
+    public function setDtid( $value){
+      return $this->_set(4, $value);
+    }
+  }
+}
+
+namespace query {
+
+  class CommitPreparedResponse extends \DrSlump\Protobuf\Message {
+
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.CommitPreparedResponse');
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+  }
+}
+
+namespace query {
+
+  class RollbackPreparedRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $effective_caller_id = null;
+    
+    /**  @var \query\VTGateCallerID */
+    public $immediate_caller_id = null;
+    
+    /**  @var \query\Target */
+    public $target = null;
+    
+    /**  @var int */
+    public $transaction_id = null;
+    
+    /**  @var string */
+    public $dtid = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.RollbackPreparedRequest');
+
+      // OPTIONAL MESSAGE effective_caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "effective_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE immediate_caller_id = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "immediate_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\VTGateCallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE target = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "target";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      // OPTIONAL INT64 transaction_id = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "transaction_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_INT64;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING dtid = 5
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 5;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <effective_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasEffectiveCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <effective_caller_id> value
+     *
+     * @return \query\RollbackPreparedRequest
+     */
+    public function clearEffectiveCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <effective_caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getEffectiveCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <effective_caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \query\RollbackPreparedRequest
+     */
+    public function setEffectiveCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <immediate_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasImmediateCallerId(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <immediate_caller_id> value
+     *
+     * @return \query\RollbackPreparedRequest
+     */
+    public function clearImmediateCallerId(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <immediate_caller_id> value
+     *
+     * @return \query\VTGateCallerID
+     */
+    public function getImmediateCallerId(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <immediate_caller_id> value
+     *
+     * @param \query\VTGateCallerID $value
+     * @return \query\RollbackPreparedRequest
+     */
+    public function setImmediateCallerId(\query\VTGateCallerID $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <target> has a value
+     *
+     * @return boolean
+     */
+    public function hasTarget(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <target> value
+     *
+     * @return \query\RollbackPreparedRequest
+     */
+    public function clearTarget(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <target> value
+     *
+     * @return \query\Target
+     */
+    public function getTarget(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <target> value
+     *
+     * @param \query\Target $value
+     * @return \query\RollbackPreparedRequest
+     */
+    public function setTarget(\query\Target $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <transaction_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasTransactionId(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <transaction_id> value
+     *
+     * @return \query\RollbackPreparedRequest
+     */
+    public function clearTransactionId(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <transaction_id> value
+     *
+     * @return int
+     */
+    public function getTransactionId(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <transaction_id> value
+     *
+     * @param int $value
+     * @return \query\RollbackPreparedRequest
+     */
+    public function setTransactionId( $value){
+      return $this->_set(4, $value);
+    }
+    
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(5);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \query\RollbackPreparedRequest
+     */
+    public function clearDtid(){
+      return $this->_clear(5);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(5);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \query\RollbackPreparedRequest
+     */
+    public function setDtid( $value){
+      return $this->_set(5, $value);
+    }
+  }
+}
+
+namespace query {
+
+  class RollbackPreparedResponse extends \DrSlump\Protobuf\Message {
+
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.RollbackPreparedResponse');
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+  }
+}
+
+namespace query {
+
+  class CreateTransactionRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $effective_caller_id = null;
+    
+    /**  @var \query\VTGateCallerID */
+    public $immediate_caller_id = null;
+    
+    /**  @var \query\Target */
+    public $target = null;
+    
+    /**  @var string */
+    public $dtid = null;
+    
+    /**  @var \query\Target[]   */
+    public $participants = array();
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.CreateTransactionRequest');
+
+      // OPTIONAL MESSAGE effective_caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "effective_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE immediate_caller_id = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "immediate_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\VTGateCallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE target = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "target";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING dtid = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // REPEATED MESSAGE participants = 5
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 5;
+      $f->name      = "participants";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_REPEATED;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <effective_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasEffectiveCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <effective_caller_id> value
+     *
+     * @return \query\CreateTransactionRequest
+     */
+    public function clearEffectiveCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <effective_caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getEffectiveCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <effective_caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \query\CreateTransactionRequest
+     */
+    public function setEffectiveCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <immediate_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasImmediateCallerId(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <immediate_caller_id> value
+     *
+     * @return \query\CreateTransactionRequest
+     */
+    public function clearImmediateCallerId(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <immediate_caller_id> value
+     *
+     * @return \query\VTGateCallerID
+     */
+    public function getImmediateCallerId(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <immediate_caller_id> value
+     *
+     * @param \query\VTGateCallerID $value
+     * @return \query\CreateTransactionRequest
+     */
+    public function setImmediateCallerId(\query\VTGateCallerID $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <target> has a value
+     *
+     * @return boolean
+     */
+    public function hasTarget(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <target> value
+     *
+     * @return \query\CreateTransactionRequest
+     */
+    public function clearTarget(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <target> value
+     *
+     * @return \query\Target
+     */
+    public function getTarget(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <target> value
+     *
+     * @param \query\Target $value
+     * @return \query\CreateTransactionRequest
+     */
+    public function setTarget(\query\Target $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \query\CreateTransactionRequest
+     */
+    public function clearDtid(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \query\CreateTransactionRequest
+     */
+    public function setDtid( $value){
+      return $this->_set(4, $value);
+    }
+    
+    /**
+     * Check if <participants> has a value
+     *
+     * @return boolean
+     */
+    public function hasParticipants(){
+      return $this->_has(5);
+    }
+    
+    /**
+     * Clear <participants> value
+     *
+     * @return \query\CreateTransactionRequest
+     */
+    public function clearParticipants(){
+      return $this->_clear(5);
+    }
+    
+    /**
+     * Get <participants> value
+     *
+     * @param int $idx
+     * @return \query\Target
+     */
+    public function getParticipants($idx = NULL){
+      return $this->_get(5, $idx);
+    }
+    
+    /**
+     * Set <participants> value
+     *
+     * @param \query\Target $value
+     * @return \query\CreateTransactionRequest
+     */
+    public function setParticipants(\query\Target $value, $idx = NULL){
+      return $this->_set(5, $value, $idx);
+    }
+    
+    /**
+     * Get all elements of <participants>
+     *
+     * @return \query\Target[]
+     */
+    public function getParticipantsList(){
+     return $this->_get(5);
+    }
+    
+    /**
+     * Add a new element to <participants>
+     *
+     * @param \query\Target $value
+     * @return \query\CreateTransactionRequest
+     */
+    public function addParticipants(\query\Target $value){
+     return $this->_add(5, $value);
+    }
+  }
+}
+
+namespace query {
+
+  class CreateTransactionResponse extends \DrSlump\Protobuf\Message {
+
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.CreateTransactionResponse');
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+  }
+}
+
+namespace query {
+
+  class StartCommitRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $effective_caller_id = null;
+    
+    /**  @var \query\VTGateCallerID */
+    public $immediate_caller_id = null;
+    
+    /**  @var \query\Target */
+    public $target = null;
+    
+    /**  @var int */
+    public $transaction_id = null;
+    
+    /**  @var string */
+    public $dtid = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.StartCommitRequest');
+
+      // OPTIONAL MESSAGE effective_caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "effective_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE immediate_caller_id = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "immediate_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\VTGateCallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE target = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "target";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      // OPTIONAL INT64 transaction_id = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "transaction_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_INT64;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING dtid = 5
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 5;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <effective_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasEffectiveCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <effective_caller_id> value
+     *
+     * @return \query\StartCommitRequest
+     */
+    public function clearEffectiveCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <effective_caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getEffectiveCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <effective_caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \query\StartCommitRequest
+     */
+    public function setEffectiveCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <immediate_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasImmediateCallerId(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <immediate_caller_id> value
+     *
+     * @return \query\StartCommitRequest
+     */
+    public function clearImmediateCallerId(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <immediate_caller_id> value
+     *
+     * @return \query\VTGateCallerID
+     */
+    public function getImmediateCallerId(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <immediate_caller_id> value
+     *
+     * @param \query\VTGateCallerID $value
+     * @return \query\StartCommitRequest
+     */
+    public function setImmediateCallerId(\query\VTGateCallerID $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <target> has a value
+     *
+     * @return boolean
+     */
+    public function hasTarget(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <target> value
+     *
+     * @return \query\StartCommitRequest
+     */
+    public function clearTarget(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <target> value
+     *
+     * @return \query\Target
+     */
+    public function getTarget(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <target> value
+     *
+     * @param \query\Target $value
+     * @return \query\StartCommitRequest
+     */
+    public function setTarget(\query\Target $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <transaction_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasTransactionId(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <transaction_id> value
+     *
+     * @return \query\StartCommitRequest
+     */
+    public function clearTransactionId(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <transaction_id> value
+     *
+     * @return int
+     */
+    public function getTransactionId(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <transaction_id> value
+     *
+     * @param int $value
+     * @return \query\StartCommitRequest
+     */
+    public function setTransactionId( $value){
+      return $this->_set(4, $value);
+    }
+    
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(5);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \query\StartCommitRequest
+     */
+    public function clearDtid(){
+      return $this->_clear(5);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(5);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \query\StartCommitRequest
+     */
+    public function setDtid( $value){
+      return $this->_set(5, $value);
+    }
+  }
+}
+
+namespace query {
+
+  class StartCommitResponse extends \DrSlump\Protobuf\Message {
+
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.StartCommitResponse');
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+  }
+}
+
+namespace query {
+
+  class SetRollbackRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $effective_caller_id = null;
+    
+    /**  @var \query\VTGateCallerID */
+    public $immediate_caller_id = null;
+    
+    /**  @var \query\Target */
+    public $target = null;
+    
+    /**  @var int */
+    public $transaction_id = null;
+    
+    /**  @var string */
+    public $dtid = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.SetRollbackRequest');
+
+      // OPTIONAL MESSAGE effective_caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "effective_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE immediate_caller_id = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "immediate_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\VTGateCallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE target = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "target";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      // OPTIONAL INT64 transaction_id = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "transaction_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_INT64;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING dtid = 5
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 5;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <effective_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasEffectiveCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <effective_caller_id> value
+     *
+     * @return \query\SetRollbackRequest
+     */
+    public function clearEffectiveCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <effective_caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getEffectiveCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <effective_caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \query\SetRollbackRequest
+     */
+    public function setEffectiveCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <immediate_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasImmediateCallerId(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <immediate_caller_id> value
+     *
+     * @return \query\SetRollbackRequest
+     */
+    public function clearImmediateCallerId(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <immediate_caller_id> value
+     *
+     * @return \query\VTGateCallerID
+     */
+    public function getImmediateCallerId(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <immediate_caller_id> value
+     *
+     * @param \query\VTGateCallerID $value
+     * @return \query\SetRollbackRequest
+     */
+    public function setImmediateCallerId(\query\VTGateCallerID $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <target> has a value
+     *
+     * @return boolean
+     */
+    public function hasTarget(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <target> value
+     *
+     * @return \query\SetRollbackRequest
+     */
+    public function clearTarget(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <target> value
+     *
+     * @return \query\Target
+     */
+    public function getTarget(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <target> value
+     *
+     * @param \query\Target $value
+     * @return \query\SetRollbackRequest
+     */
+    public function setTarget(\query\Target $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <transaction_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasTransactionId(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <transaction_id> value
+     *
+     * @return \query\SetRollbackRequest
+     */
+    public function clearTransactionId(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <transaction_id> value
+     *
+     * @return int
+     */
+    public function getTransactionId(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <transaction_id> value
+     *
+     * @param int $value
+     * @return \query\SetRollbackRequest
+     */
+    public function setTransactionId( $value){
+      return $this->_set(4, $value);
+    }
+    
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(5);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \query\SetRollbackRequest
+     */
+    public function clearDtid(){
+      return $this->_clear(5);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(5);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \query\SetRollbackRequest
+     */
+    public function setDtid( $value){
+      return $this->_set(5, $value);
+    }
+  }
+}
+
+namespace query {
+
+  class SetRollbackResponse extends \DrSlump\Protobuf\Message {
+
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.SetRollbackResponse');
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+  }
+}
+
+namespace query {
+
+  class ConcludeTransactionRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $effective_caller_id = null;
+    
+    /**  @var \query\VTGateCallerID */
+    public $immediate_caller_id = null;
+    
+    /**  @var \query\Target */
+    public $target = null;
+    
+    /**  @var string */
+    public $dtid = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.ConcludeTransactionRequest');
+
+      // OPTIONAL MESSAGE effective_caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "effective_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE immediate_caller_id = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "immediate_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\VTGateCallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE target = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "target";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING dtid = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <effective_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasEffectiveCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <effective_caller_id> value
+     *
+     * @return \query\ConcludeTransactionRequest
+     */
+    public function clearEffectiveCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <effective_caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getEffectiveCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <effective_caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \query\ConcludeTransactionRequest
+     */
+    public function setEffectiveCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <immediate_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasImmediateCallerId(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <immediate_caller_id> value
+     *
+     * @return \query\ConcludeTransactionRequest
+     */
+    public function clearImmediateCallerId(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <immediate_caller_id> value
+     *
+     * @return \query\VTGateCallerID
+     */
+    public function getImmediateCallerId(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <immediate_caller_id> value
+     *
+     * @param \query\VTGateCallerID $value
+     * @return \query\ConcludeTransactionRequest
+     */
+    public function setImmediateCallerId(\query\VTGateCallerID $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <target> has a value
+     *
+     * @return boolean
+     */
+    public function hasTarget(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <target> value
+     *
+     * @return \query\ConcludeTransactionRequest
+     */
+    public function clearTarget(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <target> value
+     *
+     * @return \query\Target
+     */
+    public function getTarget(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <target> value
+     *
+     * @param \query\Target $value
+     * @return \query\ConcludeTransactionRequest
+     */
+    public function setTarget(\query\Target $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \query\ConcludeTransactionRequest
+     */
+    public function clearDtid(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \query\ConcludeTransactionRequest
+     */
+    public function setDtid( $value){
+      return $this->_set(4, $value);
+    }
+  }
+}
+
+namespace query {
+
+  class ConcludeTransactionResponse extends \DrSlump\Protobuf\Message {
+
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.ConcludeTransactionResponse');
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+  }
+}
+
+namespace query {
+
+  class TransactionMetadata extends \DrSlump\Protobuf\Message {
+
+    /**  @var string */
+    public $dtid = null;
+    
+    /**  @var int - \query\TransactionState */
+    public $state = null;
+    
+    /**  @var int */
+    public $time_created = null;
+    
+    /**  @var \query\Target[]   */
+    public $participants = array();
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.TransactionMetadata');
+
+      // OPTIONAL STRING dtid = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL ENUM state = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "state";
+      $f->type      = \DrSlump\Protobuf::TYPE_ENUM;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\TransactionState';
+      $descriptor->addField($f);
+
+      // OPTIONAL INT64 time_created = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "time_created";
+      $f->type      = \DrSlump\Protobuf::TYPE_INT64;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // REPEATED MESSAGE participants = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "participants";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_REPEATED;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \query\TransactionMetadata
+     */
+    public function clearDtid(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \query\TransactionMetadata
+     */
+    public function setDtid( $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <state> has a value
+     *
+     * @return boolean
+     */
+    public function hasState(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <state> value
+     *
+     * @return \query\TransactionMetadata
+     */
+    public function clearState(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <state> value
+     *
+     * @return int - \query\TransactionState
+     */
+    public function getState(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <state> value
+     *
+     * @param int - \query\TransactionState $value
+     * @return \query\TransactionMetadata
+     */
+    public function setState( $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <time_created> has a value
+     *
+     * @return boolean
+     */
+    public function hasTimeCreated(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <time_created> value
+     *
+     * @return \query\TransactionMetadata
+     */
+    public function clearTimeCreated(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <time_created> value
+     *
+     * @return int
+     */
+    public function getTimeCreated(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <time_created> value
+     *
+     * @param int $value
+     * @return \query\TransactionMetadata
+     */
+    public function setTimeCreated( $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <participants> has a value
+     *
+     * @return boolean
+     */
+    public function hasParticipants(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <participants> value
+     *
+     * @return \query\TransactionMetadata
+     */
+    public function clearParticipants(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <participants> value
+     *
+     * @param int $idx
+     * @return \query\Target
+     */
+    public function getParticipants($idx = NULL){
+      return $this->_get(4, $idx);
+    }
+    
+    /**
+     * Set <participants> value
+     *
+     * @param \query\Target $value
+     * @return \query\TransactionMetadata
+     */
+    public function setParticipants(\query\Target $value, $idx = NULL){
+      return $this->_set(4, $value, $idx);
+    }
+    
+    /**
+     * Get all elements of <participants>
+     *
+     * @return \query\Target[]
+     */
+    public function getParticipantsList(){
+     return $this->_get(4);
+    }
+    
+    /**
+     * Add a new element to <participants>
+     *
+     * @param \query\Target $value
+     * @return \query\TransactionMetadata
+     */
+    public function addParticipants(\query\Target $value){
+     return $this->_add(4, $value);
+    }
+  }
+}
+
+namespace query {
+
+  class ReadTransactionRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $effective_caller_id = null;
+    
+    /**  @var \query\VTGateCallerID */
+    public $immediate_caller_id = null;
+    
+    /**  @var \query\Target */
+    public $target = null;
+    
+    /**  @var string */
+    public $dtid = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.ReadTransactionRequest');
+
+      // OPTIONAL MESSAGE effective_caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "effective_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE immediate_caller_id = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "immediate_caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\VTGateCallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE target = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "target";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\Target';
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING dtid = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <effective_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasEffectiveCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <effective_caller_id> value
+     *
+     * @return \query\ReadTransactionRequest
+     */
+    public function clearEffectiveCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <effective_caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getEffectiveCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <effective_caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \query\ReadTransactionRequest
+     */
+    public function setEffectiveCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <immediate_caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasImmediateCallerId(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <immediate_caller_id> value
+     *
+     * @return \query\ReadTransactionRequest
+     */
+    public function clearImmediateCallerId(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <immediate_caller_id> value
+     *
+     * @return \query\VTGateCallerID
+     */
+    public function getImmediateCallerId(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <immediate_caller_id> value
+     *
+     * @param \query\VTGateCallerID $value
+     * @return \query\ReadTransactionRequest
+     */
+    public function setImmediateCallerId(\query\VTGateCallerID $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <target> has a value
+     *
+     * @return boolean
+     */
+    public function hasTarget(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <target> value
+     *
+     * @return \query\ReadTransactionRequest
+     */
+    public function clearTarget(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <target> value
+     *
+     * @return \query\Target
+     */
+    public function getTarget(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <target> value
+     *
+     * @param \query\Target $value
+     * @return \query\ReadTransactionRequest
+     */
+    public function setTarget(\query\Target $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \query\ReadTransactionRequest
+     */
+    public function clearDtid(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \query\ReadTransactionRequest
+     */
+    public function setDtid( $value){
+      return $this->_set(4, $value);
+    }
+  }
+}
+
+namespace query {
+
+  class ReadTransactionResponse extends \DrSlump\Protobuf\Message {
+
+    /**  @var \query\TransactionMetadata */
+    public $metadata = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'query.ReadTransactionResponse');
+
+      // OPTIONAL MESSAGE metadata = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "metadata";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\TransactionMetadata';
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <metadata> has a value
+     *
+     * @return boolean
+     */
+    public function hasMetadata(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <metadata> value
+     *
+     * @return \query\ReadTransactionResponse
+     */
+    public function clearMetadata(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <metadata> value
+     *
+     * @return \query\TransactionMetadata
+     */
+    public function getMetadata(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <metadata> value
+     *
+     * @param \query\TransactionMetadata $value
+     * @return \query\ReadTransactionResponse
+     */
+    public function setMetadata(\query\TransactionMetadata $value){
+      return $this->_set(1, $value);
+    }
+  }
+}
+
@@ -52,6 +52,54 @@ namespace queryservice {
     public function Rollback(\query\RollbackRequest $argument, $metadata = array(), $options = array()) {
       return $this->_simpleRequest('/queryservice.Query/Rollback', $argument, '\query\RollbackResponse::deserialize', $metadata, $options);
     }
+    /**
+     * @param query\PrepareRequest $input
+     */
+    public function Prepare(\query\PrepareRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/queryservice.Query/Prepare', $argument, '\query\PrepareResponse::deserialize', $metadata, $options);
+    }
+    /**
+     * @param query\CommitPreparedRequest $input
+     */
+    public function CommitPrepared(\query\CommitPreparedRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/queryservice.Query/CommitPrepared', $argument, '\query\CommitPreparedResponse::deserialize', $metadata, $options);
+    }
+    /**
+     * @param query\RollbackPreparedRequest $input
+     */
+    public function RollbackPrepared(\query\RollbackPreparedRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/queryservice.Query/RollbackPrepared', $argument, '\query\RollbackPreparedResponse::deserialize', $metadata, $options);
+    }
+    /**
+     * @param query\CreateTransactionRequest $input
+     */
+    public function CreateTransaction(\query\CreateTransactionRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/queryservice.Query/CreateTransaction', $argument, '\query\CreateTransactionResponse::deserialize', $metadata, $options);
+    }
+    /**
+     * @param query\StartCommitRequest $input
+     */
+    public function StartCommit(\query\StartCommitRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/queryservice.Query/StartCommit', $argument, '\query\StartCommitResponse::deserialize', $metadata, $options);
+    }
+    /**
+     * @param query\SetRollbackRequest $input
+     */
+    public function SetRollback(\query\SetRollbackRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/queryservice.Query/SetRollback', $argument, '\query\SetRollbackResponse::deserialize', $metadata, $options);
+    }
+    /**
+     * @param query\ConcludeTransactionRequest $input
+     */
+    public function ConcludeTransaction(\query\ConcludeTransactionRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/queryservice.Query/ConcludeTransaction', $argument, '\query\ConcludeTransactionResponse::deserialize', $metadata, $options);
+    }
+    /**
+     * @param query\ReadTransactionRequest $input
+     */
+    public function ReadTransaction(\query\ReadTransactionRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/queryservice.Query/ReadTransaction', $argument, '\query\ReadTransactionResponse::deserialize', $metadata, $options);
+    }
     /**
      * @param query\SplitQueryRequest $input
      */
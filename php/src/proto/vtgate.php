@@ -131,6 +131,21 @@ namespace vtgate {
     /**  @var \vtgate\Session\ShardSession[]  */
     public $shard_sessions = array();
     
+    /**  @var string */
+    public $keyspace = null;
+    
+    /**  @var string */
+    public $shard = null;
+    
+    /**  @var int - \topodata\TabletType */
+    public $tablet_type = null;
+    
+    /**  @var boolean */
+    public $no_autocommit = null;
+    
+    /**  @var string */
+    public $workload = null;
+    
 
     /** @var \Closure[] */
     protected static $__extensions = array();
@@ -156,6 +171,47 @@ namespace vtgate {
       $f->reference = '\vtgate\Session\ShardSession';
       $descriptor->addField($f);
 
+      // OPTIONAL STRING keyspace = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "keyspace";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING shard = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "shard";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL ENUM tablet_type = 5
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 5;
+      $f->name      = "tablet_type";
+      $f->type      = \DrSlump\Protobuf::TYPE_ENUM;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\topodata\TabletType';
+      $descriptor->addField($f);
+
+      // OPTIONAL BOOL no_autocommit = 6
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 6;
+      $f->name      = "no_autocommit";
+      $f->type      = \DrSlump\Protobuf::TYPE_BOOL;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING workload = 7
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 7;
+      $f->name      = "workload";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
       foreach (self::$__extensions as $cb) {
         $descriptor->addField($cb(), true);
       }
@@ -256,6 +312,191 @@ namespace vtgate {
     public function addShardSessions(\vtgate\Session\ShardSession $value){
      return $this->_add(2, $value);
     }
+    
+    /**
+     * Check if <keyspace> has a value
+     *
+     * @return boolean
+     */
+    public function hasKeyspace(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <keyspace> value
+     *
+     * @return \vtgate\Session
+     */
+    public function clearKeyspace(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <keyspace> value
+     *
+     * @return string
+     */
+    public function getKeyspace(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <keyspace> value
+     *
+     * @param string $value
+     * @return \vtgate\Session
+     */
+    public function setKeyspace( $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <shard> has a value
+     *
+     * @return boolean
+     */
+    public function hasShard(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <shard> value
+     *
+     * @return \vtgate\Session
+     */
+    public function clearShard(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <shard> value
+     *
+     * @return string
+     */
+    public function getShard(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <shard> value
+     *
+     * @param string $value
+     * @return \vtgate\Session
+     */
+    public function setShard( $value){
+      return $this->_set(4, $value);
+    }
+    
+    /**
+     * Check if <tablet_type> has a value
+     *
+     * @return boolean
+     */
+    public function hasTabletType(){
+      return $this->_has(5);
+    }
+    
+    /**
+     * Clear <tablet_type> value
+     *
+     * @return \vtgate\Session
+     */
+    public function clearTabletType(){
+      return $this->_clear(5);
+    }
+    
+    /**
+     * Get <tablet_type> value
+     *
+     * @return int - \topodata\TabletType
+     */
+    public function getTabletType(){
+      return $this->_get(5);
+    }
+    
+    /**
+     * Set <tablet_type> value
+     *
+     * @param int - \topodata\TabletType $value
+     * @return \vtgate\Session
+     */
+    public function setTabletType( $value){
+      return $this->_set(5, $value);
+    }
+    
+    /**
+     * Check if <no_autocommit> has a value
+     *
+     * @return boolean
+     */
+    public function hasNoAutocommit(){
+      return $this->_has(6);
+    }
+    
+    /**
+     * Clear <no_autocommit> value
+     *
+     * @return \vtgate\Session
+     */
+    public function clearNoAutocommit(){
+      return $this->_clear(6);
+    }
+    
+    /**
+     * Get <no_autocommit> value
+     *
+     * @return boolean
+     */
+    public function getNoAutocommit(){
+      return $this->_get(6);
+    }
+    
+    /**
+     * Set <no_autocommit> value
+     *
+     * @param boolean $value
+     * @return \vtgate\Session
+     */
+    public function setNoAutocommit( $value){
+      return $this->_set(6, $value);
+    }
+    
+    /**
+     * Check if <workload> has a value
+     *
+     * @return boolean
+     */
+    public function hasWorkload(){
+      return $this->_has(7);
+    }
+    
+    /**
+     * Clear <workload> value
+     *
+     * @return \vtgate\Session
+     */
+    public function clearWorkload(){
+      return $this->_clear(7);
+    }
+    
+    /**
+     * Get <workload> value
+     *
+     * @return string
+     */
+    public function getWorkload(){
+      return $this->_get(7);
+    }
+    
+    /**
+     * Set <workload> value
+     *
+     * @param string $value
+     * @return \vtgate\Session
+     */
+    public function setWorkload( $value){
+      return $this->_set(7, $value);
+    }
   }
 }
 
@@ -7126,3 +7367,972 @@ namespace vtgate {
   }
 }
 
+namespace vtgate {
+
+  class ExplainRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $caller_id = null;
+    
+    /**  @var \query\BoundQuery */
+    public $query = null;
+    
+    /**  @var int - \topodata\TabletType */
+    public $tablet_type = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'vtgate.ExplainRequest');
+
+      // OPTIONAL MESSAGE caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE query = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "query";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\query\BoundQuery';
+      $descriptor->addField($f);
+
+      // OPTIONAL ENUM tablet_type = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "tablet_type";
+      $f->type      = \DrSlump\Protobuf::TYPE_ENUM;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\topodata\TabletType';
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <caller_id> value
+     *
+     * @return \vtgate\ExplainRequest
+     */
+    public function clearCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \vtgate\ExplainRequest
+     */
+    public function setCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <query> has a value
+     *
+     * @return boolean
+     */
+    public function hasQuery(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <query> value
+     *
+     * @return \vtgate\ExplainRequest
+     */
+    public function clearQuery(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <query> value
+     *
+     * @return \query\BoundQuery
+     */
+    public function getQuery(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <query> value
+     *
+     * @param \query\BoundQuery $value
+     * @return \vtgate\ExplainRequest
+     */
+    public function setQuery(\query\BoundQuery $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <tablet_type> has a value
+     *
+     * @return boolean
+     */
+    public function hasTabletType(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <tablet_type> value
+     *
+     * @return \vtgate\ExplainRequest
+     */
+    public function clearTabletType(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <tablet_type> value
+     *
+     * @return int - \topodata\TabletType
+     */
+    public function getTabletType(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <tablet_type> value
+     *
+     * @param int - \topodata\TabletType $value
+     * @return \vtgate\ExplainRequest
+     */
+    public function setTabletType( $value){
+      return $this->_set(3, $value);
+    }
+  }
+}
+
+namespace vtgate {
+
+  class QueryPlan extends \DrSlump\Protobuf\Message {
+
+    /**  @var string */
+    public $plan_id = null;
+    
+    /**  @var string */
+    public $reason = null;
+    
+    /**  @var string */
+    public $table = null;
+    
+    /**  @var string */
+    public $keyspace = null;
+    
+    /**  @var string */
+    public $vindex = null;
+    
+    /**  @var string */
+    public $rewritten_sql = null;
+    
+    /**  @var string[]   */
+    public $shards = array();
+    
+    /**  @var string */
+    public $shard_error = null;
+    
+    /**  @var \vtgate\QueryPlan[]   */
+    public $pullouts = array();
+    
+    /**  @var \vtgate\QueryPlan */
+    public $join_left = null;
+    
+    /**  @var \vtgate\QueryPlan */
+    public $join_right = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'vtgate.QueryPlan');
+
+      // OPTIONAL STRING plan_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "plan_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING reason = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "reason";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING table = 3
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 3;
+      $f->name      = "table";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING keyspace = 4
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 4;
+      $f->name      = "keyspace";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING vindex = 5
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 5;
+      $f->name      = "vindex";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING rewritten_sql = 6
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 6;
+      $f->name      = "rewritten_sql";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // REPEATED STRING shards = 7
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 7;
+      $f->name      = "shards";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_REPEATED;
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING shard_error = 8
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 8;
+      $f->name      = "shard_error";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      // REPEATED MESSAGE pullouts = 9
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 9;
+      $f->name      = "pullouts";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_REPEATED;
+      $f->reference = '\vtgate\QueryPlan';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE join_left = 10
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 10;
+      $f->name      = "join_left";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtgate\QueryPlan';
+      $descriptor->addField($f);
+
+      // OPTIONAL MESSAGE join_right = 11
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 11;
+      $f->name      = "join_right";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtgate\QueryPlan';
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <plan_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasPlanId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <plan_id> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearPlanId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <plan_id> value
+     *
+     * @return string
+     */
+    public function getPlanId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <plan_id> value
+     *
+     * @param string $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setPlanId( $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <reason> has a value
+     *
+     * @return boolean
+     */
+    public function hasReason(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <reason> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearReason(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <reason> value
+     *
+     * @return string
+     */
+    public function getReason(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <reason> value
+     *
+     * @param string $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setReason( $value){
+      return $this->_set(2, $value);
+    }
+    
+    /**
+     * Check if <table> has a value
+     *
+     * @return boolean
+     */
+    public function hasTable(){
+      return $this->_has(3);
+    }
+    
+    /**
+     * Clear <table> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearTable(){
+      return $this->_clear(3);
+    }
+    
+    /**
+     * Get <table> value
+     *
+     * @return string
+     */
+    public function getTable(){
+      return $this->_get(3);
+    }
+    
+    /**
+     * Set <table> value
+     *
+     * @param string $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setTable( $value){
+      return $this->_set(3, $value);
+    }
+    
+    /**
+     * Check if <keyspace> has a value
+     *
+     * @return boolean
+     */
+    public function hasKeyspace(){
+      return $this->_has(4);
+    }
+    
+    /**
+     * Clear <keyspace> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearKeyspace(){
+      return $this->_clear(4);
+    }
+    
+    /**
+     * Get <keyspace> value
+     *
+     * @return string
+     */
+    public function getKeyspace(){
+      return $this->_get(4);
+    }
+    
+    /**
+     * Set <keyspace> value
+     *
+     * @param string $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setKeyspace( $value){
+      return $this->_set(4, $value);
+    }
+    
+    /**
+     * Check if <vindex> has a value
+     *
+     * @return boolean
+     */
+    public function hasVindex(){
+      return $this->_has(5);
+    }
+    
+    /**
+     * Clear <vindex> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearVindex(){
+      return $this->_clear(5);
+    }
+    
+    /**
+     * Get <vindex> value
+     *
+     * @return string
+     */
+    public function getVindex(){
+      return $this->_get(5);
+    }
+    
+    /**
+     * Set <vindex> value
+     *
+     * @param string $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setVindex( $value){
+      return $this->_set(5, $value);
+    }
+    
+    /**
+     * Check if <rewritten_sql> has a value
+     *
+     * @return boolean
+     */
+    public function hasRewrittenSql(){
+      return $this->_has(6);
+    }
+    
+    /**
+     * Clear <rewritten_sql> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearRewrittenSql(){
+      return $this->_clear(6);
+    }
+    
+    /**
+     * Get <rewritten_sql> value
+     *
+     * @return string
+     */
+    public function getRewrittenSql(){
+      return $this->_get(6);
+    }
+    
+    /**
+     * Set <rewritten_sql> value
+     *
+     * @param string $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setRewrittenSql( $value){
+      return $this->_set(6, $value);
+    }
+    
+    /**
+     * Check if <shards> has a value
+     *
+     * @return boolean
+     */
+    public function hasShards(){
+      return $this->_has(7);
+    }
+    
+    /**
+     * Clear <shards> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearShards(){
+      return $this->_clear(7);
+    }
+    
+    /**
+     * Get <shards> value
+     *
+     * @param int $idx
+     * @return string
+     */
+    public function getShards($idx = NULL){
+      return $this->_get(7, $idx);
+    }
+    
+    /**
+     * Set <shards> value
+     *
+     * @param string $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setShards( $value, $idx = NULL){
+      return $this->_set(7, $value, $idx);
+    }
+    
+    /**
+     * Get all elements of <shards>
+     *
+     * @return string[]
+     */
+    public function getShardsList(){
+     return $this->_get(7);
+    }
+    
+    /**
+     * Add a new element to <shards>
+     *
+     * @param string $value
+     * @return \vtgate\QueryPlan
+     */
+    public function addShards( $value){
+     return $this->_add(7, $value);
+    }
+    
+    /**
+     * Check if <shard_error> has a value
+     *
+     * @return boolean
+     */
+    public function hasShardError(){
+      return $this->_has(8);
+    }
+    
+    /**
+     * Clear <shard_error> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearShardError(){
+      return $this->_clear(8);
+    }
+    
+    /**
+     * Get <shard_error> value
+     *
+     * @return string
+     */
+    public function getShardError(){
+      return $this->_get(8);
+    }
+    
+    /**
+     * Set <shard_error> value
+     *
+     * @param string $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setShardError( $value){
+      return $this->_set(8, $value);
+    }
+    
+    /**
+     * Check if <pullouts> has a value
+     *
+     * @return boolean
+     */
+    public function hasPullouts(){
+      return $this->_has(9);
+    }
+    
+    /**
+     * Clear <pullouts> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearPullouts(){
+      return $this->_clear(9);
+    }
+    
+    /**
+     * Get <pullouts> value
+     *
+     * @param int $idx
+     * @return \vtgate\QueryPlan
+     */
+    public function getPullouts($idx = NULL){
+      return $this->_get(9, $idx);
+    }
+    
+    /**
+     * Set <pullouts> value
+     *
+     * @param \vtgate\QueryPlan $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setPullouts(\vtgate\QueryPlan $value, $idx = NULL){
+      return $this->_set(9, $value, $idx);
+    }
+    
+    /**
+     * Get all elements of <pullouts>
+     *
+     * @return \vtgate\QueryPlan[]
+     */
+    public function getPulloutsList(){
+     return $this->_get(9);
+    }
+    
+    /**
+     * Add a new element to <pullouts>
+     *
+     * @param \vtgate\QueryPlan $value
+     * @return \vtgate\QueryPlan
+     */
+    public function addPullouts(\vtgate\QueryPlan $value){
+     return $this->_add(9, $value);
+    }
+    
+    /**
+     * Check if <join_left> has a value
+     *
+     * @return boolean
+     */
+    public function hasJoinLeft(){
+      return $this->_has(10);
+    }
+    
+    /**
+     * Clear <join_left> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearJoinLeft(){
+      return $this->_clear(10);
+    }
+    
+    /**
+     * Get <join_left> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function getJoinLeft(){
+      return $this->_get(10);
+    }
+    
+    /**
+     * Set <join_left> value
+     *
+     * @param \vtgate\QueryPlan $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setJoinLeft(\vtgate\QueryPlan $value){
+      return $this->_set(10, $value);
+    }
+    
+    /**
+     * Check if <join_right> has a value
+     *
+     * @return boolean
+     */
+    public function hasJoinRight(){
+      return $this->_has(11);
+    }
+    
+    /**
+     * Clear <join_right> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function clearJoinRight(){
+      return $this->_clear(11);
+    }
+    
+    /**
+     * Get <join_right> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function getJoinRight(){
+      return $this->_get(11);
+    }
+    
+    /**
+     * Set <join_right> value
+     *
+     * @param \vtgate\QueryPlan $value
+     * @return \vtgate\QueryPlan
+     */
+    public function setJoinRight(\vtgate\QueryPlan $value){
+      return $this->_set(11, $value);
+    }
+  }
+}
+
+namespace vtgate {
+
+  class ExplainResponse extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtgate\QueryPlan */
+    public $plan = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'vtgate.ExplainResponse');
+
+      // OPTIONAL MESSAGE plan = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "plan";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtgate\QueryPlan';
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <plan> has a value
+     *
+     * @return boolean
+     */
+    public function hasPlan(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <plan> value
+     *
+     * @return \vtgate\ExplainResponse
+     */
+    public function clearPlan(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <plan> value
+     *
+     * @return \vtgate\QueryPlan
+     */
+    public function getPlan(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <plan> value
+     *
+     * @param \vtgate\QueryPlan $value
+     * @return \vtgate\ExplainResponse
+     */
+    public function setPlan(\vtgate\QueryPlan $value){
+      return $this->_set(1, $value);
+    }
+  }
+}
+
+namespace vtgate {
+
+  class ResolveTransactionRequest extends \DrSlump\Protobuf\Message {
+
+    /**  @var \vtrpc\CallerID */
+    public $caller_id = null;
+    
+    /**  @var string */
+    public $dtid = null;
+    
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'vtgate.ResolveTransactionRequest');
+
+      // OPTIONAL MESSAGE caller_id = 1
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 1;
+      $f->name      = "caller_id";
+      $f->type      = \DrSlump\Protobuf::TYPE_MESSAGE;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $f->reference = '\vtrpc\CallerID';
+      $descriptor->addField($f);
+
+      // OPTIONAL STRING dtid = 2
+      $f = new \DrSlump\Protobuf\Field();
+      $f->number    = 2;
+      $f->name      = "dtid";
+      $f->type      = \DrSlump\Protobuf::TYPE_STRING;
+      $f->rule      = \DrSlump\Protobuf::RULE_OPTIONAL;
+      $descriptor->addField($f);
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+
+    /**
+     * Check if <caller_id> has a value
+     *
+     * @return boolean
+     */
+    public function hasCallerId(){
+      return $this->_has(1);
+    }
+    
+    /**
+     * Clear <caller_id> value
+     *
+     * @return \vtgate\ResolveTransactionRequest
+     */
+    public function clearCallerId(){
+      return $this->_clear(1);
+    }
+    
+    /**
+     * Get <caller_id> value
+     *
+     * @return \vtrpc\CallerID
+     */
+    public function getCallerId(){
+      return $this->_get(1);
+    }
+    
+    /**
+     * Set <caller_id> value
+     *
+     * @param \vtrpc\CallerID $value
+     * @return \vtgate\ResolveTransactionRequest
+     */
+    public function setCallerId(\vtrpc\CallerID $value){
+      return $this->_set(1, $value);
+    }
+    
+    /**
+     * Check if <dtid> has a value
+     *
+     * @return boolean
+     */
+    public function hasDtid(){
+      return $this->_has(2);
+    }
+    
+    /**
+     * Clear <dtid> value
+     *
+     * @return \vtgate\ResolveTransactionRequest
+     */
+    public function clearDtid(){
+      return $this->_clear(2);
+    }
+    
+    /**
+     * Get <dtid> value
+     *
+     * @return string
+     */
+    public function getDtid(){
+      return $this->_get(2);
+    }
+    
+    /**
+     * Set <dtid> value
+     *
+     * @param string $value
+     * @return \vtgate\ResolveTransactionRequest
+     */
+    public function setDtid( $value){
+      return $this->_set(2, $value);
+    }
+  }
+}
+
+namespace vtgate {
+
+  class ResolveTransactionResponse extends \DrSlump\Protobuf\Message {
+
+
+    /** @var \Closure[] */
+    protected static $__extensions = array();
+
+    public static function descriptor()
+    {
+      $descriptor = new \DrSlump\Protobuf\Descriptor(__CLASS__, 'vtgate.ResolveTransactionResponse');
+
+      foreach (self::$__extensions as $cb) {
+        $descriptor->addField($cb(), true);
+      }
+
+      return $descriptor;
+    }
+  }
+}
+
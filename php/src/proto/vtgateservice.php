@@ -94,6 +94,12 @@ namespace vtgateservice {
     public function Rollback(\vtgate\RollbackRequest $argument, $metadata = array(), $options = array()) {
       return $this->_simpleRequest('/vtgateservice.Vitess/Rollback', $argument, '\vtgate\RollbackResponse::deserialize', $metadata, $options);
     }
+    /**
+     * @param vtgate\ResolveTransactionRequest $input
+     */
+    public function ResolveTransaction(\vtgate\ResolveTransactionRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/vtgateservice.Vitess/ResolveTransaction', $argument, '\vtgate\ResolveTransactionResponse::deserialize', $metadata, $options);
+    }
     /**
      * @param vtgate\SplitQueryRequest $input
      */
@@ -106,5 +112,11 @@ namespace vtgateservice {
     public function GetSrvKeyspace(\vtgate\GetSrvKeyspaceRequest $argument, $metadata = array(), $options = array()) {
       return $this->_simpleRequest('/vtgateservice.Vitess/GetSrvKeyspace', $argument, '\vtgate\GetSrvKeyspaceResponse::deserialize', $metadata, $options);
     }
+    /**
+     * @param vtgate\ExplainRequest $input
+     */
+    public function Explain(\vtgate\ExplainRequest $argument, $metadata = array(), $options = array()) {
+      return $this->_simpleRequest('/vtgateservice.Vitess/Explain', $argument, '\vtgate\ExplainResponse::deserialize', $metadata, $options);
+    }
   }
 }
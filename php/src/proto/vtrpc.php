@@ -19,6 +19,7 @@ namespace vtrpc {
     const INTERNAL_ERROR = 10;
     const TRANSIENT_ERROR = 11;
     const UNAUTHENTICATED = 12;
+    const QUOTA_EXCEEDED = 13;
   }
 }
 namespace vtrpc {
@@ -20,7 +20,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='query.proto',
   package='query',
   syntax='proto3',
-  serialized_pb=b'\n\x0bquery.proto\x12\x05query\x1a\x0etopodata.proto\x1a\x0bvtrpc.proto\"T\n\x06Target\x12\x10\n\x08keyspace\x18\x01 \x01(\t\x12\r\n\x05shard\x18\x02 \x01(\t\x12)\n\x0btablet_type\x18\x03 \x01(\x0e\x32\x14.topodata.TabletType\"\"\n\x0eVTGateCallerID\x12\x10\n\x08username\x18\x01 \x01(\t\"1\n\x05Value\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\"V\n\x0c\x42indVariable\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\x12\x1c\n\x06values\x18\x03 \x03(\x0b\x32\x0c.query.Value\"\xa2\x01\n\nBoundQuery\x12\x0b\n\x03sql\x18\x01 \x01(\t\x12<\n\x0e\x62ind_variables\x18\x02 \x03(\x0b\x32$.query.BoundQuery.BindVariablesEntry\x1aI\n\x12\x42indVariablesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.query.BindVariable:\x02\x38\x01\"0\n\x05\x46ield\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x19\n\x04type\x18\x02 \x01(\x0e\x32\x0b.query.Type\"&\n\x03Row\x12\x0f\n\x07lengths\x18\x01 \x03(\x12\x12\x0e\n\x06values\x18\x02 \x01(\x0c\"o\n\x0bQueryResult\x12\x1c\n\x06\x66ields\x18\x01 \x03(\x0b\x32\x0c.query.Field\x12\x15\n\rrows_affected\x18\x02 \x01(\x04\x12\x11\n\tinsert_id\x18\x03 \x01(\x04\x12\x18\n\x04rows\x18\x04 \x03(\x0b\x32\n.query.Row\"\x98\x01\n\x13GetSessionIdRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12\r\n\x05shard\x18\x04 \x01(\t\"*\n\x14GetSessionIdResponse\x12\x12\n\nsession_id\x18\x01 \x01(\x03\"\xdf\x01\n\x0e\x45xecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0etransaction_id\x18\x05 \x01(\x03\x12\x12\n\nsession_id\x18\x06 \x01(\x03\"5\n\x0f\x45xecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xfe\x01\n\x13\x45xecuteBatchRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\"\n\x07queries\x18\x04 \x03(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\x12\x16\n\x0etransaction_id\x18\x06 \x01(\x03\x12\x12\n\nsession_id\x18\x07 \x01(\x03\";\n\x14\x45xecuteBatchResponse\x12#\n\x07results\x18\x01 \x03(\x0b\x32\x12.query.QueryResult\"\xcd\x01\n\x14StreamExecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x12\n\nsession_id\x18\x05 \x01(\x03\";\n\x15StreamExecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xa3\x01\n\x0c\x42\x65ginRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x12\n\nsession_id\x18\x04 \x01(\x03\"\'\n\rBeginResponse\x12\x16\n\x0etransaction_id\x18\x01 \x01(\x03\"\xbc\x01\n\rCommitRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x12\n\nsession_id\x18\x05 \x01(\x03\"\x10\n\x0e\x43ommitResponse\"\xbe\x01\n\x0fRollbackRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x12\n\nsession_id\x18\x05 \x01(\x03\"\x12\n\x10RollbackResponse\"\xf5\x01\n\x11SplitQueryRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x14\n\x0csplit_column\x18\x05 \x01(\t\x12\x13\n\x0bsplit_count\x18\x06 \x01(\x03\x12\x12\n\nsession_id\x18\x07 \x01(\x03\"A\n\nQuerySplit\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12\x11\n\trow_count\x18\x02 \x01(\x03\"8\n\x12SplitQueryResponse\x12\"\n\x07queries\x18\x01 \x03(\x0b\x32\x11.query.QuerySplit\"\x15\n\x13StreamHealthRequest\"\xa9\x01\n\rRealtimeStats\x12\x14\n\x0chealth_error\x18\x01 \x01(\t\x12\x1d\n\x15seconds_behind_master\x18\x02 \x01(\r\x12\x1c\n\x14\x62inlog_players_count\x18\x03 \x01(\x05\x12\x32\n*seconds_behind_master_filtered_replication\x18\x04 \x01(\x03\x12\x11\n\tcpu_usage\x18\x05 \x01(\x01\"\xa4\x01\n\x14StreamHealthResponse\x12\x1d\n\x06target\x18\x01 \x01(\x0b\x32\r.query.Target\x12\x0f\n\x07serving\x18\x02 \x01(\x08\x12.\n&tablet_externally_reparented_timestamp\x18\x03 \x01(\x03\x12,\n\x0erealtime_stats\x18\x04 \x01(\x0b\x32\x14.query.RealtimeStats*k\n\x04\x46lag\x12\x08\n\x04NONE\x10\x00\x12\x0f\n\nISINTEGRAL\x10\x80\x02\x12\x0f\n\nISUNSIGNED\x10\x80\x04\x12\x0c\n\x07ISFLOAT\x10\x80\x08\x12\r\n\x08ISQUOTED\x10\x80\x10\x12\x0b\n\x06ISTEXT\x10\x80 \x12\r\n\x08ISBINARY\x10\x80@*\xef\x02\n\x04Type\x12\r\n\tNULL_TYPE\x10\x00\x12\t\n\x04INT8\x10\x81\x02\x12\n\n\x05UINT8\x10\x82\x06\x12\n\n\x05INT16\x10\x83\x02\x12\x0b\n\x06UINT16\x10\x84\x06\x12\n\n\x05INT24\x10\x85\x02\x12\x0b\n\x06UINT24\x10\x86\x06\x12\n\n\x05INT32\x10\x87\x02\x12\x0b\n\x06UINT32\x10\x88\x06\x12\n\n\x05INT64\x10\x89\x02\x12\x0b\n\x06UINT64\x10\x8a\x06\x12\x0c\n\x07\x46LOAT32\x10\x8b\x08\x12\x0c\n\x07\x46LOAT64\x10\x8c\x08\x12\x0e\n\tTIMESTAMP\x10\x8d\x10\x12\t\n\x04\x44\x41TE\x10\x8e\x10\x12\t\n\x04TIME\x10\x8f\x10\x12\r\n\x08\x44\x41TETIME\x10\x90\x10\x12\t\n\x04YEAR\x10\x91\x06\x12\x0b\n\x07\x44\x45\x43IMAL\x10\x12\x12\t\n\x04TEXT\x10\x93\x30\x12\t\n\x04\x42LOB\x10\x94P\x12\x0c\n\x07VARCHAR\x10\x95\x30\x12\x0e\n\tVARBINARY\x10\x96P\x12\t\n\x04\x43HAR\x10\x97\x30\x12\x0b\n\x06\x42INARY\x10\x98P\x12\x08\n\x03\x42IT\x10\x99\x10\x12\t\n\x04\x45NUM\x10\x9a\x10\x12\x08\n\x03SET\x10\x9b\x10\x12\t\n\x05TUPLE\x10\x1c\x42\x1a\n\x18\x63om.youtube.vitess.protob\x06proto3'
+  serialized_pb=b'\n\x0bquery.proto\x12\x05query\x1a\x0etopodata.proto\x1a\x0bvtrpc.proto\"T\n\x06Target\x12\x10\n\x08keyspace\x18\x01 \x01(\t\x12\r\n\x05shard\x18\x02 \x01(\t\x12)\n\x0btablet_type\x18\x03 \x01(\x0e\x32\x14.topodata.TabletType\"\"\n\x0eVTGateCallerID\x12\x10\n\x08username\x18\x01 \x01(\t\"1\n\x05Value\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\"V\n\x0c\x42indVariable\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\x12\x1c\n\x06values\x18\x03 \x03(\x0b\x32\x0c.query.Value\"\xa2\x01\n\nBoundQuery\x12\x0b\n\x03sql\x18\x01 \x01(\t\x12<\n\x0e\x62ind_variables\x18\x02 \x03(\x0b\x32$.query.BoundQuery.BindVariablesEntry\x1aI\n\x12\x42indVariablesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.query.BindVariable:\x02\x38\x01\"0\n\x05\x46ield\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x19\n\x04type\x18\x02 \x01(\x0e\x32\x0b.query.Type\"&\n\x03Row\x12\x0f\n\x07lengths\x18\x01 \x03(\x12\x12\x0e\n\x06values\x18\x02 \x01(\x0c\"o\n\x0bQueryResult\x12\x1c\n\x06\x66ields\x18\x01 \x03(\x0b\x32\x0c.query.Field\x12\x15\n\rrows_affected\x18\x02 \x01(\x04\x12\x11\n\tinsert_id\x18\x03 \x01(\x04\x12\x18\n\x04rows\x18\x04 \x03(\x0b\x32\n.query.Row\"\x98\x01\n\x13GetSessionIdRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12\r\n\x05shard\x18\x04 \x01(\t\"*\n\x14GetSessionIdResponse\x12\x12\n\nsession_id\x18\x01 \x01(\x03\"\xdf\x01\n\x0e\x45xecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0etransaction_id\x18\x05 \x01(\x03\x12\x12\n\nsession_id\x18\x06 \x01(\x03\"5\n\x0f\x45xecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xfe\x01\n\x13\x45xecuteBatchRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\"\n\x07queries\x18\x04 \x03(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\x12\x16\n\x0etransaction_id\x18\x06 \x01(\x03\x12\x12\n\nsession_id\x18\x07 \x01(\x03\";\n\x14\x45xecuteBatchResponse\x12#\n\x07results\x18\x01 \x03(\x0b\x32\x12.query.QueryResult\"\xcd\x01\n\x14StreamExecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x12\n\nsession_id\x18\x05 \x01(\x03\";\n\x15StreamExecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xa3\x01\n\x0c\x42\x65ginRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x12\n\nsession_id\x18\x04 \x01(\x03\"\'\n\rBeginResponse\x12\x16\n\x0etransaction_id\x18\x01 \x01(\x03\"\xbc\x01\n\rCommitRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x12\n\nsession_id\x18\x05 \x01(\x03\"\x10\n\x0e\x43ommitResponse\"\xbe\x01\n\x0fRollbackRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x12\n\nsession_id\x18\x05 \x01(\x03\"\x12\n\x10RollbackResponse\"\xf5\x01\n\x11SplitQueryRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x14\n\x0csplit_column\x18\x05 \x01(\t\x12\x13\n\x0bsplit_count\x18\x06 \x01(\x03\x12\x12\n\nsession_id\x18\x07 \x01(\x03\"A\n\nQuerySplit\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12\x11\n\trow_count\x18\x02 \x01(\x03\"8\n\x12SplitQueryResponse\x12\"\n\x07queries\x18\x01 \x03(\x0b\x32\x11.query.QuerySplit\"\x15\n\x13StreamHealthRequest\"\xa9\x01\n\rRealtimeStats\x12\x14\n\x0chealth_error\x18\x01 \x01(\t\x12\x1d\n\x15seconds_behind_master\x18\x02 \x01(\r\x12\x1c\n\x14\x62inlog_players_count\x18\x03 \x01(\x05\x12\x32\n*seconds_behind_master_filtered_replication\x18\x04 \x01(\x03\x12\x11\n\tcpu_usage\x18\x05 \x01(\x01\"\xa4\x01\n\x14StreamHealthResponse\x12\x1d\n\x06target\x18\x01 \x01(\x0b\x32\r.query.Target\x12\x0f\n\x07serving\x18\x02 \x01(\x08\x12.\n&tablet_externally_reparented_timestamp\x18\x03 \x01(\x03\x12,\n\x0erealtime_stats\x18\x04 \x01(\x0b\x32\x14.query.RealtimeStats\"\xb7\x01\n\x0ePrepareRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x0c\n\x04\x64tid\x18\x05 \x01(\t\"\x11\n\x0fPrepareResponse\"\xa6\x01\n\x15\x43ommitPreparedRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x0c\n\x04\x64tid\x18\x04 \x01(\t\"\x18\n\x16\x43ommitPreparedResponse\"\xc0\x01\n\x17RollbackPreparedRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x0c\n\x04\x64tid\x18\x05 \x01(\t\"\x1a\n\x18RollbackPreparedResponse\"\xce\x01\n\x18\x43reateTransactionRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x0c\n\x04\x64tid\x18\x04 \x01(\t\x12#\n\x0cparticipants\x18\x05 \x03(\x0b\x32\r.query.Target\"\x1b\n\x19\x43reateTransactionResponse\"\xbb\x01\n\x12StartCommitRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x0c\n\x04\x64tid\x18\x05 \x01(\t\"\x15\n\x13StartCommitResponse\"\xbb\x01\n\x12SetRollbackRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x0c\n\x04\x64tid\x18\x05 \x01(\t\"\x15\n\x13SetRollbackResponse\"\xab\x01\n\x1a\x43oncludeTransactionRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x0c\n\x04\x64tid\x18\x04 \x01(\t\"\x1d\n\x1b\x43oncludeTransactionResponse\"\x86\x01\n\x13TransactionMetadata\x12\x0c\n\x04\x64tid\x18\x01 \x01(\t\x12&\n\x05state\x18\x02 \x01(\x0e\x32\x17.query.TransactionState\x12\x14\n\x0ctime_created\x18\x03 \x01(\x03\x12#\n\x0cparticipants\x18\x04 \x03(\x0b\x32\r.query.Target\"\xa7\x01\n\x16ReadTransactionRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x0c\n\x04\x64tid\x18\x04 \x01(\t\"G\n\x17ReadTransactionResponse\x12,\n\x08metadata\x18\x01 \x01(\x0b\x32\x1a.query.TransactionMetadata*k\n\x04\x46lag\x12\x08\n\x04NONE\x10\x00\x12\x0f\n\nISINTEGRAL\x10\x80\x02\x12\x0f\n\nISUNSIGNED\x10\x80\x04\x12\x0c\n\x07ISFLOAT\x10\x80\x08\x12\r\n\x08ISQUOTED\x10\x80\x10\x12\x0b\n\x06ISTEXT\x10\x80 \x12\r\n\x08ISBINARY\x10\x80@*\xef\x02\n\x04Type\x12\r\n\tNULL_TYPE\x10\x00\x12\t\n\x04INT8\x10\x81\x02\x12\n\n\x05UINT8\x10\x82\x06\x12\n\n\x05INT16\x10\x83\x02\x12\x0b\n\x06UINT16\x10\x84\x06\x12\n\n\x05INT24\x10\x85\x02\x12\x0b\n\x06UINT24\x10\x86\x06\x12\n\n\x05INT32\x10\x87\x02\x12\x0b\n\x06UINT32\x10\x88\x06\x12\n\n\x05INT64\x10\x89\x02\x12\x0b\n\x06UINT64\x10\x8a\x06\x12\x0c\n\x07\x46LOAT32\x10\x8b\x08\x12\x0c\n\x07\x46LOAT64\x10\x8c\x08\x12\x0e\n\tTIMESTAMP\x10\x8d\x10\x12\t\n\x04\x44\x41TE\x10\x8e\x10\x12\t\n\x04TIME\x10\x8f\x10\x12\r\n\x08\x44\x41TETIME\x10\x90\x10\x12\t\n\x04YEAR\x10\x91\x06\x12\x0b\n\x07\x44\x45\x43IMAL\x10\x12\x12\t\n\x04TEXT\x10\x93\x30\x12\t\n\x04\x42LOB\x10\x94P\x12\x0c\n\x07VARCHAR\x10\x95\x30\x12\x0e\n\tVARBINARY\x10\x96P\x12\t\n\x04\x43HAR\x10\x97\x30\x12\x0b\n\x06\x42INARY\x10\x98P\x12\x08\n\x03\x42IT\x10\x99\x10\x12\t\n\x04\x45NUM\x10\x9a\x10\x12\x08\n\x03SET\x10\x9b\x10\x12\t\n\x05TUPLE\x10\x1c*F\n\x10TransactionState\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x0b\n\x07PREPARE\x10\x01\x12\n\n\x06\x43OMMIT\x10\x02\x12\x0c\n\x08ROLLBACK\x10\x03\x42\x1a\n\x18\x63om.youtube.vitess.protob\x06proto3'
   ,
   dependencies=[topodata__pb2.DESCRIPTOR,vtrpc__pb2.DESCRIPTOR,])
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
@@ -62,8 +62,8 @@ _FLAG = _descriptor.EnumDescriptor(
   ],
   containing_type=None,
   options=None,
-  serialized_start=3111,
-  serialized_end=3218,
+  serialized_start=4983,
+  serialized_end=5090,
 )
 _sym_db.RegisterEnumDescriptor(_FLAG)
 
@@ -193,12 +193,43 @@ _TYPE = _descriptor.EnumDescriptor(
   ],
   containing_type=None,
   options=None,
-  serialized_start=3221,
-  serialized_end=3588,
+  serialized_start=5093,
+  serialized_end=5460,
 )
 _sym_db.RegisterEnumDescriptor(_TYPE)
 
 Type = enum_type_wrapper.EnumTypeWrapper(_TYPE)
+_TRANSACTIONSTATE = _descriptor.EnumDescriptor(
+  name='TransactionState',
+  full_name='query.TransactionState',
+  filename=None,
+  file=DESCRIPTOR,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='UNKNOWN', index=0, number=0,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='PREPARE', index=1, number=1,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='COMMIT', index=2, number=2,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='ROLLBACK', index=3, number=3,
+      options=None,
+      type=None),
+  ],
+  containing_type=None,
+  options=None,
+  serialized_start=5462,
+  serialized_end=5532,
+)
+_sym_db.RegisterEnumDescriptor(_TRANSACTIONSTATE)
+
+TransactionState = enum_type_wrapper.EnumTypeWrapper(_TRANSACTIONSTATE)
 NONE = 0
 ISINTEGRAL = 256
 ISUNSIGNED = 512
@@ -235,6 +266,10 @@ BIT = 2073
 ENUM = 2074
 SET = 2075
 TUPLE = 28
+UNKNOWN = 0
+PREPARE = 1
+COMMIT = 2
+ROLLBACK = 3
 
 
 
@@ -1499,144 +1534,892 @@ _STREAMHEALTHRESPONSE = _descriptor.Descriptor(
   serialized_end=3109,
 )
 
-_TARGET.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_VALUE.fields_by_name['type'].enum_type = _TYPE
-_BINDVARIABLE.fields_by_name['type'].enum_type = _TYPE
-_BINDVARIABLE.fields_by_name['values'].message_type = _VALUE
-_BOUNDQUERY_BINDVARIABLESENTRY.fields_by_name['value'].message_type = _BINDVARIABLE
-_BOUNDQUERY_BINDVARIABLESENTRY.containing_type = _BOUNDQUERY
-_BOUNDQUERY.fields_by_name['bind_variables'].message_type = _BOUNDQUERY_BINDVARIABLESENTRY
-_FIELD.fields_by_name['type'].enum_type = _TYPE
-_QUERYRESULT.fields_by_name['fields'].message_type = _FIELD
-_QUERYRESULT.fields_by_name['rows'].message_type = _ROW
-_GETSESSIONIDREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_GETSESSIONIDREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_EXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_EXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
-_EXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
-_EXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
-_EXECUTEBATCHREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEBATCHREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_EXECUTEBATCHREQUEST.fields_by_name['target'].message_type = _TARGET
-_EXECUTEBATCHREQUEST.fields_by_name['queries'].message_type = _BOUNDQUERY
-_EXECUTEBATCHRESPONSE.fields_by_name['results'].message_type = _QUERYRESULT
-_STREAMEXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_STREAMEXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_STREAMEXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
-_STREAMEXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
-_STREAMEXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
-_BEGINREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_BEGINREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_BEGINREQUEST.fields_by_name['target'].message_type = _TARGET
-_COMMITREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_COMMITREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_COMMITREQUEST.fields_by_name['target'].message_type = _TARGET
-_ROLLBACKREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_ROLLBACKREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_ROLLBACKREQUEST.fields_by_name['target'].message_type = _TARGET
-_SPLITQUERYREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_SPLITQUERYREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_SPLITQUERYREQUEST.fields_by_name['target'].message_type = _TARGET
-_SPLITQUERYREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
-_QUERYSPLIT.fields_by_name['query'].message_type = _BOUNDQUERY
-_SPLITQUERYRESPONSE.fields_by_name['queries'].message_type = _QUERYSPLIT
-_STREAMHEALTHRESPONSE.fields_by_name['target'].message_type = _TARGET
-_STREAMHEALTHRESPONSE.fields_by_name['realtime_stats'].message_type = _REALTIMESTATS
-DESCRIPTOR.message_types_by_name['Target'] = _TARGET
-DESCRIPTOR.message_types_by_name['VTGateCallerID'] = _VTGATECALLERID
-DESCRIPTOR.message_types_by_name['Value'] = _VALUE
-DESCRIPTOR.message_types_by_name['BindVariable'] = _BINDVARIABLE
-DESCRIPTOR.message_types_by_name['BoundQuery'] = _BOUNDQUERY
-DESCRIPTOR.message_types_by_name['Field'] = _FIELD
-DESCRIPTOR.message_types_by_name['Row'] = _ROW
-DESCRIPTOR.message_types_by_name['QueryResult'] = _QUERYRESULT
-DESCRIPTOR.message_types_by_name['GetSessionIdRequest'] = _GETSESSIONIDREQUEST
-DESCRIPTOR.message_types_by_name['GetSessionIdResponse'] = _GETSESSIONIDRESPONSE
-DESCRIPTOR.message_types_by_name['ExecuteRequest'] = _EXECUTEREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteResponse'] = _EXECUTERESPONSE
-DESCRIPTOR.message_types_by_name['ExecuteBatchRequest'] = _EXECUTEBATCHREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteBatchResponse'] = _EXECUTEBATCHRESPONSE
-DESCRIPTOR.message_types_by_name['StreamExecuteRequest'] = _STREAMEXECUTEREQUEST
-DESCRIPTOR.message_types_by_name['StreamExecuteResponse'] = _STREAMEXECUTERESPONSE
-DESCRIPTOR.message_types_by_name['BeginRequest'] = _BEGINREQUEST
-DESCRIPTOR.message_types_by_name['BeginResponse'] = _BEGINRESPONSE
-DESCRIPTOR.message_types_by_name['CommitRequest'] = _COMMITREQUEST
-DESCRIPTOR.message_types_by_name['CommitResponse'] = _COMMITRESPONSE
-DESCRIPTOR.message_types_by_name['RollbackRequest'] = _ROLLBACKREQUEST
-DESCRIPTOR.message_types_by_name['RollbackResponse'] = _ROLLBACKRESPONSE
-DESCRIPTOR.message_types_by_name['SplitQueryRequest'] = _SPLITQUERYREQUEST
-DESCRIPTOR.message_types_by_name['QuerySplit'] = _QUERYSPLIT
-DESCRIPTOR.message_types_by_name['SplitQueryResponse'] = _SPLITQUERYRESPONSE
-DESCRIPTOR.message_types_by_name['StreamHealthRequest'] = _STREAMHEALTHREQUEST
-DESCRIPTOR.message_types_by_name['RealtimeStats'] = _REALTIMESTATS
-DESCRIPTOR.message_types_by_name['StreamHealthResponse'] = _STREAMHEALTHRESPONSE
-DESCRIPTOR.enum_types_by_name['Flag'] = _FLAG
-DESCRIPTOR.enum_types_by_name['Type'] = _TYPE
-
-Target = _reflection.GeneratedProtocolMessageType('Target', (_message.Message,), dict(
-  DESCRIPTOR = _TARGET,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.Target)
-  ))
-_sym_db.RegisterMessage(Target)
-
-VTGateCallerID = _reflection.GeneratedProtocolMessageType('VTGateCallerID', (_message.Message,), dict(
-  DESCRIPTOR = _VTGATECALLERID,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.VTGateCallerID)
-  ))
-_sym_db.RegisterMessage(VTGateCallerID)
 
-Value = _reflection.GeneratedProtocolMessageType('Value', (_message.Message,), dict(
-  DESCRIPTOR = _VALUE,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.Value)
-  ))
-_sym_db.RegisterMessage(Value)
+_PREPAREREQUEST = _descriptor.Descriptor(
+  name='PrepareRequest',
+  full_name='query.PrepareRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.PrepareRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.PrepareRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.PrepareRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='transaction_id', full_name='query.PrepareRequest.transaction_id', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dtid', full_name='query.PrepareRequest.dtid', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3112,
+  serialized_end=3295,
+)
 
-BindVariable = _reflection.GeneratedProtocolMessageType('BindVariable', (_message.Message,), dict(
-  DESCRIPTOR = _BINDVARIABLE,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.BindVariable)
-  ))
-_sym_db.RegisterMessage(BindVariable)
 
-BoundQuery = _reflection.GeneratedProtocolMessageType('BoundQuery', (_message.Message,), dict(
+_PREPARERESPONSE = _descriptor.Descriptor(
+  name='PrepareResponse',
+  full_name='query.PrepareResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3297,
+  serialized_end=3314,
+)
 
-  BindVariablesEntry = _reflection.GeneratedProtocolMessageType('BindVariablesEntry', (_message.Message,), dict(
-    DESCRIPTOR = _BOUNDQUERY_BINDVARIABLESENTRY,
-    __module__ = 'query_pb2'
-    # @@protoc_insertion_point(class_scope:query.BoundQuery.BindVariablesEntry)
-    ))
-  ,
-  DESCRIPTOR = _BOUNDQUERY,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.BoundQuery)
-  ))
-_sym_db.RegisterMessage(BoundQuery)
-_sym_db.RegisterMessage(BoundQuery.BindVariablesEntry)
 
-Field = _reflection.GeneratedProtocolMessageType('Field', (_message.Message,), dict(
-  DESCRIPTOR = _FIELD,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.Field)
-  ))
-_sym_db.RegisterMessage(Field)
+_COMMITPREPAREDREQUEST = _descriptor.Descriptor(
+  name='CommitPreparedRequest',
+  full_name='query.CommitPreparedRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.CommitPreparedRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.CommitPreparedRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.CommitPreparedRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dtid', full_name='query.CommitPreparedRequest.dtid', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3317,
+  serialized_end=3483,
+)
 
-Row = _reflection.GeneratedProtocolMessageType('Row', (_message.Message,), dict(
-  DESCRIPTOR = _ROW,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.Row)
-  ))
-_sym_db.RegisterMessage(Row)
 
-QueryResult = _reflection.GeneratedProtocolMessageType('QueryResult', (_message.Message,), dict(
-  DESCRIPTOR = _QUERYRESULT,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.QueryResult)
-  ))
-_sym_db.RegisterMessage(QueryResult)
+_COMMITPREPAREDRESPONSE = _descriptor.Descriptor(
+  name='CommitPreparedResponse',
+  full_name='query.CommitPreparedResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3485,
+  serialized_end=3509,
+)
+
+
+_ROLLBACKPREPAREDREQUEST = _descriptor.Descriptor(
+  name='RollbackPreparedRequest',
+  full_name='query.RollbackPreparedRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.RollbackPreparedRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.RollbackPreparedRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.RollbackPreparedRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='transaction_id', full_name='query.RollbackPreparedRequest.transaction_id', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dtid', full_name='query.RollbackPreparedRequest.dtid', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3512,
+  serialized_end=3704,
+)
+
+
+_ROLLBACKPREPAREDRESPONSE = _descriptor.Descriptor(
+  name='RollbackPreparedResponse',
+  full_name='query.RollbackPreparedResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3706,
+  serialized_end=3732,
+)
+
+
+_CREATETRANSACTIONREQUEST = _descriptor.Descriptor(
+  name='CreateTransactionRequest',
+  full_name='query.CreateTransactionRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.CreateTransactionRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.CreateTransactionRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.CreateTransactionRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dtid', full_name='query.CreateTransactionRequest.dtid', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='participants', full_name='query.CreateTransactionRequest.participants', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3735,
+  serialized_end=3941,
+)
+
+
+_CREATETRANSACTIONRESPONSE = _descriptor.Descriptor(
+  name='CreateTransactionResponse',
+  full_name='query.CreateTransactionResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3943,
+  serialized_end=3970,
+)
+
+
+_STARTCOMMITREQUEST = _descriptor.Descriptor(
+  name='StartCommitRequest',
+  full_name='query.StartCommitRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.StartCommitRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.StartCommitRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.StartCommitRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='transaction_id', full_name='query.StartCommitRequest.transaction_id', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dtid', full_name='query.StartCommitRequest.dtid', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3973,
+  serialized_end=4160,
+)
+
+
+_STARTCOMMITRESPONSE = _descriptor.Descriptor(
+  name='StartCommitResponse',
+  full_name='query.StartCommitResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4162,
+  serialized_end=4183,
+)
+
+
+_SETROLLBACKREQUEST = _descriptor.Descriptor(
+  name='SetRollbackRequest',
+  full_name='query.SetRollbackRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.SetRollbackRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.SetRollbackRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.SetRollbackRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='transaction_id', full_name='query.SetRollbackRequest.transaction_id', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dtid', full_name='query.SetRollbackRequest.dtid', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4186,
+  serialized_end=4373,
+)
+
+
+_SETROLLBACKRESPONSE = _descriptor.Descriptor(
+  name='SetRollbackResponse',
+  full_name='query.SetRollbackResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4375,
+  serialized_end=4396,
+)
+
+
+_CONCLUDETRANSACTIONREQUEST = _descriptor.Descriptor(
+  name='ConcludeTransactionRequest',
+  full_name='query.ConcludeTransactionRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.ConcludeTransactionRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.ConcludeTransactionRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.ConcludeTransactionRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dtid', full_name='query.ConcludeTransactionRequest.dtid', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4399,
+  serialized_end=4570,
+)
+
+
+_CONCLUDETRANSACTIONRESPONSE = _descriptor.Descriptor(
+  name='ConcludeTransactionResponse',
+  full_name='query.ConcludeTransactionResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4572,
+  serialized_end=4601,
+)
+
+
+_TRANSACTIONMETADATA = _descriptor.Descriptor(
+  name='TransactionMetadata',
+  full_name='query.TransactionMetadata',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='dtid', full_name='query.TransactionMetadata.dtid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='state', full_name='query.TransactionMetadata.state', index=1,
+      number=2, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='time_created', full_name='query.TransactionMetadata.time_created', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='participants', full_name='query.TransactionMetadata.participants', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4604,
+  serialized_end=4738,
+)
+
+
+_READTRANSACTIONREQUEST = _descriptor.Descriptor(
+  name='ReadTransactionRequest',
+  full_name='query.ReadTransactionRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.ReadTransactionRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.ReadTransactionRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.ReadTransactionRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dtid', full_name='query.ReadTransactionRequest.dtid', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4741,
+  serialized_end=4908,
+)
+
+
+_READTRANSACTIONRESPONSE = _descriptor.Descriptor(
+  name='ReadTransactionResponse',
+  full_name='query.ReadTransactionResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='metadata', full_name='query.ReadTransactionResponse.metadata', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4910,
+  serialized_end=4981,
+)
+
+_TARGET.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_VALUE.fields_by_name['type'].enum_type = _TYPE
+_BINDVARIABLE.fields_by_name['type'].enum_type = _TYPE
+_BINDVARIABLE.fields_by_name['values'].message_type = _VALUE
+_BOUNDQUERY_BINDVARIABLESENTRY.fields_by_name['value'].message_type = _BINDVARIABLE
+_BOUNDQUERY_BINDVARIABLESENTRY.containing_type = _BOUNDQUERY
+_BOUNDQUERY.fields_by_name['bind_variables'].message_type = _BOUNDQUERY_BINDVARIABLESENTRY
+_FIELD.fields_by_name['type'].enum_type = _TYPE
+_QUERYRESULT.fields_by_name['fields'].message_type = _FIELD
+_QUERYRESULT.fields_by_name['rows'].message_type = _ROW
+_GETSESSIONIDREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_GETSESSIONIDREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_EXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_EXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
+_EXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
+_EXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
+_EXECUTEBATCHREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEBATCHREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_EXECUTEBATCHREQUEST.fields_by_name['target'].message_type = _TARGET
+_EXECUTEBATCHREQUEST.fields_by_name['queries'].message_type = _BOUNDQUERY
+_EXECUTEBATCHRESPONSE.fields_by_name['results'].message_type = _QUERYRESULT
+_STREAMEXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_STREAMEXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_STREAMEXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
+_STREAMEXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
+_STREAMEXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
+_BEGINREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_BEGINREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_BEGINREQUEST.fields_by_name['target'].message_type = _TARGET
+_COMMITREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_COMMITREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_COMMITREQUEST.fields_by_name['target'].message_type = _TARGET
+_ROLLBACKREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_ROLLBACKREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_ROLLBACKREQUEST.fields_by_name['target'].message_type = _TARGET
+_SPLITQUERYREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_SPLITQUERYREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_SPLITQUERYREQUEST.fields_by_name['target'].message_type = _TARGET
+_SPLITQUERYREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
+_QUERYSPLIT.fields_by_name['query'].message_type = _BOUNDQUERY
+_SPLITQUERYRESPONSE.fields_by_name['queries'].message_type = _QUERYSPLIT
+_STREAMHEALTHRESPONSE.fields_by_name['target'].message_type = _TARGET
+_STREAMHEALTHRESPONSE.fields_by_name['realtime_stats'].message_type = _REALTIMESTATS
+_PREPAREREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_PREPAREREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_PREPAREREQUEST.fields_by_name['target'].message_type = _TARGET
+_COMMITPREPAREDREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_COMMITPREPAREDREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_COMMITPREPAREDREQUEST.fields_by_name['target'].message_type = _TARGET
+_ROLLBACKPREPAREDREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_ROLLBACKPREPAREDREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_ROLLBACKPREPAREDREQUEST.fields_by_name['target'].message_type = _TARGET
+_CREATETRANSACTIONREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_CREATETRANSACTIONREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_CREATETRANSACTIONREQUEST.fields_by_name['target'].message_type = _TARGET
+_CREATETRANSACTIONREQUEST.fields_by_name['participants'].message_type = _TARGET
+_STARTCOMMITREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_STARTCOMMITREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_STARTCOMMITREQUEST.fields_by_name['target'].message_type = _TARGET
+_SETROLLBACKREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_SETROLLBACKREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_SETROLLBACKREQUEST.fields_by_name['target'].message_type = _TARGET
+_CONCLUDETRANSACTIONREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_CONCLUDETRANSACTIONREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_CONCLUDETRANSACTIONREQUEST.fields_by_name['target'].message_type = _TARGET
+_TRANSACTIONMETADATA.fields_by_name['state'].enum_type = _TRANSACTIONSTATE
+_TRANSACTIONMETADATA.fields_by_name['participants'].message_type = _TARGET
+_READTRANSACTIONREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_READTRANSACTIONREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_READTRANSACTIONREQUEST.fields_by_name['target'].message_type = _TARGET
+_READTRANSACTIONRESPONSE.fields_by_name['metadata'].message_type = _TRANSACTIONMETADATA
+DESCRIPTOR.message_types_by_name['Target'] = _TARGET
+DESCRIPTOR.message_types_by_name['VTGateCallerID'] = _VTGATECALLERID
+DESCRIPTOR.message_types_by_name['Value'] = _VALUE
+DESCRIPTOR.message_types_by_name['BindVariable'] = _BINDVARIABLE
+DESCRIPTOR.message_types_by_name['BoundQuery'] = _BOUNDQUERY
+DESCRIPTOR.message_types_by_name['Field'] = _FIELD
+DESCRIPTOR.message_types_by_name['Row'] = _ROW
+DESCRIPTOR.message_types_by_name['QueryResult'] = _QUERYRESULT
+DESCRIPTOR.message_types_by_name['GetSessionIdRequest'] = _GETSESSIONIDREQUEST
+DESCRIPTOR.message_types_by_name['GetSessionIdResponse'] = _GETSESSIONIDRESPONSE
+DESCRIPTOR.message_types_by_name['ExecuteRequest'] = _EXECUTEREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteResponse'] = _EXECUTERESPONSE
+DESCRIPTOR.message_types_by_name['ExecuteBatchRequest'] = _EXECUTEBATCHREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteBatchResponse'] = _EXECUTEBATCHRESPONSE
+DESCRIPTOR.message_types_by_name['StreamExecuteRequest'] = _STREAMEXECUTEREQUEST
+DESCRIPTOR.message_types_by_name['StreamExecuteResponse'] = _STREAMEXECUTERESPONSE
+DESCRIPTOR.message_types_by_name['BeginRequest'] = _BEGINREQUEST
+DESCRIPTOR.message_types_by_name['BeginResponse'] = _BEGINRESPONSE
+DESCRIPTOR.message_types_by_name['CommitRequest'] = _COMMITREQUEST
+DESCRIPTOR.message_types_by_name['CommitResponse'] = _COMMITRESPONSE
+DESCRIPTOR.message_types_by_name['RollbackRequest'] = _ROLLBACKREQUEST
+DESCRIPTOR.message_types_by_name['RollbackResponse'] = _ROLLBACKRESPONSE
+DESCRIPTOR.message_types_by_name['SplitQueryRequest'] = _SPLITQUERYREQUEST
+DESCRIPTOR.message_types_by_name['QuerySplit'] = _QUERYSPLIT
+DESCRIPTOR.message_types_by_name['SplitQueryResponse'] = _SPLITQUERYRESPONSE
+DESCRIPTOR.message_types_by_name['StreamHealthRequest'] = _STREAMHEALTHREQUEST
+DESCRIPTOR.message_types_by_name['RealtimeStats'] = _REALTIMESTATS
+DESCRIPTOR.message_types_by_name['StreamHealthResponse'] = _STREAMHEALTHRESPONSE
+DESCRIPTOR.message_types_by_name['PrepareRequest'] = _PREPAREREQUEST
+DESCRIPTOR.message_types_by_name['PrepareResponse'] = _PREPARERESPONSE
+DESCRIPTOR.message_types_by_name['CommitPreparedRequest'] = _COMMITPREPAREDREQUEST
+DESCRIPTOR.message_types_by_name['CommitPreparedResponse'] = _COMMITPREPAREDRESPONSE
+DESCRIPTOR.message_types_by_name['RollbackPreparedRequest'] = _ROLLBACKPREPAREDREQUEST
+DESCRIPTOR.message_types_by_name['RollbackPreparedResponse'] = _ROLLBACKPREPAREDRESPONSE
+DESCRIPTOR.message_types_by_name['CreateTransactionRequest'] = _CREATETRANSACTIONREQUEST
+DESCRIPTOR.message_types_by_name['CreateTransactionResponse'] = _CREATETRANSACTIONRESPONSE
+DESCRIPTOR.message_types_by_name['StartCommitRequest'] = _STARTCOMMITREQUEST
+DESCRIPTOR.message_types_by_name['StartCommitResponse'] = _STARTCOMMITRESPONSE
+DESCRIPTOR.message_types_by_name['SetRollbackRequest'] = _SETROLLBACKREQUEST
+DESCRIPTOR.message_types_by_name['SetRollbackResponse'] = _SETROLLBACKRESPONSE
+DESCRIPTOR.message_types_by_name['ConcludeTransactionRequest'] = _CONCLUDETRANSACTIONREQUEST
+DESCRIPTOR.message_types_by_name['ConcludeTransactionResponse'] = _CONCLUDETRANSACTIONRESPONSE
+DESCRIPTOR.message_types_by_name['TransactionMetadata'] = _TRANSACTIONMETADATA
+DESCRIPTOR.message_types_by_name['ReadTransactionRequest'] = _READTRANSACTIONREQUEST
+DESCRIPTOR.message_types_by_name['ReadTransactionResponse'] = _READTRANSACTIONRESPONSE
+DESCRIPTOR.enum_types_by_name['Flag'] = _FLAG
+DESCRIPTOR.enum_types_by_name['Type'] = _TYPE
+DESCRIPTOR.enum_types_by_name['TransactionState'] = _TRANSACTIONSTATE
+
+Target = _reflection.GeneratedProtocolMessageType('Target', (_message.Message,), dict(
+  DESCRIPTOR = _TARGET,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.Target)
+  ))
+_sym_db.RegisterMessage(Target)
+
+VTGateCallerID = _reflection.GeneratedProtocolMessageType('VTGateCallerID', (_message.Message,), dict(
+  DESCRIPTOR = _VTGATECALLERID,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.VTGateCallerID)
+  ))
+_sym_db.RegisterMessage(VTGateCallerID)
+
+Value = _reflection.GeneratedProtocolMessageType('Value', (_message.Message,), dict(
+  DESCRIPTOR = _VALUE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.Value)
+  ))
+_sym_db.RegisterMessage(Value)
+
+BindVariable = _reflection.GeneratedProtocolMessageType('BindVariable', (_message.Message,), dict(
+  DESCRIPTOR = _BINDVARIABLE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.BindVariable)
+  ))
+_sym_db.RegisterMessage(BindVariable)
+
+BoundQuery = _reflection.GeneratedProtocolMessageType('BoundQuery', (_message.Message,), dict(
+
+  BindVariablesEntry = _reflection.GeneratedProtocolMessageType('BindVariablesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _BOUNDQUERY_BINDVARIABLESENTRY,
+    __module__ = 'query_pb2'
+    # @@protoc_insertion_point(class_scope:query.BoundQuery.BindVariablesEntry)
+    ))
+  ,
+  DESCRIPTOR = _BOUNDQUERY,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.BoundQuery)
+  ))
+_sym_db.RegisterMessage(BoundQuery)
+_sym_db.RegisterMessage(BoundQuery.BindVariablesEntry)
+
+Field = _reflection.GeneratedProtocolMessageType('Field', (_message.Message,), dict(
+  DESCRIPTOR = _FIELD,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.Field)
+  ))
+_sym_db.RegisterMessage(Field)
+
+Row = _reflection.GeneratedProtocolMessageType('Row', (_message.Message,), dict(
+  DESCRIPTOR = _ROW,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.Row)
+  ))
+_sym_db.RegisterMessage(Row)
+
+QueryResult = _reflection.GeneratedProtocolMessageType('QueryResult', (_message.Message,), dict(
+  DESCRIPTOR = _QUERYRESULT,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.QueryResult)
+  ))
+_sym_db.RegisterMessage(QueryResult)
 
 GetSessionIdRequest = _reflection.GeneratedProtocolMessageType('GetSessionIdRequest', (_message.Message,), dict(
   DESCRIPTOR = _GETSESSIONIDREQUEST,
@@ -1778,6 +2561,125 @@ StreamHealthResponse = _reflection.GeneratedProtocolMessageType('StreamHealthRes
   ))
 _sym_db.RegisterMessage(StreamHealthResponse)
 
+PrepareRequest = _reflection.GeneratedProtocolMessageType('PrepareRequest', (_message.Message,), dict(
+  DESCRIPTOR = _PREPAREREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.PrepareRequest)
+  ))
+_sym_db.RegisterMessage(PrepareRequest)
+
+PrepareResponse = _reflection.GeneratedProtocolMessageType('PrepareResponse', (_message.Message,), dict(
+  DESCRIPTOR = _PREPARERESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.PrepareResponse)
+  ))
+_sym_db.RegisterMessage(PrepareResponse)
+
+CommitPreparedRequest = _reflection.GeneratedProtocolMessageType('CommitPreparedRequest', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITPREPAREDREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.CommitPreparedRequest)
+  ))
+_sym_db.RegisterMessage(CommitPreparedRequest)
+
+CommitPreparedResponse = _reflection.GeneratedProtocolMessageType('CommitPreparedResponse', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITPREPAREDRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.CommitPreparedResponse)
+  ))
+_sym_db.RegisterMessage(CommitPreparedResponse)
+
+RollbackPreparedRequest = _reflection.GeneratedProtocolMessageType('RollbackPreparedRequest', (_message.Message,), dict(
+  DESCRIPTOR = _ROLLBACKPREPAREDREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.RollbackPreparedRequest)
+  ))
+_sym_db.RegisterMessage(RollbackPreparedRequest)
+
+RollbackPreparedResponse = _reflection.GeneratedProtocolMessageType('RollbackPreparedResponse', (_message.Message,), dict(
+  DESCRIPTOR = _ROLLBACKPREPAREDRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.RollbackPreparedResponse)
+  ))
+_sym_db.RegisterMessage(RollbackPreparedResponse)
+
+CreateTransactionRequest = _reflection.GeneratedProtocolMessageType('CreateTransactionRequest', (_message.Message,), dict(
+  DESCRIPTOR = _CREATETRANSACTIONREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.CreateTransactionRequest)
+  ))
+_sym_db.RegisterMessage(CreateTransactionRequest)
+
+CreateTransactionResponse = _reflection.GeneratedProtocolMessageType('CreateTransactionResponse', (_message.Message,), dict(
+  DESCRIPTOR = _CREATETRANSACTIONRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.CreateTransactionResponse)
+  ))
+_sym_db.RegisterMessage(CreateTransactionResponse)
+
+StartCommitRequest = _reflection.GeneratedProtocolMessageType('StartCommitRequest', (_message.Message,), dict(
+  DESCRIPTOR = _STARTCOMMITREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.StartCommitRequest)
+  ))
+_sym_db.RegisterMessage(StartCommitRequest)
+
+StartCommitResponse = _reflection.GeneratedProtocolMessageType('StartCommitResponse', (_message.Message,), dict(
+  DESCRIPTOR = _STARTCOMMITRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.StartCommitResponse)
+  ))
+_sym_db.RegisterMessage(StartCommitResponse)
+
+SetRollbackRequest = _reflection.GeneratedProtocolMessageType('SetRollbackRequest', (_message.Message,), dict(
+  DESCRIPTOR = _SETROLLBACKREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.SetRollbackRequest)
+  ))
+_sym_db.RegisterMessage(SetRollbackRequest)
+
+SetRollbackResponse = _reflection.GeneratedProtocolMessageType('SetRollbackResponse', (_message.Message,), dict(
+  DESCRIPTOR = _SETROLLBACKRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.SetRollbackResponse)
+  ))
+_sym_db.RegisterMessage(SetRollbackResponse)
+
+ConcludeTransactionRequest = _reflection.GeneratedProtocolMessageType('ConcludeTransactionRequest', (_message.Message,), dict(
+  DESCRIPTOR = _CONCLUDETRANSACTIONREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.ConcludeTransactionRequest)
+  ))
+_sym_db.RegisterMessage(ConcludeTransactionRequest)
+
+ConcludeTransactionResponse = _reflection.GeneratedProtocolMessageType('ConcludeTransactionResponse', (_message.Message,), dict(
+  DESCRIPTOR = _CONCLUDETRANSACTIONRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.ConcludeTransactionResponse)
+  ))
+_sym_db.RegisterMessage(ConcludeTransactionResponse)
+
+TransactionMetadata = _reflection.GeneratedProtocolMessageType('TransactionMetadata', (_message.Message,), dict(
+  DESCRIPTOR = _TRANSACTIONMETADATA,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.TransactionMetadata)
+  ))
+_sym_db.RegisterMessage(TransactionMetadata)
+
+ReadTransactionRequest = _reflection.GeneratedProtocolMessageType('ReadTransactionRequest', (_message.Message,), dict(
+  DESCRIPTOR = _READTRANSACTIONREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.ReadTransactionRequest)
+  ))
+_sym_db.RegisterMessage(ReadTransactionRequest)
+
+ReadTransactionResponse = _reflection.GeneratedProtocolMessageType('ReadTransactionResponse', (_message.Message,), dict(
+  DESCRIPTOR = _READTRANSACTIONRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.ReadTransactionResponse)
+  ))
+_sym_db.RegisterMessage(ReadTransactionResponse)
+
 
 DESCRIPTOR.has_options = True
 DESCRIPTOR._options = _descriptor._ParseOptions(descriptor_pb2.FileOptions(), b'\n\030com.youtube.vitess.proto')
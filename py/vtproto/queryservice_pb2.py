@@ -18,7 +18,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='queryservice.proto',
   package='queryservice',
   syntax='proto3',
-  serialized_pb=b'\n\x12queryservice.proto\x12\x0cqueryservice\x1a\x0bquery.proto2\xe9\x04\n\x05Query\x12I\n\x0cGetSessionId\x12\x1a.query.GetSessionIdRequest\x1a\x1b.query.GetSessionIdResponse\"\x00\x12:\n\x07\x45xecute\x12\x15.query.ExecuteRequest\x1a\x16.query.ExecuteResponse\"\x00\x12I\n\x0c\x45xecuteBatch\x12\x1a.query.ExecuteBatchRequest\x1a\x1b.query.ExecuteBatchResponse\"\x00\x12N\n\rStreamExecute\x12\x1b.query.StreamExecuteRequest\x1a\x1c.query.StreamExecuteResponse\"\x00\x30\x01\x12\x34\n\x05\x42\x65gin\x12\x13.query.BeginRequest\x1a\x14.query.BeginResponse\"\x00\x12\x37\n\x06\x43ommit\x12\x14.query.CommitRequest\x1a\x15.query.CommitResponse\"\x00\x12=\n\x08Rollback\x12\x16.query.RollbackRequest\x1a\x17.query.RollbackResponse\"\x00\x12\x43\n\nSplitQuery\x12\x18.query.SplitQueryRequest\x1a\x19.query.SplitQueryResponse\"\x00\x12K\n\x0cStreamHealth\x12\x1a.query.StreamHealthRequest\x1a\x1b.query.StreamHealthResponse\"\x00\x30\x01\x62\x06proto3'
+  serialized_pb=b'\n\x12queryservice.proto\x12\x0cqueryservice\x1a\x0bquery.proto2\xeb\t\n\x05Query\x12I\n\x0cGetSessionId\x12\x1a.query.GetSessionIdRequest\x1a\x1b.query.GetSessionIdResponse\"\x00\x12:\n\x07\x45xecute\x12\x15.query.ExecuteRequest\x1a\x16.query.ExecuteResponse\"\x00\x12I\n\x0c\x45xecuteBatch\x12\x1a.query.ExecuteBatchRequest\x1a\x1b.query.ExecuteBatchResponse\"\x00\x12N\n\rStreamExecute\x12\x1b.query.StreamExecuteRequest\x1a\x1c.query.StreamExecuteResponse\"\x00\x30\x01\x12\x34\n\x05\x42\x65gin\x12\x13.query.BeginRequest\x1a\x14.query.BeginResponse\"\x00\x12\x37\n\x06\x43ommit\x12\x14.query.CommitRequest\x1a\x15.query.CommitResponse\"\x00\x12=\n\x08Rollback\x12\x16.query.RollbackRequest\x1a\x17.query.RollbackResponse\"\x00\x12:\n\x07Prepare\x12\x15.query.PrepareRequest\x1a\x16.query.PrepareResponse\"\x00\x12O\n\x0e\x43ommitPrepared\x12\x1c.query.CommitPreparedRequest\x1a\x1d.query.CommitPreparedResponse\"\x00\x12U\n\x10RollbackPrepared\x12\x1e.query.RollbackPreparedRequest\x1a\x1f.query.RollbackPreparedResponse\"\x00\x12X\n\x11\x43reateTransaction\x12\x1f.query.CreateTransactionRequest\x1a .query.CreateTransactionResponse\"\x00\x12\x46\n\x0bStartCommit\x12\x19.query.StartCommitRequest\x1a\x1a.query.StartCommitResponse\"\x00\x12\x46\n\x0bSetRollback\x12\x19.query.SetRollbackRequest\x1a\x1a.query.SetRollbackResponse\"\x00\x12^\n\x13\x43oncludeTransaction\x12!.query.ConcludeTransactionRequest\x1a\".query.ConcludeTransactionResponse\"\x00\x12R\n\x0fReadTransaction\x12\x1d.query.ReadTransactionRequest\x1a\x1e.query.ReadTransactionResponse\"\x00\x12\x43\n\nSplitQuery\x12\x18.query.SplitQueryRequest\x1a\x19.query.SplitQueryResponse\"\x00\x12K\n\x0cStreamHealth\x12\x1a.query.StreamHealthRequest\x1a\x1b.query.StreamHealthResponse\"\x00\x30\x01\x62\x06proto3'
   ,
   dependencies=[query__pb2.DESCRIPTOR,])
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
@@ -58,6 +58,30 @@ class EarlyAdopterQueryServicer(object):
   def Rollback(self, request, context):
     raise NotImplementedError()
   @abc.abstractmethod
+  def Prepare(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def CommitPrepared(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def RollbackPrepared(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def CreateTransaction(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def StartCommit(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def SetRollback(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def ConcludeTransaction(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def ReadTransaction(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
   def SplitQuery(self, request, context):
     raise NotImplementedError()
   @abc.abstractmethod
@@ -104,6 +128,38 @@ class EarlyAdopterQueryStub(object):
     raise NotImplementedError()
   Rollback.async = None
   @abc.abstractmethod
+  def Prepare(self, request):
+    raise NotImplementedError()
+  Prepare.async = None
+  @abc.abstractmethod
+  def CommitPrepared(self, request):
+    raise NotImplementedError()
+  CommitPrepared.async = None
+  @abc.abstractmethod
+  def RollbackPrepared(self, request):
+    raise NotImplementedError()
+  RollbackPrepared.async = None
+  @abc.abstractmethod
+  def CreateTransaction(self, request):
+    raise NotImplementedError()
+  CreateTransaction.async = None
+  @abc.abstractmethod
+  def StartCommit(self, request):
+    raise NotImplementedError()
+  StartCommit.async = None
+  @abc.abstractmethod
+  def SetRollback(self, request):
+    raise NotImplementedError()
+  SetRollback.async = None
+  @abc.abstractmethod
+  def ConcludeTransaction(self, request):
+    raise NotImplementedError()
+  ConcludeTransaction.async = None
+  @abc.abstractmethod
+  def ReadTransaction(self, request):
+    raise NotImplementedError()
+  ReadTransaction.async = None
+  @abc.abstractmethod
   def SplitQuery(self, request):
     raise NotImplementedError()
   SplitQuery.async = None
@@ -130,6 +186,22 @@ def early_adopter_create_Query_server(servicer, port, private_key=None, certific
   import query_pb2
   import query_pb2
   import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
   method_service_descriptions = {
     "Begin": alpha_utilities.unary_unary_service_description(
       servicer.Begin,
@@ -161,6 +233,46 @@ def early_adopter_create_Query_server(servicer, port, private_key=None, certific
       query_pb2.RollbackRequest.FromString,
       query_pb2.RollbackResponse.SerializeToString,
     ),
+    "Prepare": alpha_utilities.unary_unary_service_description(
+      servicer.Prepare,
+      query_pb2.PrepareRequest.FromString,
+      query_pb2.PrepareResponse.SerializeToString,
+    ),
+    "CommitPrepared": alpha_utilities.unary_unary_service_description(
+      servicer.CommitPrepared,
+      query_pb2.CommitPreparedRequest.FromString,
+      query_pb2.CommitPreparedResponse.SerializeToString,
+    ),
+    "RollbackPrepared": alpha_utilities.unary_unary_service_description(
+      servicer.RollbackPrepared,
+      query_pb2.RollbackPreparedRequest.FromString,
+      query_pb2.RollbackPreparedResponse.SerializeToString,
+    ),
+    "CreateTransaction": alpha_utilities.unary_unary_service_description(
+      servicer.CreateTransaction,
+      query_pb2.CreateTransactionRequest.FromString,
+      query_pb2.CreateTransactionResponse.SerializeToString,
+    ),
+    "StartCommit": alpha_utilities.unary_unary_service_description(
+      servicer.StartCommit,
+      query_pb2.StartCommitRequest.FromString,
+      query_pb2.StartCommitResponse.SerializeToString,
+    ),
+    "SetRollback": alpha_utilities.unary_unary_service_description(
+      servicer.SetRollback,
+      query_pb2.SetRollbackRequest.FromString,
+      query_pb2.SetRollbackResponse.SerializeToString,
+    ),
+    "ConcludeTransaction": alpha_utilities.unary_unary_service_description(
+      servicer.ConcludeTransaction,
+      query_pb2.ConcludeTransactionRequest.FromString,
+      query_pb2.ConcludeTransactionResponse.SerializeToString,
+    ),
+    "ReadTransaction": alpha_utilities.unary_unary_service_description(
+      servicer.ReadTransaction,
+      query_pb2.ReadTransactionRequest.FromString,
+      query_pb2.ReadTransactionResponse.SerializeToString,
+    ),
     "SplitQuery": alpha_utilities.unary_unary_service_description(
       servicer.SplitQuery,
       query_pb2.SplitQueryRequest.FromString,
@@ -197,6 +309,22 @@ def early_adopter_create_Query_stub(host, port, metadata_transformer=None, secur
   import query_pb2
   import query_pb2
   import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
   method_invocation_descriptions = {
     "Begin": alpha_utilities.unary_unary_invocation_description(
       query_pb2.BeginRequest.SerializeToString,
@@ -222,6 +350,38 @@ def early_adopter_create_Query_stub(host, port, metadata_transformer=None, secur
       query_pb2.RollbackRequest.SerializeToString,
       query_pb2.RollbackResponse.FromString,
     ),
+    "Prepare": alpha_utilities.unary_unary_invocation_description(
+      query_pb2.PrepareRequest.SerializeToString,
+      query_pb2.PrepareResponse.FromString,
+    ),
+    "CommitPrepared": alpha_utilities.unary_unary_invocation_description(
+      query_pb2.CommitPreparedRequest.SerializeToString,
+      query_pb2.CommitPreparedResponse.FromString,
+    ),
+    "RollbackPrepared": alpha_utilities.unary_unary_invocation_description(
+      query_pb2.RollbackPreparedRequest.SerializeToString,
+      query_pb2.RollbackPreparedResponse.FromString,
+    ),
+    "CreateTransaction": alpha_utilities.unary_unary_invocation_description(
+      query_pb2.CreateTransactionRequest.SerializeToString,
+      query_pb2.CreateTransactionResponse.FromString,
+    ),
+    "StartCommit": alpha_utilities.unary_unary_invocation_description(
+      query_pb2.StartCommitRequest.SerializeToString,
+      query_pb2.StartCommitResponse.FromString,
+    ),
+    "SetRollback": alpha_utilities.unary_unary_invocation_description(
+      query_pb2.SetRollbackRequest.SerializeToString,
+      query_pb2.SetRollbackResponse.FromString,
+    ),
+    "ConcludeTransaction": alpha_utilities.unary_unary_invocation_description(
+      query_pb2.ConcludeTransactionRequest.SerializeToString,
+      query_pb2.ConcludeTransactionResponse.FromString,
+    ),
+    "ReadTransaction": alpha_utilities.unary_unary_invocation_description(
+      query_pb2.ReadTransactionRequest.SerializeToString,
+      query_pb2.ReadTransactionResponse.FromString,
+    ),
     "SplitQuery": alpha_utilities.unary_unary_invocation_description(
       query_pb2.SplitQueryRequest.SerializeToString,
       query_pb2.SplitQueryResponse.FromString,
@@ -262,6 +422,30 @@ class BetaQueryServicer(object):
   def Rollback(self, request, context):
     raise NotImplementedError()
   @abc.abstractmethod
+  def Prepare(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def CommitPrepared(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def RollbackPrepared(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def CreateTransaction(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def StartCommit(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def SetRollback(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def ConcludeTransaction(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def ReadTransaction(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
   def SplitQuery(self, request, context):
     raise NotImplementedError()
   @abc.abstractmethod
@@ -299,6 +483,38 @@ class BetaQueryStub(object):
     raise NotImplementedError()
   Rollback.future = None
   @abc.abstractmethod
+  def Prepare(self, request, timeout):
+    raise NotImplementedError()
+  Prepare.future = None
+  @abc.abstractmethod
+  def CommitPrepared(self, request, timeout):
+    raise NotImplementedError()
+  CommitPrepared.future = None
+  @abc.abstractmethod
+  def RollbackPrepared(self, request, timeout):
+    raise NotImplementedError()
+  RollbackPrepared.future = None
+  @abc.abstractmethod
+  def CreateTransaction(self, request, timeout):
+    raise NotImplementedError()
+  CreateTransaction.future = None
+  @abc.abstractmethod
+  def StartCommit(self, request, timeout):
+    raise NotImplementedError()
+  StartCommit.future = None
+  @abc.abstractmethod
+  def SetRollback(self, request, timeout):
+    raise NotImplementedError()
+  SetRollback.future = None
+  @abc.abstractmethod
+  def ConcludeTransaction(self, request, timeout):
+    raise NotImplementedError()
+  ConcludeTransaction.future = None
+  @abc.abstractmethod
+  def ReadTransaction(self, request, timeout):
+    raise NotImplementedError()
+  ReadTransaction.future = None
+  @abc.abstractmethod
   def SplitQuery(self, request, timeout):
     raise NotImplementedError()
   SplitQuery.future = None
@@ -325,36 +541,76 @@ def beta_create_Query_server(servicer, pool=None, pool_size=None, default_timeou
   import query_pb2
   import query_pb2
   import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
   request_deserializers = {
     ('queryservice.Query', 'Begin'): query_pb2.BeginRequest.FromString,
     ('queryservice.Query', 'Commit'): query_pb2.CommitRequest.FromString,
+    ('queryservice.Query', 'CommitPrepared'): query_pb2.CommitPreparedRequest.FromString,
+    ('queryservice.Query', 'ConcludeTransaction'): query_pb2.ConcludeTransactionRequest.FromString,
+    ('queryservice.Query', 'CreateTransaction'): query_pb2.CreateTransactionRequest.FromString,
     ('queryservice.Query', 'Execute'): query_pb2.ExecuteRequest.FromString,
     ('queryservice.Query', 'ExecuteBatch'): query_pb2.ExecuteBatchRequest.FromString,
     ('queryservice.Query', 'GetSessionId'): query_pb2.GetSessionIdRequest.FromString,
+    ('queryservice.Query', 'Prepare'): query_pb2.PrepareRequest.FromString,
+    ('queryservice.Query', 'ReadTransaction'): query_pb2.ReadTransactionRequest.FromString,
     ('queryservice.Query', 'Rollback'): query_pb2.RollbackRequest.FromString,
+    ('queryservice.Query', 'RollbackPrepared'): query_pb2.RollbackPreparedRequest.FromString,
+    ('queryservice.Query', 'SetRollback'): query_pb2.SetRollbackRequest.FromString,
     ('queryservice.Query', 'SplitQuery'): query_pb2.SplitQueryRequest.FromString,
+    ('queryservice.Query', 'StartCommit'): query_pb2.StartCommitRequest.FromString,
     ('queryservice.Query', 'StreamExecute'): query_pb2.StreamExecuteRequest.FromString,
     ('queryservice.Query', 'StreamHealth'): query_pb2.StreamHealthRequest.FromString,
   }
   response_serializers = {
     ('queryservice.Query', 'Begin'): query_pb2.BeginResponse.SerializeToString,
     ('queryservice.Query', 'Commit'): query_pb2.CommitResponse.SerializeToString,
+    ('queryservice.Query', 'CommitPrepared'): query_pb2.CommitPreparedResponse.SerializeToString,
+    ('queryservice.Query', 'ConcludeTransaction'): query_pb2.ConcludeTransactionResponse.SerializeToString,
+    ('queryservice.Query', 'CreateTransaction'): query_pb2.CreateTransactionResponse.SerializeToString,
     ('queryservice.Query', 'Execute'): query_pb2.ExecuteResponse.SerializeToString,
     ('queryservice.Query', 'ExecuteBatch'): query_pb2.ExecuteBatchResponse.SerializeToString,
     ('queryservice.Query', 'GetSessionId'): query_pb2.GetSessionIdResponse.SerializeToString,
+    ('queryservice.Query', 'Prepare'): query_pb2.PrepareResponse.SerializeToString,
+    ('queryservice.Query', 'ReadTransaction'): query_pb2.ReadTransactionResponse.SerializeToString,
     ('queryservice.Query', 'Rollback'): query_pb2.RollbackResponse.SerializeToString,
+    ('queryservice.Query', 'RollbackPrepared'): query_pb2.RollbackPreparedResponse.SerializeToString,
+    ('queryservice.Query', 'SetRollback'): query_pb2.SetRollbackResponse.SerializeToString,
     ('queryservice.Query', 'SplitQuery'): query_pb2.SplitQueryResponse.SerializeToString,
+    ('queryservice.Query', 'StartCommit'): query_pb2.StartCommitResponse.SerializeToString,
     ('queryservice.Query', 'StreamExecute'): query_pb2.StreamExecuteResponse.SerializeToString,
     ('queryservice.Query', 'StreamHealth'): query_pb2.StreamHealthResponse.SerializeToString,
   }
   method_implementations = {
     ('queryservice.Query', 'Begin'): face_utilities.unary_unary_inline(servicer.Begin),
     ('queryservice.Query', 'Commit'): face_utilities.unary_unary_inline(servicer.Commit),
+    ('queryservice.Query', 'CommitPrepared'): face_utilities.unary_unary_inline(servicer.CommitPrepared),
+    ('queryservice.Query', 'ConcludeTransaction'): face_utilities.unary_unary_inline(servicer.ConcludeTransaction),
+    ('queryservice.Query', 'CreateTransaction'): face_utilities.unary_unary_inline(servicer.CreateTransaction),
     ('queryservice.Query', 'Execute'): face_utilities.unary_unary_inline(servicer.Execute),
     ('queryservice.Query', 'ExecuteBatch'): face_utilities.unary_unary_inline(servicer.ExecuteBatch),
     ('queryservice.Query', 'GetSessionId'): face_utilities.unary_unary_inline(servicer.GetSessionId),
+    ('queryservice.Query', 'Prepare'): face_utilities.unary_unary_inline(servicer.Prepare),
+    ('queryservice.Query', 'ReadTransaction'): face_utilities.unary_unary_inline(servicer.ReadTransaction),
     ('queryservice.Query', 'Rollback'): face_utilities.unary_unary_inline(servicer.Rollback),
+    ('queryservice.Query', 'RollbackPrepared'): face_utilities.unary_unary_inline(servicer.RollbackPrepared),
+    ('queryservice.Query', 'SetRollback'): face_utilities.unary_unary_inline(servicer.SetRollback),
     ('queryservice.Query', 'SplitQuery'): face_utilities.unary_unary_inline(servicer.SplitQuery),
+    ('queryservice.Query', 'StartCommit'): face_utilities.unary_unary_inline(servicer.StartCommit),
     ('queryservice.Query', 'StreamExecute'): face_utilities.unary_stream_inline(servicer.StreamExecute),
     ('queryservice.Query', 'StreamHealth'): face_utilities.unary_stream_inline(servicer.StreamHealth),
   }
@@ -380,36 +636,76 @@ def beta_create_Query_stub(channel, host=None, metadata_transformer=None, pool=N
   import query_pb2
   import query_pb2
   import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
   request_serializers = {
     ('queryservice.Query', 'Begin'): query_pb2.BeginRequest.SerializeToString,
     ('queryservice.Query', 'Commit'): query_pb2.CommitRequest.SerializeToString,
+    ('queryservice.Query', 'CommitPrepared'): query_pb2.CommitPreparedRequest.SerializeToString,
+    ('queryservice.Query', 'ConcludeTransaction'): query_pb2.ConcludeTransactionRequest.SerializeToString,
+    ('queryservice.Query', 'CreateTransaction'): query_pb2.CreateTransactionRequest.SerializeToString,
     ('queryservice.Query', 'Execute'): query_pb2.ExecuteRequest.SerializeToString,
     ('queryservice.Query', 'ExecuteBatch'): query_pb2.ExecuteBatchRequest.SerializeToString,
     ('queryservice.Query', 'GetSessionId'): query_pb2.GetSessionIdRequest.SerializeToString,
+    ('queryservice.Query', 'Prepare'): query_pb2.PrepareRequest.SerializeToString,
+    ('queryservice.Query', 'ReadTransaction'): query_pb2.ReadTransactionRequest.SerializeToString,
     ('queryservice.Query', 'Rollback'): query_pb2.RollbackRequest.SerializeToString,
+    ('queryservice.Query', 'RollbackPrepared'): query_pb2.RollbackPreparedRequest.SerializeToString,
+    ('queryservice.Query', 'SetRollback'): query_pb2.SetRollbackRequest.SerializeToString,
     ('queryservice.Query', 'SplitQuery'): query_pb2.SplitQueryRequest.SerializeToString,
+    ('queryservice.Query', 'StartCommit'): query_pb2.StartCommitRequest.SerializeToString,
     ('queryservice.Query', 'StreamExecute'): query_pb2.StreamExecuteRequest.SerializeToString,
     ('queryservice.Query', 'StreamHealth'): query_pb2.StreamHealthRequest.SerializeToString,
   }
   response_deserializers = {
     ('queryservice.Query', 'Begin'): query_pb2.BeginResponse.FromString,
     ('queryservice.Query', 'Commit'): query_pb2.CommitResponse.FromString,
+    ('queryservice.Query', 'CommitPrepared'): query_pb2.CommitPreparedResponse.FromString,
+    ('queryservice.Query', 'ConcludeTransaction'): query_pb2.ConcludeTransactionResponse.FromString,
+    ('queryservice.Query', 'CreateTransaction'): query_pb2.CreateTransactionResponse.FromString,
     ('queryservice.Query', 'Execute'): query_pb2.ExecuteResponse.FromString,
     ('queryservice.Query', 'ExecuteBatch'): query_pb2.ExecuteBatchResponse.FromString,
     ('queryservice.Query', 'GetSessionId'): query_pb2.GetSessionIdResponse.FromString,
+    ('queryservice.Query', 'Prepare'): query_pb2.PrepareResponse.FromString,
+    ('queryservice.Query', 'ReadTransaction'): query_pb2.ReadTransactionResponse.FromString,
     ('queryservice.Query', 'Rollback'): query_pb2.RollbackResponse.FromString,
+    ('queryservice.Query', 'RollbackPrepared'): query_pb2.RollbackPreparedResponse.FromString,
+    ('queryservice.Query', 'SetRollback'): query_pb2.SetRollbackResponse.FromString,
     ('queryservice.Query', 'SplitQuery'): query_pb2.SplitQueryResponse.FromString,
+    ('queryservice.Query', 'StartCommit'): query_pb2.StartCommitResponse.FromString,
     ('queryservice.Query', 'StreamExecute'): query_pb2.StreamExecuteResponse.FromString,
     ('queryservice.Query', 'StreamHealth'): query_pb2.StreamHealthResponse.FromString,
   }
   cardinalities = {
     'Begin': cardinality.Cardinality.UNARY_UNARY,
     'Commit': cardinality.Cardinality.UNARY_UNARY,
+    'CommitPrepared': cardinality.Cardinality.UNARY_UNARY,
+    'ConcludeTransaction': cardinality.Cardinality.UNARY_UNARY,
+    'CreateTransaction': cardinality.Cardinality.UNARY_UNARY,
     'Execute': cardinality.Cardinality.UNARY_UNARY,
     'ExecuteBatch': cardinality.Cardinality.UNARY_UNARY,
     'GetSessionId': cardinality.Cardinality.UNARY_UNARY,
+    'Prepare': cardinality.Cardinality.UNARY_UNARY,
+    'ReadTransaction': cardinality.Cardinality.UNARY_UNARY,
     'Rollback': cardinality.Cardinality.UNARY_UNARY,
+    'RollbackPrepared': cardinality.Cardinality.UNARY_UNARY,
+    'SetRollback': cardinality.Cardinality.UNARY_UNARY,
     'SplitQuery': cardinality.Cardinality.UNARY_UNARY,
+    'StartCommit': cardinality.Cardinality.UNARY_UNARY,
     'StreamExecute': cardinality.Cardinality.UNARY_STREAM,
     'StreamHealth': cardinality.Cardinality.UNARY_STREAM,
   }
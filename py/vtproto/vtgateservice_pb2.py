@@ -18,7 +18,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='vtgateservice.proto',
   package='vtgateservice',
   syntax='proto3',
-  serialized_pb=b'\n\x13vtgateservice.proto\x12\rvtgateservice\x1a\x0cvtgate.proto2\xd8\n\n\x06Vitess\x12<\n\x07\x45xecute\x12\x16.vtgate.ExecuteRequest\x1a\x17.vtgate.ExecuteResponse\"\x00\x12N\n\rExecuteShards\x12\x1c.vtgate.ExecuteShardsRequest\x1a\x1d.vtgate.ExecuteShardsResponse\"\x00\x12]\n\x12\x45xecuteKeyspaceIds\x12!.vtgate.ExecuteKeyspaceIdsRequest\x1a\".vtgate.ExecuteKeyspaceIdsResponse\"\x00\x12W\n\x10\x45xecuteKeyRanges\x12\x1f.vtgate.ExecuteKeyRangesRequest\x1a .vtgate.ExecuteKeyRangesResponse\"\x00\x12W\n\x10\x45xecuteEntityIds\x12\x1f.vtgate.ExecuteEntityIdsRequest\x1a .vtgate.ExecuteEntityIdsResponse\"\x00\x12]\n\x12\x45xecuteBatchShards\x12!.vtgate.ExecuteBatchShardsRequest\x1a\".vtgate.ExecuteBatchShardsResponse\"\x00\x12l\n\x17\x45xecuteBatchKeyspaceIds\x12&.vtgate.ExecuteBatchKeyspaceIdsRequest\x1a\'.vtgate.ExecuteBatchKeyspaceIdsResponse\"\x00\x12P\n\rStreamExecute\x12\x1c.vtgate.StreamExecuteRequest\x1a\x1d.vtgate.StreamExecuteResponse\"\x00\x30\x01\x12\x62\n\x13StreamExecuteShards\x12\".vtgate.StreamExecuteShardsRequest\x1a#.vtgate.StreamExecuteShardsResponse\"\x00\x30\x01\x12q\n\x18StreamExecuteKeyspaceIds\x12\'.vtgate.StreamExecuteKeyspaceIdsRequest\x1a(.vtgate.StreamExecuteKeyspaceIdsResponse\"\x00\x30\x01\x12k\n\x16StreamExecuteKeyRanges\x12%.vtgate.StreamExecuteKeyRangesRequest\x1a&.vtgate.StreamExecuteKeyRangesResponse\"\x00\x30\x01\x12\x36\n\x05\x42\x65gin\x12\x14.vtgate.BeginRequest\x1a\x15.vtgate.BeginResponse\"\x00\x12\x39\n\x06\x43ommit\x12\x15.vtgate.CommitRequest\x1a\x16.vtgate.CommitResponse\"\x00\x12?\n\x08Rollback\x12\x17.vtgate.RollbackRequest\x1a\x18.vtgate.RollbackResponse\"\x00\x12\x45\n\nSplitQuery\x12\x19.vtgate.SplitQueryRequest\x1a\x1a.vtgate.SplitQueryResponse\"\x00\x12Q\n\x0eGetSrvKeyspace\x12\x1d.vtgate.GetSrvKeyspaceRequest\x1a\x1e.vtgate.GetSrvKeyspaceResponse\"\x00\x42\x1f\n\x1d\x63om.youtube.vitess.proto.grpcb\x06proto3'
+  serialized_pb=b'\n\x13vtgateservice.proto\x12\rvtgateservice\x1a\x0cvtgate.proto2\xf5\x0b\n\x06Vitess\x12<\n\x07\x45xecute\x12\x16.vtgate.ExecuteRequest\x1a\x17.vtgate.ExecuteResponse\"\x00\x12N\n\rExecuteShards\x12\x1c.vtgate.ExecuteShardsRequest\x1a\x1d.vtgate.ExecuteShardsResponse\"\x00\x12]\n\x12\x45xecuteKeyspaceIds\x12!.vtgate.ExecuteKeyspaceIdsRequest\x1a\".vtgate.ExecuteKeyspaceIdsResponse\"\x00\x12W\n\x10\x45xecuteKeyRanges\x12\x1f.vtgate.ExecuteKeyRangesRequest\x1a .vtgate.ExecuteKeyRangesResponse\"\x00\x12W\n\x10\x45xecuteEntityIds\x12\x1f.vtgate.ExecuteEntityIdsRequest\x1a .vtgate.ExecuteEntityIdsResponse\"\x00\x12]\n\x12\x45xecuteBatchShards\x12!.vtgate.ExecuteBatchShardsRequest\x1a\".vtgate.ExecuteBatchShardsResponse\"\x00\x12l\n\x17\x45xecuteBatchKeyspaceIds\x12&.vtgate.ExecuteBatchKeyspaceIdsRequest\x1a\'.vtgate.ExecuteBatchKeyspaceIdsResponse\"\x00\x12P\n\rStreamExecute\x12\x1c.vtgate.StreamExecuteRequest\x1a\x1d.vtgate.StreamExecuteResponse\"\x00\x30\x01\x12\x62\n\x13StreamExecuteShards\x12\".vtgate.StreamExecuteShardsRequest\x1a#.vtgate.StreamExecuteShardsResponse\"\x00\x30\x01\x12q\n\x18StreamExecuteKeyspaceIds\x12\'.vtgate.StreamExecuteKeyspaceIdsRequest\x1a(.vtgate.StreamExecuteKeyspaceIdsResponse\"\x00\x30\x01\x12k\n\x16StreamExecuteKeyRanges\x12%.vtgate.StreamExecuteKeyRangesRequest\x1a&.vtgate.StreamExecuteKeyRangesResponse\"\x00\x30\x01\x12\x36\n\x05\x42\x65gin\x12\x14.vtgate.BeginRequest\x1a\x15.vtgate.BeginResponse\"\x00\x12\x39\n\x06\x43ommit\x12\x15.vtgate.CommitRequest\x1a\x16.vtgate.CommitResponse\"\x00\x12?\n\x08Rollback\x12\x17.vtgate.RollbackRequest\x1a\x18.vtgate.RollbackResponse\"\x00\x12]\n\x12ResolveTransaction\x12!.vtgate.ResolveTransactionRequest\x1a\".vtgate.ResolveTransactionResponse\"\x00\x12\x45\n\nSplitQuery\x12\x19.vtgate.SplitQueryRequest\x1a\x1a.vtgate.SplitQueryResponse\"\x00\x12Q\n\x0eGetSrvKeyspace\x12\x1d.vtgate.GetSrvKeyspaceRequest\x1a\x1e.vtgate.GetSrvKeyspaceResponse\"\x00\x12<\n\x07\x45xplain\x12\x16.vtgate.ExplainRequest\x1a\x17.vtgate.ExplainResponse\"\x00\x42\x1f\n\x1d\x63om.youtube.vitess.proto.grpcb\x06proto3'
   ,
   dependencies=[vtgate__pb2.DESCRIPTOR,])
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
@@ -81,11 +81,17 @@ class EarlyAdopterVitessServicer(object):
   def Rollback(self, request, context):
     raise NotImplementedError()
   @abc.abstractmethod
+  def ResolveTransaction(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
   def SplitQuery(self, request, context):
     raise NotImplementedError()
   @abc.abstractmethod
   def GetSrvKeyspace(self, request, context):
     raise NotImplementedError()
+  @abc.abstractmethod
+  def Explain(self, request, context):
+    raise NotImplementedError()
 class EarlyAdopterVitessServer(object):
   """<fill me in later!>"""
   __metaclass__ = abc.ABCMeta
@@ -155,6 +161,10 @@ class EarlyAdopterVitessStub(object):
     raise NotImplementedError()
   Rollback.async = None
   @abc.abstractmethod
+  def ResolveTransaction(self, request):
+    raise NotImplementedError()
+  ResolveTransaction.async = None
+  @abc.abstractmethod
   def SplitQuery(self, request):
     raise NotImplementedError()
   SplitQuery.async = None
@@ -162,6 +172,10 @@ class EarlyAdopterVitessStub(object):
   def GetSrvKeyspace(self, request):
     raise NotImplementedError()
   GetSrvKeyspace.async = None
+  @abc.abstractmethod
+  def Explain(self, request):
+    raise NotImplementedError()
+  Explain.async = None
 def early_adopter_create_Vitess_server(servicer, port, private_key=None, certificate_chain=None):
   import vtgate_pb2
   import vtgate_pb2
@@ -195,6 +209,10 @@ def early_adopter_create_Vitess_server(servicer, port, private_key=None, certifi
   import vtgate_pb2
   import vtgate_pb2
   import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
   method_service_descriptions = {
     "Begin": alpha_utilities.unary_unary_service_description(
       servicer.Begin,
@@ -241,6 +259,11 @@ def early_adopter_create_Vitess_server(servicer, port, private_key=None, certifi
       vtgate_pb2.ExecuteShardsRequest.FromString,
       vtgate_pb2.ExecuteShardsResponse.SerializeToString,
     ),
+    "Explain": alpha_utilities.unary_unary_service_description(
+      servicer.Explain,
+      vtgate_pb2.ExplainRequest.FromString,
+      vtgate_pb2.ExplainResponse.SerializeToString,
+    ),
     "GetSrvKeyspace": alpha_utilities.unary_unary_service_description(
       servicer.GetSrvKeyspace,
       vtgate_pb2.GetSrvKeyspaceRequest.FromString,
@@ -251,6 +274,11 @@ def early_adopter_create_Vitess_server(servicer, port, private_key=None, certifi
       vtgate_pb2.RollbackRequest.FromString,
       vtgate_pb2.RollbackResponse.SerializeToString,
     ),
+    "ResolveTransaction": alpha_utilities.unary_unary_service_description(
+      servicer.ResolveTransaction,
+      vtgate_pb2.ResolveTransactionRequest.FromString,
+      vtgate_pb2.ResolveTransactionResponse.SerializeToString,
+    ),
     "SplitQuery": alpha_utilities.unary_unary_service_description(
       servicer.SplitQuery,
       vtgate_pb2.SplitQueryRequest.FromString,
@@ -311,6 +339,10 @@ def early_adopter_create_Vitess_stub(host, port, metadata_transformer=None, secu
   import vtgate_pb2
   import vtgate_pb2
   import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
   method_invocation_descriptions = {
     "Begin": alpha_utilities.unary_unary_invocation_description(
       vtgate_pb2.BeginRequest.SerializeToString,
@@ -348,6 +380,10 @@ def early_adopter_create_Vitess_stub(host, port, metadata_transformer=None, secu
       vtgate_pb2.ExecuteShardsRequest.SerializeToString,
       vtgate_pb2.ExecuteShardsResponse.FromString,
     ),
+    "Explain": alpha_utilities.unary_unary_invocation_description(
+      vtgate_pb2.ExplainRequest.SerializeToString,
+      vtgate_pb2.ExplainResponse.FromString,
+    ),
     "GetSrvKeyspace": alpha_utilities.unary_unary_invocation_description(
       vtgate_pb2.GetSrvKeyspaceRequest.SerializeToString,
       vtgate_pb2.GetSrvKeyspaceResponse.FromString,
@@ -356,6 +392,10 @@ def early_adopter_create_Vitess_stub(host, port, metadata_transformer=None, secu
       vtgate_pb2.RollbackRequest.SerializeToString,
       vtgate_pb2.RollbackResponse.FromString,
     ),
+    "ResolveTransaction": alpha_utilities.unary_unary_invocation_description(
+      vtgate_pb2.ResolveTransactionRequest.SerializeToString,
+      vtgate_pb2.ResolveTransactionResponse.FromString,
+    ),
     "SplitQuery": alpha_utilities.unary_unary_invocation_description(
       vtgate_pb2.SplitQueryRequest.SerializeToString,
       vtgate_pb2.SplitQueryResponse.FromString,
@@ -425,11 +465,17 @@ class BetaVitessServicer(object):
   def Rollback(self, request, context):
     raise NotImplementedError()
   @abc.abstractmethod
+  def ResolveTransaction(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
   def SplitQuery(self, request, context):
     raise NotImplementedError()
   @abc.abstractmethod
   def GetSrvKeyspace(self, request, context):
     raise NotImplementedError()
+  @abc.abstractmethod
+  def Explain(self, request, context):
+    raise NotImplementedError()
 
 class BetaVitessStub(object):
   """The interface to which stubs will conform."""
@@ -487,6 +533,10 @@ class BetaVitessStub(object):
     raise NotImplementedError()
   Rollback.future = None
   @abc.abstractmethod
+  def ResolveTransaction(self, request, timeout):
+    raise NotImplementedError()
+  ResolveTransaction.future = None
+  @abc.abstractmethod
   def SplitQuery(self, request, timeout):
     raise NotImplementedError()
   SplitQuery.future = None
@@ -494,6 +544,10 @@ class BetaVitessStub(object):
   def GetSrvKeyspace(self, request, timeout):
     raise NotImplementedError()
   GetSrvKeyspace.future = None
+  @abc.abstractmethod
+  def Explain(self, request, timeout):
+    raise NotImplementedError()
+  Explain.future = None
 
 def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeout=None, maximum_timeout=None):
   import vtgate_pb2
@@ -528,6 +582,10 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
   import vtgate_pb2
   import vtgate_pb2
   import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
   request_deserializers = {
     ('vtgateservice.Vitess', 'Begin'): vtgate_pb2.BeginRequest.FromString,
     ('vtgateservice.Vitess', 'Commit'): vtgate_pb2.CommitRequest.FromString,
@@ -538,7 +596,9 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): vtgate_pb2.ExecuteKeyRangesRequest.FromString,
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): vtgate_pb2.ExecuteKeyspaceIdsRequest.FromString,
     ('vtgateservice.Vitess', 'ExecuteShards'): vtgate_pb2.ExecuteShardsRequest.FromString,
+    ('vtgateservice.Vitess', 'Explain'): vtgate_pb2.ExplainRequest.FromString,
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): vtgate_pb2.GetSrvKeyspaceRequest.FromString,
+    ('vtgateservice.Vitess', 'ResolveTransaction'): vtgate_pb2.ResolveTransactionRequest.FromString,
     ('vtgateservice.Vitess', 'Rollback'): vtgate_pb2.RollbackRequest.FromString,
     ('vtgateservice.Vitess', 'SplitQuery'): vtgate_pb2.SplitQueryRequest.FromString,
     ('vtgateservice.Vitess', 'StreamExecute'): vtgate_pb2.StreamExecuteRequest.FromString,
@@ -556,7 +616,9 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): vtgate_pb2.ExecuteKeyRangesResponse.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): vtgate_pb2.ExecuteKeyspaceIdsResponse.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteShards'): vtgate_pb2.ExecuteShardsResponse.SerializeToString,
+    ('vtgateservice.Vitess', 'Explain'): vtgate_pb2.ExplainResponse.SerializeToString,
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): vtgate_pb2.GetSrvKeyspaceResponse.SerializeToString,
+    ('vtgateservice.Vitess', 'ResolveTransaction'): vtgate_pb2.ResolveTransactionResponse.SerializeToString,
     ('vtgateservice.Vitess', 'Rollback'): vtgate_pb2.RollbackResponse.SerializeToString,
     ('vtgateservice.Vitess', 'SplitQuery'): vtgate_pb2.SplitQueryResponse.SerializeToString,
     ('vtgateservice.Vitess', 'StreamExecute'): vtgate_pb2.StreamExecuteResponse.SerializeToString,
@@ -574,7 +636,9 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): face_utilities.unary_unary_inline(servicer.ExecuteKeyRanges),
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): face_utilities.unary_unary_inline(servicer.ExecuteKeyspaceIds),
     ('vtgateservice.Vitess', 'ExecuteShards'): face_utilities.unary_unary_inline(servicer.ExecuteShards),
+    ('vtgateservice.Vitess', 'Explain'): face_utilities.unary_unary_inline(servicer.Explain),
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): face_utilities.unary_unary_inline(servicer.GetSrvKeyspace),
+    ('vtgateservice.Vitess', 'ResolveTransaction'): face_utilities.unary_unary_inline(servicer.ResolveTransaction),
     ('vtgateservice.Vitess', 'Rollback'): face_utilities.unary_unary_inline(servicer.Rollback),
     ('vtgateservice.Vitess', 'SplitQuery'): face_utilities.unary_unary_inline(servicer.SplitQuery),
     ('vtgateservice.Vitess', 'StreamExecute'): face_utilities.unary_stream_inline(servicer.StreamExecute),
@@ -618,6 +682,10 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
   import vtgate_pb2
   import vtgate_pb2
   import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
   request_serializers = {
     ('vtgateservice.Vitess', 'Begin'): vtgate_pb2.BeginRequest.SerializeToString,
     ('vtgateservice.Vitess', 'Commit'): vtgate_pb2.CommitRequest.SerializeToString,
@@ -628,7 +696,9 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): vtgate_pb2.ExecuteKeyRangesRequest.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): vtgate_pb2.ExecuteKeyspaceIdsRequest.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteShards'): vtgate_pb2.ExecuteShardsRequest.SerializeToString,
+    ('vtgateservice.Vitess', 'Explain'): vtgate_pb2.ExplainRequest.SerializeToString,
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): vtgate_pb2.GetSrvKeyspaceRequest.SerializeToString,
+    ('vtgateservice.Vitess', 'ResolveTransaction'): vtgate_pb2.ResolveTransactionRequest.SerializeToString,
     ('vtgateservice.Vitess', 'Rollback'): vtgate_pb2.RollbackRequest.SerializeToString,
     ('vtgateservice.Vitess', 'SplitQuery'): vtgate_pb2.SplitQueryRequest.SerializeToString,
     ('vtgateservice.Vitess', 'StreamExecute'): vtgate_pb2.StreamExecuteRequest.SerializeToString,
@@ -646,7 +716,9 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): vtgate_pb2.ExecuteKeyRangesResponse.FromString,
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): vtgate_pb2.ExecuteKeyspaceIdsResponse.FromString,
     ('vtgateservice.Vitess', 'ExecuteShards'): vtgate_pb2.ExecuteShardsResponse.FromString,
+    ('vtgateservice.Vitess', 'Explain'): vtgate_pb2.ExplainResponse.FromString,
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): vtgate_pb2.GetSrvKeyspaceResponse.FromString,
+    ('vtgateservice.Vitess', 'ResolveTransaction'): vtgate_pb2.ResolveTransactionResponse.FromString,
     ('vtgateservice.Vitess', 'Rollback'): vtgate_pb2.RollbackResponse.FromString,
     ('vtgateservice.Vitess', 'SplitQuery'): vtgate_pb2.SplitQueryResponse.FromString,
     ('vtgateservice.Vitess', 'StreamExecute'): vtgate_pb2.StreamExecuteResponse.FromString,
@@ -664,7 +736,9 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
     'ExecuteKeyRanges': cardinality.Cardinality.UNARY_UNARY,
     'ExecuteKeyspaceIds': cardinality.Cardinality.UNARY_UNARY,
     'ExecuteShards': cardinality.Cardinality.UNARY_UNARY,
+    'Explain': cardinality.Cardinality.UNARY_UNARY,
     'GetSrvKeyspace': cardinality.Cardinality.UNARY_UNARY,
+    'ResolveTransaction': cardinality.Cardinality.UNARY_UNARY,
     'Rollback': cardinality.Cardinality.UNARY_UNARY,
     'SplitQuery': cardinality.Cardinality.UNARY_UNARY,
     'StreamExecute': cardinality.Cardinality.UNARY_STREAM,
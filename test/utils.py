@@ -444,11 +444,11 @@ def poll_for_vars(
       return v
 
 
-def apply_vschema(vschema):
+def apply_vschema(keyspace, vschema):
   fname = os.path.join(environment.tmproot, 'vschema.json')
   with open(fname, 'w') as f:
     f.write(vschema)
-  run_vtctl(['ApplyVSchema', '-vschema_file', fname])
+  run_vtctl(['ApplyVSchema', '-keyspace', keyspace, '-vschema_file', fname])
 
 
 def wait_for_tablet_type(tablet_alias, expected_type, timeout=10):
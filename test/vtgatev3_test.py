@@ -216,7 +216,10 @@ def setUpModule():
     shard_1_master = keyspace_env.tablet_map['user.80-.master']
     lookup_master = keyspace_env.tablet_map['lookup.0.master']
 
-    utils.apply_vschema(schema)
+    # The vschema is applied per keyspace, unsharded keyspaces first.
+    keyspaces = json.loads(schema)['Keyspaces']
+    for name in sorted(keyspaces, key=lambda k: keyspaces[k]['Sharded']):
+      utils.apply_vschema(name, json.dumps(keyspaces[name]))
     utils.VtGate().start()
   except:
     tearDownModule()